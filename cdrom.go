@@ -9,7 +9,8 @@ type CDROMID string
 
 // CDROMClient contains the methods required for handling CDROM (ISO) attachments to VMs.
 type CDROMClient interface {
-	// AttachCDROM attaches an ISO image to a VM's CDROM.
+	// AttachCDROM attaches an ISO image to a VM's CDROM. The isoImageID can be the ID of a file on an ISO domain, or
+	// the ID of a disk with the DiskContentTypeISO content type on a data domain.
 	AttachCDROM(
 		vmID VMID,
 		isoImageID string,
@@ -19,7 +20,8 @@ type CDROMClient interface {
 	GetCDROM(vmID VMID, id CDROMID, retries ...RetryStrategy) (CDROM, error)
 	// ListCDROMs lists all CDROM attachments for a virtual machine.
 	ListCDROMs(vmID VMID, retries ...RetryStrategy) ([]CDROM, error)
-	// ChangeCDROM changes the ISO image in an existing CDROM attachment.
+	// ChangeCDROM changes the ISO image in an existing CDROM attachment. Like with AttachCDROM, the isoImageID can be a
+	// file ID or the ID of an ISO disk.
 	ChangeCDROM(vmID VMID, cdromID CDROMID, isoImageID string, retries ...RetryStrategy) (CDROM, error)
 	// EjectCDROM ejects the ISO from the CDROM (removes the file reference).
 	EjectCDROM(vmID VMID, cdromID CDROMID, retries ...RetryStrategy) (CDROM, error)
@@ -31,7 +33,7 @@ type CDROM interface {
 	ID() CDROMID
 	// VMID returns the ID of the virtual machine this CDROM belongs to.
 	VMID() VMID
-	// FileID returns the ID of the ISO file attached to this CDROM, or empty string if no ISO is attached.
+	// FileID returns the ID of the ISO file or ISO disk attached to this CDROM, or empty string if no ISO is attached.
 	FileID() string
 	// FileName returns the name of the ISO file, or empty string if no ISO is attached.
 	FileName() string
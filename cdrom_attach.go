@@ -62,10 +62,9 @@ func (m *mockClient) AttachCDROM(
 		return nil, newError(ENotFound, "VM with ID %s not found", vmID)
 	}
 
-	// Check if the ISO file exists in mock storage domains
-	// For mock, we'll just validate that the ID is not empty
-	if isoImageID == "" {
-		return nil, newError(EBadArgument, "ISO image ID cannot be empty")
+	fileName, err := m.mockCDROMFileName(isoImageID)
+	if err != nil {
+		return nil, err
 	}
 
 	cdromID := CDROMID(m.GenerateUUID())
@@ -74,7 +73,7 @@ func (m *mockClient) AttachCDROM(
 		id:       cdromID,
 		vmid:     vm.ID(),
 		fileID:   isoImageID,
-		fileName: fmt.Sprintf("iso-%s.iso", isoImageID),
+		fileName: fileName,
 	}
 
 	if m.vmCDROMsByVM == nil {
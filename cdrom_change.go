@@ -74,12 +74,13 @@ func (m *mockClient) ChangeCDROM(
 		return nil, newError(ENotFound, "CDROM with ID %s not found for VM %s", cdromID, vmID)
 	}
 
-	if isoImageID == "" {
-		return nil, newError(EBadArgument, "ISO image ID cannot be empty")
+	fileName, err := m.mockCDROMFileName(isoImageID)
+	if err != nil {
+		return nil, err
 	}
 
 	cdrom.fileID = isoImageID
-	cdrom.fileName = fmt.Sprintf("iso-%s.iso", isoImageID)
+	cdrom.fileName = fileName
 
 	return cdrom, nil
 }
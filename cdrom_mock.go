@@ -0,0 +1,28 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+// mockCDROMFileName returns the name of the ISO image attached to a CDROM. The ID can either refer to an ISO disk on a
+// data domain or to a file on an ISO domain. The caller must hold the mock client lock.
+func (m *mockClient) mockCDROMFileName(isoImageID string) (string, error) {
+	if isoImageID == "" {
+		return "", newError(EBadArgument, "ISO image ID cannot be empty")
+	}
+	disk, ok := m.disks[DiskID(isoImageID)]
+	if !ok {
+		// Files on ISO domains are not validated by the mock.
+		return fmt.Sprintf("iso-%s.iso", isoImageID), nil
+	}
+	if disk.contentType != DiskContentTypeISO {
+		return "", newError(
+			EBadArgument,
+			"disk %s has the content type %s, only disks with the content type %s can be attached to a CDROM",
+			disk.id,
+			disk.contentType,
+			DiskContentTypeISO,
+		)
+	}
+	return disk.alias, nil
+}
@@ -1,6 +1,7 @@
 package ovirtclient_test
 
 import (
+	"bytes"
 	"fmt"
 	"testing"
 
@@ -137,6 +138,74 @@ func TestCDROMConvenienceMethods(t *testing.T) {
 	}
 }
 
+func TestCDROMAttachISODisk(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+
+	vm := assertCanCreateVM(
+		t,
+		helper,
+		fmt.Sprintf("cdrom_iso_disk_test_%s", helper.GenerateRandomID(5)),
+		ovirtclient.CreateVMParams(),
+	)
+
+	iso := make([]byte, 1024*1024)
+	copy(iso[32769:], "CD001")
+	uploadResult, err := client.UploadToNewDisk(
+		helper.GetStorageDomainID(),
+		"",
+		uint64(len(iso)),
+		ovirtclient.CreateDiskParams().MustWithAlias(fmt.Sprintf("client_test_%s.iso", helper.GenerateRandomID(5))),
+		&nopReadCloser{bytes.NewReader(iso)},
+	)
+	if err != nil {
+		t.Fatalf("Failed to upload ISO image. (%v)", err)
+	}
+	diskID := uploadResult.Disk().ID()
+	t.Cleanup(func() {
+		if err := client.RemoveDisk(diskID); err != nil && !ovirtclient.HasErrorCode(err, ovirtclient.ENotFound) {
+			t.Fatalf("Failed to remove ISO disk %s. (%v)", diskID, err)
+		}
+	})
+	disk, err := client.GetDisk(diskID)
+	if err != nil {
+		t.Fatalf("Failed to fetch ISO disk %s. (%v)", diskID, err)
+	}
+	if disk.ContentType() != ovirtclient.DiskContentTypeISO {
+		t.Fatalf("Incorrect disk content type: %s instead of %s.", disk.ContentType(), ovirtclient.DiskContentTypeISO)
+	}
+	if disk.Format() != ovirtclient.ImageFormatRaw {
+		t.Fatalf("Incorrect disk format: %s instead of %s.", disk.Format(), ovirtclient.ImageFormatRaw)
+	}
+
+	cdrom := assertCanAttachISO(t, vm, string(diskID))
+	assertCanEjectISO(t, vm, cdrom.ID())
+	changedCDROM := assertCanChangeISO(t, vm, cdrom.ID(), string(diskID))
+	if changedCDROM.FileID() != string(diskID) {
+		t.Fatalf("Changed CDROM file ID mismatch (expected %s, got %s)", diskID, changedCDROM.FileID())
+	}
+}
+
+func TestCDROMAttachDataDisk(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+
+	vm := assertCanCreateVM(
+		t,
+		helper,
+		fmt.Sprintf("cdrom_data_disk_test_%s", helper.GenerateRandomID(5)),
+		ovirtclient.CreateVMParams(),
+	)
+	disk := assertCanCreateDisk(t, helper)
+	if disk.ContentType() != ovirtclient.DiskContentTypeData {
+		t.Fatalf("Incorrect disk content type: %s instead of %s.", disk.ContentType(), ovirtclient.DiskContentTypeData)
+	}
+	if _, err := vm.AttachISO(string(disk.ID())); err == nil {
+		t.Fatalf("Attaching data disk %s to a CDROM did not result in an error.", disk.ID())
+	}
+}
+
 // Helper functions
 
 func assertCanAttachISO(t *testing.T, vm ovirtclient.VM, isoID string) ovirtclient.CDROM {
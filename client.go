@@ -34,6 +34,7 @@ type Client interface {
 	DatacenterClient
 	ClusterClient
 	StorageDomainClient
+	StorageConnectionClient
 	HostClient
 	TemplateClient
 	TemplateDiskClient
@@ -42,6 +43,11 @@ type Client interface {
 	FeatureClient
 	InstanceTypeClient
 	GraphicsConsoleClient
+	SnapshotClient
+	VMPoolClient
+	OVAClient
+	VMBackupClient
+	DiskSnapshotClient
 }
 
 // ClientWithLegacySupport is an extension of Client that also offers the ability to retrieve the underlying
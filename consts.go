@@ -4,4 +4,45 @@ const (
 	qcowHeaderSize    = 32
 	qcowMagicBytes    = "QFI\xfb"
 	qcowSizeStartByte = 24
+	qcowV2HeaderSize  = 72
+	qcowV3HeaderSize  = 104
+	// qcowOffsetMask extracts the host offset from L1 and L2 table entries.
+	qcowOffsetMask = 0x00fffffffffffe00
+	// qcowCompressedFlag marks compressed clusters in L2 table entries.
+	qcowCompressedFlag = uint64(1) << 62
+	// qcowZeroFlag marks clusters that read as zeroes in L2 table entries.
+	qcowZeroFlag = uint64(1)
+	// qcowCopiedFlag marks L1 and L2 table entries of clusters with a refcount of exactly one.
+	qcowCopiedFlag = uint64(1) << 63
+	// qcowConvertClusterBits is the cluster size of QCOW images created by ConvertImage, 64 kiB like qemu-img.
+	qcowConvertClusterBits = 16
+	// qcowConvertRefcountOrder is the refcount width of QCOW images created by ConvertImage, 16 bits like qemu-img.
+	qcowConvertRefcountOrder = 4
+	// qcowConvertScanClusters is the number of clusters read at once when looking for zero clusters in a raw image.
+	qcowConvertScanClusters = 64
+	// qcowConvertL2CacheSize is the number of L2 tables kept in memory when converting a QCOW image.
+	qcowConvertL2CacheSize = 16
+	// qcowIncompatibleFeaturesUnderstood contains the dirty, corrupt and compression type incompatible feature bits,
+	// which do not change where metadata is stored.
+	qcowIncompatibleFeaturesUnderstood = qcowFeatureDirty | qcowFeatureCorrupt | qcowFeatureCompressionType
+	// qcowMaxL1Size is the largest L1 table QEMU accepts, in entries.
+	qcowMaxL1Size = 32 * 1024 * 1024 / 8
+	// qcowMaxBackingFileNameSize is the longest backing file name QEMU accepts.
+	qcowMaxBackingFileNameSize = 1023
+
+	vhdFooterSize  = 512
+	vhdMagicBytes  = "conectix"
+	isoMagicOffset = 32769
+	isoMagicBytes  = "CD001"
+)
+
+// Incompatible feature bits of QCOW2 version 3 images.
+const (
+	qcowFeatureDirty           = uint64(1) << 0
+	qcowFeatureCorrupt         = uint64(1) << 1
+	qcowFeatureExternalData    = uint64(1) << 2
+	qcowFeatureCompressionType = uint64(1) << 3
+	qcowFeatureExtendedL2      = uint64(1) << 4
+	qcowFeaturesKnown          = qcowFeatureDirty | qcowFeatureCorrupt | qcowFeatureExternalData |
+		qcowFeatureCompressionType | qcowFeatureExtendedL2
 )
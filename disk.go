@@ -4,6 +4,7 @@ import (
 	"io"
 	"strings"
 	"sync"
+	"time"
 
 	ovirtsdk4 "github.com/ovirt/go-ovirt"
 )
@@ -59,7 +60,8 @@ type DiskClient interface {
 	//
 	// - storageDomainID: this is the UUID of the storage domain that the image should be uploaded to.
 	// - format: format of the created disk. This does not necessarily have to be identical to the format of the image
-	//   being uploaded as the oVirt engine converts images on upload.
+	//   being uploaded as the oVirt engine converts images on upload. Use StartUploadToNewDiskWithParams with
+	//   local conversion enabled to convert the image on the client instead.
 	// - size: file size of the uploaded image on the disk.
 	// - reader: this is the source of the image data. It is a reader that must support seek and close operations.
 	// - retries: a set of optional retry options.
@@ -86,6 +88,39 @@ type DiskClient interface {
 		retries ...RetryStrategy,
 	) (UploadImageProgress, error)
 
+	// StartUploadToNewDiskWithParams is identical to StartUploadToNewDisk, but allows for tuning the upload using
+	// uploadParams. Use UploadImageParams() to obtain a buildable structure.
+	//
+	// If uploadParams enables local conversion and the disk format differs from the format of the image, raw and
+	// QCOW2 images are converted on the client using ConvertImage instead of by the engine. Note that converting a
+	// QCOW2 image to raw sends the full virtual size of the image, and QCOW2 images with zstd compressed clusters
+	// cannot be converted locally.
+	//
+	// If the upload fails and uploadParams requests the image transfer to be kept, the created disk is not removed
+	// and the upload can be resumed with StartUploadToDiskWithParams using the TransferID() and Chunks() of the
+	// returned progress.
+	StartUploadToNewDiskWithParams(
+		storageDomainID StorageDomainID,
+		format ImageFormat,
+		size uint64,
+		params CreateDiskOptionalParameters,
+		reader io.ReadSeekCloser,
+		uploadParams UploadImageParameters,
+		retries ...RetryStrategy,
+	) (UploadImageProgress, error)
+
+	// UploadToNewDiskWithParams is identical to StartUploadToNewDiskWithParams, but waits until the upload is
+	// complete.
+	UploadToNewDiskWithParams(
+		storageDomainID StorageDomainID,
+		format ImageFormat,
+		size uint64,
+		params CreateDiskOptionalParameters,
+		reader io.ReadSeekCloser,
+		uploadParams UploadImageParameters,
+		retries ...RetryStrategy,
+	) (UploadImageResult, error)
+
 	// UploadImage is identical to StartImageUpload, but waits until the upload is complete. It returns the disk ID
 	// as a result, or the error if one happened.
 	//
@@ -154,6 +189,79 @@ type DiskClient interface {
 		retries ...RetryStrategy,
 	) error
 
+	// StartUploadToDiskWithParams is identical to StartUploadToDisk, but allows for tuning the upload using
+	// uploadParams. Use UploadImageParams() to obtain a buildable structure. This function can also be used to
+	// resume an upload that failed while keeping its image transfer:
+	//
+	//     params := ovirtclient.UploadImageParams().
+	//         MustWithResume(failedProgress.TransferID(), failedProgress.Chunks())
+	//     progress, err := cli.StartUploadToDiskWithParams(diskID, size, reader, params)
+	StartUploadToDiskWithParams(
+		diskID DiskID,
+		size uint64,
+		reader io.ReadSeekCloser,
+		uploadParams UploadImageParameters,
+		retries ...RetryStrategy,
+	) (UploadImageProgress, error)
+
+	// UploadToDiskWithParams runs StartUploadToDiskWithParams and then waits for the upload to complete.
+	UploadToDiskWithParams(
+		diskID DiskID,
+		size uint64,
+		reader io.ReadSeekCloser,
+		uploadParams UploadImageParameters,
+		retries ...RetryStrategy,
+	) error
+
+	// StartUploadStreamToDisk uploads a disk image from a reader that cannot seek, such as an HTTP response body or a
+	// pipe, to an existing disk. Use UploadStreamParams() to declare the size of the image in the stream. Only the
+	// header needed for detecting the format is buffered, the rest of the image is read sequentially while uploading.
+	// gzip compressed streams are decompressed transparently.
+	//
+	// The uploadParams can be used to tune the upload as with StartUploadToDiskWithParams, or nil for the defaults.
+	// Resuming is not supported, as the stream cannot be read again. If the reader implements io.Closer, it is closed
+	// by the Close method of the progress.
+	StartUploadStreamToDisk(
+		diskID DiskID,
+		reader io.Reader,
+		streamParams UploadStreamParameters,
+		uploadParams UploadImageParameters,
+		retries ...RetryStrategy,
+	) (UploadImageProgress, error)
+
+	// UploadStreamToDisk runs StartUploadStreamToDisk and then waits for the upload to complete.
+	UploadStreamToDisk(
+		diskID DiskID,
+		reader io.Reader,
+		streamParams UploadStreamParameters,
+		uploadParams UploadImageParameters,
+		retries ...RetryStrategy,
+	) error
+
+	// StartUploadStreamToNewDisk creates a new disk and uploads a disk image from a reader that cannot seek into it,
+	// see StartUploadStreamToDisk for details. The format of the disk must match the format of the image in the
+	// stream or be empty to use the format of the image, as streamed images cannot be converted.
+	StartUploadStreamToNewDisk(
+		storageDomainID StorageDomainID,
+		format ImageFormat,
+		params CreateDiskOptionalParameters,
+		reader io.Reader,
+		streamParams UploadStreamParameters,
+		uploadParams UploadImageParameters,
+		retries ...RetryStrategy,
+	) (UploadImageProgress, error)
+
+	// UploadStreamToNewDisk runs StartUploadStreamToNewDisk and then waits for the upload to complete.
+	UploadStreamToNewDisk(
+		storageDomainID StorageDomainID,
+		format ImageFormat,
+		params CreateDiskOptionalParameters,
+		reader io.Reader,
+		streamParams UploadStreamParameters,
+		uploadParams UploadImageParameters,
+		retries ...RetryStrategy,
+	) (UploadImageResult, error)
+
 	// StartImageDownload starts the download of the image file of a specific disk.
 	// The caller can then wait for the initialization using the Initialized() call:
 	//
@@ -204,6 +312,53 @@ type DiskClient interface {
 		retries ...RetryStrategy,
 	) (ImageDownloadReader, error)
 
+	// StartDownloadDiskWithParams is identical to StartDownloadDisk, but allows for verifying the downloaded data
+	// using params. Use DownloadImageParams() to obtain a buildable structure.
+	StartDownloadDiskWithParams(
+		diskID DiskID,
+		format ImageFormat,
+		params DownloadImageParameters,
+		retries ...RetryStrategy,
+	) (ImageDownload, error)
+
+	// DownloadDiskWithParams runs StartDownloadDiskWithParams, then waits for the download to be ready before
+	// returning the reader. The caller MUST close the ImageDownloadReader in order to properly unlock the disk in the
+	// oVirt engine.
+	DownloadDiskWithParams(
+		diskID DiskID,
+		format ImageFormat,
+		params DownloadImageParameters,
+		retries ...RetryStrategy,
+	) (ImageDownloadReader, error)
+
+	// DownloadDiskToFile downloads the image of a disk into a local file at path in the specified format. Only the
+	// extents ImageIO reports as containing data are downloaded, in parallel range requests. Zero extents are not
+	// written, so they remain holes in the file on file systems supporting sparse files. Use DownloadDiskToFileParams()
+	// to obtain a buildable structure for params, or pass nil for the defaults.
+	//
+	// The progress of the download is recorded in a state file next to the target file with the
+	// DiskFileDownloadStateSuffix appended to the name, which is removed when the download completes. The progress is
+	// recorded periodically after syncing the target file, and when the download fails. If the download is restarted
+	// with the resume option enabled, the data recorded as complete is not downloaded again.
+	//
+	// The function returns the number of bytes downloaded, which excludes zero extents and ranges skipped when
+	// resuming.
+	DownloadDiskToFile(
+		diskID DiskID,
+		format ImageFormat,
+		path string,
+		params DownloadDiskToFileParameters,
+		retries ...RetryStrategy,
+	) (uint64, error)
+
+	// DiskExtents returns the extents of the disk in raw format as reported by ImageIO, ordered by their offset. The
+	// Zero flag is set on the extents that read as zeroes. This requires creating an image transfer, so the disk is
+	// locked for a short time.
+	DiskExtents(
+		diskID DiskID,
+		retries ...RetryStrategy,
+	) ([]DiskExtent, error)
+
 	// StartCreateDisk starts creating an empty disk with the specified parameters and returns a DiskCreation object,
 	// which can be queried for completion. Optional parameters can be created using CreateDiskParams().
 	StartCreateDisk(
@@ -228,6 +383,18 @@ type DiskClient interface {
 		retries ...RetryStrategy,
 	) (Disk, error)
 
+	// CreateLUNDisk creates a disk that gives virtual machines direct access to a LUN on iSCSI or FCP storage. The
+	// host must be able to see the LUN, which is used to look up the LUN details. The disk does not reside on a
+	// storage domain and is ready as soon as it is created. Of the optional parameters only the alias and the
+	// shareable flag apply to LUN disks, setting the other parameters results in an EBadArgument error.
+	CreateLUNDisk(
+		hostID HostID,
+		lunID string,
+		storageType LUNStorageType,
+		params CreateDiskOptionalParameters,
+		retries ...RetryStrategy,
+	) (Disk, error)
+
 	// StartUpdateDisk sends the disk update request to the oVirt API and returns a DiskUpdate
 	// object, which can be used to wait for the update to complete. Use UpdateDiskParams to
 	// obtain a builder for the parameters structure.
@@ -245,6 +412,44 @@ type DiskClient interface {
 		retries ...RetryStrategy,
 	) (Disk, error)
 
+	// StartMoveDisk starts moving a disk to a different storage domain and returns a DiskUpdate object, which can be
+	// used to wait for the move to complete. If the disk is attached to a running VM the engine performs a live
+	// storage migration.
+	StartMoveDisk(
+		diskID DiskID,
+		storageDomainID StorageDomainID,
+		retries ...RetryStrategy,
+	) (DiskUpdate, error)
+
+	// MoveDisk is a shorthand for calling StartMoveDisk, and then waiting for the move to complete.
+	MoveDisk(
+		diskID DiskID,
+		storageDomainID StorageDomainID,
+		retries ...RetryStrategy,
+	) (Disk, error)
+
+	// StartCopyDisk starts copying a disk to a storage domain as a new disk with the specified alias and returns a
+	// DiskCreation object, which can be used to wait for the copy to complete. If the alias is empty, the copy gets
+	// the alias of the original disk.
+	//
+	// The oVirt Engine does not return the new disk when the copy is started, therefore the Disk() call of the
+	// returned DiskCreation may return nil until Wait() has located the copy.
+	StartCopyDisk(
+		diskID DiskID,
+		storageDomainID StorageDomainID,
+		alias string,
+		retries ...RetryStrategy,
+	) (DiskCreation, error)
+
+	// CopyDisk is a shorthand for calling StartCopyDisk, and then waiting for the copy to complete. It returns the
+	// new disk.
+	CopyDisk(
+		diskID DiskID,
+		storageDomainID StorageDomainID,
+		alias string,
+		retries ...RetryStrategy,
+	) (Disk, error)
+
 	// ListDisks lists all disks.
 	ListDisks(retries ...RetryStrategy) ([]Disk, error)
 	// GetDisk fetches a disk with a specific ID from the oVirt Engine.
@@ -342,6 +547,14 @@ type CreateDiskOptionalParameters interface {
 
 	// InitialSize is the initially reserved disk space when creating the disk.
 	InitialSize() *uint64
+
+	// ContentType is the content type of the disk. If it returns an empty string, the engine creates a data disk, or
+	// an ISO disk when uploading an ISO9660 image to a new disk. ISO disks must use the raw format.
+	ContentType() DiskContentType
+
+	// Shareable indicates that the disk can be attached to multiple virtual machines at the same time. Shareable
+	// disks must use the raw format. If it returns nil, the disk is not shareable.
+	Shareable() *bool
 }
 
 // BuildableCreateDiskParameters is a buildable version of CreateDiskOptionalParameters.
@@ -362,6 +575,16 @@ type BuildableCreateDiskParameters interface {
 	WithInitialSize(size uint64) (BuildableCreateDiskParameters, error)
 	// MustWithInitialSize is the same as WithInitialSize, but panics instead of returning an error.
 	MustWithInitialSize(size uint64) BuildableCreateDiskParameters
+
+	// WithContentType sets the content type of the disk.
+	WithContentType(contentType DiskContentType) (BuildableCreateDiskParameters, error)
+	// MustWithContentType is the same as WithContentType, but panics instead of returning an error.
+	MustWithContentType(contentType DiskContentType) BuildableCreateDiskParameters
+
+	// WithShareable sets if the disk can be attached to multiple virtual machines at the same time.
+	WithShareable(shareable bool) (BuildableCreateDiskParameters, error)
+	// MustWithShareable is the same as WithShareable, but panics instead of returning an error.
+	MustWithShareable(shareable bool) BuildableCreateDiskParameters
 }
 
 // CreateDiskParams creates a buildable set of CreateDiskOptionalParameters for use with
@@ -374,6 +597,8 @@ type createDiskParams struct {
 	alias       string
 	sparse      *bool
 	initialSize *uint64
+	contentType DiskContentType
+	shareable   *bool
 }
 
 func (c *createDiskParams) Alias() string {
@@ -427,6 +652,43 @@ func (c *createDiskParams) MustWithInitialSize(size uint64) BuildableCreateDiskP
 	return builder
 }
 
+func (c *createDiskParams) ContentType() DiskContentType {
+	return c.contentType
+}
+
+func (c *createDiskParams) WithContentType(contentType DiskContentType) (BuildableCreateDiskParameters, error) {
+	if err := contentType.Validate(); err != nil {
+		return nil, err
+	}
+	c.contentType = contentType
+	return c, nil
+}
+
+func (c *createDiskParams) MustWithContentType(contentType DiskContentType) BuildableCreateDiskParameters {
+	builder, err := c.WithContentType(contentType)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (c *createDiskParams) Shareable() *bool {
+	return c.shareable
+}
+
+func (c *createDiskParams) WithShareable(shareable bool) (BuildableCreateDiskParameters, error) {
+	c.shareable = &shareable
+	return c, nil
+}
+
+func (c *createDiskParams) MustWithShareable(shareable bool) BuildableCreateDiskParameters {
+	builder, err := c.WithShareable(shareable)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
 // DiskCreation is a process object that lets you query the status of the disk creation.
 type DiskCreation interface {
 	// Disk returns the disk that has been created, even if it is not yet ready.
@@ -461,6 +723,9 @@ type ImageDownloadReader interface {
 	// Size returns the size of the disk image in bytes. This is ONLY available after the initialization is complete and
 	// MAY return 0 before.
 	Size() uint64
+	// Checksum returns the checksum of the data read so far in the checksum algorithm requested in the download
+	// parameters. It is empty if no checksum was requested and only complete once all data has been read.
+	Checksum() string
 }
 
 // ImageDownload represents an image download in progress. The caller MUST
@@ -504,6 +769,16 @@ type DiskData interface {
 	Status() DiskStatus
 	// Sparse indicates sparse provisioning on the disk.
 	Sparse() bool
+	// ContentType returns what the disk is used for, for example DiskContentTypeISO for ISO images stored on a data
+	// domain.
+	ContentType() DiskContentType
+	// Shareable indicates that the disk can be attached to multiple virtual machines at the same time.
+	Shareable() bool
+	// StorageType returns where the data of the disk is stored. Image disks are stored on storage domains, LUN disks
+	// give direct access to a LUN and have no storage domains, format or image file.
+	StorageType() DiskStorageType
+	// LUN returns the details of the LUN backing a LUN disk, or nil for other disks.
+	LUN() LUN
 }
 
 // Disk is a disk in oVirt.
@@ -532,6 +807,18 @@ type Disk interface {
 		retries ...RetryStrategy,
 	) (ImageDownloadReader, error)
 
+	// DownloadToFile downloads the image of the current disk into a local file, skipping zero extents. See
+	// DiskClient.DownloadDiskToFile for details.
+	DownloadToFile(
+		format ImageFormat,
+		path string,
+		params DownloadDiskToFileParameters,
+		retries ...RetryStrategy,
+	) (uint64, error)
+
+	// Extents returns the extents of the current disk in raw format.
+	Extents(retries ...RetryStrategy) ([]DiskExtent, error)
+
 	// Remove removes the current disk in the oVirt engine.
 	Remove(retries ...RetryStrategy) error
 
@@ -562,6 +849,19 @@ type Disk interface {
 
 	// WaitForOK waits for the disk status to return to OK.
 	WaitForOK(retries ...RetryStrategy) (Disk, error)
+
+	// Move moves the current disk to a different storage domain and waits for the move to complete.
+	Move(storageDomainID StorageDomainID, retries ...RetryStrategy) (Disk, error)
+
+	// Copy copies the current disk to a storage domain as a new disk with the specified alias and returns the new
+	// disk.
+	Copy(storageDomainID StorageDomainID, alias string, retries ...RetryStrategy) (Disk, error)
+
+	// ListSnapshots lists all layers in the image chain of the current disk, including the active layer.
+	ListSnapshots(retries ...RetryStrategy) ([]DiskSnapshot, error)
+
+	// ImageChain returns the layers of the current disk ordered from the base image to the active layer.
+	ImageChain(retries ...RetryStrategy) ([]DiskSnapshot, error)
 }
 
 // DiskStatus shows the status of a disk. Certain operations lock a disk, which is important because the disk can then
@@ -599,6 +899,113 @@ func (l DiskStatusList) Strings() []string {
 	return result
 }
 
+// DiskContentType describes what a disk is used for. Disks attached to virtual machines have the data content type,
+// ISO images stored on data domains have the ISO content type and can be attached to CDROMs. The other content types
+// are used by the engine internally.
+type DiskContentType string
+
+// Validate returns an error if the disk content type doesn't have a valid value.
+func (c DiskContentType) Validate() error {
+	for _, contentType := range DiskContentTypeValues() {
+		if contentType == c {
+			return nil
+		}
+	}
+	return newError(
+		EBadArgument,
+		"invalid disk content type: %s must be one of: %s",
+		c,
+		strings.Join(DiskContentTypeValues().Strings(), ", "),
+	)
+}
+
+const (
+	// DiskContentTypeData is a regular disk holding data of a virtual machine.
+	DiskContentTypeData DiskContentType = "data"
+	// DiskContentTypeISO is an ISO9660 image that can be attached to the CDROM of a virtual machine.
+	DiskContentTypeISO DiskContentType = "iso"
+	// DiskContentTypeOVFStore stores the OVF descriptions of the virtual machines and templates on a storage domain.
+	DiskContentTypeOVFStore DiskContentType = "ovf_store"
+	// DiskContentTypeMemoryDumpVolume holds the memory of a virtual machine saved in a snapshot or hibernation.
+	DiskContentTypeMemoryDumpVolume DiskContentType = "memory_dump_volume"
+	// DiskContentTypeMemoryMetadataVolume holds the metadata of a memory dump volume.
+	DiskContentTypeMemoryMetadataVolume DiskContentType = "memory_metadata_volume"
+	// DiskContentTypeBackupScratch is a temporary disk used during incremental backups.
+	DiskContentTypeBackupScratch DiskContentType = "backup_scratch"
+	// DiskContentTypeHostedEngine is the disk of the hosted engine virtual machine.
+	DiskContentTypeHostedEngine DiskContentType = "hosted_engine"
+	// DiskContentTypeHostedEngineConfiguration holds the configuration of the hosted engine.
+	DiskContentTypeHostedEngineConfiguration DiskContentType = "hosted_engine_configuration"
+	// DiskContentTypeHostedEngineMetadata holds the metadata of the hosted engine.
+	DiskContentTypeHostedEngineMetadata DiskContentType = "hosted_engine_metadata"
+	// DiskContentTypeHostedEngineSanlock holds the sanlock lockspace of the hosted engine.
+	DiskContentTypeHostedEngineSanlock DiskContentType = "hosted_engine_sanlock"
+)
+
+// DiskContentTypeList is a list of DiskContentType values.
+type DiskContentTypeList []DiskContentType
+
+// DiskContentTypeValues returns all possible values for DiskContentType.
+func DiskContentTypeValues() DiskContentTypeList {
+	return []DiskContentType{
+		DiskContentTypeData,
+		DiskContentTypeISO,
+		DiskContentTypeOVFStore,
+		DiskContentTypeMemoryDumpVolume,
+		DiskContentTypeMemoryMetadataVolume,
+		DiskContentTypeBackupScratch,
+		DiskContentTypeHostedEngine,
+		DiskContentTypeHostedEngineConfiguration,
+		DiskContentTypeHostedEngineMetadata,
+		DiskContentTypeHostedEngineSanlock,
+	}
+}
+
+// Strings returns a list of strings.
+func (l DiskContentTypeList) Strings() []string {
+	result := make([]string, len(l))
+	for i, contentType := range l {
+		result[i] = string(contentType)
+	}
+	return result
+}
+
+// DiskStorageType describes where the data of a disk is stored.
+type DiskStorageType string
+
+const (
+	// DiskStorageTypeImage is a disk stored as an image on a storage domain.
+	DiskStorageTypeImage DiskStorageType = "image"
+	// DiskStorageTypeLUN is a disk that gives direct access to a LUN on iSCSI or FCP storage.
+	DiskStorageTypeLUN DiskStorageType = "lun"
+	// DiskStorageTypeCinder is a disk stored on an OpenStack Cinder volume.
+	DiskStorageTypeCinder DiskStorageType = "cinder"
+	// DiskStorageTypeManagedBlockStorage is a disk stored on a managed block storage domain.
+	DiskStorageTypeManagedBlockStorage DiskStorageType = "managed_block_storage"
+)
+
+// DiskStorageTypeList is a list of DiskStorageType values.
+type DiskStorageTypeList []DiskStorageType
+
+// DiskStorageTypeValues returns all possible values for DiskStorageType.
+func DiskStorageTypeValues() DiskStorageTypeList {
+	return []DiskStorageType{
+		DiskStorageTypeImage,
+		DiskStorageTypeLUN,
+		DiskStorageTypeCinder,
+		DiskStorageTypeManagedBlockStorage,
+	}
+}
+
+// Strings returns a list of strings.
+func (l DiskStorageTypeList) Strings() []string {
+	result := make([]string, len(l))
+	for i, storageType := range l {
+		result[i] = string(storageType)
+	}
+	return result
+}
+
 // UploadImageProgress is a tracker for the upload progress happening in the background.
 type UploadImageProgress interface {
 	// Disk returns the disk created as part of the upload process once the upload is complete. Before the upload
@@ -614,89 +1021,968 @@ type UploadImageProgress interface {
 	Err() error
 	// Done returns a channel that will be closed when the upload is complete.
 	Done() <-chan struct{}
+	// TransferID returns the ID of the image transfer used for the upload. It is empty until the image transfer has
+	// been created.
+	TransferID() ImageTransferID
+	// Chunks returns the state of the individual chunks of the upload.
+	Chunks() []UploadChunk
+	// Checksum returns the checksum of the uploaded data in the checksum algorithm requested in the upload
+	// parameters. It is empty if no checksum was requested or the upload is not complete.
+	Checksum() string
 }
 
-// ImageFormat is a constant for representing the format that images can be in. This is relevant
-// for both image uploads and image downloads, as the oVirt engine has the capability of converting
-// between these formats.
-//
-// Note: the mocking facility cannot convert between the formats due to the complexity of the QCOW2
-// format. It is recommended to write tests only using the raw format as comparing QCOW2 files
-// is complex.
-type ImageFormat string
+// ImageTransferID is the identifier of an image transfer in the oVirt Engine.
+type ImageTransferID string
 
-// Validate returns an error if the image format doesn't have a valid value.
-func (f ImageFormat) Validate() error {
-	for _, format := range ImageFormatValues() {
-		if format == f {
+// UploadChunkState is the state of a single chunk of an image upload.
+type UploadChunkState string
+
+const (
+	// UploadChunkStatePending means that the chunk has not been uploaded yet.
+	UploadChunkStatePending UploadChunkState = "pending"
+	// UploadChunkStateUploading means that the chunk is currently being uploaded.
+	UploadChunkStateUploading UploadChunkState = "uploading"
+	// UploadChunkStateDone means that the chunk has been uploaded successfully.
+	UploadChunkStateDone UploadChunkState = "done"
+	// UploadChunkStateFailed means that the chunk could not be uploaded despite retries.
+	UploadChunkStateFailed UploadChunkState = "failed"
+)
+
+// UploadChunkStateList is a list of UploadChunkState.
+type UploadChunkStateList []UploadChunkState
+
+// UploadChunkStateValues returns all possible UploadChunkState values.
+func UploadChunkStateValues() UploadChunkStateList {
+	return []UploadChunkState{
+		UploadChunkStatePending,
+		UploadChunkStateUploading,
+		UploadChunkStateDone,
+		UploadChunkStateFailed,
+	}
+}
+
+// Strings creates a string list of the values.
+func (l UploadChunkStateList) Strings() []string {
+	result := make([]string, len(l))
+	for i, state := range l {
+		result[i] = string(state)
+	}
+	return result
+}
+
+// Validate returns an error if the upload chunk state is not valid.
+func (s UploadChunkState) Validate() error {
+	for _, state := range UploadChunkStateValues() {
+		if state == s {
 			return nil
 		}
 	}
 	return newError(
 		EBadArgument,
-		"invalid image format: %s must be one of: %s",
-		f,
-		strings.Join(ImageFormatValues().Strings(), ", "),
+		"invalid upload chunk state: %s must be one of: %s",
+		s,
+		strings.Join(UploadChunkStateValues().Strings(), ", "),
 	)
 }
 
+// UploadChunk is a range of the uploaded image that is sent in a single request.
+type UploadChunk struct {
+	// Offset is the position of the chunk in the image in bytes.
+	Offset uint64
+	// Length is the size of the chunk in bytes.
+	Length uint64
+	// State is the upload state of the chunk.
+	State UploadChunkState
+}
+
+// ChecksumAlgorithm is an algorithm for verifying the integrity of transferred image data.
+type ChecksumAlgorithm string
+
 const (
-	// ImageFormatCow is an image conforming to the QCOW2 image format. This image format can use
-	// compression, supports snapshots, and other features.
-	// See https://github.com/qemu/qemu/blob/master/docs/interop/qcow2.txt for details.
-	ImageFormatCow ImageFormat = "cow"
-	// ImageFormatRaw is not actually a format, it only contains the raw bytes on the block device.
-	ImageFormatRaw ImageFormat = "raw"
+	// ChecksumAlgorithmSHA256 is the SHA-256 hash of the whole image. The ImageIO server cannot calculate this
+	// checksum, so it is only compared to the expected checksum passed in the parameters.
+	ChecksumAlgorithmSHA256 ChecksumAlgorithm = "sha256"
+	// ChecksumAlgorithmBlockSHA256 is the block based checksum calculated by ImageIO using SHA-256. The image is split
+	// into blocks of 4 MiB, and the checksum is the SHA-256 hash of the concatenated SHA-256 hashes of the blocks. The
+	// checksum is also compared to the checksum calculated by the ImageIO server if the server supports it.
+	ChecksumAlgorithmBlockSHA256 ChecksumAlgorithm = "block-sha256"
 )
 
-// ImageFormatList is a list of ImageFormat values.
-type ImageFormatList []ImageFormat
+// ChecksumAlgorithmList is a list of ChecksumAlgorithm.
+type ChecksumAlgorithmList []ChecksumAlgorithm
 
-// ImageFormatValues returns all possible ImageFormat values.
-func ImageFormatValues() ImageFormatList {
-	return []ImageFormat{
-		ImageFormatCow,
-		ImageFormatRaw,
+// ChecksumAlgorithmValues returns all possible ChecksumAlgorithm values.
+func ChecksumAlgorithmValues() ChecksumAlgorithmList {
+	return []ChecksumAlgorithm{
+		ChecksumAlgorithmSHA256,
+		ChecksumAlgorithmBlockSHA256,
 	}
 }
 
 // Strings creates a string list of the values.
-func (l ImageFormatList) Strings() []string {
+func (l ChecksumAlgorithmList) Strings() []string {
 	result := make([]string, len(l))
-	for i, status := range l {
-		result[i] = string(status)
+	for i, algorithm := range l {
+		result[i] = string(algorithm)
 	}
 	return result
 }
 
-func convertSDKDisk(sdkDisk *ovirtsdk4.Disk, client Client) (Disk, error) {
-	id, ok := sdkDisk.Id()
-	if !ok {
-		return nil, newError(EFieldMissing, "disk does not contain an ID")
-	}
-	var storageDomainIDs []StorageDomainID
-	if sdkStorageDomain, ok := sdkDisk.StorageDomain(); ok {
-		storageDomainID, _ := sdkStorageDomain.Id()
-		storageDomainIDs = append(storageDomainIDs, StorageDomainID(storageDomainID))
-	}
-	if sdkStorageDomains, ok := sdkDisk.StorageDomains(); ok {
-		for _, sd := range sdkStorageDomains.Slice() {
-			storageDomainID, _ := sd.Id()
-			storageDomainIDs = append(storageDomainIDs, StorageDomainID(storageDomainID))
+// Validate returns an error if the checksum algorithm is not valid.
+func (a ChecksumAlgorithm) Validate() error {
+	for _, algorithm := range ChecksumAlgorithmValues() {
+		if algorithm == a {
+			return nil
 		}
 	}
-	if len(storageDomainIDs) == 0 {
-		return nil, newError(EFieldMissing, "failed to find a valid storage domain for disk %s", id)
+	return newError(
+		EBadArgument,
+		"invalid checksum algorithm: %s must be one of: %s",
+		a,
+		strings.Join(ChecksumAlgorithmValues().Strings(), ", "),
+	)
+}
+
+// defaultUploadChunkSize is the size of the ranges uploaded in a single request if no chunk size is specified.
+const defaultUploadChunkSize = 8 * 1024 * 1024
+
+// defaultUploadWorkers is the number of chunks uploaded in parallel if no worker count is specified.
+const defaultUploadWorkers = 4
+
+// UploadImageParameters contains the optional parameters for tuning image uploads.
+type UploadImageParameters interface {
+	TransferOptions
+
+	// ChunkSize returns the number of bytes sent in a single request. Each chunk is retried individually if the
+	// request fails.
+	ChunkSize() uint64
+	// Workers returns the number of chunks uploaded in parallel.
+	Workers() uint
+	// ResumeTransferID returns the ID of the image transfer to resume, or an empty string if a new image transfer
+	// should be created.
+	ResumeTransferID() ImageTransferID
+	// CompletedChunks returns the chunks of a previous upload. Chunks in the done state are not uploaded again when
+	// resuming if they match the chunk size of the current upload.
+	CompletedChunks() []UploadChunk
+	// KeepTransferOnError returns true if the image transfer should be paused instead of canceled when the upload
+	// fails, so it can be resumed later. The disk stays locked while the image transfer exists.
+	KeepTransferOnError() bool
+	// ZeroDetection returns true if ranges of the image that contain only zeroes are sent using the zero operation
+	// of ImageIO instead of sending the data. For QCOW2 images, ranges of the file that are not referenced by the
+	// image metadata are also sent as zeroes without reading them.
+	ZeroDetection() bool
+	// ChecksumAlgorithm returns the algorithm used to verify the uploaded data, or an empty string if the data should
+	// not be verified. The checksum is calculated over the data as sent, so unreferenced ranges of QCOW2 images sent
+	// as zeroes are hashed as zeroes. Calculating the checksum requires reading the image a second time after the
+	// data has been sent.
+	ChecksumAlgorithm() ChecksumAlgorithm
+	// ExpectedChecksum returns the hex-encoded checksum the uploaded data must match. If empty, the checksum is only
+	// compared to the checksum calculated by the ImageIO server where available.
+	ExpectedChecksum() string
+	// LocalConversion returns true if images uploaded to a new disk in a different format are converted on the
+	// client using ConvertImage instead of by the engine.
+	LocalConversion() bool
+}
+
+// BuildableUploadImageParameters is a buildable version of UploadImageParameters.
+type BuildableUploadImageParameters interface {
+	UploadImageParameters
+
+	// WithChunkSize sets the number of bytes sent in a single request.
+	WithChunkSize(chunkSize uint64) (BuildableUploadImageParameters, error)
+	// MustWithChunkSize is identical to WithChunkSize, but panics instead of returning an error.
+	MustWithChunkSize(chunkSize uint64) BuildableUploadImageParameters
+
+	// WithWorkers sets the number of chunks uploaded in parallel.
+	WithWorkers(workers uint) (BuildableUploadImageParameters, error)
+	// MustWithWorkers is identical to WithWorkers, but panics instead of returning an error.
+	MustWithWorkers(workers uint) BuildableUploadImageParameters
+
+	// WithResume sets the image transfer to resume and the chunks of the previous upload. This also sets
+	// KeepTransferOnError so a failed resume can be resumed again.
+	WithResume(transferID ImageTransferID, chunks []UploadChunk) (BuildableUploadImageParameters, error)
+	// MustWithResume is identical to WithResume, but panics instead of returning an error.
+	MustWithResume(transferID ImageTransferID, chunks []UploadChunk) BuildableUploadImageParameters
+
+	// WithKeepTransferOnError sets if the image transfer should be paused instead of canceled when the upload fails.
+	WithKeepTransferOnError(keep bool) (BuildableUploadImageParameters, error)
+	// MustWithKeepTransferOnError is identical to WithKeepTransferOnError, but panics instead of returning an error.
+	MustWithKeepTransferOnError(keep bool) BuildableUploadImageParameters
+
+	// WithZeroDetection sets if ranges containing only zeroes should be sent using the zero operation. This is
+	// enabled by default.
+	WithZeroDetection(zeroDetection bool) (BuildableUploadImageParameters, error)
+	// MustWithZeroDetection is identical to WithZeroDetection, but panics instead of returning an error.
+	MustWithZeroDetection(zeroDetection bool) BuildableUploadImageParameters
+
+	// WithChecksum enables verifying the uploaded data using the specified algorithm. If expected is not empty, the
+	// upload fails with EChecksumMismatch if the checksum of the uploaded data does not match it.
+	WithChecksum(algorithm ChecksumAlgorithm, expected string) (BuildableUploadImageParameters, error)
+	// MustWithChecksum is identical to WithChecksum, but panics instead of returning an error.
+	MustWithChecksum(algorithm ChecksumAlgorithm, expected string) BuildableUploadImageParameters
+
+	// WithRateLimiter passes the uploaded data through the specified limiter. Use NewTransferRateLimiter to create a
+	// limiter, which can be shared with other transfers.
+	WithRateLimiter(limiter TransferRateLimiter) (BuildableUploadImageParameters, error)
+	// MustWithRateLimiter is identical to WithRateLimiter, but panics instead of returning an error.
+	MustWithRateLimiter(limiter TransferRateLimiter) BuildableUploadImageParameters
+
+	// WithProgressCallback sets a function that is called with the progress of the upload in the specified interval,
+	// and once more when the upload completes or fails. If the interval is 0, the progress is reported every second.
+	WithProgressCallback(
+		callback TransferProgressCallback,
+		interval time.Duration,
+	) (BuildableUploadImageParameters, error)
+	// MustWithProgressCallback is identical to WithProgressCallback, but panics instead of returning an error.
+	MustWithProgressCallback(callback TransferProgressCallback, interval time.Duration) BuildableUploadImageParameters
+
+	// WithInactivityTimeout sets the time after which the upload fails with ETimeout if no data has been sent. The
+	// image transfer is then canceled, or paused if KeepTransferOnError is set. If the source reader implements
+	// io.Closer, it is closed to interrupt a blocked read.
+	WithInactivityTimeout(timeout time.Duration) (BuildableUploadImageParameters, error)
+	// MustWithInactivityTimeout is identical to WithInactivityTimeout, but panics instead of returning an error.
+	MustWithInactivityTimeout(timeout time.Duration) BuildableUploadImageParameters
+
+	// WithLocalConversion sets if images uploaded to a new disk in a different format should be converted on the
+	// client instead of by the engine. This is disabled by default.
+	WithLocalConversion(localConversion bool) (BuildableUploadImageParameters, error)
+	// MustWithLocalConversion is identical to WithLocalConversion, but panics instead of returning an error.
+	MustWithLocalConversion(localConversion bool) BuildableUploadImageParameters
+}
+
+// UploadImageParams creates a buildable set of UploadImageParameters for use with the image upload functions.
+func UploadImageParams() BuildableUploadImageParameters {
+	return &uploadImageParams{
+		chunkSize:     defaultUploadChunkSize,
+		workers:       defaultUploadWorkers,
+		zeroDetection: true,
 	}
-	alias, ok := sdkDisk.Alias()
-	if !ok {
-		return nil, newError(EFieldMissing, "disk %s does not contain an alias", id)
+}
+
+type uploadImageParams struct {
+	transferOptions
+
+	chunkSize           uint64
+	workers             uint
+	resumeTransferID    ImageTransferID
+	completedChunks     []UploadChunk
+	keepTransferOnError bool
+	zeroDetection       bool
+	checksumAlgorithm   ChecksumAlgorithm
+	expectedChecksum    string
+	localConversion     bool
+}
+
+func (u *uploadImageParams) ChunkSize() uint64 {
+	return u.chunkSize
+}
+
+func (u *uploadImageParams) WithChunkSize(chunkSize uint64) (BuildableUploadImageParameters, error) {
+	if chunkSize == 0 {
+		return nil, newError(EBadArgument, "the upload chunk size must be positive")
 	}
-	provisionedSize, ok := sdkDisk.ProvisionedSize()
-	if !ok {
-		return nil, newError(EFieldMissing, "disk %s does not contain a provisioned size", id)
+	u.chunkSize = chunkSize
+	return u, nil
+}
+
+func (u *uploadImageParams) MustWithChunkSize(chunkSize uint64) BuildableUploadImageParameters {
+	builder, err := u.WithChunkSize(chunkSize)
+	if err != nil {
+		panic(err)
 	}
-	totalSize, ok := sdkDisk.TotalSize()
+	return builder
+}
+
+func (u *uploadImageParams) Workers() uint {
+	return u.workers
+}
+
+func (u *uploadImageParams) WithWorkers(workers uint) (BuildableUploadImageParameters, error) {
+	if workers == 0 {
+		return nil, newError(EBadArgument, "at least one upload worker is required")
+	}
+	u.workers = workers
+	return u, nil
+}
+
+func (u *uploadImageParams) MustWithWorkers(workers uint) BuildableUploadImageParameters {
+	builder, err := u.WithWorkers(workers)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (u *uploadImageParams) ResumeTransferID() ImageTransferID {
+	return u.resumeTransferID
+}
+
+func (u *uploadImageParams) CompletedChunks() []UploadChunk {
+	return u.completedChunks
+}
+
+func (u *uploadImageParams) WithResume(
+	transferID ImageTransferID,
+	chunks []UploadChunk,
+) (BuildableUploadImageParameters, error) {
+	if transferID == "" {
+		return nil, newError(EBadArgument, "the image transfer ID to resume must not be empty")
+	}
+	u.resumeTransferID = transferID
+	u.completedChunks = chunks
+	u.keepTransferOnError = true
+	return u, nil
+}
+
+func (u *uploadImageParams) MustWithResume(
+	transferID ImageTransferID,
+	chunks []UploadChunk,
+) BuildableUploadImageParameters {
+	builder, err := u.WithResume(transferID, chunks)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (u *uploadImageParams) KeepTransferOnError() bool {
+	return u.keepTransferOnError
+}
+
+func (u *uploadImageParams) WithKeepTransferOnError(keep bool) (BuildableUploadImageParameters, error) {
+	u.keepTransferOnError = keep
+	return u, nil
+}
+
+func (u *uploadImageParams) MustWithKeepTransferOnError(keep bool) BuildableUploadImageParameters {
+	builder, err := u.WithKeepTransferOnError(keep)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (u *uploadImageParams) ZeroDetection() bool {
+	return u.zeroDetection
+}
+
+func (u *uploadImageParams) WithZeroDetection(zeroDetection bool) (BuildableUploadImageParameters, error) {
+	u.zeroDetection = zeroDetection
+	return u, nil
+}
+
+func (u *uploadImageParams) MustWithZeroDetection(zeroDetection bool) BuildableUploadImageParameters {
+	builder, err := u.WithZeroDetection(zeroDetection)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (u *uploadImageParams) ChecksumAlgorithm() ChecksumAlgorithm {
+	return u.checksumAlgorithm
+}
+
+func (u *uploadImageParams) ExpectedChecksum() string {
+	return u.expectedChecksum
+}
+
+func (u *uploadImageParams) WithChecksum(
+	algorithm ChecksumAlgorithm,
+	expected string,
+) (BuildableUploadImageParameters, error) {
+	if err := algorithm.Validate(); err != nil {
+		return nil, err
+	}
+	u.checksumAlgorithm = algorithm
+	u.expectedChecksum = strings.ToLower(expected)
+	return u, nil
+}
+
+func (u *uploadImageParams) MustWithChecksum(algorithm ChecksumAlgorithm, expected string) BuildableUploadImageParameters {
+	builder, err := u.WithChecksum(algorithm, expected)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (u *uploadImageParams) LocalConversion() bool {
+	return u.localConversion
+}
+
+func (u *uploadImageParams) WithLocalConversion(localConversion bool) (BuildableUploadImageParameters, error) {
+	u.localConversion = localConversion
+	return u, nil
+}
+
+func (u *uploadImageParams) MustWithLocalConversion(localConversion bool) BuildableUploadImageParameters {
+	builder, err := u.WithLocalConversion(localConversion)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (u *uploadImageParams) WithRateLimiter(limiter TransferRateLimiter) (BuildableUploadImageParameters, error) {
+	u.rateLimiter = limiter
+	return u, nil
+}
+
+func (u *uploadImageParams) MustWithRateLimiter(limiter TransferRateLimiter) BuildableUploadImageParameters {
+	builder, err := u.WithRateLimiter(limiter)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (u *uploadImageParams) WithProgressCallback(
+	callback TransferProgressCallback,
+	interval time.Duration,
+) (BuildableUploadImageParameters, error) {
+	if err := u.setProgressCallback(callback, interval); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+func (u *uploadImageParams) MustWithProgressCallback(
+	callback TransferProgressCallback,
+	interval time.Duration,
+) BuildableUploadImageParameters {
+	builder, err := u.WithProgressCallback(callback, interval)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (u *uploadImageParams) WithInactivityTimeout(timeout time.Duration) (BuildableUploadImageParameters, error) {
+	if err := u.setInactivityTimeout(timeout); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+func (u *uploadImageParams) MustWithInactivityTimeout(timeout time.Duration) BuildableUploadImageParameters {
+	builder, err := u.WithInactivityTimeout(timeout)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+// DownloadImageParameters contains the optional parameters for image downloads.
+type DownloadImageParameters interface {
+	TransferOptions
+
+	// ChecksumAlgorithm returns the algorithm used to verify the downloaded data, or an empty string if the data
+	// should not be verified.
+	ChecksumAlgorithm() ChecksumAlgorithm
+	// ExpectedChecksum returns the hex-encoded checksum the downloaded data must match. If empty, the checksum is only
+	// compared to the checksum calculated by the ImageIO server where available.
+	ExpectedChecksum() string
+}
+
+// BuildableDownloadImageParameters is a buildable version of DownloadImageParameters.
+type BuildableDownloadImageParameters interface {
+	DownloadImageParameters
+
+	// WithChecksum enables verifying the downloaded data using the specified algorithm. The checksum is verified when
+	// the last byte is read, and the final Read call returns EChecksumMismatch if the checksum does not match the
+	// expected checksum or the checksum calculated by the ImageIO server.
+	WithChecksum(algorithm ChecksumAlgorithm, expected string) (BuildableDownloadImageParameters, error)
+	// MustWithChecksum is identical to WithChecksum, but panics instead of returning an error.
+	MustWithChecksum(algorithm ChecksumAlgorithm, expected string) BuildableDownloadImageParameters
+
+	// WithRateLimiter passes the downloaded data through the specified limiter. Use NewTransferRateLimiter to create
+	// a limiter, which can be shared with other transfers.
+	WithRateLimiter(limiter TransferRateLimiter) (BuildableDownloadImageParameters, error)
+	// MustWithRateLimiter is identical to WithRateLimiter, but panics instead of returning an error.
+	MustWithRateLimiter(limiter TransferRateLimiter) BuildableDownloadImageParameters
+
+	// WithProgressCallback sets a function that is called with the progress of the download in the specified
+	// interval, and once more when the download is closed or fails. If the interval is 0, the progress is reported
+	// every second.
+	WithProgressCallback(
+		callback TransferProgressCallback,
+		interval time.Duration,
+	) (BuildableDownloadImageParameters, error)
+	// MustWithProgressCallback is identical to WithProgressCallback, but panics instead of returning an error.
+	MustWithProgressCallback(callback TransferProgressCallback, interval time.Duration) BuildableDownloadImageParameters
+
+	// WithInactivityTimeout sets the time after which the download is aborted if a Read call has not received any
+	// data. Time spent by the caller between Read calls does not count as inactivity. After the timeout, Read returns
+	// ETimeout and the image transfer is canceled.
+	WithInactivityTimeout(timeout time.Duration) (BuildableDownloadImageParameters, error)
+	// MustWithInactivityTimeout is identical to WithInactivityTimeout, but panics instead of returning an error.
+	MustWithInactivityTimeout(timeout time.Duration) BuildableDownloadImageParameters
+}
+
+// DownloadImageParams creates a buildable set of DownloadImageParameters for use with the image download functions.
+func DownloadImageParams() BuildableDownloadImageParameters {
+	return &downloadImageParams{}
+}
+
+type downloadImageParams struct {
+	transferOptions
+
+	checksumAlgorithm ChecksumAlgorithm
+	expectedChecksum  string
+}
+
+func (d *downloadImageParams) ChecksumAlgorithm() ChecksumAlgorithm {
+	return d.checksumAlgorithm
+}
+
+func (d *downloadImageParams) ExpectedChecksum() string {
+	return d.expectedChecksum
+}
+
+func (d *downloadImageParams) WithChecksum(
+	algorithm ChecksumAlgorithm,
+	expected string,
+) (BuildableDownloadImageParameters, error) {
+	if err := algorithm.Validate(); err != nil {
+		return nil, err
+	}
+	d.checksumAlgorithm = algorithm
+	d.expectedChecksum = strings.ToLower(expected)
+	return d, nil
+}
+
+func (d *downloadImageParams) MustWithChecksum(
+	algorithm ChecksumAlgorithm,
+	expected string,
+) BuildableDownloadImageParameters {
+	builder, err := d.WithChecksum(algorithm, expected)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (d *downloadImageParams) WithRateLimiter(limiter TransferRateLimiter) (BuildableDownloadImageParameters, error) {
+	d.rateLimiter = limiter
+	return d, nil
+}
+
+func (d *downloadImageParams) MustWithRateLimiter(limiter TransferRateLimiter) BuildableDownloadImageParameters {
+	builder, err := d.WithRateLimiter(limiter)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (d *downloadImageParams) WithProgressCallback(
+	callback TransferProgressCallback,
+	interval time.Duration,
+) (BuildableDownloadImageParameters, error) {
+	if err := d.setProgressCallback(callback, interval); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (d *downloadImageParams) MustWithProgressCallback(
+	callback TransferProgressCallback,
+	interval time.Duration,
+) BuildableDownloadImageParameters {
+	builder, err := d.WithProgressCallback(callback, interval)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (d *downloadImageParams) WithInactivityTimeout(timeout time.Duration) (BuildableDownloadImageParameters, error) {
+	if err := d.setInactivityTimeout(timeout); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (d *downloadImageParams) MustWithInactivityTimeout(timeout time.Duration) BuildableDownloadImageParameters {
+	builder, err := d.WithInactivityTimeout(timeout)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+// DiskFileDownloadStateSuffix is appended to the path of the target file of DownloadDiskToFile to obtain the path of
+// the file recording the progress of the download.
+const DiskFileDownloadStateSuffix = ".download"
+
+// defaultDownloadChunkSize is the size of the ranges downloaded in a single request if no chunk size is specified.
+const defaultDownloadChunkSize = 8 * 1024 * 1024
+
+// defaultDownloadWorkers is the number of chunks downloaded in parallel if no worker count is specified.
+const defaultDownloadWorkers = 4
+
+// DownloadDiskToFileParameters contains the optional parameters for downloading disks to files.
+type DownloadDiskToFileParameters interface {
+	TransferOptions
+
+	// ChunkSize returns the maximum number of bytes requested in a single range request. Each chunk is retried
+	// individually if the request fails.
+	ChunkSize() uint64
+	// Workers returns the number of chunks downloaded in parallel.
+	Workers() uint
+	// Resume returns true if a previous download into the same file should be continued using its state file. If
+	// false, the target file is overwritten.
+	Resume() bool
+}
+
+// BuildableDownloadDiskToFileParameters is a buildable version of DownloadDiskToFileParameters.
+type BuildableDownloadDiskToFileParameters interface {
+	DownloadDiskToFileParameters
+
+	// WithChunkSize sets the maximum number of bytes requested in a single range request.
+	WithChunkSize(chunkSize uint64) (BuildableDownloadDiskToFileParameters, error)
+	// MustWithChunkSize is identical to WithChunkSize, but panics instead of returning an error.
+	MustWithChunkSize(chunkSize uint64) BuildableDownloadDiskToFileParameters
+
+	// WithWorkers sets the number of chunks downloaded in parallel.
+	WithWorkers(workers uint) (BuildableDownloadDiskToFileParameters, error)
+	// MustWithWorkers is identical to WithWorkers, but panics instead of returning an error.
+	MustWithWorkers(workers uint) BuildableDownloadDiskToFileParameters
+
+	// WithResume sets if a previous download into the same file should be continued.
+	WithResume(resume bool) (BuildableDownloadDiskToFileParameters, error)
+	// MustWithResume is identical to WithResume, but panics instead of returning an error.
+	MustWithResume(resume bool) BuildableDownloadDiskToFileParameters
+
+	// WithRateLimiter passes the downloaded data through the specified limiter. Use NewTransferRateLimiter to create
+	// a limiter, which can be shared with other transfers.
+	WithRateLimiter(limiter TransferRateLimiter) (BuildableDownloadDiskToFileParameters, error)
+	// MustWithRateLimiter is identical to WithRateLimiter, but panics instead of returning an error.
+	MustWithRateLimiter(limiter TransferRateLimiter) BuildableDownloadDiskToFileParameters
+
+	// WithProgressCallback sets a function that is called with the progress of the download in the specified
+	// interval, and once more when the download completes or fails. The progress is counted in whole chunks. If the
+	// interval is 0, the progress is reported every second.
+	WithProgressCallback(
+		callback TransferProgressCallback,
+		interval time.Duration,
+	) (BuildableDownloadDiskToFileParameters, error)
+	// MustWithProgressCallback is identical to WithProgressCallback, but panics instead of returning an error.
+	MustWithProgressCallback(
+		callback TransferProgressCallback,
+		interval time.Duration,
+	) BuildableDownloadDiskToFileParameters
+
+	// WithInactivityTimeout sets the time after which the download fails with ETimeout if no chunk has been
+	// downloaded. The timeout must be longer than downloading a single chunk takes. The progress recorded so far is
+	// kept, so the download can be resumed.
+	WithInactivityTimeout(timeout time.Duration) (BuildableDownloadDiskToFileParameters, error)
+	// MustWithInactivityTimeout is identical to WithInactivityTimeout, but panics instead of returning an error.
+	MustWithInactivityTimeout(timeout time.Duration) BuildableDownloadDiskToFileParameters
+}
+
+// DownloadDiskToFileParams creates a buildable set of DownloadDiskToFileParameters for use with DownloadDiskToFile.
+func DownloadDiskToFileParams() BuildableDownloadDiskToFileParameters {
+	return &downloadDiskToFileParams{
+		chunkSize: defaultDownloadChunkSize,
+		workers:   defaultDownloadWorkers,
+	}
+}
+
+type downloadDiskToFileParams struct {
+	transferOptions
+
+	chunkSize uint64
+	workers   uint
+	resume    bool
+}
+
+func (d *downloadDiskToFileParams) ChunkSize() uint64 {
+	return d.chunkSize
+}
+
+func (d *downloadDiskToFileParams) Workers() uint {
+	return d.workers
+}
+
+func (d *downloadDiskToFileParams) Resume() bool {
+	return d.resume
+}
+
+func (d *downloadDiskToFileParams) WithChunkSize(chunkSize uint64) (BuildableDownloadDiskToFileParameters, error) {
+	if chunkSize == 0 {
+		return nil, newError(EBadArgument, "the download chunk size must be positive")
+	}
+	d.chunkSize = chunkSize
+	return d, nil
+}
+
+func (d *downloadDiskToFileParams) MustWithChunkSize(chunkSize uint64) BuildableDownloadDiskToFileParameters {
+	builder, err := d.WithChunkSize(chunkSize)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (d *downloadDiskToFileParams) WithWorkers(workers uint) (BuildableDownloadDiskToFileParameters, error) {
+	if workers == 0 {
+		return nil, newError(EBadArgument, "at least one download worker is required")
+	}
+	d.workers = workers
+	return d, nil
+}
+
+func (d *downloadDiskToFileParams) MustWithWorkers(workers uint) BuildableDownloadDiskToFileParameters {
+	builder, err := d.WithWorkers(workers)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (d *downloadDiskToFileParams) WithResume(resume bool) (BuildableDownloadDiskToFileParameters, error) {
+	d.resume = resume
+	return d, nil
+}
+
+func (d *downloadDiskToFileParams) MustWithResume(resume bool) BuildableDownloadDiskToFileParameters {
+	builder, err := d.WithResume(resume)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (d *downloadDiskToFileParams) WithRateLimiter(
+	limiter TransferRateLimiter,
+) (BuildableDownloadDiskToFileParameters, error) {
+	d.rateLimiter = limiter
+	return d, nil
+}
+
+func (d *downloadDiskToFileParams) MustWithRateLimiter(
+	limiter TransferRateLimiter,
+) BuildableDownloadDiskToFileParameters {
+	builder, err := d.WithRateLimiter(limiter)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (d *downloadDiskToFileParams) WithProgressCallback(
+	callback TransferProgressCallback,
+	interval time.Duration,
+) (BuildableDownloadDiskToFileParameters, error) {
+	if err := d.setProgressCallback(callback, interval); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (d *downloadDiskToFileParams) MustWithProgressCallback(
+	callback TransferProgressCallback,
+	interval time.Duration,
+) BuildableDownloadDiskToFileParameters {
+	builder, err := d.WithProgressCallback(callback, interval)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (d *downloadDiskToFileParams) WithInactivityTimeout(
+	timeout time.Duration,
+) (BuildableDownloadDiskToFileParameters, error) {
+	if err := d.setInactivityTimeout(timeout); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (d *downloadDiskToFileParams) MustWithInactivityTimeout(
+	timeout time.Duration,
+) BuildableDownloadDiskToFileParameters {
+	builder, err := d.WithInactivityTimeout(timeout)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+// UploadStreamParameters describes the image in a stream uploaded using StartUploadStreamToDisk or
+// StartUploadStreamToNewDisk.
+type UploadStreamParameters interface {
+	// Size returns the number of bytes of the image in the stream after decompression. For raw images this is the
+	// virtual size of the disk. The upload fails if the stream is shorter or longer.
+	Size() uint64
+	// Format returns the declared format of the image, or an empty string if the format should be detected from the
+	// header of the image.
+	Format() ImageFormat
+	// Compression returns the declared compression of the stream, or an empty string if the compression should be
+	// detected from the first bytes of the stream.
+	Compression() ImageCompression
+}
+
+// BuildableUploadStreamParameters is a buildable version of UploadStreamParameters.
+type BuildableUploadStreamParameters interface {
+	UploadStreamParameters
+
+	// WithFormat sets the declared format of the image. The upload fails if the header of the image does not match.
+	WithFormat(format ImageFormat) (BuildableUploadStreamParameters, error)
+	// MustWithFormat is identical to WithFormat, but panics instead of returning an error.
+	MustWithFormat(format ImageFormat) BuildableUploadStreamParameters
+
+	// WithCompression sets the declared compression of the stream.
+	WithCompression(compression ImageCompression) (BuildableUploadStreamParameters, error)
+	// MustWithCompression is identical to WithCompression, but panics instead of returning an error.
+	MustWithCompression(compression ImageCompression) BuildableUploadStreamParameters
+}
+
+// UploadStreamParams creates a buildable set of UploadStreamParameters for a stream containing an image of the
+// specified size after decompression.
+func UploadStreamParams(size uint64) BuildableUploadStreamParameters {
+	return &uploadStreamParams{
+		size: size,
+	}
+}
+
+type uploadStreamParams struct {
+	size        uint64
+	format      ImageFormat
+	compression ImageCompression
+}
+
+func (u *uploadStreamParams) Size() uint64 {
+	return u.size
+}
+
+func (u *uploadStreamParams) Format() ImageFormat {
+	return u.format
+}
+
+func (u *uploadStreamParams) Compression() ImageCompression {
+	return u.compression
+}
+
+func (u *uploadStreamParams) WithFormat(format ImageFormat) (BuildableUploadStreamParameters, error) {
+	if err := format.Validate(); err != nil {
+		return nil, err
+	}
+	u.format = format
+	return u, nil
+}
+
+func (u *uploadStreamParams) MustWithFormat(format ImageFormat) BuildableUploadStreamParameters {
+	builder, err := u.WithFormat(format)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (u *uploadStreamParams) WithCompression(compression ImageCompression) (BuildableUploadStreamParameters, error) {
+	if err := compression.Validate(); err != nil {
+		return nil, err
+	}
+	u.compression = compression
+	return u, nil
+}
+
+func (u *uploadStreamParams) MustWithCompression(compression ImageCompression) BuildableUploadStreamParameters {
+	builder, err := u.WithCompression(compression)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+// ImageFormat is a constant for representing the format that images can be in. This is relevant
+// for both image uploads and image downloads, as the oVirt engine has the capability of converting
+// between these formats. Images can also be converted locally using ConvertImage.
+//
+// Note: the mocking facility only converts between the formats when uploading to a new disk with local
+// conversion enabled. It is recommended to write tests only using the raw format as comparing QCOW2 files is
+// complex.
+type ImageFormat string
+
+// Validate returns an error if the image format doesn't have a valid value.
+func (f ImageFormat) Validate() error {
+	for _, format := range ImageFormatValues() {
+		if format == f {
+			return nil
+		}
+	}
+	return newError(
+		EBadArgument,
+		"invalid image format: %s must be one of: %s",
+		f,
+		strings.Join(ImageFormatValues().Strings(), ", "),
+	)
+}
+
+const (
+	// ImageFormatCow is an image conforming to the QCOW2 image format. This image format can use
+	// compression, supports snapshots, and other features.
+	// See https://github.com/qemu/qemu/blob/master/docs/interop/qcow2.txt for details.
+	ImageFormatCow ImageFormat = "cow"
+	// ImageFormatRaw is not actually a format, it only contains the raw bytes on the block device.
+	ImageFormatRaw ImageFormat = "raw"
+)
+
+// ImageFormatList is a list of ImageFormat values.
+type ImageFormatList []ImageFormat
+
+// ImageFormatValues returns all possible ImageFormat values.
+func ImageFormatValues() ImageFormatList {
+	return []ImageFormat{
+		ImageFormatCow,
+		ImageFormatRaw,
+	}
+}
+
+// Strings creates a string list of the values.
+func (l ImageFormatList) Strings() []string {
+	result := make([]string, len(l))
+	for i, status := range l {
+		result[i] = string(status)
+	}
+	return result
+}
+
+func convertSDKDisk(sdkDisk *ovirtsdk4.Disk, client Client) (Disk, error) {
+	id, ok := sdkDisk.Id()
+	if !ok {
+		return nil, newError(EFieldMissing, "disk does not contain an ID")
+	}
+	if sdkStorageType, ok := sdkDisk.StorageType(); ok && DiskStorageType(sdkStorageType) == DiskStorageTypeLUN {
+		return convertSDKLUNDisk(id, sdkDisk, client)
+	}
+	var storageDomainIDs []StorageDomainID
+	if sdkStorageDomain, ok := sdkDisk.StorageDomain(); ok {
+		storageDomainID, _ := sdkStorageDomain.Id()
+		storageDomainIDs = append(storageDomainIDs, StorageDomainID(storageDomainID))
+	}
+	if sdkStorageDomains, ok := sdkDisk.StorageDomains(); ok {
+		for _, sd := range sdkStorageDomains.Slice() {
+			storageDomainID, _ := sd.Id()
+			storageDomainIDs = append(storageDomainIDs, StorageDomainID(storageDomainID))
+		}
+	}
+	if len(storageDomainIDs) == 0 {
+		return nil, newError(EFieldMissing, "failed to find a valid storage domain for disk %s", id)
+	}
+	alias, ok := sdkDisk.Alias()
+	if !ok {
+		return nil, newError(EFieldMissing, "disk %s does not contain an alias", id)
+	}
+	provisionedSize, ok := sdkDisk.ProvisionedSize()
+	if !ok {
+		return nil, newError(EFieldMissing, "disk %s does not contain a provisioned size", id)
+	}
+	totalSize, ok := sdkDisk.TotalSize()
 	if !ok {
 		return nil, newError(EFieldMissing, "disk %s does not contain a total size", id)
 	}
@@ -712,6 +1998,11 @@ func convertSDKDisk(sdkDisk *ovirtsdk4.Disk, client Client) (Disk, error) {
 	if !ok {
 		return nil, newError(EFieldMissing, "disk %s has no sparse field", id)
 	}
+	contentType := DiskContentTypeData
+	if sdkContentType, ok := sdkDisk.ContentType(); ok {
+		contentType = DiskContentType(sdkContentType)
+	}
+	shareable, _ := sdkDisk.Shareable()
 	return &disk{
 		client: client,
 
@@ -723,6 +2014,57 @@ func convertSDKDisk(sdkDisk *ovirtsdk4.Disk, client Client) (Disk, error) {
 		storageDomainIDs: storageDomainIDs,
 		status:           DiskStatus(status),
 		sparse:           sparse,
+		contentType:      contentType,
+		shareable:        shareable,
+		storageType:      DiskStorageTypeImage,
+	}, nil
+}
+
+// convertSDKLUNDisk converts a disk backed by a LUN. These disks have no storage domain, format or image file, the
+// size is taken from the LUN instead.
+func convertSDKLUNDisk(id string, sdkDisk *ovirtsdk4.Disk, client Client) (Disk, error) {
+	alias, ok := sdkDisk.Alias()
+	if !ok {
+		return nil, newError(EFieldMissing, "disk %s does not contain an alias", id)
+	}
+	hostStorage, ok := sdkDisk.LunStorage()
+	if !ok {
+		return nil, newError(EFieldMissing, "LUN disk %s does not contain LUN storage", id)
+	}
+	storageType, ok := hostStorage.Type()
+	if !ok {
+		return nil, newError(EFieldMissing, "LUN storage of disk %s has no type", id)
+	}
+	logicalUnits, ok := hostStorage.LogicalUnits()
+	if !ok || len(logicalUnits.Slice()) != 1 {
+		return nil, newError(EFieldMissing, "LUN storage of disk %s does not contain exactly one logical unit", id)
+	}
+	diskLUN, err := convertSDKLogicalUnit(logicalUnits.Slice()[0], LUNStorageType(storageType))
+	if err != nil {
+		return nil, wrap(err, EUnidentified, "failed to convert logical unit of disk %s", id)
+	}
+	status := DiskStatusOK
+	if sdkStatus, ok := sdkDisk.Status(); ok {
+		status = DiskStatus(sdkStatus)
+	}
+	contentType := DiskContentTypeData
+	if sdkContentType, ok := sdkDisk.ContentType(); ok {
+		contentType = DiskContentType(sdkContentType)
+	}
+	shareable, _ := sdkDisk.Shareable()
+	return &disk{
+		client: client,
+
+		id:              DiskID(id),
+		alias:           alias,
+		provisionedSize: diskLUN.Size(),
+		totalSize:       diskLUN.Size(),
+		format:          ImageFormatRaw,
+		status:          status,
+		contentType:     contentType,
+		shareable:       shareable,
+		storageType:     DiskStorageTypeLUN,
+		lun:             diskLUN,
 	}, nil
 }
 
@@ -737,6 +2079,10 @@ type disk struct {
 	status           DiskStatus
 	totalSize        uint64
 	sparse           bool
+	contentType      DiskContentType
+	shareable        bool
+	storageType      DiskStorageType
+	lun              LUN
 }
 
 func (d *disk) WaitForOK(retries ...RetryStrategy) (Disk, error) {
@@ -771,6 +2117,22 @@ func (d *disk) Sparse() bool {
 	return d.sparse
 }
 
+func (d *disk) ContentType() DiskContentType {
+	return d.contentType
+}
+
+func (d *disk) Shareable() bool {
+	return d.shareable
+}
+
+func (d *disk) StorageType() DiskStorageType {
+	return d.storageType
+}
+
+func (d *disk) LUN() LUN {
+	return d.lun
+}
+
 func (d *disk) AttachToVM(
 	vmID VMID,
 	diskInterface DiskInterface,
@@ -784,6 +2146,22 @@ func (d *disk) Remove(retries ...RetryStrategy) error {
 	return d.client.RemoveDisk(d.id, retries...)
 }
 
+func (d *disk) Move(storageDomainID StorageDomainID, retries ...RetryStrategy) (Disk, error) {
+	return d.client.MoveDisk(d.id, storageDomainID, retries...)
+}
+
+func (d *disk) Copy(storageDomainID StorageDomainID, alias string, retries ...RetryStrategy) (Disk, error) {
+	return d.client.CopyDisk(d.id, storageDomainID, alias, retries...)
+}
+
+func (d *disk) ListSnapshots(retries ...RetryStrategy) ([]DiskSnapshot, error) {
+	return d.client.ListDiskSnapshots(d.id, retries...)
+}
+
+func (d *disk) ImageChain(retries ...RetryStrategy) ([]DiskSnapshot, error) {
+	return d.client.GetDiskImageChain(d.id, retries...)
+}
+
 func (d *disk) TotalSize() uint64 {
 	return d.totalSize
 }
@@ -816,6 +2194,19 @@ func (d disk) Download(format ImageFormat, retries ...RetryStrategy) (ImageDownl
 	return d.client.DownloadDisk(d.id, format, retries...)
 }
 
+func (d disk) DownloadToFile(
+	format ImageFormat,
+	path string,
+	params DownloadDiskToFileParameters,
+	retries ...RetryStrategy,
+) (uint64, error) {
+	return d.client.DownloadDiskToFile(d.id, format, path, params, retries...)
+}
+
+func (d disk) Extents(retries ...RetryStrategy) ([]DiskExtent, error) {
+	return d.client.DiskExtents(d.id, retries...)
+}
+
 type diskWait struct {
 	client        *oVirtClient
 	disk          Disk
@@ -25,6 +25,15 @@ type DiskAttachmentClient interface {
 	ListDiskAttachments(vmID VMID, retries ...RetryStrategy) ([]DiskAttachment, error)
 	// RemoveDiskAttachment removes the disk attachment in question.
 	RemoveDiskAttachment(vmID VMID, diskAttachmentID DiskAttachmentID, retries ...RetryStrategy) error
+	// UpdateDiskAttachment changes the disk attachment in question. Changing Active on a running VM hot plugs or hot
+	// unplugs the disk. If this fails, the returned error has the EHotPlugFailed code. The interface can only be
+	// changed while the disk is not active or the VM is not running.
+	UpdateDiskAttachment(
+		vmID VMID,
+		diskAttachmentID DiskAttachmentID,
+		params UpdateDiskAttachmentParameters,
+		retries ...RetryStrategy,
+	) (DiskAttachment, error)
 }
 
 // DiskInterface describes the means by which a disk will appear to the VM.
@@ -152,6 +161,185 @@ func (c createDiskAttachmentParams) MustWithActive(active bool) BuildableCreateD
 	return builder
 }
 
+// UpdateDiskAttachmentParameters are the parameters for updating a disk attachment. All fields are optional, fields
+// returning nil are left unchanged.
+type UpdateDiskAttachmentParameters interface {
+	// Bootable returns whether the disk should be bootable.
+	Bootable() *bool
+	// Active returns whether the disk should be plugged into the virtual machine.
+	Active() *bool
+	// DiskInterface returns the interface the disk should appear on in the virtual machine.
+	DiskInterface() *DiskInterface
+	// ReadOnly returns whether the virtual machine should only have read access to the disk.
+	ReadOnly() *bool
+	// PassDiscard returns whether discard requests of the virtual machine should be passed to the storage.
+	PassDiscard() *bool
+	// UsesSCSIReservation returns whether the virtual machine may use SCSI reservations on the disk.
+	UsesSCSIReservation() *bool
+}
+
+// BuildableUpdateDiskAttachmentParameters is a buildable version of UpdateDiskAttachmentParameters.
+type BuildableUpdateDiskAttachmentParameters interface {
+	UpdateDiskAttachmentParameters
+
+	// WithBootable sets whether the disk is bootable.
+	WithBootable(bootable bool) (BuildableUpdateDiskAttachmentParameters, error)
+	// MustWithBootable is the same as WithBootable, but panics instead of returning an error.
+	MustWithBootable(bootable bool) BuildableUpdateDiskAttachmentParameters
+
+	// WithActive sets whether the disk is plugged into the virtual machine.
+	WithActive(active bool) (BuildableUpdateDiskAttachmentParameters, error)
+	// MustWithActive is the same as WithActive, but panics instead of returning an error.
+	MustWithActive(active bool) BuildableUpdateDiskAttachmentParameters
+
+	// WithDiskInterface sets the interface the disk appears on in the virtual machine.
+	WithDiskInterface(diskInterface DiskInterface) (BuildableUpdateDiskAttachmentParameters, error)
+	// MustWithDiskInterface is the same as WithDiskInterface, but panics instead of returning an error.
+	MustWithDiskInterface(diskInterface DiskInterface) BuildableUpdateDiskAttachmentParameters
+
+	// WithReadOnly sets whether the virtual machine only has read access to the disk.
+	WithReadOnly(readOnly bool) (BuildableUpdateDiskAttachmentParameters, error)
+	// MustWithReadOnly is the same as WithReadOnly, but panics instead of returning an error.
+	MustWithReadOnly(readOnly bool) BuildableUpdateDiskAttachmentParameters
+
+	// WithPassDiscard sets whether discard requests of the virtual machine are passed to the storage.
+	WithPassDiscard(passDiscard bool) (BuildableUpdateDiskAttachmentParameters, error)
+	// MustWithPassDiscard is the same as WithPassDiscard, but panics instead of returning an error.
+	MustWithPassDiscard(passDiscard bool) BuildableUpdateDiskAttachmentParameters
+
+	// WithUsesSCSIReservation sets whether the virtual machine may use SCSI reservations on the disk.
+	WithUsesSCSIReservation(usesSCSIReservation bool) (BuildableUpdateDiskAttachmentParameters, error)
+	// MustWithUsesSCSIReservation is the same as WithUsesSCSIReservation, but panics instead of returning an error.
+	MustWithUsesSCSIReservation(usesSCSIReservation bool) BuildableUpdateDiskAttachmentParameters
+}
+
+// UpdateDiskAttachmentParams creates a buildable set of parameters for updating a disk attachment.
+func UpdateDiskAttachmentParams() BuildableUpdateDiskAttachmentParameters {
+	return &updateDiskAttachmentParams{}
+}
+
+type updateDiskAttachmentParams struct {
+	bootable            *bool
+	active              *bool
+	diskInterface       *DiskInterface
+	readOnly            *bool
+	passDiscard         *bool
+	usesSCSIReservation *bool
+}
+
+func (u *updateDiskAttachmentParams) Bootable() *bool {
+	return u.bootable
+}
+
+func (u *updateDiskAttachmentParams) Active() *bool {
+	return u.active
+}
+
+func (u *updateDiskAttachmentParams) DiskInterface() *DiskInterface {
+	return u.diskInterface
+}
+
+func (u *updateDiskAttachmentParams) ReadOnly() *bool {
+	return u.readOnly
+}
+
+func (u *updateDiskAttachmentParams) PassDiscard() *bool {
+	return u.passDiscard
+}
+
+func (u *updateDiskAttachmentParams) UsesSCSIReservation() *bool {
+	return u.usesSCSIReservation
+}
+
+func (u *updateDiskAttachmentParams) WithBootable(bootable bool) (BuildableUpdateDiskAttachmentParameters, error) {
+	u.bootable = &bootable
+	return u, nil
+}
+
+func (u *updateDiskAttachmentParams) MustWithBootable(bootable bool) BuildableUpdateDiskAttachmentParameters {
+	builder, err := u.WithBootable(bootable)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (u *updateDiskAttachmentParams) WithActive(active bool) (BuildableUpdateDiskAttachmentParameters, error) {
+	u.active = &active
+	return u, nil
+}
+
+func (u *updateDiskAttachmentParams) MustWithActive(active bool) BuildableUpdateDiskAttachmentParameters {
+	builder, err := u.WithActive(active)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (u *updateDiskAttachmentParams) WithDiskInterface(
+	diskInterface DiskInterface,
+) (BuildableUpdateDiskAttachmentParameters, error) {
+	if err := diskInterface.Validate(); err != nil {
+		return nil, err
+	}
+	u.diskInterface = &diskInterface
+	return u, nil
+}
+
+func (u *updateDiskAttachmentParams) MustWithDiskInterface(
+	diskInterface DiskInterface,
+) BuildableUpdateDiskAttachmentParameters {
+	builder, err := u.WithDiskInterface(diskInterface)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (u *updateDiskAttachmentParams) WithReadOnly(readOnly bool) (BuildableUpdateDiskAttachmentParameters, error) {
+	u.readOnly = &readOnly
+	return u, nil
+}
+
+func (u *updateDiskAttachmentParams) MustWithReadOnly(readOnly bool) BuildableUpdateDiskAttachmentParameters {
+	builder, err := u.WithReadOnly(readOnly)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (u *updateDiskAttachmentParams) WithPassDiscard(passDiscard bool) (BuildableUpdateDiskAttachmentParameters, error) {
+	u.passDiscard = &passDiscard
+	return u, nil
+}
+
+func (u *updateDiskAttachmentParams) MustWithPassDiscard(passDiscard bool) BuildableUpdateDiskAttachmentParameters {
+	builder, err := u.WithPassDiscard(passDiscard)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (u *updateDiskAttachmentParams) WithUsesSCSIReservation(
+	usesSCSIReservation bool,
+) (BuildableUpdateDiskAttachmentParameters, error) {
+	u.usesSCSIReservation = &usesSCSIReservation
+	return u, nil
+}
+
+func (u *updateDiskAttachmentParams) MustWithUsesSCSIReservation(
+	usesSCSIReservation bool,
+) BuildableUpdateDiskAttachmentParameters {
+	builder, err := u.WithUsesSCSIReservation(usesSCSIReservation)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
 // DiskAttachment links together a Disk and a VM.
 type DiskAttachment interface {
 	// ID returns the identifier of the attachment.
@@ -166,6 +354,15 @@ type DiskAttachment interface {
 	Bootable() bool
 	// Active defines whether the disk is active in the virtual machine it’s attached to.
 	Active() bool
+	// ReadOnly defines whether the virtual machine only has read access to the disk.
+	ReadOnly() bool
+	// PassDiscard defines whether discard requests of the virtual machine are passed to the storage.
+	PassDiscard() bool
+	// UsesSCSIReservation defines whether the virtual machine may use SCSI reservations on the disk.
+	UsesSCSIReservation() bool
+	// LogicalName is the name of the disk as reported by the guest agent, for example /dev/vda. It is empty if the
+	// VM is not running or has no guest agent.
+	LogicalName() string
 
 	// VM fetches the virtual machine this attachment belongs to.
 	VM(retries ...RetryStrategy) (VM, error)
@@ -174,17 +371,23 @@ type DiskAttachment interface {
 
 	// Remove removes the current disk attachment.
 	Remove(retries ...RetryStrategy) error
+	// Update changes the current disk attachment. See DiskAttachmentClient.UpdateDiskAttachment for details.
+	Update(params UpdateDiskAttachmentParameters, retries ...RetryStrategy) (DiskAttachment, error)
 }
 
 type diskAttachment struct {
 	client Client
 
-	id            DiskAttachmentID
-	vmid          VMID
-	diskID        DiskID
-	diskInterface DiskInterface
-	active        bool
-	bootable      bool
+	id                  DiskAttachmentID
+	vmid                VMID
+	diskID              DiskID
+	diskInterface       DiskInterface
+	active              bool
+	bootable            bool
+	readOnly            bool
+	passDiscard         bool
+	usesSCSIReservation bool
+	logicalName         string
 }
 
 func (d *diskAttachment) DiskInterface() DiskInterface {
@@ -195,6 +398,10 @@ func (d *diskAttachment) Remove(retries ...RetryStrategy) error {
 	return d.client.RemoveDiskAttachment(d.vmid, d.id, retries...)
 }
 
+func (d *diskAttachment) Update(params UpdateDiskAttachmentParameters, retries ...RetryStrategy) (DiskAttachment, error) {
+	return d.client.UpdateDiskAttachment(d.vmid, d.id, params, retries...)
+}
+
 func (d *diskAttachment) ID() DiskAttachmentID {
 	return d.id
 }
@@ -215,6 +422,22 @@ func (d *diskAttachment) Active() bool {
 	return d.active
 }
 
+func (d *diskAttachment) ReadOnly() bool {
+	return d.readOnly
+}
+
+func (d *diskAttachment) PassDiscard() bool {
+	return d.passDiscard
+}
+
+func (d *diskAttachment) UsesSCSIReservation() bool {
+	return d.usesSCSIReservation
+}
+
+func (d *diskAttachment) LogicalName() string {
+	return d.logicalName
+}
+
 func (d *diskAttachment) VM(retries ...RetryStrategy) (VM, error) {
 	return d.client.GetVM(d.vmid, retries...)
 }
@@ -256,7 +479,7 @@ func convertSDKDiskAttachment(object *ovirtsdk4.DiskAttachment, o *oVirtClient)
 	if !ok {
 		return nil, newFieldNotFound("active on disk attachment", "active")
 	}
-	return &diskAttachment{
+	result := &diskAttachment{
 		client: o,
 
 		id:            DiskAttachmentID(id),
@@ -265,5 +488,19 @@ func convertSDKDiskAttachment(object *ovirtsdk4.DiskAttachment, o *oVirtClient)
 		diskInterface: DiskInterface(diskInterface),
 		bootable:      bootable,
 		active:        active,
-	}, nil
+	}
+	// The following fields are not returned by all engine versions.
+	if readOnly, ok := object.ReadOnly(); ok {
+		result.readOnly = readOnly
+	}
+	if passDiscard, ok := object.PassDiscard(); ok {
+		result.passDiscard = passDiscard
+	}
+	if usesSCSIReservation, ok := object.UsesScsiReservation(); ok {
+		result.usesSCSIReservation = usesSCSIReservation
+	}
+	if logicalName, ok := object.LogicalName(); ok {
+		result.logicalName = logicalName
+	}
+	return result, nil
 }
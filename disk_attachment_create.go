@@ -109,17 +109,16 @@ func (m *mockClient) CreateDiskAttachment(
 		}
 	}
 
-	if diskAttachment, ok := m.vmDiskAttachmentsByDisk[disk.ID()]; ok {
+	if len(m.vmDiskAttachmentsByDisk[disk.ID()]) > 0 && !disk.shareable {
 		return nil, newError(
 			EConflict,
-			"cannot attach disk %s to VM %s, already attached to VM %s",
+			"cannot attach disk %s to VM %s, the disk is not shareable and already attached to another VM",
 			diskID,
 			vmID,
-			diskAttachment.VMID(),
 		)
 	}
 
-	m.vmDiskAttachmentsByDisk[disk.ID()] = attachment
+	m.addVMDiskAttachmentByDisk(attachment)
 	m.vmDiskAttachmentsByVM[vm.ID()][attachment.ID()] = attachment
 
 	return attachment, nil
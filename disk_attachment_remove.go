@@ -38,7 +38,7 @@ func (m *mockClient) RemoveDiskAttachment(vmID VMID, diskAttachmentID DiskAttach
 		return newError(ENotFound, "Disk attachment %s not found on VM %s", diskAttachmentID, vmID)
 	}
 
-	delete(m.vmDiskAttachmentsByDisk, diskAttachment.DiskID())
+	m.removeVMDiskAttachmentByDisk(diskAttachment)
 	delete(m.vmDiskAttachmentsByVM[vmID], diskAttachmentID)
 
 	return nil
@@ -55,6 +55,126 @@ func TestDiskAttachmentCannotBeAttachedToSecondVM(t *testing.T) {
 	assertCannotAttachDisk(t, vm2, disk, ovirtclient.EConflict)
 }
 
+func TestDiskAttachmentShareableDiskToMultipleVMs(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+
+	vm1 := assertCanCreateVM(
+		t,
+		helper,
+		fmt.Sprintf("disk_attachment_test_%s", helper.GenerateRandomID(5)),
+		ovirtclient.CreateVMParams(),
+	)
+	vm2 := assertCanCreateVM(
+		t,
+		helper,
+		fmt.Sprintf("disk_attachment_test_%s", helper.GenerateRandomID(5)),
+		ovirtclient.CreateVMParams(),
+	)
+	disk := assertCanCreateDiskWithParameters(
+		t,
+		helper,
+		ovirtclient.ImageFormatRaw,
+		ovirtclient.CreateDiskParams().MustWithSparse(false).MustWithShareable(true),
+	)
+	if !disk.Shareable() {
+		t.Fatalf("Disk %s created as shareable is not shareable.", disk.ID())
+	}
+	attachment1 := assertCanAttachDisk(t, vm1, disk)
+	attachment2 := assertCanAttachDisk(t, vm2, disk)
+	assertDiskAttachmentMatches(t, attachment1, disk, vm1)
+	assertDiskAttachmentMatches(t, attachment2, disk, vm2)
+
+	assertCanDetachDisk(t, attachment1)
+	assertDiskAttachmentCount(t, vm1, 0)
+	assertDiskAttachmentCount(t, vm2, 1)
+	assertCanDetachDisk(t, attachment2)
+}
+
+func TestShareableDiskMustBeRaw(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+
+	_, err := helper.GetClient().CreateDisk(
+		helper.GetStorageDomainID(),
+		ovirtclient.ImageFormatCow,
+		1048576,
+		ovirtclient.CreateDiskParams().MustWithShareable(true),
+	)
+	if err == nil {
+		t.Fatalf("Creating a shareable QCOW2 disk did not result in an error.")
+	}
+	if !ovirtclient.HasErrorCode(err, ovirtclient.EBadArgument) {
+		t.Fatalf("Creating a shareable QCOW2 disk did not result in an EBadArgument error. (%v)", err)
+	}
+}
+
+func TestDiskAttachmentUpdate(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+
+	vm := assertCanCreateVM(
+		t,
+		helper,
+		fmt.Sprintf("disk_attachment_update_test_%s", helper.GenerateRandomID(5)),
+		ovirtclient.CreateVMParams(),
+	)
+	disk := assertCanCreateDisk(t, helper)
+	attachment := assertCanAttachDisk(t, vm, disk)
+
+	updated, err := attachment.Update(
+		ovirtclient.UpdateDiskAttachmentParams().
+			MustWithBootable(true).
+			MustWithActive(true).
+			MustWithDiskInterface(ovirtclient.DiskInterfaceVirtIOSCSI).
+			MustWithReadOnly(true).
+			MustWithPassDiscard(true),
+	)
+	if err != nil {
+		t.Fatalf("Failed to update disk attachment %s on VM %s (%v)", attachment.ID(), vm.ID(), err)
+	}
+	if !updated.Bootable() || !updated.Active() || !updated.ReadOnly() || !updated.PassDiscard() {
+		t.Fatalf("The disk attachment was not updated correctly.")
+	}
+	if updated.DiskInterface() != ovirtclient.DiskInterfaceVirtIOSCSI {
+		t.Fatalf(
+			"Incorrect disk interface after update (%s != %s)",
+			updated.DiskInterface(),
+			ovirtclient.DiskInterfaceVirtIOSCSI,
+		)
+	}
+
+	fetched, err := vm.GetDiskAttachment(attachment.ID())
+	if err != nil {
+		t.Fatalf("Failed to fetch disk attachment %s on VM %s (%v)", attachment.ID(), vm.ID(), err)
+	}
+	if !fetched.Bootable() || !fetched.ReadOnly() || fetched.DiskInterface() != ovirtclient.DiskInterfaceVirtIOSCSI {
+		t.Fatalf("The update of disk attachment %s was not persisted.", attachment.ID())
+	}
+
+	deactivated, err := vm.UpdateDiskAttachment(
+		attachment.ID(),
+		ovirtclient.UpdateDiskAttachmentParams().MustWithActive(false),
+	)
+	if err != nil {
+		t.Fatalf("Failed to deactivate disk attachment %s on VM %s (%v)", attachment.ID(), vm.ID(), err)
+	}
+	if deactivated.Active() {
+		t.Fatalf("Disk attachment %s is still active after deactivating it.", attachment.ID())
+	}
+	if !deactivated.Bootable() {
+		t.Fatalf("Deactivating disk attachment %s changed an unrelated field.", attachment.ID())
+	}
+}
+
+func TestDiskAttachmentUpdateInvalidInterface(t *testing.T) {
+	t.Parallel()
+
+	if _, err := ovirtclient.UpdateDiskAttachmentParams().WithDiskInterface("floppy"); err == nil {
+		t.Fatalf("Setting an invalid disk interface did not result in an error.")
+	}
+}
+
 func assertCanCreateDisk(t *testing.T, helper ovirtclient.TestHelper) ovirtclient.Disk {
 	return assertCanCreateDiskWithParameters(t, helper, ovirtclient.ImageFormatRaw, nil)
 }
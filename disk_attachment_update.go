@@ -0,0 +1,145 @@
+package ovirtclient
+
+import (
+	"fmt"
+
+	ovirtsdk "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) UpdateDiskAttachment(
+	vmID VMID,
+	diskAttachmentID DiskAttachmentID,
+	params UpdateDiskAttachmentParameters,
+	retries ...RetryStrategy,
+) (result DiskAttachment, err error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	if err := validateUpdateDiskAttachmentParams(params); err != nil {
+		return nil, err
+	}
+	err = retry(
+		fmt.Sprintf("updating disk attachment %s on VM %s", diskAttachmentID, vmID),
+		o.logger,
+		retries,
+		func() error {
+			attachmentBuilder := ovirtsdk.NewDiskAttachmentBuilder()
+			if params != nil {
+				if bootable := params.Bootable(); bootable != nil {
+					attachmentBuilder.Bootable(*bootable)
+				}
+				if active := params.Active(); active != nil {
+					attachmentBuilder.Active(*active)
+				}
+				if diskInterface := params.DiskInterface(); diskInterface != nil {
+					attachmentBuilder.Interface(ovirtsdk.DiskInterface(*diskInterface))
+				}
+				if readOnly := params.ReadOnly(); readOnly != nil {
+					attachmentBuilder.ReadOnly(*readOnly)
+				}
+				if passDiscard := params.PassDiscard(); passDiscard != nil {
+					attachmentBuilder.PassDiscard(*passDiscard)
+				}
+				if usesSCSIReservation := params.UsesSCSIReservation(); usesSCSIReservation != nil {
+					attachmentBuilder.UsesScsiReservation(*usesSCSIReservation)
+				}
+			}
+
+			response, err := o.conn.
+				SystemService().
+				VmsService().
+				VmService(string(vmID)).
+				DiskAttachmentsService().
+				AttachmentService(string(diskAttachmentID)).
+				Update().
+				DiskAttachment(attachmentBuilder.MustBuild()).
+				Send()
+			if err != nil {
+				return wrap(
+					err,
+					EUnidentified,
+					"failed to update disk attachment %s on VM %s",
+					diskAttachmentID,
+					vmID,
+				)
+			}
+			attachment, ok := response.DiskAttachment()
+			if !ok {
+				return newFieldNotFound("disk attachment update response", "attachment")
+			}
+			result, err = convertSDKDiskAttachment(attachment, o)
+			if err != nil {
+				return wrap(err, EUnidentified, "failed to convert SDK disk attachment")
+			}
+			return nil
+		},
+	)
+	return result, err
+}
+
+func validateUpdateDiskAttachmentParams(params UpdateDiskAttachmentParameters) error {
+	if params == nil || params.DiskInterface() == nil {
+		return nil
+	}
+	if err := params.DiskInterface().Validate(); err != nil {
+		return wrap(err, EBadArgument, "failed to update disk attachment")
+	}
+	return nil
+}
+
+func (m *mockClient) UpdateDiskAttachment(
+	vmID VMID,
+	diskAttachmentID DiskAttachmentID,
+	params UpdateDiskAttachmentParameters,
+	_ ...RetryStrategy,
+) (DiskAttachment, error) {
+	if err := validateUpdateDiskAttachmentParams(params); err != nil {
+		return nil, err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	vm, ok := m.vms[vmID]
+	if !ok {
+		return nil, newError(ENotFound, "VM with ID %s not found", vmID)
+	}
+	attachment, ok := m.vmDiskAttachmentsByVM[vmID][diskAttachmentID]
+	if !ok {
+		return nil, newError(ENotFound, "disk attachment %s not found on VM %s", diskAttachmentID, vmID)
+	}
+
+	// The attachment is copied, so attachments returned earlier keep their values like with the real client.
+	updated := *attachment
+	if params != nil {
+		if bootable := params.Bootable(); bootable != nil {
+			updated.bootable = *bootable
+		}
+		if active := params.Active(); active != nil {
+			updated.active = *active
+		}
+		if diskInterface := params.DiskInterface(); diskInterface != nil {
+			updated.diskInterface = *diskInterface
+		}
+		if readOnly := params.ReadOnly(); readOnly != nil {
+			updated.readOnly = *readOnly
+		}
+		if passDiscard := params.PassDiscard(); passDiscard != nil {
+			updated.passDiscard = *passDiscard
+		}
+		if usesSCSIReservation := params.UsesSCSIReservation(); usesSCSIReservation != nil {
+			updated.usesSCSIReservation = *usesSCSIReservation
+		}
+	}
+	if updated.diskInterface != attachment.diskInterface && attachment.active && updated.active &&
+		vm.status != VMStatusDown {
+		return nil, newError(
+			EConflict,
+			"cannot change the interface of disk attachment %s while the disk is plugged into running VM %s",
+			diskAttachmentID,
+			vmID,
+		)
+	}
+
+	m.vmDiskAttachmentsByVM[vmID][diskAttachmentID] = &updated
+	m.vmDiskAttachmentsByDisk[updated.diskID][vmID] = &updated
+	return &updated, nil
+}
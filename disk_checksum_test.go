@@ -0,0 +1,105 @@
+package ovirtclient_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"testing"
+
+	ovirtclient "github.com/dyudin0821/go-ovirt-client/v3"
+)
+
+func TestImageUploadChecksum(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+
+	disk := assertCanCreateDisk(t, helper)
+	diskID := disk.ID()
+	data := sparseTestData()
+	sum := sha256.Sum256(data)
+	expected := hex.EncodeToString(sum[:])
+
+	progress, err := client.StartUploadToDiskWithParams(
+		diskID,
+		uint64(len(data)),
+		&nopReadCloser{bytes.NewReader(data)},
+		ovirtclient.UploadImageParams().MustWithChecksum(ovirtclient.ChecksumAlgorithmSHA256, expected),
+	)
+	if err != nil {
+		t.Fatalf("Failed to start upload to disk %s. (%v)", diskID, err)
+	}
+	<-progress.Done()
+	if err := progress.Err(); err != nil {
+		t.Fatalf("Failed to upload to disk %s with checksum verification. (%v)", diskID, err)
+	}
+	if progress.Checksum() != expected {
+		t.Fatalf("Incorrect upload checksum: %s instead of %s.", progress.Checksum(), expected)
+	}
+
+	if err := client.UploadToDiskWithParams(
+		diskID,
+		uint64(len(data)),
+		&nopReadCloser{bytes.NewReader(data)},
+		ovirtclient.UploadImageParams().MustWithChecksum(ovirtclient.ChecksumAlgorithmBlockSHA256, ""),
+	); err != nil {
+		t.Fatalf("Failed to upload to disk %s with block checksum verification. (%v)", diskID, err)
+	}
+
+	err = client.UploadToDiskWithParams(
+		diskID,
+		uint64(len(data)),
+		&nopReadCloser{bytes.NewReader(data)},
+		ovirtclient.UploadImageParams().MustWithChecksum(ovirtclient.ChecksumAlgorithmSHA256, "0000"),
+	)
+	if !ovirtclient.HasErrorCode(err, ovirtclient.EChecksumMismatch) {
+		t.Fatalf("Uploading with an incorrect expected checksum did not result in a checksum mismatch. (%v)", err)
+	}
+}
+
+func TestImageDownloadChecksum(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+
+	disk := assertCanCreateDisk(t, helper)
+	diskID := disk.ID()
+	assertCanUploadDiskData(t, client, disk, sparseTestData())
+
+	for _, algorithm := range ovirtclient.ChecksumAlgorithmValues() {
+		download, err := client.DownloadDiskWithParams(
+			diskID,
+			ovirtclient.ImageFormatRaw,
+			ovirtclient.DownloadImageParams().MustWithChecksum(algorithm, ""),
+		)
+		if err != nil {
+			t.Fatalf("Failed to start download of disk %s. (%v)", diskID, err)
+		}
+		data, err := io.ReadAll(download)
+		_ = download.Close()
+		if err != nil {
+			t.Fatalf("Failed to download disk %s with %s checksum verification. (%v)", diskID, algorithm, err)
+		}
+		if algorithm == ovirtclient.ChecksumAlgorithmSHA256 {
+			sum := sha256.Sum256(data)
+			if expected := hex.EncodeToString(sum[:]); download.Checksum() != expected {
+				t.Fatalf("Incorrect download checksum: %s instead of %s.", download.Checksum(), expected)
+			}
+		}
+	}
+
+	download, err := client.DownloadDiskWithParams(
+		diskID,
+		ovirtclient.ImageFormatRaw,
+		ovirtclient.DownloadImageParams().MustWithChecksum(ovirtclient.ChecksumAlgorithmSHA256, "0000"),
+	)
+	if err != nil {
+		t.Fatalf("Failed to start download of disk %s. (%v)", diskID, err)
+	}
+	_, err = io.ReadAll(download)
+	_ = download.Close()
+	if !ovirtclient.HasErrorCode(err, ovirtclient.EChecksumMismatch) {
+		t.Fatalf("Downloading with an incorrect expected checksum did not result in a checksum mismatch. (%v)", err)
+	}
+}
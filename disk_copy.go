@@ -0,0 +1,232 @@
+package ovirtclient
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	ovirtsdk "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) CopyDisk(
+	diskID DiskID,
+	storageDomainID StorageDomainID,
+	alias string,
+	retries ...RetryStrategy,
+) (Disk, error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(o))
+	progress, err := o.StartCopyDisk(diskID, storageDomainID, alias, retries...)
+	if err != nil {
+		return nil, err
+	}
+	return progress.Wait(retries...)
+}
+
+func (o *oVirtClient) StartCopyDisk(
+	diskID DiskID,
+	storageDomainID StorageDomainID,
+	alias string,
+	retries ...RetryStrategy,
+) (DiskCreation, error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	correlationID := fmt.Sprintf("disk_copy_%s", generateRandomID(5, o.nonSecureRandom))
+
+	if alias == "" {
+		disk, err := o.GetDisk(diskID, retries...)
+		if err != nil {
+			return nil, err
+		}
+		alias = disk.Alias()
+	}
+	// The copy response does not contain the new disk, so the disks that already have the alias are recorded to
+	// tell the copy apart from them later.
+	existingDisks, err := o.ListDisksByAlias(alias, retries...)
+	if err != nil {
+		return nil, err
+	}
+	existingDiskIDs := make(map[DiskID]struct{}, len(existingDisks)+1)
+	existingDiskIDs[diskID] = struct{}{}
+	for _, disk := range existingDisks {
+		existingDiskIDs[disk.ID()] = struct{}{}
+	}
+
+	err = retry(
+		fmt.Sprintf("copying disk %s to storage domain %s", diskID, storageDomainID),
+		o.logger,
+		retries,
+		func() error {
+			_, err := o.conn.
+				SystemService().
+				DisksService().
+				DiskService(string(diskID)).
+				Copy().
+				StorageDomain(ovirtsdk.NewStorageDomainBuilder().Id(string(storageDomainID)).MustBuild()).
+				Disk(ovirtsdk.NewDiskBuilder().Alias(alias).MustBuild()).
+				Query("correlation_id", correlationID).
+				Send()
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &diskCopyWait{
+		client:          o,
+		sourceDiskID:    diskID,
+		storageDomainID: storageDomainID,
+		alias:           alias,
+		existingDiskIDs: existingDiskIDs,
+		correlationID:   correlationID,
+		lock:            &sync.Mutex{},
+	}, nil
+}
+
+type diskCopyWait struct {
+	client          *oVirtClient
+	disk            Disk
+	sourceDiskID    DiskID
+	storageDomainID StorageDomainID
+	alias           string
+	existingDiskIDs map[DiskID]struct{}
+	correlationID   string
+	lock            *sync.Mutex
+}
+
+func (d *diskCopyWait) Disk() Disk {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return d.disk
+}
+
+func (d *diskCopyWait) Wait(retries ...RetryStrategy) (Disk, error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(d.client))
+	if err := d.client.waitForJobFinished(d.correlationID, retries); err != nil {
+		return nil, err
+	}
+
+	var newDiskID DiskID
+	err := retry(
+		fmt.Sprintf("locating copy of disk %s on storage domain %s", d.sourceDiskID, d.storageDomainID),
+		d.client.logger,
+		retries,
+		func() error {
+			disks, err := d.client.ListDisksByAlias(d.alias, retries...)
+			if err != nil {
+				return err
+			}
+			for _, disk := range disks {
+				if _, ok := d.existingDiskIDs[disk.ID()]; ok {
+					continue
+				}
+				for _, storageDomainID := range disk.StorageDomainIDs() {
+					if storageDomainID == d.storageDomainID {
+						newDiskID = disk.ID()
+						return nil
+					}
+				}
+			}
+			return newError(
+				EPending,
+				"copy of disk %s not yet visible on storage domain %s",
+				d.sourceDiskID,
+				d.storageDomainID,
+			)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	disk, err := d.client.WaitForDiskOK(newDiskID, retries...)
+	if err != nil {
+		return nil, err
+	}
+	d.lock.Lock()
+	d.disk = disk
+	d.lock.Unlock()
+	return disk, nil
+}
+
+func (m *mockClient) CopyDisk(
+	diskID DiskID,
+	storageDomainID StorageDomainID,
+	alias string,
+	retries ...RetryStrategy,
+) (Disk, error) {
+	progress, err := m.StartCopyDisk(diskID, storageDomainID, alias, retries...)
+	if err != nil {
+		return nil, err
+	}
+	return progress.Wait(retries...)
+}
+
+func (m *mockClient) StartCopyDisk(
+	diskID DiskID,
+	storageDomainID StorageDomainID,
+	alias string,
+	_ ...RetryStrategy,
+) (DiskCreation, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	source, ok := m.disks[diskID]
+	if !ok {
+		return nil, newError(ENotFound, "disk with ID %s not found", diskID)
+	}
+	target, err := m.checkStorageDomainSpace(storageDomainID, source)
+	if err != nil {
+		return nil, err
+	}
+	if err := source.Lock(); err != nil {
+		return nil, err
+	}
+
+	disk := source.clone(nil)
+	disk.id = DiskID(m.GenerateUUID())
+	if alias != "" {
+		disk.alias = alias
+	}
+	disk.storageDomainIDs = []StorageDomainID{storageDomainID}
+	disk.data = append([]byte(nil), source.data...)
+	m.disks[disk.id] = disk
+	target.available -= disk.totalSize
+
+	creation := &mockDiskCopyCreation{
+		client: m,
+		source: source,
+		disk:   disk,
+		done:   make(chan struct{}),
+	}
+	defer creation.do()
+	return creation, nil
+}
+
+type mockDiskCopyCreation struct {
+	client *mockClient
+	source *diskWithData
+	disk   *diskWithData
+	done   chan struct{}
+}
+
+func (c *mockDiskCopyCreation) Disk() Disk {
+	c.client.lock.Lock()
+	defer c.client.lock.Unlock()
+
+	return c.disk
+}
+
+func (c *mockDiskCopyCreation) Wait(_ ...RetryStrategy) (Disk, error) {
+	<-c.done
+
+	return c.disk, nil
+}
+
+func (c *mockDiskCopyCreation) do() {
+	// Sleep to trigger potential race conditions / improper status handling.
+	time.Sleep(time.Second)
+
+	c.disk.Unlock()
+	c.source.Unlock()
+
+	close(c.done)
+}
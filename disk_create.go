@@ -16,7 +16,7 @@ func (o *oVirtClient) StartCreateDisk(
 ) (DiskCreation, error) {
 	retries = defaultRetries(retries, defaultWriteTimeouts(o))
 
-	if err := validateDiskCreationParameters(format, size); err != nil {
+	if err := validateDiskCreationParameters(format, size, params); err != nil {
 		return nil, err
 	}
 
@@ -63,10 +63,21 @@ func (o *oVirtClient) StartCreateDisk(
 	return result, nil
 }
 
-func validateDiskCreationParameters(format ImageFormat, size uint64) error {
+func validateDiskCreationParameters(format ImageFormat, size uint64, params CreateDiskOptionalParameters) error {
 	if err := format.Validate(); err != nil {
 		return err
 	}
+	if params != nil && params.ContentType() != "" {
+		if err := params.ContentType().Validate(); err != nil {
+			return err
+		}
+		if params.ContentType() == DiskContentTypeISO && format != ImageFormatRaw {
+			return newError(EBadArgument, "ISO disks must use the %s format, not %s", ImageFormatRaw, format)
+		}
+	}
+	if params != nil && params.Shareable() != nil && *params.Shareable() && format != ImageFormatRaw {
+		return newError(EBadArgument, "shareable disks must use the %s format, not %s", ImageFormatRaw, format)
+	}
 	return validateDiskSize(size)
 }
 
@@ -130,6 +141,12 @@ func (o *oVirtClient) buildDiskObjectForCreation(
 		if alias := params.Alias(); alias != "" {
 			diskBuilder.Alias(alias)
 		}
+		if contentType := params.ContentType(); contentType != "" {
+			diskBuilder.ContentType(ovirtsdk4.DiskContentType(contentType))
+		}
+		if shareable := params.Shareable(); shareable != nil {
+			diskBuilder.Shareable(*shareable)
+		}
 		if initialSize := params.InitialSize(); initialSize != nil {
 			if *initialSize > 9223372036854775807 { // max int64
 				return nil, newError(EBadArgument, "initial size exceeds maximum allowed value")
@@ -0,0 +1,168 @@
+package ovirtclient
+
+import (
+	"fmt"
+	"sync"
+
+	ovirtsdk4 "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) CreateLUNDisk(
+	hostID HostID,
+	lunID string,
+	storageType LUNStorageType,
+	params CreateDiskOptionalParameters,
+	retries ...RetryStrategy,
+) (result Disk, err error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+
+	if err := validateLUNDiskCreationParameters(hostID, lunID, storageType, params); err != nil {
+		return nil, err
+	}
+	sdkDisk, err := buildLUNDiskObjectForCreation(hostID, lunID, storageType, params)
+	if err != nil {
+		return nil, wrap(err, EBug, "failed to construct LUN disk object")
+	}
+
+	err = retry(
+		fmt.Sprintf("creating disk for LUN %s", lunID),
+		o.logger,
+		retries,
+		func() error {
+			response, err := o.conn.
+				SystemService().
+				DisksService().
+				Add().
+				Disk(sdkDisk).
+				Send()
+			if err != nil {
+				return wrap(err, EUnidentified, "failed to create disk for LUN %s", lunID)
+			}
+			createdDisk, ok := response.Disk()
+			if !ok {
+				return newFieldNotFound("disk add response", "disk")
+			}
+			result, err = convertSDKDisk(createdDisk, o)
+			if err != nil {
+				return wrap(err, EUnidentified, "failed to convert SDK disk object")
+			}
+			return nil
+		},
+	)
+	return result, err
+}
+
+func validateLUNDiskCreationParameters(
+	hostID HostID,
+	lunID string,
+	storageType LUNStorageType,
+	params CreateDiskOptionalParameters,
+) error {
+	if hostID == "" {
+		return newError(EBadArgument, "the host ID is required to create a LUN disk")
+	}
+	if lunID == "" {
+		return newError(EBadArgument, "the LUN ID is required to create a LUN disk")
+	}
+	if err := storageType.Validate(); err != nil {
+		return err
+	}
+	if params == nil {
+		return nil
+	}
+	if params.Sparse() != nil || params.InitialSize() != nil {
+		return newError(EBadArgument, "sparse provisioning and initial size cannot be set on LUN disks")
+	}
+	if contentType := params.ContentType(); contentType != "" && contentType != DiskContentTypeData {
+		return newError(EBadArgument, "LUN disks must have the %s content type, not %s", DiskContentTypeData, contentType)
+	}
+	return nil
+}
+
+func buildLUNDiskObjectForCreation(
+	hostID HostID,
+	lunID string,
+	storageType LUNStorageType,
+	params CreateDiskOptionalParameters,
+) (*ovirtsdk4.Disk, error) {
+	hostStorage, err := ovirtsdk4.NewHostStorageBuilder().
+		Type(ovirtsdk4.StorageType(storageType)).
+		Host(ovirtsdk4.NewHostBuilder().Id(string(hostID)).MustBuild()).
+		LogicalUnitsOfAny(ovirtsdk4.NewLogicalUnitBuilder().Id(lunID).MustBuild()).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	diskBuilder := ovirtsdk4.NewDiskBuilder().LunStorage(hostStorage)
+	if params != nil {
+		if alias := params.Alias(); alias != "" {
+			diskBuilder.Alias(alias)
+		}
+		if shareable := params.Shareable(); shareable != nil {
+			diskBuilder.Shareable(*shareable)
+		}
+	}
+	return diskBuilder.Build()
+}
+
+func (m *mockClient) CreateLUNDisk(
+	hostID HostID,
+	lunID string,
+	storageType LUNStorageType,
+	params CreateDiskOptionalParameters,
+	_ ...RetryStrategy,
+) (Disk, error) {
+	if err := validateLUNDiskCreationParameters(hostID, lunID, storageType, params); err != nil {
+		return nil, err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.hosts[hostID]; !ok {
+		return nil, newError(ENotFound, "host with ID %s not found", hostID)
+	}
+	inventoryLUN, err := m.getHostLUN(hostID, lunID)
+	if err != nil {
+		return nil, err
+	}
+	if inventoryLUN.storageType != storageType {
+		return nil, newError(EBadArgument, "LUN %s is a %s LUN, not %s", lunID, inventoryLUN.storageType, storageType)
+	}
+	lunWithStatus := m.getLUNWithStatus(inventoryLUN)
+	if lunWithStatus.diskID != "" {
+		return nil, newError(EConflict, "LUN %s is already used by disk %s", lunID, lunWithStatus.diskID)
+	}
+	if lunWithStatus.storageDomainID != "" {
+		return nil, newError(
+			EConflict,
+			"LUN %s is already used by storage domain %s",
+			lunID,
+			lunWithStatus.storageDomainID,
+		)
+	}
+
+	diskLUN := *inventoryLUN
+	result := &diskWithData{
+		disk: disk{
+			client:          m,
+			id:              DiskID(m.GenerateUUID()),
+			provisionedSize: diskLUN.size,
+			totalSize:       diskLUN.size,
+			format:          ImageFormatRaw,
+			status:          DiskStatusOK,
+			contentType:     DiskContentTypeData,
+			storageType:     DiskStorageTypeLUN,
+			lun:             &diskLUN,
+		},
+		lock: &sync.Mutex{},
+	}
+	if params != nil {
+		result.alias = params.Alias()
+		if shareable := params.Shareable(); shareable != nil {
+			result.shareable = *shareable
+		}
+	}
+	m.disks[result.id] = result
+	return result, nil
+}
@@ -0,0 +1,88 @@
+package ovirtclient_test
+
+import (
+	"fmt"
+	"testing"
+
+	ovirtclient "github.com/dyudin0821/go-ovirt-client/v3"
+)
+
+func TestCreateLUNDisk(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+	if _, ok := client.(ovirtclient.MockClient); !ok {
+		t.Skipf("Direct LUN disks require an unused LUN visible to the test host, skipping.")
+	}
+
+	hostID := assertCanFindOVAHost(t, helper)
+	freeLUN := assertCanFindFreeLUN(t, client, hostID)
+	lunID := freeLUN.ID()
+	disk, err := client.CreateLUNDisk(
+		hostID,
+		lunID,
+		freeLUN.StorageType(),
+		ovirtclient.CreateDiskParams().
+			MustWithAlias(fmt.Sprintf("lun_disk_test_%s", helper.GenerateRandomID(5))).
+			MustWithShareable(true),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create LUN disk for LUN %s. (%v)", lunID, err)
+	}
+	t.Cleanup(func() {
+		if err := disk.Remove(); err != nil && !ovirtclient.HasErrorCode(err, ovirtclient.ENotFound) {
+			t.Fatalf("Failed to remove LUN disk %s. (%v)", disk.ID(), err)
+		}
+	})
+
+	if disk.StorageType() != ovirtclient.DiskStorageTypeLUN {
+		t.Fatalf("Incorrect storage type for LUN disk %s: %s.", disk.ID(), disk.StorageType())
+	}
+	if !disk.Shareable() {
+		t.Fatalf("LUN disk %s created as shareable is not shareable.", disk.ID())
+	}
+	if disk.LUN() == nil {
+		t.Fatalf("LUN disk %s has no LUN details.", disk.ID())
+	}
+	if disk.LUN().ID() != lunID {
+		t.Fatalf("Incorrect LUN ID on disk %s: %s instead of %s.", disk.ID(), disk.LUN().ID(), lunID)
+	}
+	if disk.LUN().StorageType() != freeLUN.StorageType() {
+		t.Fatalf("Incorrect LUN storage type on disk %s: %s.", disk.ID(), disk.LUN().StorageType())
+	}
+	if len(disk.StorageDomainIDs()) != 0 {
+		t.Fatalf("LUN disk %s is on storage domains.", disk.ID())
+	}
+
+	_, err = client.CreateLUNDisk(hostID, lunID, freeLUN.StorageType(), nil)
+	if err == nil {
+		t.Fatalf("Creating a second disk for LUN %s did not result in an error.", lunID)
+	}
+	if !ovirtclient.HasErrorCode(err, ovirtclient.EConflict) {
+		t.Fatalf("Creating a second disk for LUN %s did not result in an EConflict error. (%v)", lunID, err)
+	}
+}
+
+func TestCreateLUNDiskInvalidParameters(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+
+	_, err := client.CreateLUNDisk("", "lun-id", ovirtclient.LUNStorageTypeFCP, nil)
+	if !ovirtclient.HasErrorCode(err, ovirtclient.EBadArgument) {
+		t.Fatalf("Creating a LUN disk without a host ID did not result in an EBadArgument error. (%v)", err)
+	}
+	_, err = client.CreateLUNDisk("host-id", "lun-id", "nfs", nil)
+	if !ovirtclient.HasErrorCode(err, ovirtclient.EBadArgument) {
+		t.Fatalf("Creating a LUN disk with an invalid storage type did not result in an EBadArgument error. (%v)", err)
+	}
+	_, err = client.CreateLUNDisk(
+		"host-id",
+		"lun-id",
+		ovirtclient.LUNStorageTypeFCP,
+		ovirtclient.CreateDiskParams().MustWithSparse(true),
+	)
+	if !ovirtclient.HasErrorCode(err, ovirtclient.EBadArgument) {
+		t.Fatalf("Creating a sparse LUN disk did not result in an EBadArgument error. (%v)", err)
+	}
+}
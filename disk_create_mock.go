@@ -35,13 +35,22 @@ func (m *mockClient) createDisk(
 	size uint64,
 	params CreateDiskOptionalParameters,
 ) (*diskWithData, error) {
-	if err := validateDiskCreationParameters(format, size); err != nil {
+	if err := validateDiskCreationParameters(format, size, params); err != nil {
 		return nil, err
 	}
 
-	if _, ok := m.storageDomains[storageDomainID]; !ok {
+	storageDomain, ok := m.storageDomains[storageDomainID]
+	if !ok {
 		return nil, newError(ENotFound, "storage domain with ID %s not found", storageDomainID)
 	}
+	if storageDomain.status != StorageDomainStatusActive {
+		return nil, newError(
+			EConflict,
+			"cannot create disk on storage domain %s, the storage domain is %s",
+			storageDomainID,
+			storageDomain.status,
+		)
+	}
 
 	disk := &diskWithData{
 		disk: disk{
@@ -52,6 +61,8 @@ func (m *mockClient) createDisk(
 			totalSize:        size,
 			storageDomainIDs: []StorageDomainID{storageDomainID},
 			status:           DiskStatusLocked,
+			contentType:      DiskContentTypeData,
+			storageType:      DiskStorageTypeImage,
 		},
 		lock: &sync.Mutex{},
 		data: nil,
@@ -64,6 +75,12 @@ func (m *mockClient) createDisk(
 		if sparse := params.Sparse(); sparse != nil {
 			disk.sparse = *sparse
 		}
+		if contentType := params.ContentType(); contentType != "" {
+			disk.contentType = contentType
+		}
+		if shareable := params.Shareable(); shareable != nil {
+			disk.shareable = *shareable
+		}
 	}
 
 	m.disks[disk.id] = disk
@@ -20,7 +20,19 @@ func (o *oVirtClient) StartImageDownload(diskID DiskID, format ImageFormat, retr
 }
 
 func (o *oVirtClient) StartDownloadDisk(diskID DiskID, format ImageFormat, retries ...RetryStrategy) (ImageDownload, error) {
+	return o.StartDownloadDiskWithParams(diskID, format, nil, retries...)
+}
+
+func (o *oVirtClient) StartDownloadDiskWithParams(
+	diskID DiskID,
+	format ImageFormat,
+	params DownloadImageParameters,
+	retries ...RetryStrategy,
+) (ImageDownload, error) {
 	retries = defaultRetries(retries, defaultLongTimeouts(o))
+	if params == nil {
+		params = DownloadImageParams()
+	}
 
 	o.logger.Infof("Starting disk %s image download...", diskID)
 	disk, err := o.GetDisk(diskID)
@@ -48,6 +60,8 @@ func (o *oVirtClient) StartDownloadDisk(diskID DiskID, format ImageFormat, retri
 		logger:     o.logger,
 		retries:    retries,
 		format:     format,
+		params:     params,
+		checksum:   newImageChecksum(params.ChecksumAlgorithm()),
 	}
 	go dl.poll()
 	return dl, nil
@@ -67,7 +81,16 @@ func (o *oVirtClient) DownloadDisk(
 	format ImageFormat,
 	retries ...RetryStrategy,
 ) (ImageDownloadReader, error) {
-	download, err := o.StartDownloadDisk(diskID, format, retries...)
+	return o.DownloadDiskWithParams(diskID, format, nil, retries...)
+}
+
+func (o *oVirtClient) DownloadDiskWithParams(
+	diskID DiskID,
+	format ImageFormat,
+	params DownloadImageParameters,
+	retries ...RetryStrategy,
+) (ImageDownloadReader, error) {
+	download, err := o.StartDownloadDiskWithParams(diskID, format, params, retries...)
 	if err != nil {
 		return nil, err
 	}
@@ -91,15 +114,26 @@ type imageDownload struct {
 	conn      *ovirtsdk4.Connection
 	done      chan struct{}
 
-	reader     io.ReadCloser
-	httpClient http.Client
-	createReq  *ovirtsdk4.ImageTransfersServiceAddRequest
-	transfer   imageTransfer
-	cli        *oVirtClient
-	logger     Logger
-	retries    []RetryStrategy
-	format     ImageFormat
-	disk       Disk
+	reader      io.ReadCloser
+	httpClient  http.Client
+	createReq   *ovirtsdk4.ImageTransfersServiceAddRequest
+	transfer    imageTransfer
+	transferURL string
+	cli         *oVirtClient
+	logger      Logger
+	retries     []RetryStrategy
+	format      ImageFormat
+	disk        Disk
+	params      DownloadImageParameters
+	// checksum calculates the checksum of the data read. It is nil if no checksum was requested.
+	checksum imageChecksum
+	// verified indicates that the checksum has already been verified.
+	verified bool
+	// monitor reports the progress of the download and aborts it if a read stalls. It is set once the download is
+	// initialized.
+	monitor *transferMonitor
+	// reading indicates that a Read call is waiting for data from the server.
+	reading bool
 }
 
 // poll polls the oVirt API for the status of the transfer and initializes the HTTP request to
@@ -124,13 +158,48 @@ func (i *imageDownload) poll() {
 		i.lastError = i.transfer.finalize(err)
 		return
 	}
+	i.transferURL = transferURL
 	var httpResponse *http.Response
 	httpResponse, err = i.transferImage(transferURL) //nolint:bodyclose
 	if err != nil {
 		i.lastError = i.transfer.finalize(err)
 		return
 	}
+	i.lock.Lock()
 	i.reader = httpResponse.Body
+	i.lock.Unlock()
+
+	monitor := startTransferMonitor(i.params, i.BytesRead, i.Size, i.isReading, i.abortStalled)
+	i.lock.Lock()
+	i.monitor = monitor
+	closed := i.reader == nil
+	i.lock.Unlock()
+	if closed {
+		monitor.stop()
+	}
+}
+
+// isReading returns true while a Read call is waiting for data. Time spent by the caller between Read calls does not
+// count towards the inactivity timeout.
+func (i *imageDownload) isReading() bool {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	return i.reading
+}
+
+// abortStalled closes the HTTP response body to interrupt the pending read and cancels the image transfer.
+func (i *imageDownload) abortStalled(err error) {
+	i.lock.Lock()
+	if i.reader == nil {
+		i.lock.Unlock()
+		return
+	}
+	i.lastError = err
+	i.cancel()
+	_ = i.reader.Close()
+	i.reader = nil
+	i.lock.Unlock()
+	_ = i.transfer.finalize(err)
 }
 
 // updateDisk is a helper function that updates the internally-stored disk object when the transfer updates it.
@@ -237,17 +306,51 @@ func (i *imageDownload) Initialized() <-chan struct{} {
 // finalize the transfer by calling the Close function.
 func (i *imageDownload) Read(p []byte) (n int, err error) {
 	<-i.done
+	i.lock.Lock()
 	if i.lastError != nil {
+		i.lock.Unlock()
 		return 0, i.lastError
 	}
-	n, err = i.reader.Read(p)
+	reader := i.reader
+	if reader == nil {
+		complete := i.bytesRead == i.size
+		i.lock.Unlock()
+		if complete {
+			return 0, io.EOF
+		}
+		return 0, newError(EBadArgument, "the image download for disk %s has already been closed", i.disk.ID())
+	}
+	i.reading = true
+	i.lock.Unlock()
+
+	n, err = reader.Read(p)
+
+	i.lock.Lock()
+	i.reading = false
+	stallErr := i.lastError
+	i.lock.Unlock()
+	if stallErr != nil {
+		return 0, stallErr
+	}
+	if n > 0 {
+		// The data has already been received, so a canceled context is ignored here.
+		_ = waitTransferRate(i.ctx, i.params, n)
+	}
+
 	i.lock.Lock()
 	defer i.lock.Unlock()
 	if n > 0 {
 		i.bytesRead += uint64(n)
+		if i.checksum != nil {
+			_, _ = i.checksum.Write(p[:n])
+		}
 	}
 
 	if i.bytesRead == i.size {
+		if verifyErr := i.verifyChecksum(); verifyErr != nil {
+			i.lastError = verifyErr
+			err = verifyErr
+		}
 		go func() {
 			_ = i.Close()
 		}()
@@ -260,6 +363,14 @@ func (i *imageDownload) Read(p []byte) (n int, err error) {
 // closing the HTTP reader body and finalizing the image download. This is important so the disk
 // does not stay locked.
 func (i *imageDownload) Close() error {
+	i.lock.Lock()
+	monitor := i.monitor
+	i.lock.Unlock()
+	if monitor != nil {
+		// The monitor must be stopped without holding the lock, as aborting a stalled download takes the lock.
+		monitor.stop()
+	}
+
 	i.lock.Lock()
 	defer i.lock.Unlock()
 
@@ -274,8 +385,49 @@ func (i *imageDownload) Close() error {
 	return nil
 }
 
+// verifyChecksum compares the checksum of the downloaded data to the expected checksum and the checksum calculated by
+// the ImageIO server once all data has been read. The caller must hold the lock.
+func (i *imageDownload) verifyChecksum() error {
+	if i.checksum == nil || i.verified {
+		return nil
+	}
+	i.verified = true
+	algorithm := i.params.ChecksumAlgorithm()
+	localChecksum := i.checksum.Sum()
+	if err := checkExpectedChecksum(algorithm, localChecksum, i.params.ExpectedChecksum(), i.disk.ID()); err != nil {
+		return err
+	}
+	if algorithm != ChecksumAlgorithmBlockSHA256 {
+		return nil
+	}
+	serverChecksum, err := fetchImageIOChecksum(
+		i.httpClient,
+		i.logger,
+		i.retries,
+		i.transfer,
+		i.transferURL,
+		i.disk.ID(),
+	)
+	if err != nil {
+		return err
+	}
+	return checkServerChecksum(algorithm, localChecksum, serverChecksum, i.disk.ID())
+}
+
+// Checksum returns the checksum of the data read so far.
+func (i *imageDownload) Checksum() string {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	if i.checksum == nil {
+		return ""
+	}
+	return i.checksum.Sum()
+}
+
 // BytesRead returns the number of bytes already read from the download reader.
 func (i *imageDownload) BytesRead() uint64 {
+	i.lock.Lock()
+	defer i.lock.Unlock()
 	return i.bytesRead
 }
 
@@ -293,7 +445,19 @@ func (m *mockClient) StartImageDownload(diskID DiskID, format ImageFormat, retri
 	return m.StartDownloadDisk(diskID, format, retries...)
 }
 
-func (m *mockClient) StartDownloadDisk(diskID DiskID, format ImageFormat, _ ...RetryStrategy) (ImageDownload, error) {
+func (m *mockClient) StartDownloadDisk(diskID DiskID, format ImageFormat, retries ...RetryStrategy) (ImageDownload, error) {
+	return m.StartDownloadDiskWithParams(diskID, format, nil, retries...)
+}
+
+func (m *mockClient) StartDownloadDiskWithParams(
+	diskID DiskID,
+	format ImageFormat,
+	params DownloadImageParameters,
+	_ ...RetryStrategy,
+) (ImageDownload, error) {
+	if params == nil {
+		params = DownloadImageParams()
+	}
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
@@ -313,7 +477,10 @@ func (m *mockClient) StartDownloadDisk(diskID DiskID, format ImageFormat, _ ...R
 		done:      make(chan struct{}),
 		lastError: nil,
 		lock:      &sync.Mutex{},
+		data:      disk.data,
 		reader:    bytes.NewReader(disk.data),
+		params:    params,
+		checksum:  newImageChecksum(params.ChecksumAlgorithm()),
 	}
 	go dl.prepare()
 
@@ -332,7 +499,16 @@ func (m *mockClient) DownloadDisk(diskID DiskID, format ImageFormat, retries ...
 	ImageDownloadReader,
 	error,
 ) {
-	download, err := m.StartDownloadDisk(diskID, format, retries...)
+	return m.DownloadDiskWithParams(diskID, format, nil, retries...)
+}
+
+func (m *mockClient) DownloadDiskWithParams(
+	diskID DiskID,
+	format ImageFormat,
+	params DownloadImageParameters,
+	retries ...RetryStrategy,
+) (ImageDownloadReader, error) {
+	download, err := m.StartDownloadDiskWithParams(diskID, format, params, retries...)
 	if err != nil {
 		return nil, err
 	}
@@ -350,25 +526,58 @@ type mockImageDownload struct {
 	done      chan struct{}
 	lastError error
 	lock      *sync.Mutex
-	reader    io.Reader
+	// data is the disk data at the start of the download. The mock calculates the server checksum from it.
+	data     []byte
+	reader   io.Reader
+	params   DownloadImageParameters
+	checksum imageChecksum
+	verified bool
+	monitor  *transferMonitor
+	reading  bool
+	closed   bool
 }
 
 func (m *mockImageDownload) Err() error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
 	return m.lastError
 }
 
+func (m *mockImageDownload) isReading() bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.reading
+}
+
+func (m *mockImageDownload) abortStalled(err error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.lastError = err
+}
+
 func (m *mockImageDownload) Initialized() <-chan struct{} {
 	return m.done
 }
 
 func (m *mockImageDownload) Read(p []byte) (n int, err error) {
 	<-m.done
+	m.lock.Lock()
 	if m.lastError != nil {
+		m.lock.Unlock()
 		return 0, m.lastError
 	}
+	m.reading = true
+	m.lock.Unlock()
 
 	n, err = m.reader.Read(p)
 
+	m.lock.Lock()
+	m.reading = false
+	m.lock.Unlock()
+	if n > 0 {
+		_ = waitTransferRate(context.Background(), m.params, n)
+	}
+
 	m.lock.Lock()
 	defer m.lock.Unlock()
 	if err != nil {
@@ -376,9 +585,16 @@ func (m *mockImageDownload) Read(p []byte) (n int, err error) {
 	}
 	if n > 0 {
 		m.bytesRead += uint64(n)
+		if m.checksum != nil {
+			_, _ = m.checksum.Write(p[:n])
+		}
 	}
 
 	if m.bytesRead == m.size {
+		if verifyErr := m.verifyChecksum(); verifyErr != nil {
+			m.lastError = verifyErr
+			err = verifyErr
+		}
 		go func() {
 			_ = m.Close()
 		}()
@@ -388,12 +604,44 @@ func (m *mockImageDownload) Read(p []byte) (n int, err error) {
 }
 
 func (m *mockImageDownload) Close() error {
+	m.lock.Lock()
+	monitor := m.monitor
+	m.lock.Unlock()
+	if monitor != nil {
+		monitor.stop()
+	}
+
 	m.lock.Lock()
 	defer m.lock.Unlock()
+	m.closed = true
 	m.disk.Unlock()
 	return nil
 }
 
+// verifyChecksum compares the checksum of the downloaded data to the expected checksum and the checksum the mock server
+// calculates over the disk data. The caller must hold the lock.
+func (m *mockImageDownload) verifyChecksum() error {
+	if m.checksum == nil || m.verified {
+		return nil
+	}
+	m.verified = true
+	algorithm := m.params.ChecksumAlgorithm()
+	localChecksum := m.checksum.Sum()
+	if err := checkExpectedChecksum(algorithm, localChecksum, m.params.ExpectedChecksum(), m.disk.id); err != nil {
+		return err
+	}
+	return checkServerChecksum(algorithm, localChecksum, mockServerChecksum(algorithm, m.data), m.disk.id)
+}
+
+func (m *mockImageDownload) Checksum() string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.checksum == nil {
+		return ""
+	}
+	return m.checksum.Sum()
+}
+
 func (m *mockImageDownload) BytesRead() uint64 {
 	m.lock.Lock()
 	defer m.lock.Unlock()
@@ -410,7 +658,16 @@ func (m *mockImageDownload) prepare() {
 	// Sleep one second to trigger possible race condition with determining size.
 	time.Sleep(time.Second)
 	m.lock.Lock()
-	defer m.lock.Unlock()
 	m.size = uint64(len(m.disk.data))
+	m.lock.Unlock()
+
+	monitor := startTransferMonitor(m.params, m.BytesRead, m.Size, m.isReading, m.abortStalled)
+	m.lock.Lock()
+	m.monitor = monitor
+	closed := m.closed
+	m.lock.Unlock()
+	if closed {
+		monitor.stop()
+	}
 	close(m.done)
 }
@@ -0,0 +1,108 @@
+package ovirtclient
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	ovirtsdk4 "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) DownloadDiskToFile(
+	diskID DiskID,
+	format ImageFormat,
+	path string,
+	params DownloadDiskToFileParameters,
+	retries ...RetryStrategy,
+) (uint64, error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(o))
+	if err := format.Validate(); err != nil {
+		return 0, err
+	}
+
+	o.logger.Infof("Starting download of disk %s to %s...", diskID, path)
+	transfer := newImageTransfer(
+		o,
+		o.logger,
+		diskID,
+		"",
+		retries,
+		ovirtsdk4.IMAGETRANSFERDIRECTION_DOWNLOAD,
+		ovirtsdk4.DiskFormat(format),
+		func(disk Disk) {},
+	)
+	transferURL, err := transfer.initialize()
+	if err != nil {
+		return 0, transfer.finalize(err)
+	}
+	imageIOExtents, err := fetchImageIOExtents(o.httpClient, o.logger, retries, transfer, transferURL, "zero", diskID)
+	if err != nil {
+		return 0, transfer.finalize(err)
+	}
+	downloaded, err := downloadDiskToFile(
+		o.logger,
+		diskID,
+		format,
+		path,
+		convertImageIOExtents(imageIOExtents),
+		params,
+		func(ctx context.Context, p []byte, offset uint64) error {
+			return retry(
+				fmt.Sprintf("downloading bytes %d-%d of disk %s", offset, offset+uint64(len(p)), diskID),
+				o.logger,
+				append([]RetryStrategy{ContextStrategy(ctx)}, retries...),
+				func() error {
+					_, err := readImageIORange(ctx, o.httpClient, transfer, transferURL, diskID, p, int64(offset)) //nolint:gosec
+					return err
+				},
+			)
+		},
+	)
+	return downloaded, transfer.finalize(err)
+}
+
+func (m *mockClient) DownloadDiskToFile(
+	diskID DiskID,
+	format ImageFormat,
+	path string,
+	params DownloadDiskToFileParameters,
+	_ ...RetryStrategy,
+) (uint64, error) {
+	if err := format.Validate(); err != nil {
+		return 0, err
+	}
+
+	m.lock.Lock()
+	disk, ok := m.disks[diskID]
+	if !ok {
+		m.lock.Unlock()
+		return 0, newError(ENotFound, "disk with ID %s not found", diskID)
+	}
+	if disk.format != format {
+		m.logger.Warningf(
+			"the download to file requested a conversion from %s to %s; the mock library does not support this and "+
+				"the source image data will be used unmodified which may lead to errors",
+			disk.format,
+			format,
+		)
+	}
+	// The disk data is replaced, never modified in place, so the reader stays consistent without holding the lock.
+	reader := bytes.NewReader(disk.data)
+	extents := mockDiskExtents(disk.data)
+	m.lock.Unlock()
+
+	return downloadDiskToFile(
+		m.logger,
+		diskID,
+		format,
+		path,
+		extents,
+		params,
+		func(_ context.Context, p []byte, offset uint64) error {
+			if _, err := reader.ReadAt(p, int64(offset)); err != nil { //nolint:gosec
+				return wrap(err, EConnection, "failed to read bytes %d-%d of disk %s", offset, offset+uint64(len(p)), diskID)
+			}
+			return nil
+		},
+	)
+}
@@ -0,0 +1,107 @@
+package ovirtclient_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ovirtclient "github.com/dyudin0821/go-ovirt-client/v3"
+)
+
+func TestDiskExtents(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+
+	disk := assertCanCreateDisk(t, helper)
+	diskID := disk.ID()
+	assertCanUploadDiskData(t, client, disk, sparseTestData())
+
+	extents, err := client.DiskExtents(diskID)
+	if err != nil {
+		t.Fatalf("Failed to fetch extents of disk %s. (%v)", diskID, err)
+	}
+	offset := uint64(0)
+	hasZero := false
+	for _, extent := range extents {
+		if extent.Start != offset {
+			t.Fatalf("Extent at %d does not follow the previous extent ending at %d.", extent.Start, offset)
+		}
+		offset += extent.Length
+		hasZero = hasZero || extent.Zero
+	}
+	if offset < uint64(len(sparseTestData())) {
+		t.Fatalf("The extents only cover %d bytes of disk %s.", offset, diskID)
+	}
+	if !hasZero {
+		t.Fatalf("No zero extents reported for disk %s.", diskID)
+	}
+}
+
+func TestDownloadDiskToFile(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+
+	disk := assertCanCreateDisk(t, helper)
+	diskID := disk.ID()
+	data := sparseTestData()
+	assertCanUploadDiskData(t, client, disk, data)
+	path := filepath.Join(t.TempDir(), "disk.raw")
+	params := ovirtclient.DownloadDiskToFileParams().
+		MustWithChunkSize(64 * 1024).
+		MustWithWorkers(2)
+
+	downloaded, err := client.DownloadDiskToFile(diskID, ovirtclient.ImageFormatRaw, path, params)
+	if err != nil {
+		t.Fatalf("Failed to download disk %s to %s. (%v)", diskID, path, err)
+	}
+	contents := assertDownloadedFileMatches(t, path, data)
+	if downloaded >= uint64(len(contents)) {
+		t.Fatalf("Downloaded %d bytes for a %d byte sparse image, zero extents were not skipped.", downloaded, len(contents))
+	}
+	if _, err := os.Stat(path + ovirtclient.DiskFileDownloadStateSuffix); !os.IsNotExist(err) {
+		t.Fatalf("The download state file was not removed after the download completed. (%v)", err)
+	}
+
+	// Without a state file resuming starts over, so the corrupted contents must be overwritten.
+	if err := os.WriteFile(path, bytes.Repeat([]byte{0xff}, len(contents)), 0o600); err != nil {
+		t.Fatalf("Failed to corrupt %s. (%v)", path, err)
+	}
+	if _, err := client.DownloadDiskToFile(
+		diskID,
+		ovirtclient.ImageFormatRaw,
+		path,
+		params.MustWithResume(true),
+	); err != nil {
+		t.Fatalf("Failed to download disk %s to %s with resume enabled. (%v)", diskID, path, err)
+	}
+	assertDownloadedFileMatches(t, path, data)
+}
+
+// sparseTestData returns 256 KiB of data where the second and third 64 KiB blocks are zero.
+func sparseTestData() []byte {
+	const blockSize = 64 * 1024
+	data := make([]byte, 4*blockSize)
+	for i := range data {
+		if i < blockSize || i >= 3*blockSize {
+			data[i] = byte(i%251 + 1)
+		}
+	}
+	return data
+}
+
+func assertDownloadedFileMatches(t *testing.T, path string, data []byte) []byte {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read %s. (%v)", path, err)
+	}
+	if len(contents) < len(data) || !bytes.Equal(contents[:len(data)], data) {
+		t.Fatalf("The contents of %s do not match the disk contents.", path)
+	}
+	if !bytes.Equal(contents[len(data):], make([]byte, len(contents)-len(data))) {
+		t.Fatalf("The contents of %s after the uploaded data are not zero.", path)
+	}
+	return contents
+}
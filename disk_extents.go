@@ -0,0 +1,52 @@
+package ovirtclient
+
+import (
+	ovirtsdk4 "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) DiskExtents(diskID DiskID, retries ...RetryStrategy) ([]DiskExtent, error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(o))
+	transfer := newImageTransfer(
+		o,
+		o.logger,
+		diskID,
+		"",
+		retries,
+		ovirtsdk4.IMAGETRANSFERDIRECTION_DOWNLOAD,
+		ovirtsdk4.DISKFORMAT_RAW,
+		func(disk Disk) {},
+	)
+	transferURL, err := transfer.initialize()
+	if err != nil {
+		return nil, transfer.finalize(err)
+	}
+	imageIOExtents, err := fetchImageIOExtents(o.httpClient, o.logger, retries, transfer, transferURL, "zero", diskID)
+	if err := transfer.finalize(err); err != nil {
+		return nil, err
+	}
+	return convertImageIOExtents(imageIOExtents), nil
+}
+
+// convertImageIOExtents converts the extents returned by the ImageIO zero context, merging adjacent extents.
+func convertImageIOExtents(imageIOExtents []imageIOExtent) []DiskExtent {
+	var extents []DiskExtent
+	for _, extent := range imageIOExtents {
+		extents = appendDiskExtent(extents, DiskExtent{
+			Start:  extent.Start,
+			Length: extent.Length,
+			Zero:   extent.Zero,
+		})
+	}
+	return extents
+}
+
+func (m *mockClient) DiskExtents(diskID DiskID, _ ...RetryStrategy) ([]DiskExtent, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	disk, ok := m.disks[diskID]
+	if !ok {
+		return nil, newError(ENotFound, "disk with ID %s not found", diskID)
+	}
+	return mockDiskExtents(disk.data), nil
+}
@@ -0,0 +1,248 @@
+package ovirtclient
+
+import (
+	"crypto/sha256"
+	"encoding"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"net/http"
+)
+
+// imageChecksumBlockSize is the block size of the block based checksum. This matches the default block size of the
+// ImageIO checksum API.
+const imageChecksumBlockSize = 4 * 1024 * 1024
+
+// imageChecksum calculates the checksum of image data written to it sequentially.
+type imageChecksum interface {
+	// Write adds data to the checksum. It never returns an error.
+	Write(p []byte) (int, error)
+	// WriteZeroes adds the specified number of zero bytes to the checksum.
+	WriteZeroes(length uint64)
+	// Sum returns the hex-encoded checksum of the data written so far.
+	Sum() string
+}
+
+// newImageChecksum creates an imageChecksum for the specified algorithm. It returns nil if the algorithm is empty.
+func newImageChecksum(algorithm ChecksumAlgorithm) imageChecksum {
+	switch algorithm {
+	case ChecksumAlgorithmSHA256:
+		return &hashChecksum{hash: sha256.New()}
+	case ChecksumAlgorithmBlockSHA256:
+		return &blockChecksum{outer: sha256.New()}
+	default:
+		return nil
+	}
+}
+
+// hashChecksum is a checksum of the whole data using a single hash.
+type hashChecksum struct {
+	hash hash.Hash
+}
+
+func (h *hashChecksum) Write(p []byte) (int, error) {
+	return h.hash.Write(p)
+}
+
+func (h *hashChecksum) WriteZeroes(length uint64) {
+	zeroes := make([]byte, imageChecksumBlockSize)
+	for length > 0 {
+		n := uint64(len(zeroes))
+		if length < n {
+			n = length
+		}
+		_, _ = h.hash.Write(zeroes[:n])
+		length -= n
+	}
+}
+
+func (h *hashChecksum) Sum() string {
+	return hex.EncodeToString(h.hash.Sum(nil))
+}
+
+// blockChecksum is the block based checksum calculated by ImageIO. Each block is hashed separately, and the hashes of
+// the blocks are hashed again to obtain the checksum. The last block may be shorter than the block size.
+type blockChecksum struct {
+	outer hash.Hash
+	// block contains the data of the current incomplete block.
+	block []byte
+	// zeroDigest caches the hash of a block containing only zeroes.
+	zeroDigest []byte
+}
+
+func (b *blockChecksum) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		if len(b.block) == 0 && len(p) >= imageChecksumBlockSize {
+			b.addBlock(p[:imageChecksumBlockSize])
+			p = p[imageChecksumBlockSize:]
+			continue
+		}
+		take := imageChecksumBlockSize - len(b.block)
+		if take > len(p) {
+			take = len(p)
+		}
+		b.block = append(b.block, p[:take]...)
+		p = p[take:]
+		if len(b.block) == imageChecksumBlockSize {
+			b.addBlock(b.block)
+			b.block = b.block[:0]
+		}
+	}
+	return n, nil
+}
+
+func (b *blockChecksum) WriteZeroes(length uint64) {
+	if len(b.block) > 0 {
+		fill := uint64(imageChecksumBlockSize - len(b.block))
+		if fill > length {
+			fill = length
+		}
+		_, _ = b.Write(make([]byte, fill))
+		length -= fill
+	}
+	for ; length >= imageChecksumBlockSize; length -= imageChecksumBlockSize {
+		b.addBlock(nil)
+	}
+	if length > 0 {
+		_, _ = b.Write(make([]byte, length))
+	}
+}
+
+// addBlock adds the hash of a complete block to the outer hash. A nil block is treated as a block of zeroes.
+func (b *blockChecksum) addBlock(block []byte) {
+	if block == nil || isZero(block) {
+		if b.zeroDigest == nil {
+			digest := sha256.Sum256(make([]byte, imageChecksumBlockSize))
+			b.zeroDigest = digest[:]
+		}
+		_, _ = b.outer.Write(b.zeroDigest)
+		return
+	}
+	digest := sha256.Sum256(block)
+	_, _ = b.outer.Write(digest[:])
+}
+
+// Sum returns the checksum including the current incomplete block without modifying the state, so more data can be
+// written afterwards.
+func (b *blockChecksum) Sum() string {
+	outer := sha256.New()
+	state, err := b.outer.(encoding.BinaryMarshaler).MarshalBinary()
+	if err == nil {
+		err = outer.(encoding.BinaryUnmarshaler).UnmarshalBinary(state)
+	}
+	if err != nil {
+		// The standard library SHA-256 implementation always supports marshaling its state.
+		panic(err)
+	}
+	if len(b.block) > 0 {
+		digest := sha256.Sum256(b.block)
+		_, _ = outer.Write(digest[:])
+	}
+	return hex.EncodeToString(outer.Sum(nil))
+}
+
+// imageIOChecksum is the representation of a checksum as returned by the ImageIO checksum API.
+type imageIOChecksum struct {
+	Algorithm string `json:"algorithm"`
+	BlockSize uint64 `json:"block_size"`
+	Checksum  string `json:"checksum"`
+}
+
+// fetchImageIOChecksum asks the ImageIO server to calculate the block based SHA-256 checksum of the image served by
+// the image transfer. It returns an empty checksum if the server does not provide the checksum API for the transfer.
+func fetchImageIOChecksum(
+	httpClient http.Client,
+	logger Logger,
+	retries []RetryStrategy,
+	transfer imageTransfer,
+	transferURL string,
+	diskID DiskID,
+) (string, error) {
+	checksumURL := fmt.Sprintf(
+		"%s/checksum?algorithm=sha256&block_size=%d",
+		transferURL,
+		imageChecksumBlockSize,
+	)
+	var result string
+	err := retry(
+		fmt.Sprintf("fetching checksum of disk %s from %s", diskID, checksumURL),
+		logger,
+		retries,
+		func() error {
+			req, err := http.NewRequest(http.MethodGet, checksumURL, nil)
+			if err != nil {
+				return wrap(err, EBug, "failed to create HTTP request to %s", checksumURL)
+			}
+			res, err := httpClient.Do(req)
+			if err != nil {
+				return wrap(err, EConnection, "HTTP request to %s failed", checksumURL)
+			}
+			defer func() {
+				_ = res.Body.Close()
+			}()
+			switch res.StatusCode {
+			case http.StatusForbidden, http.StatusNotFound, http.StatusMethodNotAllowed:
+				logger.Debugf(
+					"The ImageIO server does not provide checksums for disk %s (status %d), skipping server-side verification.",
+					diskID,
+					res.StatusCode,
+				)
+				result = ""
+				return nil
+			}
+			if err := transfer.checkStatusCode(res.StatusCode); err != nil {
+				return err
+			}
+			var checksum imageIOChecksum
+			if err := json.NewDecoder(res.Body).Decode(&checksum); err != nil {
+				return wrap(err, EBug, "failed to decode checksum of disk %s", diskID)
+			}
+			if checksum.BlockSize != imageChecksumBlockSize {
+				return newError(
+					EUnsupported,
+					"the ImageIO server calculated the checksum of disk %s with a block size of %d instead of %d",
+					diskID,
+					checksum.BlockSize,
+					imageChecksumBlockSize,
+				)
+			}
+			result = checksum.Checksum
+			return nil
+		},
+	)
+	return result, err
+}
+
+// checkExpectedChecksum returns an EChecksumMismatch error if an expected checksum is set and does not match the
+// checksum of the transferred data.
+func checkExpectedChecksum(algorithm ChecksumAlgorithm, checksum string, expected string, diskID DiskID) error {
+	if expected == "" || checksum == expected {
+		return nil
+	}
+	return newError(
+		EChecksumMismatch,
+		"the %s checksum of the data transferred for disk %s is %s instead of the expected %s",
+		algorithm,
+		diskID,
+		checksum,
+		expected,
+	)
+}
+
+// checkServerChecksum returns an EChecksumMismatch error if the server returned a checksum and it does not match the
+// checksum of the transferred data.
+func checkServerChecksum(algorithm ChecksumAlgorithm, checksum string, serverChecksum string, diskID DiskID) error {
+	if serverChecksum == "" || checksum == serverChecksum {
+		return nil
+	}
+	return newError(
+		EChecksumMismatch,
+		"the %s checksum of the data transferred for disk %s is %s, but the ImageIO server calculated %s",
+		algorithm,
+		diskID,
+		checksum,
+		serverChecksum,
+	)
+}
@@ -0,0 +1,345 @@
+package ovirtclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+	"time"
+)
+
+// diskFileDownloadStateInterval is the interval in which the progress of a download into a file is recorded in the
+// state file. Recording the progress requires syncing the target file, so it is not done after every chunk.
+const diskFileDownloadStateInterval = 10 * time.Second
+
+// diskFileDownloadState is the content of the state file recording the progress of a download into a file.
+type diskFileDownloadState struct {
+	DiskID DiskID      `json:"disk_id"`
+	Format ImageFormat `json:"format"`
+	Size   uint64      `json:"size"`
+	// Completed is the offset in the image below which all data has been written to the target file and synced.
+	Completed uint64 `json:"completed"`
+}
+
+// diskFileRangeReader reads a range of the disk image into p. It must fill p completely or return an error.
+type diskFileRangeReader func(ctx context.Context, p []byte, offset uint64) error
+
+// diskFileDownload writes the data extents of a disk image into a local file in parallel. It is shared between the
+// real and the mock client, which only differ in how they read ranges of the image.
+type diskFileDownload struct {
+	logger    Logger
+	diskID    DiskID
+	path      string
+	params    DownloadDiskToFileParameters
+	readRange diskFileRangeReader
+
+	lock       *sync.Mutex
+	file       *os.File
+	state      diskFileDownloadState
+	downloaded uint64
+	// chunks are the chunks being downloaded in order, done marks the chunks that have been written to the file.
+	// nextChunk is the index of the first chunk that has not been written yet.
+	chunks    []DiskExtent
+	done      []bool
+	nextChunk int
+}
+
+// downloadDiskToFile downloads the data extents of a disk image into the file at path using readRange. Zero extents
+// are not written, the file is truncated to the image size instead, which leaves holes on file systems supporting
+// sparse files.
+func downloadDiskToFile(
+	logger Logger,
+	diskID DiskID,
+	format ImageFormat,
+	path string,
+	extents []DiskExtent,
+	params DownloadDiskToFileParameters,
+	readRange diskFileRangeReader,
+) (uint64, error) {
+	if params == nil {
+		params = DownloadDiskToFileParams()
+	}
+	d := &diskFileDownload{
+		logger:    logger,
+		diskID:    diskID,
+		path:      path,
+		params:    params,
+		readRange: readRange,
+		lock:      &sync.Mutex{},
+		state: diskFileDownloadState{
+			DiskID: diskID,
+			Format: format,
+		},
+	}
+	for _, extent := range extents {
+		if end := extent.Start + extent.Length; end > d.state.Size {
+			d.state.Size = end
+		}
+	}
+	if err := d.open(); err != nil {
+		return 0, err
+	}
+	d.chunks = planDiskFileChunks(extents, params.ChunkSize(), d.state.Completed)
+	d.done = make([]bool, len(d.chunks))
+	err := d.download()
+	if err == nil {
+		err = d.file.Sync()
+		if err != nil {
+			err = wrap(err, ELocalIO, "failed to sync %s", path)
+		}
+	} else if checkpointErr := d.checkpoint(); checkpointErr != nil {
+		d.logger.Warningf("Failed to record the progress of the download to %s. (%v)", path, checkpointErr)
+	}
+	if closeErr := d.file.Close(); closeErr != nil && err == nil {
+		err = wrap(closeErr, ELocalIO, "failed to close %s", path)
+	}
+	if err != nil {
+		return d.downloaded, err
+	}
+	if err := os.Remove(d.statePath()); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return d.downloaded, wrap(err, ELocalIO, "failed to remove download state file %s", d.statePath())
+	}
+	return d.downloaded, nil
+}
+
+// planDiskFileChunks splits the data extents into chunks of at most chunkSize bytes. Data below the completed offset
+// is left out.
+func planDiskFileChunks(extents []DiskExtent, chunkSize uint64, completed uint64) []DiskExtent {
+	var chunks []DiskExtent
+	for _, extent := range extents {
+		if extent.Zero {
+			continue
+		}
+		start := extent.Start
+		if start < completed {
+			start = completed
+		}
+		end := extent.Start + extent.Length
+		for ; start < end; start += chunkSize {
+			length := chunkSize
+			if start+length > end {
+				length = end - start
+			}
+			chunks = append(chunks, DiskExtent{Start: start, Length: length})
+		}
+	}
+	return chunks
+}
+
+func (d *diskFileDownload) statePath() string {
+	return d.path + DiskFileDownloadStateSuffix
+}
+
+// open opens the target file. When resuming, the completed offset is loaded from the state file if it exists and
+// belongs to the same download, otherwise the target file is truncated.
+func (d *diskFileDownload) open() error {
+	flags := os.O_RDWR | os.O_CREATE | os.O_TRUNC
+	if d.params.Resume() {
+		resumed, err := d.loadState()
+		if err != nil {
+			return err
+		}
+		if resumed {
+			flags = os.O_RDWR | os.O_CREATE
+		}
+	}
+	file, err := os.OpenFile(d.path, flags, 0o600)
+	if err != nil {
+		return wrap(err, ELocalIO, "failed to open %s", d.path)
+	}
+	if err := file.Truncate(int64(d.state.Size)); err != nil { //nolint:gosec
+		_ = file.Close()
+		return wrap(err, ELocalIO, "failed to resize %s to %d bytes", d.path, d.state.Size)
+	}
+	d.file = file
+	if err := d.saveState(); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return nil
+}
+
+// loadState loads the completed offset from the state file. It returns false if there is no state file or the target
+// file does not exist.
+func (d *diskFileDownload) loadState() (bool, error) {
+	if _, err := os.Stat(d.path); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			d.logger.Infof("Target file %s does not exist, starting download from the beginning.", d.path)
+			return false, nil
+		}
+		return false, wrap(err, ELocalIO, "failed to stat %s", d.path)
+	}
+	data, err := os.ReadFile(d.statePath())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			d.logger.Infof("No download state file found at %s, starting download from the beginning.", d.statePath())
+			return false, nil
+		}
+		return false, wrap(err, ELocalIO, "failed to read download state file %s", d.statePath())
+	}
+	var state diskFileDownloadState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return false, wrap(err, ELocalIO, "failed to decode download state file %s", d.statePath())
+	}
+	if state.DiskID != d.state.DiskID || state.Format != d.state.Format || state.Size != d.state.Size {
+		return false, newError(
+			EBadArgument,
+			"download state file %s belongs to a download of disk %s in %s format with %d bytes, cannot resume "+
+				"download of disk %s in %s format with %d bytes",
+			d.statePath(),
+			state.DiskID,
+			state.Format,
+			state.Size,
+			d.state.DiskID,
+			d.state.Format,
+			d.state.Size,
+		)
+	}
+	d.state.Completed = state.Completed
+	return true, nil
+}
+
+// checkpoint syncs the target file and records the offset below which all chunks have been written in the state
+// file. The file is synced first, so the state file never covers data that is not on disk yet.
+func (d *diskFileDownload) checkpoint() error {
+	d.lock.Lock()
+	completed := d.state.Size
+	if d.nextChunk < len(d.chunks) {
+		completed = d.chunks[d.nextChunk].Start
+	}
+	d.lock.Unlock()
+	if completed <= d.state.Completed {
+		return nil
+	}
+	if err := d.file.Sync(); err != nil {
+		return wrap(err, ELocalIO, "failed to sync %s", d.path)
+	}
+	d.state.Completed = completed
+	return d.saveState()
+}
+
+// saveState writes the state file. It replaces the state file atomically so an interrupted download never leaves a
+// partially written state file behind. It must only be called from one goroutine at a time.
+func (d *diskFileDownload) saveState() error {
+	data, err := json.Marshal(d.state)
+	if err != nil {
+		return wrap(err, EBug, "failed to encode download state")
+	}
+	tempPath := d.statePath() + ".tmp"
+	if err := os.WriteFile(tempPath, data, 0o600); err != nil {
+		return wrap(err, ELocalIO, "failed to write download state file %s", tempPath)
+	}
+	if err := os.Rename(tempPath, d.statePath()); err != nil {
+		return wrap(err, ELocalIO, "failed to replace download state file %s", d.statePath())
+	}
+	return nil
+}
+
+// download downloads the chunks in parallel and periodically records the progress. The first error cancels the
+// remaining chunks. The transfer monitor reports the progress and aborts the download if no chunk completes within
+// the inactivity timeout.
+func (d *diskFileDownload) download() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	workers := d.params.Workers()
+	work := make(chan int)
+	errs := make(chan error, workers+2)
+	var total uint64
+	for _, chunk := range d.chunks {
+		total += chunk.Length
+	}
+	monitor := startTransferMonitor(
+		d.params,
+		d.downloadedBytes,
+		func() uint64 {
+			return total
+		},
+		nil,
+		func(err error) {
+			errs <- err
+			cancel()
+		},
+	)
+	defer monitor.stop()
+	wg := &sync.WaitGroup{}
+	for i := uint(0); i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range work {
+				if err := d.downloadChunk(ctx, index); err != nil {
+					errs <- err
+					cancel()
+					return
+				}
+			}
+		}()
+	}
+	checkpointerDone := make(chan struct{})
+	go func() {
+		defer close(checkpointerDone)
+		d.checkpointPeriodically(ctx, errs, cancel)
+	}()
+feed:
+	for index := range d.chunks {
+		select {
+		case work <- index:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(work)
+	wg.Wait()
+	cancel()
+	<-checkpointerDone
+	close(errs)
+	return <-errs
+}
+
+// checkpointPeriodically records the progress of the download until ctx is canceled.
+func (d *diskFileDownload) checkpointPeriodically(ctx context.Context, errs chan<- error, cancel func()) {
+	ticker := time.NewTicker(diskFileDownloadStateInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := d.checkpoint(); err != nil {
+				errs <- err
+				cancel()
+				return
+			}
+		}
+	}
+}
+
+// downloadChunk reads a single chunk, writes it into the file and marks it as done.
+func (d *diskFileDownload) downloadChunk(ctx context.Context, index int) error {
+	chunk := d.chunks[index]
+	data := make([]byte, chunk.Length)
+	if err := d.readRange(ctx, data, chunk.Start); err != nil {
+		return err
+	}
+	if err := waitTransferRate(ctx, d.params, len(data)); err != nil {
+		return err
+	}
+	if _, err := d.file.WriteAt(data, int64(chunk.Start)); err != nil { //nolint:gosec
+		return wrap(err, ELocalIO, "failed to write bytes %d-%d to %s", chunk.Start, chunk.Start+chunk.Length, d.path)
+	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.downloaded += chunk.Length
+	d.done[index] = true
+	for d.nextChunk < len(d.done) && d.done[d.nextChunk] {
+		d.nextChunk++
+	}
+	return nil
+}
+
+func (d *diskFileDownload) downloadedBytes() uint64 {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return d.downloaded
+}
@@ -0,0 +1,144 @@
+package ovirtclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	ovirtclientlog "github.com/ovirt/go-ovirt-client-log/v3"
+)
+
+func TestDownloadDiskToFileResumesAfterCompletedOffset(t *testing.T) {
+	t.Parallel()
+
+	const chunkSize = 64 * 1024
+	data := make([]byte, 8*chunkSize)
+	for i := range data {
+		data[i] = byte(i%251 + 1)
+	}
+	extents := []DiskExtent{{Start: 0, Length: uint64(len(data))}}
+	path := filepath.Join(t.TempDir(), "disk.raw")
+	params := DownloadDiskToFileParams().MustWithChunkSize(chunkSize).MustWithWorkers(1).MustWithResume(true)
+	failAt := uint64(5 * chunkSize)
+
+	_, err := downloadDiskToFile(
+		ovirtclientlog.NewNOOPLogger(),
+		"disk-id",
+		ImageFormatRaw,
+		path,
+		extents,
+		params,
+		func(_ context.Context, p []byte, offset uint64) error {
+			if offset >= failAt {
+				return newError(EConnection, "simulated failure at byte %d", offset)
+			}
+			copy(p, data[offset:])
+			return nil
+		},
+	)
+	if err == nil {
+		t.Fatalf("The download with a failing reader did not result in an error.")
+	}
+	stateData, err := os.ReadFile(path + DiskFileDownloadStateSuffix)
+	if err != nil {
+		t.Fatalf("Failed to read download state file. (%v)", err)
+	}
+	var state diskFileDownloadState
+	if err := json.Unmarshal(stateData, &state); err != nil {
+		t.Fatalf("Failed to decode download state file. (%v)", err)
+	}
+	if state.Completed != failAt {
+		t.Fatalf("Incorrect completed offset in download state file: %d instead of %d.", state.Completed, failAt)
+	}
+
+	lock := &sync.Mutex{}
+	var readOffsets []uint64
+	downloaded, err := downloadDiskToFile(
+		ovirtclientlog.NewNOOPLogger(),
+		"disk-id",
+		ImageFormatRaw,
+		path,
+		extents,
+		params,
+		func(_ context.Context, p []byte, offset uint64) error {
+			lock.Lock()
+			readOffsets = append(readOffsets, offset)
+			lock.Unlock()
+			copy(p, data[offset:])
+			return nil
+		},
+	)
+	if err != nil {
+		t.Fatalf("Failed to resume download. (%v)", err)
+	}
+	if downloaded != uint64(len(data))-failAt {
+		t.Fatalf("Incorrect number of bytes downloaded on resume: %d instead of %d.", downloaded, uint64(len(data))-failAt)
+	}
+	for _, offset := range readOffsets {
+		if offset < failAt {
+			t.Fatalf("Byte %d was downloaded again although it was recorded as completed.", offset)
+		}
+	}
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read %s. (%v)", path, err)
+	}
+	if !bytes.Equal(contents, data) {
+		t.Fatalf("The contents of %s do not match the disk contents.", path)
+	}
+	if _, err := os.Stat(path + DiskFileDownloadStateSuffix); !os.IsNotExist(err) {
+		t.Fatalf("The download state file was not removed after the download completed. (%v)", err)
+	}
+}
+
+func TestDownloadDiskToFileInactivityTimeout(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "disk.raw")
+	_, err := downloadDiskToFile(
+		ovirtclientlog.NewNOOPLogger(),
+		"disk-id",
+		ImageFormatRaw,
+		path,
+		[]DiskExtent{{Start: 0, Length: 1024 * 1024}},
+		DownloadDiskToFileParams().MustWithInactivityTimeout(200*time.Millisecond),
+		func(ctx context.Context, _ []byte, _ uint64) error {
+			// Stall until the download is aborted.
+			<-ctx.Done()
+			return wrap(ctx.Err(), EConnection, "read canceled")
+		},
+	)
+	if err == nil {
+		t.Fatalf("Downloading from a stalled reader did not result in an error.")
+	}
+	if !HasErrorCode(err, ETimeout) {
+		t.Fatalf("Downloading from a stalled reader did not result in an ETimeout error. (%v)", err)
+	}
+	if _, err := os.Stat(path + DiskFileDownloadStateSuffix); err != nil {
+		t.Fatalf("The download state file was not kept after the download stalled. (%v)", err)
+	}
+}
+
+func TestPlanDiskFileChunksSkipsCompleted(t *testing.T) {
+	t.Parallel()
+
+	chunks := planDiskFileChunks(
+		[]DiskExtent{
+			{Start: 0, Length: 100},
+			{Start: 100, Length: 100, Zero: true},
+			{Start: 200, Length: 100},
+		},
+		40,
+		220,
+	)
+	expected := []DiskExtent{{Start: 220, Length: 40}, {Start: 260, Length: 40}}
+	if !reflect.DeepEqual(chunks, expected) {
+		t.Fatalf("Incorrect chunks: %v instead of %v.", chunks, expected)
+	}
+}
@@ -0,0 +1,99 @@
+package ovirtclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// imageIOExtent is the representation of an extent as returned by the ImageIO extents API.
+type imageIOExtent struct {
+	Start  uint64 `json:"start"`
+	Length uint64 `json:"length"`
+	Zero   bool   `json:"zero"`
+	Dirty  bool   `json:"dirty"`
+}
+
+// fetchImageIOExtents queries the ImageIO extents API of an image transfer in the specified context. The zero context
+// reports which extents read as zeroes, the dirty context reports the extents changed since a checkpoint and is only
+// available for backup transfers.
+func fetchImageIOExtents(
+	httpClient http.Client,
+	logger Logger,
+	retries []RetryStrategy,
+	transfer imageTransfer,
+	transferURL string,
+	extentsContext string,
+	diskID DiskID,
+) ([]imageIOExtent, error) {
+	extentsURL := fmt.Sprintf("%s/extents?context=%s", transferURL, extentsContext)
+	var result []imageIOExtent
+	err := retry(
+		fmt.Sprintf("fetching extents of disk %s from %s", diskID, extentsURL),
+		logger,
+		retries,
+		func() error {
+			req, err := http.NewRequest(http.MethodGet, extentsURL, nil)
+			if err != nil {
+				return wrap(err, EBug, "failed to create HTTP request to %s", extentsURL)
+			}
+			res, err := httpClient.Do(req)
+			if err != nil {
+				return wrap(err, EConnection, "HTTP request to %s failed", extentsURL)
+			}
+			defer func() {
+				_ = res.Body.Close()
+			}()
+			if err := transfer.checkStatusCode(res.StatusCode); err != nil {
+				return err
+			}
+			result = nil
+			if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+				return wrap(err, EBug, "failed to decode extents of disk %s", diskID)
+			}
+			return nil
+		},
+	)
+	return result, err
+}
+
+// readImageIORange reads a range of the image served by an image transfer using a single HTTP range request.
+func readImageIORange(
+	ctx context.Context,
+	httpClient http.Client,
+	transfer imageTransfer,
+	transferURL string,
+	diskID DiskID,
+	p []byte,
+	off int64,
+) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, transferURL, nil)
+	if err != nil {
+		return 0, wrap(err, EBug, "failed to create HTTP request to %s", transferURL)
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", off, off+int64(len(p))-1))
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return 0, wrap(err, EConnection, "HTTP request to image transfer URL %s failed", transferURL)
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+	if err := transfer.checkStatusCode(res.StatusCode); err != nil {
+		return 0, err
+	}
+	if res.StatusCode != http.StatusPartialContent {
+		return 0, newError(
+			EUnsupported,
+			"the ImageIO server did not respond with partial content to a range request (%d)",
+			res.StatusCode,
+		)
+	}
+	n, err := io.ReadFull(res.Body, p)
+	if err != nil {
+		return n, wrap(err, EConnection, "failed to read %d bytes at offset %d of disk %s", len(p), off, diskID)
+	}
+	return n, nil
+}
@@ -1,8 +1,10 @@
 package ovirtclient
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"math/rand"
 	"net/http"
 	"net/url"
@@ -68,6 +70,59 @@ func newImageTransfer(
 	}
 }
 
+// newUploadImageTransfer creates a new image transfer for uploads that is tuned by the upload parameters. If the
+// parameters contain an image transfer ID, the existing image transfer is resumed instead of creating a new one. If the
+// parameters request the transfer to be kept, a failed transfer is paused instead of canceled.
+func newUploadImageTransfer(
+	cli *oVirtClient,
+	logger Logger,
+	diskID DiskID,
+	correlationID string,
+	retries []RetryStrategy,
+	format ovirtsdk4.DiskFormat,
+	params UploadImageParameters,
+	updateDisk func(disk Disk),
+) imageTransfer {
+	transfer := newImageTransfer(
+		cli,
+		logger,
+		diskID,
+		correlationID,
+		retries,
+		ovirtsdk4.IMAGETRANSFERDIRECTION_UPLOAD,
+		format,
+		updateDisk,
+	).(*imageTransferImpl)
+	transfer.resumeTransferID = params.ResumeTransferID()
+	transfer.keepOnError = params.KeepTransferOnError()
+	return transfer
+}
+
+// newBackupImageTransfer creates a new image transfer for downloading a disk that is part of a VM backup. The transfer
+// is bound to the backup, which lets ImageIO report the dirty extents of the disk. Backup transfers are always raw,
+// the engine decides whether the extents are relative to a checkpoint based on the backup itself.
+func newBackupImageTransfer(
+	cli *oVirtClient,
+	logger Logger,
+	diskID DiskID,
+	backupID VMBackupID,
+	retries []RetryStrategy,
+) imageTransfer {
+	return &imageTransferImpl{
+		retries:       retries,
+		diskID:        diskID,
+		backupID:      backupID,
+		cli:           cli,
+		logger:        logger,
+		correlationID: generateCorrelationID("image_backup_"),
+		conn:          cli.conn,
+		httpClient:    cli.httpClient,
+		direction:     ovirtsdk4.IMAGETRANSFERDIRECTION_DOWNLOAD,
+		format:        ovirtsdk4.DISKFORMAT_RAW,
+		updateDisk:    func(disk Disk) {},
+	}
+}
+
 // imageTransfer is an internal helper to facilitate image transfers from/to the oVirt Engine. It should not be reused
 // for multiple transfers.
 type imageTransfer interface {
@@ -82,6 +137,13 @@ type imageTransfer interface {
 	// If the finalize function is not called the disk may potentially stay in locked status indefinitely.
 	finalize(err error) error
 
+	// id returns the ID of the image transfer. It is empty until the image transfer has been created.
+	id() ImageTransferID
+
+	// supports returns true if the ImageIO server reported the feature, for example "zero" or "flush", in response to
+	// the OPTIONS request sent during initialization.
+	supports(feature string) bool
+
 	// checkStatusCode checks an ImageIO status code for correctness and returns an error if it is
 	// not correct.
 	checkStatusCode(statusCode int) error
@@ -93,6 +155,15 @@ type imageTransferImpl struct {
 	retries []RetryStrategy
 	// diskID is the ID of the disk used for this transfer.
 	diskID DiskID
+	// backupID is the ID of the VM backup this transfer belongs to. It is empty for regular transfers.
+	backupID VMBackupID
+	// resumeTransferID is the ID of an existing image transfer to resume instead of creating a new one.
+	resumeTransferID ImageTransferID
+	// keepOnError indicates that the image transfer should be paused instead of canceled if it fails, so it can be
+	// resumed later.
+	keepOnError bool
+	// paused indicates that the image transfer has been paused after a failure.
+	paused bool
 	// cli is the calling client library.
 	cli *oVirtClient
 	// logger is the go-ovirt-client-log logger
@@ -119,6 +190,9 @@ type imageTransferImpl struct {
 	transferService *ovirtsdk4.ImageTransferService
 	// transferURL is the URL that is found for the transfer. It is set after findTransferURL is called.
 	transferURL string
+	// features contains the features reported by the ImageIO server for the transfer URL. It is set after
+	// findTransferURL is called.
+	features map[string]struct{}
 }
 
 // checkStatusCode takes a HTTP status code from the ImageIO endpoint and verifies it.
@@ -168,21 +242,45 @@ func (i *imageTransferImpl) initialize() (transferURL string, err error) {
 		i.waitForImageTransferReady,
 		i.findTransferURL,
 	}
+	if i.resumeTransferID != "" {
+		steps = []func() error{
+			i.resumeImageTransfer,
+			i.waitForImageTransferReady,
+			i.findTransferURL,
+		}
+	}
 
 	for _, step := range steps {
 		if err := step(); err != nil {
-			i.abortTransfer()
+			i.failTransfer()
 			return "", err
 		}
 	}
 	return i.transferURL, nil
 }
 
+// supports returns true if the ImageIO server reported the specified feature.
+func (i *imageTransferImpl) supports(feature string) bool {
+	_, ok := i.features[feature]
+	return ok
+}
+
+// id returns the ID of the image transfer.
+func (i *imageTransferImpl) id() ImageTransferID {
+	if i.transfer == nil {
+		return i.resumeTransferID
+	}
+	if id, ok := i.transfer.Id(); ok {
+		return ImageTransferID(id)
+	}
+	return ""
+}
+
 // finalize finalizes or aborts the image transfer, depending on if an error happened. The calling
 // party must pass any error that happened so that the finalize function can make the correct decision.
 func (i *imageTransferImpl) finalize(err error) error {
 	if err != nil {
-		i.abortTransfer()
+		i.failTransfer()
 		return err
 	}
 	steps := []func() error{
@@ -205,6 +303,11 @@ func (i *imageTransferImpl) finalize(err error) error {
 //
 // This function also calls the updateDisk hook to update the disk on the calling side.
 func (i *imageTransferImpl) waitForTransferOk() (err error) {
+	if i.backupID != "" {
+		// The disks stay locked for the whole duration of a backup, so only the job can be checked here.
+		return i.cli.waitForJobFinished(i.correlationID, i.retries)
+	}
+
 	disk, err := i.cli.WaitForDiskOK(i.diskID, i.retries...)
 
 	if err != nil {
@@ -225,13 +328,19 @@ func (i *imageTransferImpl) buildImageTransferRequest() (
 	*ovirtsdk4.ImageTransfersService,
 ) {
 	imageTransfersService := i.conn.SystemService().ImageTransfersService()
-	image := ovirtsdk4.NewImageBuilder().Id(string(i.diskID)).MustBuild()
-	transfer := ovirtsdk4.
+	builder := ovirtsdk4.
 		NewImageTransferBuilder().
-		Image(image).
 		Direction(i.direction).
-		Format(i.format).
-		MustBuild()
+		Format(i.format)
+	if i.backupID != "" {
+		// Backup transfers must reference the disk instead of the image.
+		builder.
+			Disk(ovirtsdk4.NewDiskBuilder().Id(string(i.diskID)).MustBuild()).
+			Backup(ovirtsdk4.NewBackupBuilder().Id(string(i.backupID)).MustBuild())
+	} else {
+		builder.Image(ovirtsdk4.NewImageBuilder().Id(string(i.diskID)).MustBuild())
+	}
+	transfer := builder.MustBuild()
 	transferReq := imageTransfersService.
 		Add().
 		ImageTransfer(transfer).
@@ -239,6 +348,85 @@ func (i *imageTransferImpl) buildImageTransferRequest() (
 	return transferReq, imageTransfersService
 }
 
+// resumeImageTransfer repeatedly tries to look up and resume an existing image transfer until it succeeds or it runs
+// out of retries. This function will set the i.transfer and i.transferService variables.
+func (i *imageTransferImpl) resumeImageTransfer() error {
+	return retry(
+		fmt.Sprintf("resuming image transfer %s for disk %s", i.resumeTransferID, i.diskID),
+		i.logger,
+		i.retries,
+		i.attemptResumeImageTransfer,
+	)
+}
+
+// attemptResumeImageTransfer fetches an existing image transfer, verifies that it belongs to the disk and resumes it
+// if it is paused.
+func (i *imageTransferImpl) attemptResumeImageTransfer() error {
+	transferService := i.conn.SystemService().ImageTransfersService().ImageTransferService(string(i.resumeTransferID))
+	response, err := transferService.Get().Send()
+	if err != nil {
+		return err
+	}
+	transfer, ok := response.ImageTransfer()
+	if !ok {
+		return newError(EFieldMissing, "fetching image transfer %s did not return an image transfer", i.resumeTransferID)
+	}
+	if direction, ok := transfer.Direction(); ok && direction != i.direction {
+		return newError(
+			EBadArgument,
+			"image transfer %s is a %s, not a %s",
+			i.resumeTransferID,
+			direction,
+			i.direction,
+		)
+	}
+	if err := i.checkResumedTransferDisk(transfer); err != nil {
+		return err
+	}
+	phase, ok := transfer.Phase()
+	if !ok {
+		return newError(EFieldMissing, "image transfer %s has no phase", i.resumeTransferID)
+	}
+	switch phase {
+	case ovirtsdk4.IMAGETRANSFERPHASE_PAUSED_USER, ovirtsdk4.IMAGETRANSFERPHASE_PAUSED_SYSTEM:
+		if _, err := transferService.Resume().Send(); err != nil {
+			return err
+		}
+	case ovirtsdk4.IMAGETRANSFERPHASE_TRANSFERRING, ovirtsdk4.IMAGETRANSFERPHASE_RESUMING:
+	default:
+		return newError(
+			EBadArgument,
+			"image transfer %s is in phase %s and cannot be resumed",
+			i.resumeTransferID,
+			phase,
+		)
+	}
+	i.transfer = transfer
+	i.transferService = transferService
+	return nil
+}
+
+// checkResumedTransferDisk verifies that an image transfer that is being resumed belongs to the disk of this transfer.
+func (i *imageTransferImpl) checkResumedTransferDisk(transfer *ovirtsdk4.ImageTransfer) error {
+	var transferDiskID string
+	if image, ok := transfer.Image(); ok {
+		transferDiskID, _ = image.Id()
+	}
+	if disk, ok := transfer.Disk(); ok && transferDiskID == "" {
+		transferDiskID, _ = disk.Id()
+	}
+	if transferDiskID != "" && DiskID(transferDiskID) != i.diskID {
+		return newError(
+			EBadArgument,
+			"image transfer %s belongs to disk %s instead of %s",
+			i.resumeTransferID,
+			transferDiskID,
+			i.diskID,
+		)
+	}
+	return nil
+}
+
 // createImageTransfer repeatedly tries to create an image transfer until it succeeds or it runs out of retries.
 // This function will set the i.transfer and i.transferService variables with the created image transfer and
 // the associated service.
@@ -317,7 +505,7 @@ func (i *imageTransferImpl) checkImageTransferReady() error {
 		)
 	}
 	switch phase {
-	case ovirtsdk4.IMAGETRANSFERPHASE_INITIALIZING:
+	case ovirtsdk4.IMAGETRANSFERPHASE_INITIALIZING, ovirtsdk4.IMAGETRANSFERPHASE_RESUMING:
 		return newError(
 			EPending,
 			"image transfer is in phase %s instead of transferring",
@@ -512,6 +700,7 @@ func (i *imageTransferImpl) optionsRequest(parsedTransferURL *url.URL) error {
 			parsedTransferURL.String(),
 		)
 	case statusCode < 399:
+		i.readFeatures(res.Body)
 		return nil
 	case statusCode < 499:
 		return newError(
@@ -530,6 +719,58 @@ func (i *imageTransferImpl) optionsRequest(parsedTransferURL *url.URL) error {
 	}
 }
 
+// failTransfer cleans up after a failed transfer. It pauses the transfer if it should be kept for resuming later,
+// otherwise it aborts it.
+func (i *imageTransferImpl) failTransfer() {
+	if i.keepOnError && i.transfer != nil {
+		if !i.paused {
+			i.pauseTransfer()
+			i.paused = true
+		}
+		return
+	}
+	i.abortTransfer()
+}
+
+// pauseTransfer pauses an image transfer with the oVirt Engine API so it can be resumed later. It calls the pause
+// repeatedly until it succeeds or the retries are exhausted.
+func (i *imageTransferImpl) pauseTransfer() {
+	if err := retry(
+		fmt.Sprintf("pausing transfer %s for disk %s", i.id(), i.diskID),
+		i.logger,
+		i.retries,
+		func() error {
+			_, err := i.transferService.Pause().Send()
+			return err
+		},
+	); err != nil {
+		// We can't really do anything as we are already in a failure state, log the error. The engine pauses
+		// inactive transfers on its own, so the transfer may still be resumable.
+		i.logger.Warningf(
+			"failed to pause transfer %s for disk %s (%v)",
+			i.id(),
+			i.diskID,
+			err,
+		)
+	}
+}
+
+// readFeatures reads the features supported by the ImageIO server from the body of an OPTIONS response. Older
+// ImageIO versions do not report features, in which case no features are recorded.
+func (i *imageTransferImpl) readFeatures(body io.Reader) {
+	var options struct {
+		Features []string `json:"features"`
+	}
+	i.features = map[string]struct{}{}
+	if err := json.NewDecoder(body).Decode(&options); err != nil {
+		i.logger.Debugf("Failed to decode ImageIO OPTIONS response, assuming no features are supported. (%v)", err)
+		return
+	}
+	for _, feature := range options.Features {
+		i.features[feature] = struct{}{}
+	}
+}
+
 // abortTransfer cancels an image transfer with the oVirt Engine API. It calls the abort repeatedly until it succeeds or
 // the retries are exhausted.
 func (i *imageTransferImpl) abortTransfer() {
@@ -0,0 +1,174 @@
+package ovirtclient
+
+import (
+	"sync"
+	"time"
+)
+
+// minTransferMonitorTick is the shortest interval in which the transfer monitor checks the progress.
+const minTransferMonitorTick = 10 * time.Millisecond
+
+// transferMonitor reports the progress of a transfer to the progress callback and detects stalled transfers. A
+// transfer is stalled if it is busy, but the number of transferred bytes has not changed for the inactivity timeout.
+type transferMonitor struct {
+	lock        *sync.Mutex
+	options     TransferOptions
+	transferred func() uint64
+	total       func() uint64
+	// busy returns true while the transfer is waiting for data. If it is nil, the transfer is always busy.
+	busy func() bool
+	// onStall is called once when the transfer has stalled. It must not call stop.
+	onStall func(err error)
+
+	start        time.Time
+	lastActivity time.Time
+	lastBytes    uint64
+	samples      []transferSample
+	stallErr     error
+	done         chan struct{}
+	exited       chan struct{}
+	stopOnce     *sync.Once
+}
+
+type transferSample struct {
+	time  time.Time
+	bytes uint64
+}
+
+// startTransferMonitor starts monitoring a transfer if a progress callback or an inactivity timeout is set in the
+// options. The returned monitor must be stopped when the transfer is complete.
+func startTransferMonitor(
+	options TransferOptions,
+	transferred func() uint64,
+	total func() uint64,
+	busy func() bool,
+	onStall func(err error),
+) *transferMonitor {
+	now := time.Now()
+	m := &transferMonitor{
+		lock:         &sync.Mutex{},
+		options:      options,
+		transferred:  transferred,
+		total:        total,
+		busy:         busy,
+		onStall:      onStall,
+		start:        now,
+		lastActivity: now,
+		lastBytes:    transferred(),
+		done:         make(chan struct{}),
+		exited:       make(chan struct{}),
+		stopOnce:     &sync.Once{},
+	}
+	if options == nil || (options.ProgressCallback() == nil && options.InactivityTimeout() == 0) {
+		close(m.exited)
+		return m
+	}
+	go m.run()
+	return m
+}
+
+func (m *transferMonitor) run() {
+	defer close(m.exited)
+	tick := m.options.ProgressInterval()
+	if timeout := m.options.InactivityTimeout(); timeout > 0 && (m.options.ProgressCallback() == nil || timeout/4 < tick) {
+		tick = timeout / 4
+	}
+	if tick < minTransferMonitorTick {
+		tick = minTransferMonitorTick
+	}
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+	lastReport := m.start
+	for {
+		select {
+		case <-m.done:
+			m.report(true)
+			return
+		case now := <-ticker.C:
+			if m.checkStalled(now) {
+				return
+			}
+			if m.options.ProgressCallback() != nil && now.Sub(lastReport) >= m.options.ProgressInterval() {
+				lastReport = now
+				m.report(false)
+			}
+		}
+	}
+}
+
+// checkStalled records the activity of the transfer and calls onStall if the inactivity timeout has passed.
+func (m *transferMonitor) checkStalled(now time.Time) bool {
+	bytes := m.transferred()
+	m.lock.Lock()
+	if bytes != m.lastBytes || (m.busy != nil && !m.busy()) {
+		m.lastBytes = bytes
+		m.lastActivity = now
+	}
+	timeout := m.options.InactivityTimeout()
+	if timeout == 0 || now.Sub(m.lastActivity) < timeout {
+		m.lock.Unlock()
+		return false
+	}
+	m.stallErr = newError(
+		ETimeout,
+		"the transfer was aborted because no data was transferred for %s",
+		now.Sub(m.lastActivity).Round(time.Millisecond),
+	)
+	err := m.stallErr
+	m.lock.Unlock()
+
+	m.onStall(err)
+	m.report(true)
+	return true
+}
+
+// report calls the progress callback with the current progress.
+func (m *transferMonitor) report(done bool) {
+	callback := m.options.ProgressCallback()
+	if callback == nil {
+		return
+	}
+	now := time.Now()
+	bytes := m.transferred()
+	total := m.total()
+
+	m.samples = append(m.samples, transferSample{time: now, bytes: bytes})
+	first := 0
+	for first < len(m.samples)-2 && now.Sub(m.samples[first+1].time) >= transferRateWindow {
+		first++
+	}
+	m.samples = m.samples[first:]
+
+	progress := TransferProgress{
+		TransferredBytes: bytes,
+		TotalBytes:       total,
+		Elapsed:          now.Sub(m.start),
+		Done:             done,
+	}
+	oldest := transferSample{time: m.start}
+	if len(m.samples) > 1 {
+		oldest = m.samples[0]
+	}
+	if elapsed := now.Sub(oldest.time).Seconds(); elapsed > 0 && bytes >= oldest.bytes {
+		progress.BytesPerSecond = float64(bytes-oldest.bytes) / elapsed
+	}
+	if progress.BytesPerSecond > 0 && total > bytes {
+		progress.ETA = time.Duration(float64(total-bytes) / progress.BytesPerSecond * float64(time.Second))
+	}
+	callback(progress)
+}
+
+// err returns the error describing the stall if the transfer has stalled.
+func (m *transferMonitor) err() error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.stallErr
+}
+
+// stop stops monitoring and waits for the last progress report. It can be called multiple times.
+func (m *transferMonitor) stop() {
+	m.stopOnce.Do(func() {
+		close(m.done)
+	})
+	<-m.exited
+}
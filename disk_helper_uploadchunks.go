@@ -0,0 +1,375 @@
+package ovirtclient
+
+import (
+	"io"
+	"sort"
+	"sync"
+)
+
+// planUploadChunks splits an image of the specified size into chunks of chunkSize bytes. Chunks of a previous upload
+// that are in the done state and have the same offset and length as a planned chunk are kept in the done state, so
+// they are not uploaded again.
+func planUploadChunks(size uint64, chunkSize uint64, previous []UploadChunk) []UploadChunk {
+	done := make(map[uint64]uint64, len(previous))
+	for _, chunk := range previous {
+		if chunk.State == UploadChunkStateDone {
+			done[chunk.Offset] = chunk.Length
+		}
+	}
+	chunks := make([]UploadChunk, 0, (size+chunkSize-1)/chunkSize)
+	for offset := uint64(0); offset < size; offset += chunkSize {
+		length := chunkSize
+		if offset+length > size {
+			length = size - offset
+		}
+		state := UploadChunkStatePending
+		if doneLength, ok := done[offset]; ok && doneLength == length {
+			state = UploadChunkStateDone
+		}
+		chunks = append(chunks, UploadChunk{
+			Offset: offset,
+			Length: length,
+			State:  state,
+		})
+	}
+	return chunks
+}
+
+// uploadParamsOrDefault returns the default upload parameters if none are passed.
+func uploadParamsOrDefault(params UploadImageParameters) UploadImageParameters {
+	if params == nil {
+		return UploadImageParams()
+	}
+	return params
+}
+
+// uploadChunkList tracks the state of the chunks of an upload. It is safe for concurrent use.
+type uploadChunkList struct {
+	lock   *sync.Mutex
+	chunks []UploadChunk
+}
+
+func newUploadChunkList(chunks []UploadChunk) *uploadChunkList {
+	return &uploadChunkList{
+		lock:   &sync.Mutex{},
+		chunks: chunks,
+	}
+}
+
+// Chunks returns a copy of the current chunk states.
+func (l *uploadChunkList) Chunks() []UploadChunk {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return append([]UploadChunk(nil), l.chunks...)
+}
+
+func (l *uploadChunkList) get(index int) UploadChunk {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.chunks[index]
+}
+
+func (l *uploadChunkList) setState(index int, state UploadChunkState) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.chunks[index].State = state
+}
+
+// pending returns the indexes of all chunks that are not done.
+func (l *uploadChunkList) pending() []int {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	var result []int
+	for i, chunk := range l.chunks {
+		if chunk.State != UploadChunkStateDone {
+			result = append(result, i)
+		}
+	}
+	return result
+}
+
+// doneBytes returns the number of bytes in chunks that are done.
+func (l *uploadChunkList) doneBytes() uint64 {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	var result uint64
+	for _, chunk := range l.chunks {
+		if chunk.State == UploadChunkStateDone {
+			result += chunk.Length
+		}
+	}
+	return result
+}
+
+// uploadZeroBlockSize is the granularity in which chunks are checked for zeroes.
+const uploadZeroBlockSize = 64 * 1024
+
+// uploadChunkSource reads chunks from an image. Readers implementing io.ReaderAt are read concurrently, other readers
+// are read one chunk at a time by seeking to the start of the chunk.
+type uploadChunkSource struct {
+	lock   *sync.Mutex
+	reader io.ReadSeeker
+	// zeroDetection indicates that the chunks should be split into data and zero extents.
+	zeroDetection bool
+	// allocation contains the extents of the image file with the Zero flag set on ranges that do not need to be read.
+	// It is nil if the whole file needs to be read.
+	allocation []DiskExtent
+
+	// stream is set instead of reader for images that can only be read sequentially. The chunks are read from the
+	// stream in order using prefetch and kept in streamChunks until they are uploaded.
+	stream         io.Reader
+	streamSize     uint64
+	streamOffset   uint64
+	streamChunks   map[uint64][]byte
+	streamChecksum imageChecksum
+	// streamRelease frees the resources used for reading the stream, or is nil for sources that are not streams.
+	streamRelease func()
+
+	// closer closes the underlying reader to interrupt a read that is blocked, or nil if the reader cannot be closed.
+	closer io.Closer
+}
+
+func newUploadChunkSource(reader io.ReadSeeker) *uploadChunkSource {
+	source := &uploadChunkSource{
+		lock:   &sync.Mutex{},
+		reader: reader,
+	}
+	if closer, ok := reader.(io.Closer); ok {
+		source.closer = closer
+	}
+	return source
+}
+
+// newImageUploadChunkSource creates a chunk source for uploading an image with the specified parameters. If zero
+// detection is enabled for a QCOW2 image, the metadata of the image is read to find the unreferenced ranges. If the
+// metadata cannot be read, the whole image is read and checked for zeroes instead.
+func newImageUploadChunkSource(
+	logger Logger,
+	reader io.ReadSeeker,
+	format ImageFormat,
+	size uint64,
+	params UploadImageParameters,
+) *uploadChunkSource {
+	source := newUploadChunkSource(reader)
+	source.zeroDetection = params.ZeroDetection()
+	if source.zeroDetection && format == ImageFormatCow {
+		allocation, err := qcowAllocation(reader, size)
+		if err != nil {
+			logger.Warningf("Failed to read QCOW allocation, checking the whole image for zeroes instead. (%v)", err)
+		}
+		source.allocation = allocation
+	}
+	return source
+}
+
+// newStreamUploadChunkSource creates a chunk source for uploading an image of the specified size from a stream. The
+// checksum is calculated while the stream is read, as the stream cannot be read a second time.
+func newStreamUploadChunkSource(stream *uploadStream, size uint64, params UploadImageParameters) *uploadChunkSource {
+	source := &uploadChunkSource{
+		lock:          &sync.Mutex{},
+		zeroDetection: params.ZeroDetection(),
+		stream:        stream.reader,
+		streamSize:    size,
+		streamChunks:  map[uint64][]byte{},
+		closer:        stream,
+		streamRelease: stream.release,
+	}
+	if algorithm := params.ChecksumAlgorithm(); algorithm != "" {
+		source.streamChecksum = newImageChecksum(algorithm)
+	}
+	return source
+}
+
+// prefetch reads the next chunk from the stream. It must be called for all chunks in order before they are read. For
+// sources that are not streams this does nothing.
+func (s *uploadChunkSource) prefetch(chunk UploadChunk) error {
+	if s.stream == nil {
+		return nil
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if chunk.Offset != s.streamOffset {
+		return newError(EBug, "chunk at byte %d read out of order at byte %d of the stream", chunk.Offset, s.streamOffset)
+	}
+	data := make([]byte, chunk.Length)
+	if n, err := io.ReadFull(s.stream, data); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return newError(
+				EBadArgument,
+				"the stream ended after %d bytes, but the image was declared to be %d bytes",
+				s.streamOffset+uint64(n),
+				s.streamSize,
+			)
+		}
+		return s.readError(err, chunk)
+	}
+	s.streamOffset += chunk.Length
+	if s.streamOffset == s.streamSize {
+		// A reader may return no data without an error, so read until there is either more data or the end of the
+		// stream.
+		if _, err := io.ReadAtLeast(s.stream, make([]byte, 1), 1); err == nil {
+			return newError(EBadArgument, "the stream is longer than the declared image size of %d bytes", s.streamSize)
+		} else if err != io.EOF {
+			return wrap(err, ELocalIO, "failed to read the end of the stream")
+		}
+	}
+	if s.streamChecksum != nil {
+		_, _ = s.streamChecksum.Write(data)
+	}
+	s.streamChunks[chunk.Offset] = data
+	return nil
+}
+
+// readChunkExtents reads a chunk and splits it into extents. Extents with the Zero flag set can be sent using the
+// zero operation. The returned data is nil if the chunk did not need to be read because it is not referenced.
+func (s *uploadChunkSource) readChunkExtents(chunk UploadChunk, zeroSupported bool) ([]DiskExtent, []byte, error) {
+	if !s.zeroDetection || !zeroSupported {
+		data, err := s.readChunk(chunk)
+		return []DiskExtent{{Start: chunk.Offset, Length: chunk.Length}}, data, err
+	}
+	if !s.referenced(chunk.Offset, chunk.Offset+chunk.Length) {
+		return []DiskExtent{{Start: chunk.Offset, Length: chunk.Length, Zero: true}}, nil, nil
+	}
+	data, err := s.readChunk(chunk)
+	if err != nil {
+		return nil, nil, err
+	}
+	var extents []DiskExtent
+	end := chunk.Offset + chunk.Length
+	for start := chunk.Offset; start < end; {
+		blockEnd := (start/uploadZeroBlockSize + 1) * uploadZeroBlockSize
+		if blockEnd > end {
+			blockEnd = end
+		}
+		zero := !s.referenced(start, blockEnd) || isZero(data[start-chunk.Offset:blockEnd-chunk.Offset])
+		extents = appendDiskExtent(extents, DiskExtent{Start: start, Length: blockEnd - start, Zero: zero})
+		start = blockEnd
+	}
+	return extents, data, nil
+}
+
+// referenced returns true if any part of the range between start and end needs to be read from the image.
+func (s *uploadChunkSource) referenced(start uint64, end uint64) bool {
+	if s.allocation == nil {
+		return true
+	}
+	// The allocation covers the image without gaps, so the extents can be searched by their end.
+	first := sort.Search(len(s.allocation), func(i int) bool {
+		return s.allocation[i].Start+s.allocation[i].Length > start
+	})
+	for _, extent := range s.allocation[first:] {
+		if extent.Start >= end {
+			break
+		}
+		if !extent.Zero {
+			return true
+		}
+	}
+	return false
+}
+
+// checksum reads the whole image and calculates its checksum with the specified algorithm. If zeroSent is true, the
+// ranges that are not referenced by the image metadata are hashed as zeroes, since they were sent as zeroes.
+func (s *uploadChunkSource) checksum(algorithm ChecksumAlgorithm, size uint64, zeroSent bool) (imageChecksum, error) {
+	if s.stream != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		if s.streamChecksum == nil || s.streamOffset != size {
+			return nil, newError(EBug, "the checksum of the stream was not calculated while reading it")
+		}
+		return s.streamChecksum, nil
+	}
+	checksum := newImageChecksum(algorithm)
+	for offset := uint64(0); offset < size; offset += imageChecksumBlockSize {
+		length := uint64(imageChecksumBlockSize)
+		if offset+length > size {
+			length = size - offset
+		}
+		data, err := s.readChunk(UploadChunk{Offset: offset, Length: length})
+		if err != nil {
+			return nil, err
+		}
+		if zeroSent && s.zeroDetection {
+			s.zeroUnreferenced(data, offset)
+		}
+		_, _ = checksum.Write(data)
+	}
+	return checksum, nil
+}
+
+// zeroUnreferenced clears the parts of data read from offset that are not referenced by the image metadata.
+func (s *uploadChunkSource) zeroUnreferenced(data []byte, offset uint64) {
+	end := offset + uint64(len(data))
+	for _, extent := range s.allocation {
+		if !extent.Zero || extent.Start >= end || extent.Start+extent.Length <= offset {
+			continue
+		}
+		from := extent.Start
+		if from < offset {
+			from = offset
+		}
+		to := extent.Start + extent.Length
+		if to > end {
+			to = end
+		}
+		zeroes := data[from-offset : to-offset]
+		for i := range zeroes {
+			zeroes[i] = 0
+		}
+	}
+}
+
+// readChunk reads the complete chunk into memory so it can be sent again if the upload of the chunk is retried.
+func (s *uploadChunkSource) readChunk(chunk UploadChunk) ([]byte, error) {
+	if s.stream != nil {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		data, ok := s.streamChunks[chunk.Offset]
+		if !ok {
+			return nil, newError(EBug, "chunk at byte %d was not read from the stream", chunk.Offset)
+		}
+		delete(s.streamChunks, chunk.Offset)
+		return data, nil
+	}
+	data := make([]byte, chunk.Length)
+	if readerAt, ok := s.reader.(io.ReaderAt); ok {
+		n, err := readerAt.ReadAt(data, int64(chunk.Offset)) //nolint:gosec
+		if n < len(data) {
+			if err == nil || err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, s.readError(err, chunk)
+		}
+		return data, nil
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if _, err := s.reader.Seek(int64(chunk.Offset), io.SeekStart); err != nil { //nolint:gosec
+		return nil, wrap(err, ELocalIO, "failed to seek to byte %d of the image", chunk.Offset)
+	}
+	if _, err := io.ReadFull(s.reader, data); err != nil {
+		return nil, s.readError(err, chunk)
+	}
+	return data, nil
+}
+
+func (s *uploadChunkSource) readError(err error, chunk UploadChunk) error {
+	return wrap(err, ELocalIO, "failed to read bytes %d-%d of the image", chunk.Offset, chunk.Offset+chunk.Length)
+}
+
+// finish frees the resources used for reading the stream once the upload is done. For sources that are not streams
+// this does nothing.
+func (s *uploadChunkSource) finish() {
+	if s.streamRelease != nil {
+		s.streamRelease()
+	}
+}
+
+// interrupt closes the underlying reader, which makes a blocked read return an error. This is used to abort stalled
+// uploads.
+func (s *uploadChunkSource) interrupt() {
+	if s.closer != nil {
+		_ = s.closer.Close()
+	}
+}
@@ -0,0 +1,146 @@
+package ovirtclient
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+// uploadStreamHeaderSize is the number of bytes buffered from the start of a stream to detect the image format. This
+// covers the QCOW2 header and the ISO9660 volume descriptor.
+const uploadStreamHeaderSize = 64 * 1024
+
+var (
+	gzipMagicBytes = []byte{0x1f, 0x8b}
+	xzMagicBytes   = []byte{0xfd, '7', 'z', 'X', 'Z', 0x00}
+	zstdMagicBytes = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// uploadStream is an image stream prepared for uploading.
+type uploadStream struct {
+	reader io.Reader
+	closer io.Closer
+	info   ImageInfo
+	// closeDecompressor frees the resources held by the decompressor, or is nil if the stream is not compressed.
+	closeDecompressor func()
+}
+
+func (u *uploadStream) Close() error {
+	if u.closer == nil {
+		return nil
+	}
+	return u.closer.Close()
+}
+
+// release frees the resources held by the decompressor. It is called once the upload of the stream finishes.
+func (u *uploadStream) release() {
+	if u.closeDecompressor != nil {
+		u.closeDecompressor()
+	}
+}
+
+// openUploadStream decompresses the stream if needed and detects the format of the image from its header.
+func openUploadStream(reader io.Reader, params UploadStreamParameters) (result *uploadStream, err error) {
+	if params == nil {
+		return nil, newError(EBadArgument, "the size of the image in the stream must be declared")
+	}
+	stream := &uploadStream{}
+	if closer, ok := reader.(io.Closer); ok {
+		stream.closer = closer
+	}
+	decompressed, closeDecompressor, err := decompressUploadStream(reader, params.Compression())
+	if err != nil {
+		return nil, err
+	}
+	stream.closeDecompressor = closeDecompressor
+	defer func() {
+		if err != nil {
+			stream.release()
+		}
+	}()
+	buffered := bufio.NewReaderSize(decompressed, uploadStreamHeaderSize)
+	header, err := buffered.Peek(uploadStreamHeaderSize)
+	if err != nil && err != io.EOF {
+		return nil, wrap(err, ELocalIO, "failed to read the header of the image from the stream")
+	}
+	info, err := inspectImageHeader(header, params.Size())
+	if err != nil {
+		return nil, err
+	}
+	if params.Format() != "" && params.Format() != info.Format() {
+		return nil, newError(
+			EBadArgument,
+			"the stream was declared to contain a %s image, but contains a %s image",
+			params.Format(),
+			info.Format(),
+		)
+	}
+	stream.reader = buffered
+	stream.info = info
+	return stream, nil
+}
+
+// decompressUploadStream returns a reader decompressing the stream and a function freeing the resources of the
+// decompressor, which is nil if the stream is not compressed. If no compression is declared, it is detected from the
+// first bytes of the stream.
+func decompressUploadStream(reader io.Reader, compression ImageCompression) (io.Reader, func(), error) {
+	buffered := bufio.NewReader(reader)
+	if compression == "" {
+		magic, err := buffered.Peek(len(xzMagicBytes))
+		if err != nil && err != io.EOF {
+			return nil, nil, wrap(err, ELocalIO, "failed to read the start of the stream")
+		}
+		compression = detectImageCompression(magic)
+	}
+	switch compression {
+	case ImageCompressionNone:
+		return buffered, nil, nil
+	case ImageCompressionGzip:
+		decompressor, err := gzip.NewReader(buffered)
+		if err != nil {
+			return nil, nil, wrap(err, EBadArgument, "failed to read the gzip header of the stream")
+		}
+		return decompressor, func() {
+			_ = decompressor.Close()
+		}, nil
+	case ImageCompressionXZ:
+		decompressor, err := xz.NewReader(buffered)
+		if err != nil {
+			return nil, nil, wrap(err, EBadArgument, "failed to read the xz header of the stream")
+		}
+		return decompressor, nil, nil
+	case ImageCompressionZstd:
+		decompressor, err := zstd.NewReader(buffered)
+		if err != nil {
+			return nil, nil, wrap(err, EBadArgument, "failed to read the Zstandard header of the stream")
+		}
+		return decompressor, decompressor.Close, nil
+	default:
+		return nil, nil, newError(EUnsupported, "unsupported stream compression: %s", compression)
+	}
+}
+
+func detectImageCompression(magic []byte) ImageCompression {
+	switch {
+	case bytes.HasPrefix(magic, gzipMagicBytes):
+		return ImageCompressionGzip
+	case bytes.HasPrefix(magic, xzMagicBytes):
+		return ImageCompressionXZ
+	case bytes.HasPrefix(magic, zstdMagicBytes):
+		return ImageCompressionZstd
+	default:
+		return ImageCompressionNone
+	}
+}
+
+// validateUploadStreamParams checks that the upload parameters can be used for uploading from a stream.
+func validateUploadStreamParams(uploadParams UploadImageParameters) error {
+	if uploadParams.ResumeTransferID() != "" || len(uploadParams.CompletedChunks()) > 0 {
+		return newError(EBadArgument, "resuming an upload is not possible when uploading from a stream")
+	}
+	return nil
+}
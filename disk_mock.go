@@ -1,6 +1,7 @@
 package ovirtclient
 
 import (
+	"sort"
 	"sync"
 
 	"github.com/google/uuid"
@@ -30,6 +31,16 @@ func (d *diskWithData) Unlock() {
 	d.status = DiskStatusOK
 }
 
+// isOnStorageDomain returns true if the disk resides on the specified storage domain.
+func (d *diskWithData) isOnStorageDomain(id StorageDomainID) bool {
+	for _, storageDomainID := range d.storageDomainIDs {
+		if storageDomainID == id {
+			return true
+		}
+	}
+	return false
+}
+
 func (d *diskWithData) WithAlias(alias *string) *diskWithData {
 	return &diskWithData{
 		disk{
@@ -42,6 +53,10 @@ func (d *diskWithData) WithAlias(alias *string) *diskWithData {
 			status:           d.status,
 			totalSize:        d.totalSize,
 			sparse:           d.sparse,
+			contentType:      d.contentType,
+			shareable:        d.shareable,
+			storageType:      d.storageType,
+			lun:              d.lun,
 		},
 		d.lock,
 		d.data,
@@ -66,6 +81,10 @@ func (d *diskWithData) withProvisionedSize(ps uint64) (*diskWithData, error) {
 			status:           d.status,
 			totalSize:        ps,
 			sparse:           d.sparse,
+			contentType:      d.contentType,
+			shareable:        d.shareable,
+			storageType:      d.storageType,
+			lun:              d.lun,
 		},
 		d.lock,
 		d.data,
@@ -88,8 +107,183 @@ func (d *diskWithData) clone(sparse *bool) *diskWithData {
 			d.status,
 			d.totalSize,
 			*sparse,
+			d.contentType,
+			d.shareable,
+			d.storageType,
+			d.lun,
 		},
 		&sync.Mutex{},
 		d.data,
 	}
 }
+
+// mockImageTransfer is an upload image transfer in the mock. The uploaded data is collected separately from the disk
+// and only written to the disk when the transfer is finalized.
+type mockImageTransfer struct {
+	id     ImageTransferID
+	diskID DiskID
+	data   []byte
+	// extents records the ranges written into the transfer with the Zero flag set on ranges sent as zeroes.
+	extents []DiskExtent
+}
+
+// createMockImageTransfer creates an image transfer for uploading an image of the specified size to the disk. The
+// caller must hold the mock client lock.
+func (m *mockClient) createMockImageTransfer(disk *diskWithData, size uint64) *mockImageTransfer {
+	transfer := &mockImageTransfer{
+		id:     ImageTransferID(m.GenerateUUID()),
+		diskID: disk.id,
+		data:   make([]byte, size),
+	}
+	m.imageTransfers[transfer.id] = transfer
+	return transfer
+}
+
+// getMockImageTransfer returns a kept image transfer for resuming the upload to the disk. The caller must hold the
+// mock client lock.
+func (m *mockClient) getMockImageTransfer(
+	id ImageTransferID,
+	disk *diskWithData,
+	size uint64,
+) (*mockImageTransfer, error) {
+	transfer, ok := m.imageTransfers[id]
+	if !ok {
+		return nil, newError(ENotFound, "image transfer with ID %s not found", id)
+	}
+	if transfer.diskID != disk.id {
+		return nil, newError(
+			EBadArgument,
+			"image transfer %s belongs to disk %s instead of %s",
+			id,
+			transfer.diskID,
+			disk.id,
+		)
+	}
+	if uint64(len(transfer.data)) != size {
+		return nil, newError(
+			EBadArgument,
+			"image transfer %s was started for %d bytes, cannot resume with %d bytes",
+			id,
+			len(transfer.data),
+			size,
+		)
+	}
+	return transfer, nil
+}
+
+// writeMockImageTransfer writes a chunk of data into an image transfer.
+func (m *mockClient) writeMockImageTransfer(transfer *mockImageTransfer, offset uint64, data []byte) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	copy(transfer.data[offset:], data)
+	transfer.extents = append(transfer.extents, DiskExtent{Start: offset, Length: uint64(len(data))})
+}
+
+// zeroMockImageTransfer zeroes a range of an image transfer, simulating the zero operation of ImageIO.
+func (m *mockClient) zeroMockImageTransfer(transfer *mockImageTransfer, offset uint64, length uint64) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	zeroes := transfer.data[offset : offset+length]
+	for i := range zeroes {
+		zeroes[i] = 0
+	}
+	transfer.extents = append(transfer.extents, DiskExtent{Start: offset, Length: length, Zero: true})
+}
+
+// DiskUploadExtents returns the ranges written by the last successful upload to the disk. Ranges sent using the zero
+// operation have the Zero flag set.
+func (m *mockClient) DiskUploadExtents(diskID DiskID) ([]DiskExtent, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if _, ok := m.disks[diskID]; !ok {
+		return nil, newError(ENotFound, "disk with ID %s not found", diskID)
+	}
+	return append([]DiskExtent(nil), m.diskUploadExtents[diskID]...), nil
+}
+
+// finalizeMockImageTransfer writes the uploaded data to the disk, removes the image transfer and unlocks the disk.
+func (m *mockClient) finalizeMockImageTransfer(transfer *mockImageTransfer) {
+	m.lock.Lock()
+	disk, ok := m.disks[transfer.diskID]
+	delete(m.imageTransfers, transfer.id)
+	if !ok {
+		m.lock.Unlock()
+		return
+	}
+	oldData := disk.data
+	disk.data = transfer.data
+	sort.Slice(transfer.extents, func(i, j int) bool {
+		return transfer.extents[i].Start < transfer.extents[j].Start
+	})
+	var extents []DiskExtent
+	for _, extent := range transfer.extents {
+		extents = appendDiskExtent(extents, extent)
+	}
+	m.diskUploadExtents[disk.id] = extents
+	m.lock.Unlock()
+
+	m.recordDiskWrite(disk.id, oldData, disk.data)
+	disk.Unlock()
+}
+
+// failMockImageTransfer cleans up after a failed upload. If the transfer is kept it stays available for resuming and
+// the disk stays locked, otherwise the transfer is removed and the disk is unlocked without changing its contents.
+func (m *mockClient) failMockImageTransfer(transfer *mockImageTransfer, keep bool) {
+	if keep {
+		return
+	}
+	m.lock.Lock()
+	disk, ok := m.disks[transfer.diskID]
+	delete(m.imageTransfers, transfer.id)
+	m.lock.Unlock()
+
+	if ok {
+		disk.Unlock()
+	}
+}
+
+// mockDiskExtents calculates the extents of the disk data. Blocks containing only zeroes are reported as zero extents.
+func mockDiskExtents(data []byte) []DiskExtent {
+	var extents []DiskExtent
+	for start := 0; start < len(data); start += mockDirtyBlockSize {
+		end := start + mockDirtyBlockSize
+		if end > len(data) {
+			end = len(data)
+		}
+		extents = appendDiskExtent(extents, DiskExtent{
+			Start:  uint64(start),
+			Length: uint64(end - start),
+			Zero:   isZero(data[start:end]),
+		})
+	}
+	return extents
+}
+
+// mockServerChecksum calculates the checksum the ImageIO server would return for the data in the mock. Only the block
+// based checksum is available on the server.
+func mockServerChecksum(algorithm ChecksumAlgorithm, data []byte) string {
+	if algorithm != ChecksumAlgorithmBlockSHA256 {
+		return ""
+	}
+	checksum := newImageChecksum(algorithm)
+	_, _ = checksum.Write(data)
+	return checksum.Sum()
+}
+
+// addVMDiskAttachmentByDisk registers a VM disk attachment in the index by disk. The caller must hold the mock client
+// lock.
+func (m *mockClient) addVMDiskAttachmentByDisk(attachment *diskAttachment) {
+	if _, ok := m.vmDiskAttachmentsByDisk[attachment.diskID]; !ok {
+		m.vmDiskAttachmentsByDisk[attachment.diskID] = map[VMID]*diskAttachment{}
+	}
+	m.vmDiskAttachmentsByDisk[attachment.diskID][attachment.vmid] = attachment
+}
+
+// removeVMDiskAttachmentByDisk removes a VM disk attachment from the index by disk. The caller must hold the mock
+// client lock.
+func (m *mockClient) removeVMDiskAttachmentByDisk(attachment *diskAttachment) {
+	delete(m.vmDiskAttachmentsByDisk[attachment.diskID], attachment.vmid)
+	if len(m.vmDiskAttachmentsByDisk[attachment.diskID]) == 0 {
+		delete(m.vmDiskAttachmentsByDisk, attachment.diskID)
+	}
+}
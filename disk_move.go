@@ -0,0 +1,225 @@
+package ovirtclient
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	ovirtsdk "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) MoveDisk(
+	diskID DiskID,
+	storageDomainID StorageDomainID,
+	retries ...RetryStrategy,
+) (Disk, error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(o))
+	progress, err := o.StartMoveDisk(diskID, storageDomainID, retries...)
+	if err != nil {
+		return nil, err
+	}
+	return progress.Wait(retries...)
+}
+
+func (o *oVirtClient) StartMoveDisk(
+	diskID DiskID,
+	storageDomainID StorageDomainID,
+	retries ...RetryStrategy,
+) (DiskUpdate, error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	correlationID := fmt.Sprintf("disk_move_%s", generateRandomID(5, o.nonSecureRandom))
+
+	disk, err := o.GetDisk(diskID, retries...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = retry(
+		fmt.Sprintf("moving disk %s to storage domain %s", diskID, storageDomainID),
+		o.logger,
+		retries,
+		func() error {
+			_, err := o.conn.
+				SystemService().
+				DisksService().
+				DiskService(string(diskID)).
+				Move().
+				StorageDomain(ovirtsdk.NewStorageDomainBuilder().Id(string(storageDomainID)).MustBuild()).
+				Query("correlation_id", correlationID).
+				Send()
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &diskMoveWait{
+		client:          o,
+		disk:            disk,
+		storageDomainID: storageDomainID,
+		correlationID:   correlationID,
+		lock:            &sync.Mutex{},
+	}, nil
+}
+
+type diskMoveWait struct {
+	client          *oVirtClient
+	disk            Disk
+	storageDomainID StorageDomainID
+	correlationID   string
+	lock            *sync.Mutex
+}
+
+func (d *diskMoveWait) Disk() Disk {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return d.disk
+}
+
+func (d *diskMoveWait) Wait(retries ...RetryStrategy) (Disk, error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(d.client))
+	d.lock.Lock()
+	diskID := d.disk.ID()
+	d.lock.Unlock()
+
+	if err := d.client.waitForJobFinished(d.correlationID, retries); err != nil {
+		return nil, err
+	}
+
+	disk, err := d.client.WaitForDiskOK(diskID, retries...)
+	if err != nil {
+		return nil, err
+	}
+
+	d.lock.Lock()
+	d.disk = disk
+	d.lock.Unlock()
+
+	for _, storageDomainID := range disk.StorageDomainIDs() {
+		if storageDomainID == d.storageDomainID {
+			return disk, nil
+		}
+	}
+	return disk, newError(
+		EUnidentified,
+		"disk %s was not moved to storage domain %s, the move has likely failed",
+		diskID,
+		d.storageDomainID,
+	)
+}
+
+func (m *mockClient) MoveDisk(
+	diskID DiskID,
+	storageDomainID StorageDomainID,
+	retries ...RetryStrategy,
+) (Disk, error) {
+	progress, err := m.StartMoveDisk(diskID, storageDomainID, retries...)
+	if err != nil {
+		return nil, err
+	}
+	return progress.Wait(retries...)
+}
+
+func (m *mockClient) StartMoveDisk(
+	diskID DiskID,
+	storageDomainID StorageDomainID,
+	_ ...RetryStrategy,
+) (DiskUpdate, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	disk, ok := m.disks[diskID]
+	if !ok {
+		return nil, newError(ENotFound, "disk with ID %s not found", diskID)
+	}
+	target, err := m.checkStorageDomainSpace(storageDomainID, disk)
+	if err != nil {
+		return nil, err
+	}
+	if len(disk.storageDomainIDs) != 1 {
+		return nil, newError(
+			EBadArgument,
+			"disk %s is stored on %d storage domains, only disks on a single storage domain can be moved",
+			diskID,
+			len(disk.storageDomainIDs),
+		)
+	}
+	if disk.storageDomainIDs[0] == storageDomainID {
+		return nil, newError(EBadArgument, "disk %s is already on storage domain %s", diskID, storageDomainID)
+	}
+	source := m.storageDomains[disk.storageDomainIDs[0]]
+	// Moving a disk attached to a running VM is a live storage migration in the engine, the mock treats both the
+	// same way.
+	if err := disk.Lock(); err != nil {
+		return nil, err
+	}
+
+	move := &mockDiskMove{
+		client: m,
+		disk:   disk,
+		source: source,
+		target: target,
+		done:   make(chan struct{}),
+	}
+	defer move.do()
+	return move, nil
+}
+
+// checkStorageDomainSpace checks if the storage domain has enough space available to hold a copy of the disk. The
+// caller must hold the mock client lock.
+func (m *mockClient) checkStorageDomainSpace(
+	storageDomainID StorageDomainID,
+	disk *diskWithData,
+) (*storageDomain, error) {
+	sd, ok := m.storageDomains[storageDomainID]
+	if !ok {
+		return nil, newError(ENotFound, "storage domain with ID %s not found", storageDomainID)
+	}
+	if sd.available < disk.totalSize {
+		return nil, newError(
+			EBadArgument,
+			"storage domain %s has %d bytes available, not enough to store disk %s of %d bytes",
+			storageDomainID,
+			sd.available,
+			disk.id,
+			disk.totalSize,
+		)
+	}
+	return sd, nil
+}
+
+type mockDiskMove struct {
+	client *mockClient
+	disk   *diskWithData
+	source *storageDomain
+	target *storageDomain
+	done   chan struct{}
+}
+
+func (c *mockDiskMove) Disk() Disk {
+	c.client.lock.Lock()
+	defer c.client.lock.Unlock()
+
+	return c.disk
+}
+
+func (c *mockDiskMove) Wait(_ ...RetryStrategy) (Disk, error) {
+	<-c.done
+
+	return c.disk, nil
+}
+
+func (c *mockDiskMove) do() {
+	// Sleep to trigger potential race conditions / improper status handling.
+	time.Sleep(time.Second)
+
+	// The storage domain ID slice may be shared with clones of the disk, so it is replaced instead of modified.
+	c.disk.storageDomainIDs = []StorageDomainID{c.target.id}
+	if c.source != nil {
+		c.source.available += c.disk.totalSize
+	}
+	c.target.available -= c.disk.totalSize
+	c.disk.Unlock()
+
+	close(c.done)
+}
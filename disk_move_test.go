@@ -0,0 +1,86 @@
+package ovirtclient_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	ovirtclient "github.com/dyudin0821/go-ovirt-client/v3"
+)
+
+func TestDiskMove(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+
+	disk := assertCanCreateDisk(t, helper)
+	assertCanUploadDiskImage(t, helper, disk)
+	targetStorageDomainID := helper.GetSecondaryStorageDomainID(t)
+
+	movedDisk, err := disk.Move(targetStorageDomainID)
+	if err != nil {
+		t.Fatalf("Failed to move disk %s to storage domain %s. (%v)", disk.ID(), targetStorageDomainID, err)
+	}
+	if movedDisk.ID() != disk.ID() {
+		t.Fatalf("The disk ID changed during the move: %s instead of %s.", movedDisk.ID(), disk.ID())
+	}
+	storageDomainIDs := movedDisk.StorageDomainIDs()
+	if len(storageDomainIDs) != 1 || storageDomainIDs[0] != targetStorageDomainID {
+		t.Fatalf("Incorrect storage domains after move: %v instead of %s.", storageDomainIDs, targetStorageDomainID)
+	}
+	assertCanGetDiskFromStorageDomain(t, helper, targetStorageDomainID, movedDisk)
+
+	if _, err := movedDisk.Move(targetStorageDomainID); err == nil {
+		t.Fatalf("Moving disk %s to the storage domain it is already on did not result in an error.", disk.ID())
+	}
+}
+
+func TestDiskCopy(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+
+	disk := assertCanCreateDisk(t, helper)
+	assertCanUploadDiskImage(t, helper, disk)
+	targetStorageDomainID := helper.GetSecondaryStorageDomainID(t)
+	alias := helper.GenerateTestResourceName(t)
+
+	copiedDisk, err := disk.Copy(targetStorageDomainID, alias)
+	if err != nil {
+		t.Fatalf("Failed to copy disk %s to storage domain %s. (%v)", disk.ID(), targetStorageDomainID, err)
+	}
+	t.Cleanup(func() {
+		if err := copiedDisk.Remove(); err != nil && !ovirtclient.HasErrorCode(err, ovirtclient.ENotFound) {
+			t.Fatalf("Failed to remove copied disk %s. (%v)", copiedDisk.ID(), err)
+		}
+	})
+	if copiedDisk.ID() == disk.ID() {
+		t.Fatalf("The copied disk has the same ID as the original disk (%s).", disk.ID())
+	}
+	if copiedDisk.Alias() != alias {
+		t.Fatalf("Incorrect alias on copied disk: %s instead of %s.", copiedDisk.Alias(), alias)
+	}
+	storageDomainIDs := copiedDisk.StorageDomainIDs()
+	if len(storageDomainIDs) != 1 || storageDomainIDs[0] != targetStorageDomainID {
+		t.Fatalf("Incorrect storage domains on copied disk: %v instead of %s.", storageDomainIDs, targetStorageDomainID)
+	}
+
+	original := assertCanDownloadDiskData(t, disk)
+	copied := assertCanDownloadDiskData(t, copiedDisk)
+	if !bytes.Equal(original, copied) {
+		t.Fatalf("The contents of the copied disk %s do not match the original disk %s.", copiedDisk.ID(), disk.ID())
+	}
+}
+
+func assertCanDownloadDiskData(t *testing.T, disk ovirtclient.Disk) []byte {
+	download, err := disk.Download(ovirtclient.ImageFormatRaw)
+	if err != nil {
+		t.Fatalf("Failed to download disk %s. (%v)", disk.ID(), err)
+	}
+	defer func() {
+		_ = download.Close()
+	}()
+	data, err := io.ReadAll(download)
+	if err != nil {
+		t.Fatalf("Failed to read disk %s. (%v)", disk.ID(), err)
+	}
+	return data
+}
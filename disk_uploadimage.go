@@ -5,10 +5,13 @@
 package ovirtclient
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
 	"sync"
 
 	ovirtsdk4 "github.com/ovirt/go-ovirt"
@@ -41,9 +44,29 @@ func (o *oVirtClient) UploadToNewDisk(
 	params CreateDiskOptionalParameters,
 	reader io.ReadSeekCloser,
 	retries ...RetryStrategy,
+) (UploadImageResult, error) {
+	return o.UploadToNewDiskWithParams(storageDomainID, format, size, params, reader, nil, retries...)
+}
+
+func (o *oVirtClient) UploadToNewDiskWithParams(
+	storageDomainID StorageDomainID,
+	format ImageFormat,
+	size uint64,
+	params CreateDiskOptionalParameters,
+	reader io.ReadSeekCloser,
+	uploadParams UploadImageParameters,
+	retries ...RetryStrategy,
 ) (UploadImageResult, error) {
 	retries = defaultRetries(retries, defaultLongTimeouts(o))
-	progress, err := o.StartUploadToNewDisk(storageDomainID, format, size, params, reader, retries...)
+	progress, err := o.StartUploadToNewDiskWithParams(
+		storageDomainID,
+		format,
+		size,
+		params,
+		reader,
+		uploadParams,
+		retries...,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -79,9 +102,19 @@ func (o *oVirtClient) UploadToDisk(
 	size uint64,
 	reader io.ReadSeekCloser,
 	retries ...RetryStrategy,
+) error {
+	return o.UploadToDiskWithParams(diskID, size, reader, nil, retries...)
+}
+
+func (o *oVirtClient) UploadToDiskWithParams(
+	diskID DiskID,
+	size uint64,
+	reader io.ReadSeekCloser,
+	uploadParams UploadImageParameters,
+	retries ...RetryStrategy,
 ) error {
 	retries = defaultRetries(retries, defaultLongTimeouts(o))
-	progress, err := o.StartUploadToDisk(diskID, size, reader, retries...)
+	progress, err := o.StartUploadToDiskWithParams(diskID, size, reader, uploadParams, retries...)
 	if err != nil {
 		return err
 	}
@@ -94,8 +127,19 @@ func (o *oVirtClient) StartUploadToDisk(
 	size uint64,
 	reader io.ReadSeekCloser,
 	retries ...RetryStrategy,
+) (UploadImageProgress, error) {
+	return o.StartUploadToDiskWithParams(diskID, size, reader, nil, retries...)
+}
+
+func (o *oVirtClient) StartUploadToDiskWithParams(
+	diskID DiskID,
+	size uint64,
+	reader io.ReadSeekCloser,
+	uploadParams UploadImageParameters,
+	retries ...RetryStrategy,
 ) (UploadImageProgress, error) {
 	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	uploadParams = uploadParamsOrDefault(uploadParams)
 	o.logger.Infof("Starting disk image upload...")
 	disk, err := o.GetDisk(diskID, retries...)
 	if err != nil {
@@ -129,7 +173,11 @@ func (o *oVirtClient) StartUploadToDisk(
 		totalBytes:    size,
 		qcowSize:      qcowSize,
 		reader:        reader,
+		source:        newImageUploadChunkSource(o.logger, reader, format, size, uploadParams),
 		retries:       retries,
+		uploadParams:  uploadParams,
+		chunks:        newUploadChunkList(planUploadChunks(size, uploadParams.ChunkSize(), uploadParams.CompletedChunks())),
+		transferID:    uploadParams.ResumeTransferID(),
 	}
 	go progress.Do()
 	return progress, nil
@@ -143,10 +191,15 @@ type uploadToDiskProgress struct {
 	cancel           func()
 	disk             Disk
 	correlationID    string
-	reader           io.ReadSeekCloser
+	reader           io.Closer
+	source           *uploadChunkSource
 	retries          []RetryStrategy
+	uploadParams     UploadImageParameters
+	chunks           *uploadChunkList
+	transferID       ImageTransferID
 	transferredBytes uint64
 	totalBytes       uint64
+	checksum         string
 	err              error
 	format           ImageFormat
 	qcowSize         uint64
@@ -164,6 +217,7 @@ func (u *uploadToDiskProgress) updateDisk(disk Disk) {
 
 func (u *uploadToDiskProgress) Do() {
 	defer func() {
+		u.source.finish()
 		close(u.done)
 		u.cancel()
 	}()
@@ -176,71 +230,284 @@ func (u *uploadToDiskProgress) Do() {
 }
 
 func (u *uploadToDiskProgress) transfer() error {
-	transfer := newImageTransfer(
+	transfer := newUploadImageTransfer(
 		u.client,
 		u.client.logger,
-		u.disk.ID(),
+		u.Disk().ID(),
 		u.correlationID,
 		u.retries,
-		ovirtsdk4.IMAGETRANSFERDIRECTION_UPLOAD,
 		ovirtsdk4.DiskFormat(u.format),
+		u.uploadParams,
 		u.updateDisk,
 	)
-	transferURL := ""
-	var err error
-	if transferURL, err = transfer.initialize(); err != nil {
+	transferURL, err := transfer.initialize()
+	u.lock.Lock()
+	u.transferID = transfer.id()
+	u.lock.Unlock()
+	if err != nil {
 		return transfer.finalize(err)
 	}
 	err = u.transferImage(transfer, transferURL)
 	return transfer.finalize(err)
 }
 
-// transferImage does an HTTP request to transfer the image to the specified transfer URL.
+// transferImage uploads the chunks of the image that are not done yet to the specified transfer URL in parallel, then
+// asks ImageIO to flush the written data to the storage.
 func (u *uploadToDiskProgress) transferImage(transfer imageTransfer, transferURL string) error {
-	return retry(
-		fmt.Sprintf(
-			"transferring image for disk %s via HTTP request to %s",
-			u.disk.ID(),
-			transferURL,
-		),
+	chunkURL, err := url.Parse(transferURL)
+	if err != nil {
+		return wrap(err, EUnidentified, "failed to parse transfer URL %s", transferURL)
+	}
+	// The data is flushed once at the end of the upload instead of after every chunk if ImageIO supports it.
+	flush := transfer.supports("flush")
+	if flush {
+		query := chunkURL.Query()
+		query.Set("flush", "n")
+		chunkURL.RawQuery = query.Encode()
+	}
+
+	u.lock.Lock()
+	u.transferredBytes = u.chunks.doneBytes()
+	u.lock.Unlock()
+
+	ctx, cancel := context.WithCancel(u.ctx)
+	defer cancel()
+	// The monitor aborts the upload if no data is sent for the inactivity timeout. The source is closed in addition to
+	// canceling the requests, as the feeder may be blocked reading a stream.
+	monitor := startTransferMonitor(u.uploadParams, u.UploadedBytes, u.TotalBytes, nil, func(_ error) {
+		cancel()
+		u.source.interrupt()
+	})
+	defer monitor.stop()
+	workers := u.uploadParams.Workers()
+	work := make(chan int)
+	errs := make(chan error, workers)
+	wg := &sync.WaitGroup{}
+	for i := uint(0); i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range work {
+				if err := u.uploadChunk(ctx, transfer, transferURL, chunkURL.String(), index); err != nil {
+					errs <- err
+					cancel()
+					return
+				}
+			}
+		}()
+	}
+	var feedErr error
+feed:
+	for _, index := range u.chunks.pending() {
+		// Streams are read here in order, before the chunk is handed to a worker.
+		if feedErr = u.source.prefetch(u.chunks.get(index)); feedErr != nil {
+			u.chunks.setState(index, UploadChunkStateFailed)
+			cancel()
+			break
+		}
+		select {
+		case work <- index:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(work)
+	wg.Wait()
+	close(errs)
+	if err := monitor.err(); err != nil {
+		return err
+	}
+	if err := <-errs; err != nil {
+		return err
+	}
+	if feedErr != nil {
+		return feedErr
+	}
+	if flush {
+		if err := u.flush(transfer, transferURL); err != nil {
+			return err
+		}
+	}
+	return u.verifyChecksum(transfer, transferURL)
+}
+
+// verifyChecksum calculates the checksum of the uploaded data if requested and compares it to the expected checksum
+// and the checksum calculated by the ImageIO server. The server checksum covers the whole disk, so the local checksum
+// is padded with zeroes to the size of the disk for the comparison.
+func (u *uploadToDiskProgress) verifyChecksum(transfer imageTransfer, transferURL string) error {
+	algorithm := u.uploadParams.ChecksumAlgorithm()
+	if algorithm == "" {
+		return nil
+	}
+	diskID := u.Disk().ID()
+	checksum, err := u.source.checksum(algorithm, u.totalBytes, transfer.supports("zero"))
+	if err != nil {
+		return err
+	}
+	localChecksum := checksum.Sum()
+	u.lock.Lock()
+	u.checksum = localChecksum
+	u.lock.Unlock()
+	if err := checkExpectedChecksum(algorithm, localChecksum, u.uploadParams.ExpectedChecksum(), diskID); err != nil {
+		return err
+	}
+	if algorithm != ChecksumAlgorithmBlockSHA256 {
+		return nil
+	}
+
+	serverChecksum, err := fetchImageIOChecksum(
+		u.client.httpClient,
 		u.client.logger,
 		u.retries,
-		func() error {
-			return u.putRequest(transferURL, transfer)
-		},
+		transfer,
+		transferURL,
+		diskID,
 	)
+	if err != nil || serverChecksum == "" {
+		return err
+	}
+	extents, err := fetchImageIOExtents(
+		u.client.httpClient,
+		u.client.logger,
+		u.retries,
+		transfer,
+		transferURL,
+		"zero",
+		diskID,
+	)
+	if err != nil {
+		return err
+	}
+	if len(extents) > 0 {
+		if serverSize := extents[len(extents)-1].Start + extents[len(extents)-1].Length; serverSize > u.totalBytes {
+			checksum.WriteZeroes(serverSize - u.totalBytes)
+		}
+	}
+	return checkServerChecksum(algorithm, checksum.Sum(), serverChecksum, diskID)
+}
+
+// uploadChunk reads a single chunk from the image and uploads it, retrying the upload of the chunk as needed. Ranges
+// of the chunk that contain only zeroes are sent using the zero operation if ImageIO supports it.
+func (u *uploadToDiskProgress) uploadChunk(
+	ctx context.Context,
+	transfer imageTransfer,
+	transferURL string,
+	chunkURL string,
+	index int,
+) error {
+	chunk := u.chunks.get(index)
+	u.chunks.setState(index, UploadChunkStateUploading)
+	extents, data, err := u.source.readChunkExtents(chunk, transfer.supports("zero"))
+	for _, extent := range extents {
+		if err != nil {
+			break
+		}
+		extent := extent
+		err = retry(
+			fmt.Sprintf(
+				"uploading bytes %d-%d of the image for disk %s",
+				extent.Start,
+				extent.Start+extent.Length,
+				u.Disk().ID(),
+			),
+			u.client.logger,
+			append([]RetryStrategy{ContextStrategy(ctx)}, u.retries...),
+			func() error {
+				if extent.Zero {
+					return u.zeroRange(ctx, transfer, transferURL, extent)
+				}
+				start := extent.Start - chunk.Offset
+				return u.putChunk(ctx, transfer, chunkURL, extent.Start, data[start:start+extent.Length])
+			},
+		)
+	}
+	if err != nil {
+		u.chunks.setState(index, UploadChunkStateFailed)
+		return err
+	}
+	u.chunks.setState(index, UploadChunkStateDone)
+	return nil
 }
 
-// putRequest performs a single HTTP put request to upload an image. This can be called multiple times to retry an
-// upload.
-func (u *uploadToDiskProgress) putRequest(transferURL string, transfer imageTransfer) error {
-	// We ensure that the reader is at the first byte before attempting a PUT request, otherwise we may upload an
-	// incomplete image.
-	if _, err := u.reader.Seek(0, io.SeekStart); err != nil {
+// zeroRange performs a single HTTP PATCH request to zero a range of the image without sending the data.
+func (u *uploadToDiskProgress) zeroRange(
+	ctx context.Context,
+	transfer imageTransfer,
+	transferURL string,
+	extent DiskExtent,
+) error {
+	patchRequest, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPatch,
+		transferURL,
+		strings.NewReader(
+			fmt.Sprintf(`{"op": "zero", "offset": %d, "size": %d, "flush": false}`, extent.Start, extent.Length),
+		),
+	)
+	if err != nil {
+		return wrap(err, EUnidentified, "failed to create HTTP request")
+	}
+	patchRequest.Header.Add("content-type", "application/json")
+	response, err := u.client.httpClient.Do(patchRequest)
+	if err != nil {
 		return wrap(
 			err,
-			ELocalIO,
-			"could not seek to the first byte of the disk image before upload",
+			EConnection,
+			"failed to zero bytes %d-%d of the image",
+			extent.Start,
+			extent.Start+extent.Length,
 		)
 	}
-
+	if err := transfer.checkStatusCode(response.StatusCode); err != nil {
+		_ = response.Body.Close()
+		return err
+	}
+	if err := response.Body.Close(); err != nil {
+		return wrap(err, EUnidentified, "failed to close response body while zeroing image range")
+	}
 	u.lock.Lock()
-	u.transferredBytes = 0
+	u.transferredBytes += extent.Length
 	u.lock.Unlock()
+	return nil
+}
 
-	putRequest, err := http.NewRequest(http.MethodPut, transferURL, u)
+// putChunk performs a single HTTP PUT request to upload a chunk of the image. This can be called multiple times to
+// retry the upload of the chunk.
+func (u *uploadToDiskProgress) putChunk(
+	ctx context.Context,
+	transfer imageTransfer,
+	chunkURL string,
+	offset uint64,
+	data []byte,
+) (err error) {
+	body := &uploadChunkBody{
+		ctx:      ctx,
+		progress: u,
+		reader:   bytes.NewReader(data),
+	}
+	defer func() {
+		if err != nil {
+			body.rollback()
+		}
+	}()
+	putRequest, err := http.NewRequestWithContext(ctx, http.MethodPut, chunkURL, body)
 	if err != nil {
 		return wrap(err, EUnidentified, "failed to create HTTP request")
 	}
 	putRequest.Header.Add("content-type", "application/octet-stream")
-	putRequest.ContentLength = int64(u.totalBytes) //nolint:gosec
-	putRequest.Body = u
+	putRequest.Header.Add(
+		"content-range",
+		fmt.Sprintf("bytes %d-%d/*", offset, offset+uint64(len(data))-1),
+	)
+	putRequest.ContentLength = int64(len(data))
 	response, err := u.client.httpClient.Do(putRequest)
 	if err != nil {
 		return wrap(
 			err,
-			EUnidentified,
-			"failed to upload image",
+			EConnection,
+			"failed to upload bytes %d-%d of the image",
+			offset,
+			offset+uint64(len(data)),
 		)
 	}
 	if err := transfer.checkStatusCode(response.StatusCode); err != nil {
@@ -257,6 +524,39 @@ func (u *uploadToDiskProgress) putRequest(transferURL string, transfer imageTran
 	return nil
 }
 
+// flush asks ImageIO to write the data uploaded without flushing to the storage.
+func (u *uploadToDiskProgress) flush(transfer imageTransfer, transferURL string) error {
+	return retry(
+		fmt.Sprintf("flushing image for disk %s", u.Disk().ID()),
+		u.client.logger,
+		u.retries,
+		func() error {
+			patchRequest, err := http.NewRequestWithContext(
+				u.ctx,
+				http.MethodPatch,
+				transferURL,
+				strings.NewReader(`{"op": "flush"}`),
+			)
+			if err != nil {
+				return wrap(err, EUnidentified, "failed to create HTTP request")
+			}
+			patchRequest.Header.Add("content-type", "application/json")
+			response, err := u.client.httpClient.Do(patchRequest)
+			if err != nil {
+				return wrap(err, EConnection, "failed to flush image")
+			}
+			if err := transfer.checkStatusCode(response.StatusCode); err != nil {
+				_ = response.Body.Close()
+				return err
+			}
+			if err := response.Body.Close(); err != nil {
+				return wrap(err, EUnidentified, "failed to close response body while flushing image")
+			}
+			return nil
+		},
+	)
+}
+
 func (u *uploadToDiskProgress) Disk() Disk {
 	u.lock.Lock()
 	defer u.lock.Unlock()
@@ -283,19 +583,62 @@ func (u *uploadToDiskProgress) Done() <-chan struct{} {
 	return u.done
 }
 
-func (u *uploadToDiskProgress) Read(p []byte) (n int, err error) {
+func (u *uploadToDiskProgress) TransferID() ImageTransferID {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	return u.transferID
+}
+
+func (u *uploadToDiskProgress) Chunks() []UploadChunk {
+	return u.chunks.Chunks()
+}
+
+func (u *uploadToDiskProgress) Checksum() string {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	return u.checksum
+}
+
+// uploadChunkBody is the request body for uploading a chunk. It counts the bytes read into the uploaded bytes of the
+// progress, which can be rolled back if the request fails.
+type uploadChunkBody struct {
+	ctx      context.Context
+	progress *uploadToDiskProgress
+	reader   io.Reader
+	read     uint64
+	done     bool
+}
+
+func (b *uploadChunkBody) Read(p []byte) (n int, err error) {
 	select {
-	case <-u.ctx.Done():
+	case <-b.progress.ctx.Done():
 		return 0, newError(ETimeout, "timeout while uploading image")
 	default:
 	}
-	n, err = u.reader.Read(p)
+	n, err = b.reader.Read(p)
 	if n > 0 {
-		u.transferredBytes += uint64(n)
+		if limitErr := waitTransferRate(b.ctx, b.progress.uploadParams, n); limitErr != nil {
+			return 0, limitErr
+		}
+	}
+	b.progress.lock.Lock()
+	defer b.progress.lock.Unlock()
+	if n > 0 && !b.done {
+		b.read += uint64(n)
+		b.progress.transferredBytes += uint64(n)
 	}
 	return
 }
 
+// rollback removes the bytes read from this body from the uploaded bytes of the progress.
+func (b *uploadChunkBody) rollback() {
+	b.progress.lock.Lock()
+	defer b.progress.lock.Unlock()
+	b.progress.transferredBytes -= b.read
+	b.read = 0
+	b.done = true
+}
+
 func (o *oVirtClient) StartUploadToNewDisk(
 	storageDomainID StorageDomainID,
 	format ImageFormat,
@@ -303,31 +646,55 @@ func (o *oVirtClient) StartUploadToNewDisk(
 	params CreateDiskOptionalParameters,
 	reader io.ReadSeekCloser,
 	retries ...RetryStrategy,
+) (UploadImageProgress, error) {
+	return o.StartUploadToNewDiskWithParams(storageDomainID, format, size, params, reader, nil, retries...)
+}
+
+func (o *oVirtClient) StartUploadToNewDiskWithParams(
+	storageDomainID StorageDomainID,
+	format ImageFormat,
+	size uint64,
+	params CreateDiskOptionalParameters,
+	reader io.ReadSeekCloser,
+	uploadParams UploadImageParameters,
+	retries ...RetryStrategy,
 ) (UploadImageProgress, error) {
 	retries = defaultRetries(retries, defaultLongTimeouts(o))
+	uploadParams = uploadParamsOrDefault(uploadParams)
+	if err := validateUploadToNewDiskParams(uploadParams); err != nil {
+		return nil, err
+	}
 
 	o.logger.Infof("Starting disk image upload...")
 
-	imageFormat, qcowSize, err := extractQCOWParameters(size, reader)
+	info, err := extractImageInfo(size, reader)
 	if err != nil {
 		return nil, err
 	}
+	imageFormat, qcowSize := info.Format(), info.VirtualSize()
 
 	if format == "" {
 		format = imageFormat
 	} else if err := format.Validate(); err != nil {
 		return nil, err
 	}
-
-	ctx, cancel := context.WithCancel(context.Background())
-
-	diskCreateParams := CreateDiskParams().
-		MustWithAlias(params.Alias()).
-		MustWithInitialSize(size)
-	if params.Sparse() != nil {
-		diskCreateParams.MustWithSparse(*params.Sparse())
+	diskCreateParams, err := uploadDiskParams(params, format, info.ContentType())
+	if err != nil {
+		return nil, err
+	}
+	// Without local conversion the image is sent in its own format and the engine converts it to the disk format.
+	transferFormat := imageFormat
+	if uploadParams.LocalConversion() {
+		reader, size, err = convertUploadImage(reader, size, imageFormat, format)
+		if err != nil {
+			return nil, err
+		}
+		transferFormat = format
 	}
 
+	diskCreateParams.MustWithInitialSize(size)
+
+	ctx, cancel := context.WithCancel(context.Background())
 	progress := &uploadToNewDiskProgress{
 		uploadToDiskProgress: uploadToDiskProgress{
 			client:        o,
@@ -336,12 +703,15 @@ func (o *oVirtClient) StartUploadToNewDisk(
 			ctx:           ctx,
 			cancel:        cancel,
 			correlationID: fmt.Sprintf("image_upload_%s", generateRandomID(5, o.nonSecureRandom)),
-			format:        imageFormat,
+			format:        transferFormat,
 			disk:          nil,
 			totalBytes:    size,
 			qcowSize:      qcowSize,
 			reader:        reader,
+			source:        newImageUploadChunkSource(o.logger, reader, transferFormat, size, uploadParams),
 			retries:       retries,
+			uploadParams:  uploadParams,
+			chunks:        newUploadChunkList(planUploadChunks(size, uploadParams.ChunkSize(), nil)),
 		},
 
 		storageDomainID: storageDomainID,
@@ -353,6 +723,47 @@ func (o *oVirtClient) StartUploadToNewDisk(
 	return progress, nil
 }
 
+// validateUploadToNewDiskParams checks that the upload parameters can be used for uploading to a new disk. Resuming is
+// only possible on the disk the image transfer was created for.
+func validateUploadToNewDiskParams(uploadParams UploadImageParameters) error {
+	if uploadParams.ResumeTransferID() != "" {
+		return newError(
+			EBadArgument,
+			"resuming image transfer %s is only possible when uploading to an existing disk",
+			uploadParams.ResumeTransferID(),
+		)
+	}
+	return nil
+}
+
+// uploadDiskParams returns the parameters for creating the disk an image is uploaded to. Unless the content type is
+// set explicitly, it is taken from the image, so ISO9660 images are stored as ISO disks. All other parameters are
+// copied from the parameters passed by the caller.
+func uploadDiskParams(
+	params CreateDiskOptionalParameters,
+	format ImageFormat,
+	imageContentType DiskContentType,
+) (BuildableCreateDiskParameters, error) {
+	diskParams := &createDiskParams{}
+	contentType := imageContentType
+	if params != nil {
+		diskParams = &createDiskParams{
+			alias:       params.Alias(),
+			sparse:      params.Sparse(),
+			initialSize: params.InitialSize(),
+			contentType: params.ContentType(),
+			shareable:   params.Shareable(),
+		}
+		if params.ContentType() != "" {
+			contentType = params.ContentType()
+		}
+	}
+	if contentType == DiskContentTypeISO && format != ImageFormatRaw {
+		return nil, newError(EBadArgument, "ISO images can only be uploaded to %s disks, not %s", ImageFormatRaw, format)
+	}
+	return diskParams.WithContentType(contentType)
+}
+
 type uploadToNewDiskProgress struct {
 	uploadToDiskProgress
 
@@ -363,6 +774,7 @@ type uploadToNewDiskProgress struct {
 
 func (u *uploadToNewDiskProgress) Do() {
 	defer func() {
+		u.source.finish()
 		close(u.done)
 		u.cancel()
 	}()
@@ -394,6 +806,15 @@ func (u *uploadToNewDiskProgress) Do() {
 	u.lock.Unlock()
 
 	if err != nil {
+		if u.uploadParams.KeepTransferOnError() {
+			u.client.logger.Infof(
+				"Image upload to new disk failed, keeping disk %s and image transfer %s for resuming (%v)",
+				disk.ID(),
+				u.TransferID(),
+				err,
+			)
+			return
+		}
 		u.client.logger.Infof("Image upload to new disk failed, removing created disk (%v)", err)
 		if err := disk.Remove(u.retries...); err != nil && !HasErrorCode(err, ENotFound) {
 			u.client.logger.Warningf(
@@ -447,6 +868,17 @@ func (m *mockClient) StartUploadToDisk(
 	reader io.ReadSeekCloser,
 	retries ...RetryStrategy,
 ) (UploadImageProgress, error) {
+	return m.StartUploadToDiskWithParams(diskID, size, reader, nil, retries...)
+}
+
+func (m *mockClient) StartUploadToDiskWithParams(
+	diskID DiskID,
+	size uint64,
+	reader io.ReadSeekCloser,
+	uploadParams UploadImageParameters,
+	retries ...RetryStrategy,
+) (UploadImageProgress, error) {
+	uploadParams = uploadParamsOrDefault(uploadParams)
 	disk, err := m.getDisk(diskID, retries...)
 	if err != nil {
 		return nil, err
@@ -477,27 +909,42 @@ func (m *mockClient) StartUploadToDisk(
 		)
 	}
 
-	progress := &mockImageUploadProgress{
-		err:    nil,
-		disk:   disk,
-		client: m,
-		reader: reader,
-		size:   size,
-		done:   make(chan struct{}),
-	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
 
-	// Lock the disk to simulate the upload being initialized.
-	if err := progress.disk.Lock(); err != nil {
-		return nil, newError(EDiskLocked, "disk locked after creation")
+	var transfer *mockImageTransfer
+	if transferID := uploadParams.ResumeTransferID(); transferID != "" {
+		// The disk stays locked while a paused image transfer exists.
+		if transfer, err = m.getMockImageTransfer(transferID, disk, size); err != nil {
+			return nil, err
+		}
+	} else {
+		// Lock the disk to simulate the upload being initialized.
+		if err := disk.Lock(); err != nil {
+			return nil, newError(EDiskLocked, "disk locked after creation")
+		}
+		transfer = m.createMockImageTransfer(disk, size)
 	}
 
+	source := newImageUploadChunkSource(m.logger, reader, imageFormat, size, uploadParams)
+	progress := newMockImageUploadProgress(m, disk, transfer, source, size, uploadParams)
 	go progress.do()
 
 	return progress, nil
 }
 
 func (m *mockClient) UploadToDisk(diskID DiskID, size uint64, reader io.ReadSeekCloser, retries ...RetryStrategy) error {
-	progress, err := m.StartUploadToDisk(diskID, size, reader, retries...)
+	return m.UploadToDiskWithParams(diskID, size, reader, nil, retries...)
+}
+
+func (m *mockClient) UploadToDiskWithParams(
+	diskID DiskID,
+	size uint64,
+	reader io.ReadSeekCloser,
+	uploadParams UploadImageParameters,
+	retries ...RetryStrategy,
+) error {
+	progress, err := m.StartUploadToDiskWithParams(diskID, size, reader, uploadParams, retries...)
 	if err != nil {
 		return err
 	}
@@ -511,8 +958,25 @@ func (m *mockClient) StartUploadToNewDisk(
 	size uint64,
 	params CreateDiskOptionalParameters,
 	reader io.ReadSeekCloser,
+	retries ...RetryStrategy,
+) (UploadImageProgress, error) {
+	return m.StartUploadToNewDiskWithParams(storageDomainID, format, size, params, reader, nil, retries...)
+}
+
+func (m *mockClient) StartUploadToNewDiskWithParams(
+	storageDomainID StorageDomainID,
+	format ImageFormat,
+	size uint64,
+	params CreateDiskOptionalParameters,
+	reader io.ReadSeekCloser,
+	uploadParams UploadImageParameters,
 	_ ...RetryStrategy,
 ) (UploadImageProgress, error) {
+	uploadParams = uploadParamsOrDefault(uploadParams)
+	if err := validateUploadToNewDiskParams(uploadParams); err != nil {
+		return nil, err
+	}
+
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
@@ -520,46 +984,54 @@ func (m *mockClient) StartUploadToNewDisk(
 		return nil, newError(ENotFound, "storage domain with ID %s not found", storageDomainID)
 	}
 
-	imageFormat, qcowSize, err := extractQCOWParameters(size, reader)
+	info, err := extractImageInfo(size, reader)
 	if err != nil {
 		return nil, err
 	}
+	imageFormat, qcowSize := info.Format(), info.VirtualSize()
 
-	if imageFormat != format {
+	if format == "" {
+		format = imageFormat
+	} else if err := format.Validate(); err != nil {
+		return nil, err
+	}
+	diskParams, err := uploadDiskParams(params, format, info.ContentType())
+	if err != nil {
+		return nil, err
+	}
+	if !uploadParams.LocalConversion() && imageFormat != format {
 		return nil, newError(
 			EBadArgument,
-			"the mock facility doesn't support uploading %s images to %s disks,"+
-				" please upload in the disk format in your tests.",
+			"the mock facility doesn't support uploading %s images to %s disks without local conversion,"+
+				" please upload in the disk format or enable local conversion in your tests.",
 			imageFormat,
 			format,
 		)
 	}
+	reader, size, err = convertUploadImage(reader, size, imageFormat, format)
+	if err != nil {
+		return nil, err
+	}
 
 	if qcowSize < 1024*1024 {
 		qcowSize = 1024 * 1024
 	}
 
-	disk, err := m.createDisk(storageDomainID, format, qcowSize, params)
+	disk, err := m.createDisk(storageDomainID, format, qcowSize, diskParams)
 	if err != nil {
 		return nil, err
 	}
 	// Unlock the disk to simulate disk creation being complete.
 	disk.Unlock()
 
-	progress := &mockImageUploadProgress{
-		err:    nil,
-		disk:   disk,
-		client: m,
-		reader: reader,
-		size:   size,
-		done:   make(chan struct{}),
-	}
-
 	// Lock the disk to simulate the upload being initialized.
-	if err := progress.disk.Lock(); err != nil {
+	if err := disk.Lock(); err != nil {
 		return nil, newError(EDiskLocked, "disk locked after creation")
 	}
 
+	transfer := m.createMockImageTransfer(disk, size)
+	source := newImageUploadChunkSource(m.logger, reader, format, size, uploadParams)
+	progress := newMockImageUploadProgress(m, disk, transfer, source, size, uploadParams)
 	go progress.do()
 
 	return progress, nil
@@ -573,7 +1045,27 @@ func (m *mockClient) UploadToNewDisk(
 	reader io.ReadSeekCloser,
 	retries ...RetryStrategy,
 ) (UploadImageResult, error) {
-	progress, err := m.StartUploadToNewDisk(storageDomainID, format, size, params, reader, retries...)
+	return m.UploadToNewDiskWithParams(storageDomainID, format, size, params, reader, nil, retries...)
+}
+
+func (m *mockClient) UploadToNewDiskWithParams(
+	storageDomainID StorageDomainID,
+	format ImageFormat,
+	size uint64,
+	params CreateDiskOptionalParameters,
+	reader io.ReadSeekCloser,
+	uploadParams UploadImageParameters,
+	retries ...RetryStrategy,
+) (UploadImageResult, error) {
+	progress, err := m.StartUploadToNewDiskWithParams(
+		storageDomainID,
+		format,
+		size,
+		params,
+		reader,
+		uploadParams,
+		retries...,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -584,14 +1076,44 @@ func (m *mockClient) UploadToNewDisk(
 	return progress, nil
 }
 
+func newMockImageUploadProgress(
+	client *mockClient,
+	disk *diskWithData,
+	transfer *mockImageTransfer,
+	source *uploadChunkSource,
+	size uint64,
+	uploadParams UploadImageParameters,
+) *mockImageUploadProgress {
+	return &mockImageUploadProgress{
+		lock:         &sync.Mutex{},
+		disk:         disk,
+		client:       client,
+		transfer:     transfer,
+		source:       source,
+		size:         size,
+		uploadParams: uploadParams,
+		chunks:       newUploadChunkList(planUploadChunks(size, uploadParams.ChunkSize(), uploadParams.CompletedChunks())),
+		done:         make(chan struct{}),
+	}
+}
+
+// mockImageUploadProgress uploads the chunks of an image into a mock image transfer. Unlike the real client, the mock
+// uploads the chunks sequentially regardless of the number of workers. The mock always supports the zero operation.
 type mockImageUploadProgress struct {
-	err           error
-	disk          *diskWithData
-	client        *mockClient
-	reader        io.ReadSeekCloser
-	size          uint64
-	uploadedBytes uint64
-	done          chan struct{}
+	lock         *sync.Mutex
+	err          error
+	disk         *diskWithData
+	client       *mockClient
+	transfer     *mockImageTransfer
+	source       *uploadChunkSource
+	size         uint64
+	uploadParams UploadImageParameters
+	chunks       *uploadChunkList
+	checksum     string
+	done         chan struct{}
+	// partialBytes is the number of bytes written from the chunk currently being uploaded. It is used for detecting
+	// stalls within large chunks.
+	partialBytes uint64
 }
 
 func (m *mockImageUploadProgress) Disk() Disk {
@@ -603,7 +1125,7 @@ func (m *mockImageUploadProgress) Disk() Disk {
 }
 
 func (m *mockImageUploadProgress) UploadedBytes() uint64 {
-	return m.uploadedBytes
+	return m.chunks.doneBytes()
 }
 
 func (m *mockImageUploadProgress) TotalBytes() uint64 {
@@ -611,6 +1133,8 @@ func (m *mockImageUploadProgress) TotalBytes() uint64 {
 }
 
 func (m *mockImageUploadProgress) Err() error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
 	return m.err
 }
 
@@ -618,20 +1142,112 @@ func (m *mockImageUploadProgress) Done() <-chan struct{} {
 	return m.done
 }
 
+func (m *mockImageUploadProgress) TransferID() ImageTransferID {
+	return m.transfer.id
+}
+
+func (m *mockImageUploadProgress) Chunks() []UploadChunk {
+	return m.chunks.Chunks()
+}
+
+func (m *mockImageUploadProgress) Checksum() string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.checksum
+}
+
 func (m *mockImageUploadProgress) do() {
 	defer func() {
-		m.disk.Unlock()
+		m.source.finish()
 		close(m.done)
 	}()
 
-	var err error
-	if _, err = m.reader.Seek(0, io.SeekStart); err != nil {
-		m.err = fmt.Errorf("failed to seek to start of image file (%w)", err)
-		return
+	err := m.upload()
+	if err != nil {
+		m.client.failMockImageTransfer(m.transfer, m.uploadParams.KeepTransferOnError())
+	} else {
+		m.client.finalizeMockImageTransfer(m.transfer)
 	}
-	m.disk.data, err = io.ReadAll(m.reader)
+
+	m.lock.Lock()
 	m.err = err
+	m.lock.Unlock()
+}
+
+func (m *mockImageUploadProgress) upload() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	transferred := func() uint64 {
+		m.lock.Lock()
+		defer m.lock.Unlock()
+		return m.chunks.doneBytes() + m.partialBytes
+	}
+	monitor := startTransferMonitor(m.uploadParams, transferred, m.TotalBytes, nil, func(_ error) {
+		cancel()
+		m.source.interrupt()
+	})
+	defer monitor.stop()
+
+	for _, index := range m.chunks.pending() {
+		chunk := m.chunks.get(index)
+		m.chunks.setState(index, UploadChunkStateUploading)
+		err := m.source.prefetch(chunk)
+		var extents []DiskExtent
+		var data []byte
+		if err == nil {
+			extents, data, err = m.source.readChunkExtents(chunk, true)
+		}
+		for _, extent := range extents {
+			if err != nil {
+				break
+			}
+			if extent.Zero {
+				m.client.zeroMockImageTransfer(m.transfer, extent.Start, extent.Length)
+				continue
+			}
+			if err = waitTransferRate(ctx, m.uploadParams, int(extent.Length)); err == nil { //nolint:gosec
+				start := extent.Start - chunk.Offset
+				m.client.writeMockImageTransfer(m.transfer, extent.Start, data[start:start+extent.Length])
+				m.lock.Lock()
+				m.partialBytes += extent.Length
+				m.lock.Unlock()
+			}
+		}
+		m.lock.Lock()
+		m.partialBytes = 0
+		m.lock.Unlock()
+		if err != nil {
+			m.chunks.setState(index, UploadChunkStateFailed)
+			if stallErr := monitor.err(); stallErr != nil {
+				return stallErr
+			}
+			return err
+		}
+		m.chunks.setState(index, UploadChunkStateDone)
+	}
+	return m.verifyChecksum()
+}
+
+// verifyChecksum calculates the checksum of the uploaded data if requested and compares it to the expected checksum
+// and the checksum of the data received by the mock image transfer.
+func (m *mockImageUploadProgress) verifyChecksum() error {
+	algorithm := m.uploadParams.ChecksumAlgorithm()
+	if algorithm == "" {
+		return nil
+	}
+	checksum, err := m.source.checksum(algorithm, m.size, true)
 	if err != nil {
-		m.uploadedBytes = m.size
+		return err
+	}
+	localChecksum := checksum.Sum()
+	m.lock.Lock()
+	m.checksum = localChecksum
+	m.lock.Unlock()
+	if err := checkExpectedChecksum(algorithm, localChecksum, m.uploadParams.ExpectedChecksum(), m.disk.id); err != nil {
+		return err
 	}
+	m.client.lock.Lock()
+	serverChecksum := mockServerChecksum(algorithm, m.transfer.data)
+	m.client.lock.Unlock()
+	return checkServerChecksum(algorithm, localChecksum, serverChecksum, m.disk.id)
 }
@@ -1,7 +1,9 @@
 package ovirtclient_test
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"testing"
 
 	ovirtclient "github.com/dyudin0821/go-ovirt-client/v3"
@@ -97,3 +99,153 @@ func TestImageUploadToExistingDisk(t *testing.T) {
 
 	assertCanUploadDiskImage(t, helper, disk)
 }
+
+func TestImageUploadResume(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+
+	const chunkSize = 64 * 1024
+	disk := assertCanCreateDisk(t, helper)
+	data := make([]byte, 4*chunkSize)
+	for i := range data {
+		data[i] = byte(i%251 + 1)
+	}
+	params := ovirtclient.UploadImageParams().
+		MustWithChunkSize(chunkSize).
+		MustWithWorkers(1).
+		MustWithKeepTransferOnError(true)
+
+	// The reader fails in the third chunk, so the first two chunks are uploaded.
+	failingReader := &failingReadSeeker{bytes.NewReader(data), 2*chunkSize + 100}
+	progress, err := client.StartUploadToDiskWithParams(
+		disk.ID(),
+		uint64(len(data)),
+		&nopReadCloser{failingReader},
+		params,
+	)
+	if err != nil {
+		t.Fatalf("Failed to start upload to disk %s. (%v)", disk.ID(), err)
+	}
+	<-progress.Done()
+	if err := progress.Err(); err == nil {
+		t.Fatalf("The upload with a failing reader did not result in an error.")
+	}
+	if progress.TransferID() == "" {
+		t.Fatalf("The failed upload has no image transfer ID.")
+	}
+	chunks := progress.Chunks()
+	if len(chunks) != 4 {
+		t.Fatalf("Incorrect number of chunks: %d instead of 4.", len(chunks))
+	}
+	for i, expected := range []ovirtclient.UploadChunkState{
+		ovirtclient.UploadChunkStateDone,
+		ovirtclient.UploadChunkStateDone,
+		ovirtclient.UploadChunkStateFailed,
+		ovirtclient.UploadChunkStatePending,
+	} {
+		if chunks[i].State != expected {
+			t.Fatalf("Incorrect state of chunk %d: %s instead of %s.", i, chunks[i].State, expected)
+		}
+	}
+
+	// The chunks that were already uploaded are zeroed in the reader for the resume. If they were uploaded again,
+	// the disk contents would not match the original data.
+	resumeData := append(make([]byte, 2*chunkSize), data[2*chunkSize:]...)
+	if err := client.UploadToDiskWithParams(
+		disk.ID(),
+		uint64(len(resumeData)),
+		&nopReadCloser{bytes.NewReader(resumeData)},
+		ovirtclient.UploadImageParams().
+			MustWithChunkSize(chunkSize).
+			MustWithResume(progress.TransferID(), chunks),
+	); err != nil {
+		t.Fatalf("Failed to resume upload to disk %s. (%v)", disk.ID(), err)
+	}
+
+	if downloaded := assertCanDownloadDiskData(t, disk); !bytes.Equal(downloaded[:len(data)], data) {
+		t.Fatalf("The contents of disk %s do not match the uploaded data after resuming.", disk.ID())
+	}
+}
+
+func TestImageUploadSkipsZeroes(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+
+	const blockSize = 64 * 1024
+	disk := assertCanCreateDisk(t, helper)
+	diskID := disk.ID()
+	data := make([]byte, 6*blockSize)
+	for i := range data {
+		data[i] = byte(i%251 + 1)
+	}
+	params := ovirtclient.UploadImageParams().MustWithChunkSize(2 * blockSize)
+	if err := client.UploadToDiskWithParams(
+		diskID,
+		uint64(len(data)),
+		&nopReadCloser{bytes.NewReader(data)},
+		params,
+	); err != nil {
+		t.Fatalf("Failed to upload to disk %s. (%v)", diskID, err)
+	}
+
+	// The second, third and sixth blocks are zeroed. The previous contents of the disk must be overwritten with
+	// zeroes even if the zeroes are not sent as data.
+	sparseData := append([]byte(nil), data...)
+	for _, block := range []int{1, 2, 5} {
+		copy(sparseData[block*blockSize:(block+1)*blockSize], make([]byte, blockSize))
+	}
+	if err := client.UploadToDiskWithParams(
+		diskID,
+		uint64(len(sparseData)),
+		&nopReadCloser{bytes.NewReader(sparseData)},
+		params,
+	); err != nil {
+		t.Fatalf("Failed to upload sparse data to disk %s. (%v)", diskID, err)
+	}
+	if downloaded := assertCanDownloadDiskData(t, disk); !bytes.Equal(downloaded[:len(sparseData)], sparseData) {
+		t.Fatalf("The contents of disk %s do not match the uploaded sparse data.", diskID)
+	}
+
+	mockClient, ok := client.(ovirtclient.MockClient)
+	if !ok {
+		return
+	}
+	extents, err := mockClient.DiskUploadExtents(diskID)
+	if err != nil {
+		t.Fatalf("Failed to get upload extents of disk %s. (%v)", diskID, err)
+	}
+	expected := []ovirtclient.DiskExtent{
+		{Start: 0, Length: blockSize},
+		{Start: blockSize, Length: 2 * blockSize, Zero: true},
+		{Start: 3 * blockSize, Length: 2 * blockSize},
+		{Start: 5 * blockSize, Length: blockSize, Zero: true},
+	}
+	if len(extents) != len(expected) {
+		t.Fatalf("Incorrect upload extents: %v instead of %v.", extents, expected)
+	}
+	for i := range expected {
+		if extents[i] != expected[i] {
+			t.Fatalf("Incorrect upload extents: %v instead of %v.", extents, expected)
+		}
+	}
+}
+
+// failingReadSeeker returns an error when a read covers the failAt offset, simulating a bad sector on the source.
+type failingReadSeeker struct {
+	*bytes.Reader
+
+	failAt int64
+}
+
+func (f *failingReadSeeker) Read(p []byte) (int, error) {
+	position, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, err
+	}
+	if position <= f.failAt && position+int64(len(p)) > f.failAt {
+		return 0, fmt.Errorf("simulated read failure at byte %d", f.failAt)
+	}
+	return f.Reader.Read(p)
+}
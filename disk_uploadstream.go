@@ -0,0 +1,355 @@
+package ovirtclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+)
+
+func (o *oVirtClient) UploadStreamToDisk(
+	diskID DiskID,
+	reader io.Reader,
+	streamParams UploadStreamParameters,
+	uploadParams UploadImageParameters,
+	retries ...RetryStrategy,
+) error {
+	retries = defaultRetries(retries, defaultLongTimeouts(o))
+	progress, err := o.StartUploadStreamToDisk(diskID, reader, streamParams, uploadParams, retries...)
+	if err != nil {
+		return err
+	}
+	<-progress.Done()
+	return progress.Err()
+}
+
+func (o *oVirtClient) StartUploadStreamToDisk(
+	diskID DiskID,
+	reader io.Reader,
+	streamParams UploadStreamParameters,
+	uploadParams UploadImageParameters,
+	retries ...RetryStrategy,
+) (result UploadImageProgress, err error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	uploadParams = uploadParamsOrDefault(uploadParams)
+	if err := validateUploadStreamParams(uploadParams); err != nil {
+		return nil, err
+	}
+	o.logger.Infof("Starting disk image stream upload...")
+	disk, err := o.GetDisk(diskID, retries...)
+	if err != nil {
+		return nil, err
+	}
+	stream, err := openUploadStream(reader, streamParams)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			stream.release()
+		}
+	}()
+	if stream.info.VirtualSize() > disk.ProvisionedSize() {
+		return nil, newError(
+			EBadArgument,
+			"the virtual image size (%d bytes) is larger than the target disk %s (%d bytes)",
+			stream.info.VirtualSize(),
+			diskID,
+			disk.ProvisionedSize(),
+		)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	progress := &uploadToDiskProgress{
+		client:        o,
+		lock:          &sync.Mutex{},
+		done:          make(chan struct{}),
+		ctx:           ctx,
+		cancel:        cancel,
+		correlationID: fmt.Sprintf("image_upload_%s", generateRandomID(5, o.nonSecureRandom)),
+		format:        stream.info.Format(),
+		disk:          disk,
+		totalBytes:    streamParams.Size(),
+		qcowSize:      stream.info.VirtualSize(),
+		reader:        stream,
+		source:        newStreamUploadChunkSource(stream, streamParams.Size(), uploadParams),
+		retries:       retries,
+		uploadParams:  uploadParams,
+		chunks:        newUploadChunkList(planUploadChunks(streamParams.Size(), uploadParams.ChunkSize(), nil)),
+	}
+	go progress.Do()
+	return progress, nil
+}
+
+func (o *oVirtClient) UploadStreamToNewDisk(
+	storageDomainID StorageDomainID,
+	format ImageFormat,
+	params CreateDiskOptionalParameters,
+	reader io.Reader,
+	streamParams UploadStreamParameters,
+	uploadParams UploadImageParameters,
+	retries ...RetryStrategy,
+) (UploadImageResult, error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(o))
+	progress, err := o.StartUploadStreamToNewDisk(
+		storageDomainID,
+		format,
+		params,
+		reader,
+		streamParams,
+		uploadParams,
+		retries...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	<-progress.Done()
+	if err := progress.Err(); err != nil {
+		return nil, err
+	}
+	return progress, nil
+}
+
+func (o *oVirtClient) StartUploadStreamToNewDisk(
+	storageDomainID StorageDomainID,
+	format ImageFormat,
+	params CreateDiskOptionalParameters,
+	reader io.Reader,
+	streamParams UploadStreamParameters,
+	uploadParams UploadImageParameters,
+	retries ...RetryStrategy,
+) (result UploadImageProgress, err error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(o))
+	uploadParams = uploadParamsOrDefault(uploadParams)
+	if err := validateUploadStreamParams(uploadParams); err != nil {
+		return nil, err
+	}
+	o.logger.Infof("Starting disk image stream upload...")
+	stream, err := openUploadStream(reader, streamParams)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			stream.release()
+		}
+	}()
+	if format, err = uploadStreamDiskFormat(format, stream.info.Format()); err != nil {
+		return nil, err
+	}
+
+	diskCreateParams, err := uploadDiskParams(params, format, stream.info.ContentType())
+	if err != nil {
+		return nil, err
+	}
+	diskCreateParams.MustWithInitialSize(streamParams.Size())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	progress := &uploadToNewDiskProgress{
+		uploadToDiskProgress: uploadToDiskProgress{
+			client:        o,
+			lock:          &sync.Mutex{},
+			done:          make(chan struct{}),
+			ctx:           ctx,
+			cancel:        cancel,
+			correlationID: fmt.Sprintf("image_upload_%s", generateRandomID(5, o.nonSecureRandom)),
+			format:        format,
+			disk:          nil,
+			totalBytes:    streamParams.Size(),
+			qcowSize:      stream.info.VirtualSize(),
+			reader:        stream,
+			source:        newStreamUploadChunkSource(stream, streamParams.Size(), uploadParams),
+			retries:       retries,
+			uploadParams:  uploadParams,
+			chunks:        newUploadChunkList(planUploadChunks(streamParams.Size(), uploadParams.ChunkSize(), nil)),
+		},
+
+		storageDomainID: storageDomainID,
+		diskFormat:      format,
+		diskParams:      diskCreateParams,
+	}
+	go progress.Do()
+	return progress, nil
+}
+
+// uploadStreamDiskFormat returns the format of the disk created for a streamed image. Streamed images cannot be
+// converted, so the format of the disk must match the format of the image.
+func uploadStreamDiskFormat(format ImageFormat, imageFormat ImageFormat) (ImageFormat, error) {
+	if format == "" {
+		return imageFormat, nil
+	}
+	if err := format.Validate(); err != nil {
+		return "", err
+	}
+	if format != imageFormat {
+		return "", newError(
+			EBadArgument,
+			"the stream contains a %s image, which cannot be converted to a %s disk while streaming,"+
+				" please use a seekable reader to convert the image",
+			imageFormat,
+			format,
+		)
+	}
+	return format, nil
+}
+
+func (m *mockClient) UploadStreamToDisk(
+	diskID DiskID,
+	reader io.Reader,
+	streamParams UploadStreamParameters,
+	uploadParams UploadImageParameters,
+	retries ...RetryStrategy,
+) error {
+	progress, err := m.StartUploadStreamToDisk(diskID, reader, streamParams, uploadParams, retries...)
+	if err != nil {
+		return err
+	}
+	<-progress.Done()
+	return progress.Err()
+}
+
+func (m *mockClient) StartUploadStreamToDisk(
+	diskID DiskID,
+	reader io.Reader,
+	streamParams UploadStreamParameters,
+	uploadParams UploadImageParameters,
+	retries ...RetryStrategy,
+) (result UploadImageProgress, err error) {
+	uploadParams = uploadParamsOrDefault(uploadParams)
+	if err := validateUploadStreamParams(uploadParams); err != nil {
+		return nil, err
+	}
+	disk, err := m.getDisk(diskID, retries...)
+	if err != nil {
+		return nil, err
+	}
+	stream, err := openUploadStream(reader, streamParams)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			stream.release()
+		}
+	}()
+	if stream.info.VirtualSize() > disk.TotalSize() {
+		return nil, newError(
+			EBadArgument,
+			"the virtual image size (%d bytes) is larger than the target disk %s (%d bytes)",
+			stream.info.VirtualSize(),
+			diskID,
+			disk.TotalSize(),
+		)
+	}
+	if stream.info.Format() != disk.Format() {
+		return nil, newError(
+			EBadArgument,
+			"the mock facility doesn't support uploading %s images to %s disks,"+
+				" please upload in the disk format in your tests.",
+			stream.info.Format(),
+			disk.Format(),
+		)
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	// Lock the disk to simulate the upload being initialized.
+	if err := disk.Lock(); err != nil {
+		return nil, newError(EDiskLocked, "disk locked after creation")
+	}
+	transfer := m.createMockImageTransfer(disk, streamParams.Size())
+	source := newStreamUploadChunkSource(stream, streamParams.Size(), uploadParams)
+	progress := newMockImageUploadProgress(m, disk, transfer, source, streamParams.Size(), uploadParams)
+	go progress.do()
+
+	return progress, nil
+}
+
+func (m *mockClient) UploadStreamToNewDisk(
+	storageDomainID StorageDomainID,
+	format ImageFormat,
+	params CreateDiskOptionalParameters,
+	reader io.Reader,
+	streamParams UploadStreamParameters,
+	uploadParams UploadImageParameters,
+	retries ...RetryStrategy,
+) (UploadImageResult, error) {
+	progress, err := m.StartUploadStreamToNewDisk(
+		storageDomainID,
+		format,
+		params,
+		reader,
+		streamParams,
+		uploadParams,
+		retries...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	<-progress.Done()
+	if err := progress.Err(); err != nil {
+		return nil, err
+	}
+	return progress, nil
+}
+
+func (m *mockClient) StartUploadStreamToNewDisk(
+	storageDomainID StorageDomainID,
+	format ImageFormat,
+	params CreateDiskOptionalParameters,
+	reader io.Reader,
+	streamParams UploadStreamParameters,
+	uploadParams UploadImageParameters,
+	_ ...RetryStrategy,
+) (result UploadImageProgress, err error) {
+	uploadParams = uploadParamsOrDefault(uploadParams)
+	if err := validateUploadStreamParams(uploadParams); err != nil {
+		return nil, err
+	}
+	stream, err := openUploadStream(reader, streamParams)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			stream.release()
+		}
+	}()
+	if format, err = uploadStreamDiskFormat(format, stream.info.Format()); err != nil {
+		return nil, err
+	}
+	diskParams, err := uploadDiskParams(params, format, stream.info.ContentType())
+	if err != nil {
+		return nil, err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.storageDomains[storageDomainID]; !ok {
+		return nil, newError(ENotFound, "storage domain with ID %s not found", storageDomainID)
+	}
+	size := stream.info.VirtualSize()
+	if size < 1024*1024 {
+		size = 1024 * 1024
+	}
+	disk, err := m.createDisk(storageDomainID, format, size, diskParams)
+	if err != nil {
+		return nil, err
+	}
+	// Unlock the disk to simulate disk creation being complete.
+	disk.Unlock()
+
+	// Lock the disk to simulate the upload being initialized.
+	if err := disk.Lock(); err != nil {
+		return nil, newError(EDiskLocked, "disk locked after creation")
+	}
+
+	transfer := m.createMockImageTransfer(disk, streamParams.Size())
+	source := newStreamUploadChunkSource(stream, streamParams.Size(), uploadParams)
+	progress := newMockImageUploadProgress(m, disk, transfer, source, streamParams.Size(), uploadParams)
+	go progress.do()
+
+	return progress, nil
+}
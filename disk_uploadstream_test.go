@@ -0,0 +1,274 @@
+package ovirtclient_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"testing"
+
+	ovirtclient "github.com/dyudin0821/go-ovirt-client/v3"
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+// streamTestData returns 1 MiB of raw image data with a zero range in the middle.
+func streamTestData() []byte {
+	data := make([]byte, 1024*1024)
+	for i := range data {
+		if i < 256*1024 || i >= 768*1024 {
+			data[i] = byte(i%253 + 1)
+		}
+	}
+	return data
+}
+
+// streamReader hides all interfaces of the underlying reader except io.Reader, like an HTTP response body.
+type streamReader struct {
+	io.Reader
+}
+
+func TestImageUploadStream(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+
+	data := streamTestData()
+	compressed := &bytes.Buffer{}
+	writer := gzip.NewWriter(compressed)
+	if _, err := writer.Write(data); err != nil {
+		t.Fatalf("Failed to compress test data. (%v)", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Failed to compress test data. (%v)", err)
+	}
+	checksum := sha256.Sum256(data)
+
+	disk := assertCanCreateDisk(t, helper)
+	diskID := disk.ID()
+	err := client.UploadStreamToDisk(
+		diskID,
+		&streamReader{compressed},
+		ovirtclient.UploadStreamParams(uint64(len(data))),
+		ovirtclient.UploadImageParams().
+			MustWithChunkSize(256*1024).
+			MustWithChecksum(ovirtclient.ChecksumAlgorithmSHA256, hex.EncodeToString(checksum[:])),
+	)
+	if err != nil {
+		t.Fatalf("Failed to upload gzip compressed stream to disk %s. (%v)", diskID, err)
+	}
+	disk, err = client.GetDisk(diskID)
+	if err != nil {
+		t.Fatalf("Failed to fetch disk %s after upload. (%v)", diskID, err)
+	}
+	if downloaded := assertCanDownloadDiskData(t, disk); !bytes.Equal(downloaded[:len(data)], data) {
+		t.Fatalf("The contents of disk %s do not match the uploaded stream.", diskID)
+	}
+}
+
+func TestImageUploadStreamToNewDisk(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+
+	data := streamTestData()
+	imageName := fmt.Sprintf("client_test_%s", helper.GenerateRandomID(5))
+	result, err := client.UploadStreamToNewDisk(
+		helper.GetStorageDomainID(),
+		"",
+		ovirtclient.CreateDiskParams().MustWithSparse(true).MustWithAlias(imageName),
+		&streamReader{bytes.NewReader(data)},
+		ovirtclient.UploadStreamParams(uint64(len(data))).MustWithFormat(ovirtclient.ImageFormatRaw),
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("Failed to upload stream to new disk. (%v)", err)
+	}
+	diskID := result.Disk().ID()
+	t.Cleanup(func() {
+		if err := client.RemoveDisk(diskID); err != nil && !ovirtclient.HasErrorCode(err, ovirtclient.ENotFound) {
+			t.Fatalf("Failed to remove disk %s. (%v)", diskID, err)
+		}
+	})
+	disk, err := client.GetDisk(diskID)
+	if err != nil {
+		t.Fatalf("Failed to fetch disk %s after upload. (%v)", diskID, err)
+	}
+	if disk.Format() != ovirtclient.ImageFormatRaw {
+		t.Fatalf("Incorrect disk format: %s instead of %s.", disk.Format(), ovirtclient.ImageFormatRaw)
+	}
+	if downloaded := assertCanDownloadDiskData(t, disk); !bytes.Equal(downloaded[:len(data)], data) {
+		t.Fatalf("The contents of disk %s do not match the uploaded stream.", diskID)
+	}
+}
+
+func TestImageUploadStreamToNewShareableDisk(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+
+	data := streamTestData()
+	result, err := client.UploadStreamToNewDisk(
+		helper.GetStorageDomainID(),
+		ovirtclient.ImageFormatRaw,
+		ovirtclient.CreateDiskParams().
+			MustWithSparse(false).
+			MustWithShareable(true).
+			MustWithAlias(fmt.Sprintf("client_test_%s", helper.GenerateRandomID(5))),
+		&streamReader{bytes.NewReader(data)},
+		ovirtclient.UploadStreamParams(uint64(len(data))),
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("Failed to upload stream to new shareable disk. (%v)", err)
+	}
+	diskID := result.Disk().ID()
+	t.Cleanup(func() {
+		if err := client.RemoveDisk(diskID); err != nil && !ovirtclient.HasErrorCode(err, ovirtclient.ENotFound) {
+			t.Fatalf("Failed to remove disk %s. (%v)", diskID, err)
+		}
+	})
+	disk, err := client.GetDisk(diskID)
+	if err != nil {
+		t.Fatalf("Failed to fetch disk %s after upload. (%v)", diskID, err)
+	}
+	if !disk.Shareable() {
+		t.Fatalf("Disk %s uploaded as shareable is not shareable.", diskID)
+	}
+}
+
+func TestImageUploadStreamSizeMismatch(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+
+	data := streamTestData()
+	disk := assertCanCreateDisk(t, helper)
+	err := client.UploadStreamToDisk(
+		disk.ID(),
+		&streamReader{bytes.NewReader(data[:len(data)-4096])},
+		ovirtclient.UploadStreamParams(uint64(len(data))),
+		nil,
+	)
+	if err == nil {
+		t.Fatalf("Uploading a stream shorter than the declared size did not result in an error.")
+	}
+	if !ovirtclient.HasErrorCode(err, ovirtclient.EBadArgument) {
+		t.Fatalf("Uploading a stream shorter than the declared size did not result in an EBadArgument error. (%v)", err)
+	}
+}
+
+func TestImageUploadStreamTooLong(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+
+	data := streamTestData()
+	disk := assertCanCreateDisk(t, helper)
+	err := client.UploadStreamToDisk(
+		disk.ID(),
+		io.MultiReader(bytes.NewReader(data), &emptyReadReader{}, bytes.NewReader(make([]byte, 4096))),
+		ovirtclient.UploadStreamParams(uint64(len(data))),
+		nil,
+	)
+	if err == nil {
+		t.Fatalf("Uploading a stream longer than the declared size did not result in an error.")
+	}
+	if !ovirtclient.HasErrorCode(err, ovirtclient.EBadArgument) {
+		t.Fatalf("Uploading a stream longer than the declared size did not result in an EBadArgument error. (%v)", err)
+	}
+}
+
+// emptyReadReader returns no data and no error on the first read, which io.Reader allows, and io.EOF afterwards.
+type emptyReadReader struct {
+	read bool
+}
+
+func (e *emptyReadReader) Read(_ []byte) (int, error) {
+	if e.read {
+		return 0, io.EOF
+	}
+	e.read = true
+	return 0, nil
+}
+
+func TestImageUploadStreamXZ(t *testing.T) {
+	t.Parallel()
+
+	data := streamTestData()
+	compressed := &bytes.Buffer{}
+	writer, err := xz.NewWriter(compressed)
+	if err != nil {
+		t.Fatalf("Failed to create xz writer. (%v)", err)
+	}
+	if _, err := writer.Write(data); err != nil {
+		t.Fatalf("Failed to compress test data. (%v)", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Failed to compress test data. (%v)", err)
+	}
+	testImageUploadCompressedStream(t, data, compressed.Bytes(), ovirtclient.ImageCompressionXZ)
+}
+
+func TestImageUploadStreamZstd(t *testing.T) {
+	t.Parallel()
+
+	data := streamTestData()
+	compressed := &bytes.Buffer{}
+	writer, err := zstd.NewWriter(compressed)
+	if err != nil {
+		t.Fatalf("Failed to create Zstandard writer. (%v)", err)
+	}
+	if _, err := writer.Write(data); err != nil {
+		t.Fatalf("Failed to compress test data. (%v)", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Failed to compress test data. (%v)", err)
+	}
+	testImageUploadCompressedStream(t, data, compressed.Bytes(), ovirtclient.ImageCompressionZstd)
+}
+
+// testImageUploadCompressedStream uploads the compressed data once with the compression detected from the stream and
+// once with the compression declared, and checks that the disk contains the uncompressed data.
+func testImageUploadCompressedStream(
+	t *testing.T,
+	data []byte,
+	compressed []byte,
+	compression ovirtclient.ImageCompression,
+) {
+	checksum := sha256.Sum256(data)
+	for name, streamParams := range map[string]ovirtclient.UploadStreamParameters{
+		"detected": ovirtclient.UploadStreamParams(uint64(len(data))),
+		"declared": ovirtclient.UploadStreamParams(uint64(len(data))).MustWithCompression(compression),
+	} {
+		streamParams := streamParams
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			helper := getHelper(t)
+			client := helper.GetClient()
+
+			disk := assertCanCreateDisk(t, helper)
+			diskID := disk.ID()
+			err := client.UploadStreamToDisk(
+				diskID,
+				&streamReader{bytes.NewReader(compressed)},
+				streamParams,
+				ovirtclient.UploadImageParams().
+					MustWithChunkSize(256*1024).
+					MustWithChecksum(ovirtclient.ChecksumAlgorithmSHA256, hex.EncodeToString(checksum[:])),
+			)
+			if err != nil {
+				t.Fatalf("Failed to upload %s compressed stream to disk %s. (%v)", compression, diskID, err)
+			}
+			disk, err = client.GetDisk(diskID)
+			if err != nil {
+				t.Fatalf("Failed to fetch disk %s after upload. (%v)", diskID, err)
+			}
+			if downloaded := assertCanDownloadDiskData(t, disk); !bytes.Equal(downloaded[:len(data)], data) {
+				t.Fatalf("The contents of disk %s do not match the uploaded stream.", diskID)
+			}
+		})
+	}
+}
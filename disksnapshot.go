@@ -0,0 +1,219 @@
+package ovirtclient
+
+import (
+	ovirtsdk4 "github.com/ovirt/go-ovirt"
+)
+
+// DiskSnapshotID is the identifier of a disk snapshot. A disk snapshot is a single layer (image) in the image chain
+// of a disk, so this is the image ID of that layer.
+type DiskSnapshotID string
+
+// DiskSnapshotClient contains the methods to inspect and clean up the image layers of disks.
+type DiskSnapshotClient interface {
+	// ListDiskSnapshots lists all layers in the image chain of a disk, including the active layer.
+	ListDiskSnapshots(diskID DiskID, retries ...RetryStrategy) ([]DiskSnapshot, error)
+	// GetDiskSnapshot returns a single layer from the image chain of a disk.
+	GetDiskSnapshot(diskID DiskID, id DiskSnapshotID, retries ...RetryStrategy) (DiskSnapshot, error)
+	// RemoveDiskSnapshot removes a layer from the image chain of a disk and waits for the images to be merged. The
+	// disk is also removed from the VM snapshot the layer belongs to. The active layer cannot be removed.
+	RemoveDiskSnapshot(diskID DiskID, id DiskSnapshotID, retries ...RetryStrategy) error
+	// GetDiskImageChain returns the layers of a disk ordered from the base image to the active layer.
+	GetDiskImageChain(diskID DiskID, retries ...RetryStrategy) ([]DiskSnapshot, error)
+}
+
+// DiskSnapshotData is the core of DiskSnapshot, providing only data access functions.
+type DiskSnapshotData interface {
+	// ID returns the image ID of the layer.
+	ID() DiskSnapshotID
+	// DiskID returns the ID of the disk this layer belongs to. This may be empty for orphaned layers.
+	DiskID() DiskID
+	// SnapshotID returns the ID of the VM snapshot this layer belongs to, or nil if it does not belong to a VM
+	// snapshot.
+	SnapshotID() *SnapshotID
+	// ParentID returns the ID of the layer this layer is based on, or nil for the base image.
+	ParentID() *DiskSnapshotID
+	// Description returns the description of the layer, which is usually the description of the VM snapshot.
+	Description() string
+	// Status returns the status of the layer.
+	Status() DiskStatus
+	// Format returns the format of the layer.
+	Format() ImageFormat
+	// StorageDomainIDs returns the storage domains the layer is stored on.
+	StorageDomainIDs() []StorageDomainID
+	// ProvisionedSize returns the size visible to the virtual machine in bytes.
+	ProvisionedSize() uint64
+	// ActualSize returns the space the layer itself takes up on the storage domain in bytes.
+	ActualSize() uint64
+}
+
+// DiskSnapshot is a single layer in the image chain of a disk.
+type DiskSnapshot interface {
+	DiskSnapshotData
+
+	// Disk fetches the disk this layer belongs to.
+	Disk(retries ...RetryStrategy) (Disk, error)
+	// Remove removes the current layer.
+	Remove(retries ...RetryStrategy) error
+}
+
+// orderDiskImageChain orders the layers of a single disk from the base image to the active layer by following the
+// parent references. Layers that are not connected to the chain are appended at the end.
+func orderDiskImageChain(layers []DiskSnapshot) []DiskSnapshot {
+	ids := make(map[DiskSnapshotID]struct{}, len(layers))
+	children := make(map[DiskSnapshotID]DiskSnapshot, len(layers))
+	var base DiskSnapshot
+	for _, layer := range layers {
+		ids[layer.ID()] = struct{}{}
+	}
+	for _, layer := range layers {
+		parentID := layer.ParentID()
+		if _, ok := ids[derefDiskSnapshotID(parentID)]; parentID == nil || !ok {
+			if base == nil {
+				base = layer
+			}
+			continue
+		}
+		children[*parentID] = layer
+	}
+	result := make([]DiskSnapshot, 0, len(layers))
+	added := make(map[DiskSnapshotID]struct{}, len(layers))
+	for layer := base; layer != nil; layer = children[layer.ID()] {
+		if _, ok := added[layer.ID()]; ok {
+			break
+		}
+		result = append(result, layer)
+		added[layer.ID()] = struct{}{}
+	}
+	for _, layer := range layers {
+		if _, ok := added[layer.ID()]; !ok {
+			result = append(result, layer)
+		}
+	}
+	return result
+}
+
+func derefDiskSnapshotID(id *DiskSnapshotID) DiskSnapshotID {
+	if id == nil {
+		return ""
+	}
+	return *id
+}
+
+func convertSDKDiskSnapshot(sdkObject *ovirtsdk4.DiskSnapshot, client Client) (DiskSnapshot, error) {
+	id, ok := sdkObject.Id()
+	if !ok {
+		return nil, newFieldNotFound("disk snapshot", "id")
+	}
+	result := &diskSnapshot{
+		client: client,
+		id:     DiskSnapshotID(id),
+	}
+	if sdkDisk, ok := sdkObject.Disk(); ok {
+		if diskID, ok := sdkDisk.Id(); ok {
+			result.diskID = DiskID(diskID)
+		}
+	}
+	if sdkSnapshot, ok := sdkObject.Snapshot(); ok {
+		if snapshotID, ok := sdkSnapshot.Id(); ok {
+			id := SnapshotID(snapshotID)
+			result.snapshotID = &id
+		}
+	}
+	if sdkParent, ok := sdkObject.Parent(); ok {
+		if parentID, ok := sdkParent.Id(); ok {
+			id := DiskSnapshotID(parentID)
+			result.parentID = &id
+		}
+	}
+	if description, ok := sdkObject.Description(); ok {
+		result.description = description
+	}
+	if status, ok := sdkObject.Status(); ok {
+		result.status = DiskStatus(status)
+	}
+	if format, ok := sdkObject.Format(); ok {
+		result.format = ImageFormat(format)
+	}
+	if sdkStorageDomain, ok := sdkObject.StorageDomain(); ok {
+		if storageDomainID, ok := sdkStorageDomain.Id(); ok {
+			result.storageDomainIDs = append(result.storageDomainIDs, StorageDomainID(storageDomainID))
+		}
+	}
+	if sdkStorageDomains, ok := sdkObject.StorageDomains(); ok {
+		for _, sd := range sdkStorageDomains.Slice() {
+			if storageDomainID, ok := sd.Id(); ok {
+				result.storageDomainIDs = append(result.storageDomainIDs, StorageDomainID(storageDomainID))
+			}
+		}
+	}
+	if provisionedSize, ok := sdkObject.ProvisionedSize(); ok {
+		result.provisionedSize = uint64(provisionedSize) //nolint:gosec
+	}
+	if actualSize, ok := sdkObject.ActualSize(); ok {
+		result.actualSize = uint64(actualSize) //nolint:gosec
+	}
+	return result, nil
+}
+
+type diskSnapshot struct {
+	client Client
+
+	id               DiskSnapshotID
+	diskID           DiskID
+	snapshotID       *SnapshotID
+	parentID         *DiskSnapshotID
+	description      string
+	status           DiskStatus
+	format           ImageFormat
+	storageDomainIDs []StorageDomainID
+	provisionedSize  uint64
+	actualSize       uint64
+}
+
+func (d *diskSnapshot) ID() DiskSnapshotID {
+	return d.id
+}
+
+func (d *diskSnapshot) DiskID() DiskID {
+	return d.diskID
+}
+
+func (d *diskSnapshot) SnapshotID() *SnapshotID {
+	return d.snapshotID
+}
+
+func (d *diskSnapshot) ParentID() *DiskSnapshotID {
+	return d.parentID
+}
+
+func (d *diskSnapshot) Description() string {
+	return d.description
+}
+
+func (d *diskSnapshot) Status() DiskStatus {
+	return d.status
+}
+
+func (d *diskSnapshot) Format() ImageFormat {
+	return d.format
+}
+
+func (d *diskSnapshot) StorageDomainIDs() []StorageDomainID {
+	return d.storageDomainIDs
+}
+
+func (d *diskSnapshot) ProvisionedSize() uint64 {
+	return d.provisionedSize
+}
+
+func (d *diskSnapshot) ActualSize() uint64 {
+	return d.actualSize
+}
+
+func (d *diskSnapshot) Disk(retries ...RetryStrategy) (Disk, error) {
+	return d.client.GetDisk(d.diskID, retries...)
+}
+
+func (d *diskSnapshot) Remove(retries ...RetryStrategy) error {
+	return d.client.RemoveDiskSnapshot(d.diskID, d.id, retries...)
+}
@@ -0,0 +1,65 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) GetDiskSnapshot(
+	diskID DiskID,
+	id DiskSnapshotID,
+	retries ...RetryStrategy,
+) (result DiskSnapshot, err error) {
+	retries = defaultRetries(retries, defaultReadTimeouts(o))
+	err = retry(
+		fmt.Sprintf("getting snapshot %s of disk %s", id, diskID),
+		o.logger,
+		retries,
+		func() error {
+			response, err := o.conn.
+				SystemService().
+				DisksService().
+				DiskService(string(diskID)).
+				DiskSnapshotsService().
+				SnapshotService(string(id)).
+				Get().
+				Send()
+			if err != nil {
+				return err
+			}
+			sdkObject, ok := response.Snapshot()
+			if !ok {
+				return newError(
+					ENotFound,
+					"no disk snapshot returned when getting snapshot ID %s of disk %s",
+					id,
+					diskID,
+				)
+			}
+			result, err = convertSDKDiskSnapshot(sdkObject, o)
+			if err != nil {
+				return wrap(
+					err,
+					EBug,
+					"failed to convert disk snapshot %s",
+					id,
+				)
+			}
+			return nil
+		})
+	return
+}
+
+func (m *mockClient) GetDiskSnapshot(diskID DiskID, id DiskSnapshotID, _ ...RetryStrategy) (DiskSnapshot, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.disks[diskID]; !ok {
+		return nil, newError(ENotFound, "disk with ID %s not found", diskID)
+	}
+	for _, layer := range m.diskSnapshotLayers(diskID) {
+		if layer.ID() == id {
+			return layer, nil
+		}
+	}
+	return nil, newError(ENotFound, "snapshot with ID %s not found for disk %s", id, diskID)
+}
@@ -0,0 +1,17 @@
+package ovirtclient
+
+func (o *oVirtClient) GetDiskImageChain(diskID DiskID, retries ...RetryStrategy) ([]DiskSnapshot, error) {
+	return getDiskImageChain(o, diskID, retries...)
+}
+
+func (m *mockClient) GetDiskImageChain(diskID DiskID, retries ...RetryStrategy) ([]DiskSnapshot, error) {
+	return getDiskImageChain(m, diskID, retries...)
+}
+
+func getDiskImageChain(client Client, diskID DiskID, retries ...RetryStrategy) ([]DiskSnapshot, error) {
+	layers, err := client.ListDiskSnapshots(diskID, retries...)
+	if err != nil {
+		return nil, err
+	}
+	return orderDiskImageChain(layers), nil
+}
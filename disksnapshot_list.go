@@ -0,0 +1,50 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) ListDiskSnapshots(diskID DiskID, retries ...RetryStrategy) (result []DiskSnapshot, err error) {
+	retries = defaultRetries(retries, defaultReadTimeouts(o))
+	result = []DiskSnapshot{}
+	err = retry(
+		fmt.Sprintf("listing snapshots of disk %s", diskID),
+		o.logger,
+		retries,
+		func() error {
+			response, e := o.conn.
+				SystemService().
+				DisksService().
+				DiskService(string(diskID)).
+				DiskSnapshotsService().
+				List().
+				IncludeActive(true).
+				Send()
+			if e != nil {
+				return e
+			}
+			sdkObjects, ok := response.Snapshots()
+			if !ok {
+				return nil
+			}
+			result = make([]DiskSnapshot, len(sdkObjects.Slice()))
+			for i, sdkObject := range sdkObjects.Slice() {
+				result[i], e = convertSDKDiskSnapshot(sdkObject, o)
+				if e != nil {
+					return wrap(e, EBug, "failed to convert disk snapshot during listing item #%d", i)
+				}
+			}
+			return nil
+		})
+	return
+}
+
+func (m *mockClient) ListDiskSnapshots(diskID DiskID, _ ...RetryStrategy) ([]DiskSnapshot, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.disks[diskID]; !ok {
+		return nil, newError(ENotFound, "disk with ID %s not found", diskID)
+	}
+	return m.diskSnapshotLayers(diskID), nil
+}
@@ -0,0 +1,121 @@
+package ovirtclient
+
+import (
+	"bytes"
+)
+
+// diskImageLayer adds the disk contents at the time the layer was frozen for mocking purposes. The data of the active
+// layer is nil, its contents are the current contents of the disk.
+type diskImageLayer struct {
+	diskSnapshot
+
+	data []byte
+}
+
+// diskImageChain returns the layers of a disk ordered from the base image to the active layer. Disks that have no
+// chain yet get a chain with a single active layer. The caller must hold the mock client lock.
+func (m *mockClient) diskImageChain(disk *diskWithData) []*diskImageLayer {
+	chain, ok := m.diskImageChains[disk.id]
+	if !ok {
+		chain = []*diskImageLayer{
+			{
+				diskSnapshot: diskSnapshot{
+					client: m,
+					id:     DiskSnapshotID(m.GenerateUUID()),
+					diskID: disk.id,
+				},
+			},
+		}
+		m.diskImageChains[disk.id] = chain
+	}
+	return chain
+}
+
+// diskSnapshotLayers returns a copy of the layers of a disk with the status, sizes and storage domains filled in from
+// the disk. The caller must hold the mock client lock.
+func (m *mockClient) diskSnapshotLayers(diskID DiskID) []DiskSnapshot {
+	disk := m.disks[diskID]
+	chain := m.diskImageChain(disk)
+	result := make([]DiskSnapshot, len(chain))
+	var parentData []byte
+	for i, layer := range chain {
+		data := layer.data
+		item := layer.diskSnapshot
+		item.status = DiskStatusOK
+		if i == len(chain)-1 {
+			data = disk.data
+			item.status = disk.status
+		}
+		item.format = disk.format
+		item.storageDomainIDs = disk.storageDomainIDs
+		item.provisionedSize = disk.provisionedSize
+		item.actualSize = mockLayerActualSize(parentData, data)
+		result[i] = &item
+		parentData = data
+	}
+	return result
+}
+
+// mockLayerActualSize returns the size of the blocks that differ between a layer and its parent.
+func mockLayerActualSize(parentData []byte, data []byte) uint64 {
+	var size uint64
+	for start := 0; start < len(data); start += mockDirtyBlockSize {
+		end := start + mockDirtyBlockSize
+		if end > len(data) {
+			end = len(data)
+		}
+		if !bytes.Equal(mockDiskBlock(parentData, start, end), data[start:end]) {
+			size += uint64(end - start)
+		}
+	}
+	return size
+}
+
+// addDiskSnapshotLayers freezes the active layers of the disks saved in a VM snapshot and creates a new active layer
+// on top of them. The caller must hold the mock client lock.
+func (m *mockClient) addDiskSnapshotLayers(s *snapshotWithState) {
+	for diskID, saved := range s.disks {
+		disk, ok := m.disks[diskID]
+		if !ok {
+			continue
+		}
+		chain := m.diskImageChain(disk)
+		active := chain[len(chain)-1]
+		snapshotID := s.id
+		active.snapshotID = &snapshotID
+		active.description = s.description
+		active.data = saved.data
+		parentID := active.id
+		m.diskImageChains[diskID] = append(chain, &diskImageLayer{
+			diskSnapshot: diskSnapshot{
+				client:   m,
+				id:       DiskSnapshotID(m.GenerateUUID()),
+				diskID:   diskID,
+				parentID: &parentID,
+			},
+		})
+	}
+}
+
+// removeDiskSnapshotLayers merges the layers belonging to a VM snapshot into their children. The caller must hold the
+// mock client lock.
+func (m *mockClient) removeDiskSnapshotLayers(s *snapshotWithState) {
+	for diskID := range s.disks {
+		for i, layer := range m.diskImageChains[diskID] {
+			if layer.snapshotID != nil && *layer.snapshotID == s.id {
+				m.removeDiskImageLayer(diskID, i)
+				break
+			}
+		}
+	}
+}
+
+// removeDiskImageLayer removes the layer at the specified index from the chain of a disk and connects its child to
+// its parent. The caller must hold the mock client lock.
+func (m *mockClient) removeDiskImageLayer(diskID DiskID, index int) {
+	chain := m.diskImageChains[diskID]
+	if index+1 < len(chain) {
+		chain[index+1].parentID = chain[index].parentID
+	}
+	m.diskImageChains[diskID] = append(chain[:index:index], chain[index+1:]...)
+}
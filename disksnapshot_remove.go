@@ -0,0 +1,65 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) RemoveDiskSnapshot(diskID DiskID, id DiskSnapshotID, retries ...RetryStrategy) (err error) {
+	writeRetries := defaultRetries(retries, defaultWriteTimeouts(o))
+	correlationID := fmt.Sprintf("disk_snapshot_remove_%s", generateRandomID(5, o.nonSecureRandom))
+	err = retry(
+		fmt.Sprintf("removing snapshot %s of disk %s", id, diskID),
+		o.logger,
+		writeRetries,
+		func() error {
+			_, err := o.conn.
+				SystemService().
+				DisksService().
+				DiskService(string(diskID)).
+				DiskSnapshotsService().
+				SnapshotService(string(id)).
+				Remove().
+				Query("correlation_id", correlationID).
+				Send()
+			return err
+		})
+	if err != nil {
+		return err
+	}
+	if err := o.waitForJobFinished(correlationID, defaultRetries(retries, defaultLongTimeouts(o))); err != nil {
+		return wrap(err, EUnidentified, "failed to wait for removal of snapshot %s of disk %s", id, diskID)
+	}
+	return nil
+}
+
+func (m *mockClient) RemoveDiskSnapshot(diskID DiskID, id DiskSnapshotID, _ ...RetryStrategy) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	disk, ok := m.disks[diskID]
+	if !ok {
+		return newError(ENotFound, "disk with ID %s not found", diskID)
+	}
+	chain := m.diskImageChain(disk)
+	for i, layer := range chain {
+		if layer.id != id {
+			continue
+		}
+		if i == len(chain)-1 {
+			return newError(EBadArgument, "cannot remove the active layer %s of disk %s", id, diskID)
+		}
+		if disk.status != DiskStatusOK {
+			return newError(EDiskLocked, "disk %s is %s", diskID, disk.status)
+		}
+		if layer.snapshotID != nil {
+			for _, snapshots := range m.snapshotsByVM {
+				if s, ok := snapshots[*layer.snapshotID]; ok {
+					delete(s.disks, diskID)
+				}
+			}
+		}
+		m.removeDiskImageLayer(diskID, i)
+		return nil
+	}
+	return newError(ENotFound, "snapshot with ID %s not found for disk %s", id, diskID)
+}
@@ -0,0 +1,99 @@
+package ovirtclient_test
+
+import (
+	"testing"
+
+	ovirtclient "github.com/dyudin0821/go-ovirt-client/v3"
+)
+
+func TestDiskImageChain(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+
+	vm := assertCanCreateVM(t, helper, helper.GenerateTestResourceName(t), nil)
+	disk := assertCanCreateDisk(t, helper)
+	assertCanUploadDiskImage(t, helper, disk)
+	assertCanAttachDisk(t, vm, disk)
+
+	if chain := assertCanGetDiskImageChain(t, disk); len(chain) != 1 {
+		t.Fatalf("Incorrect number of layers before taking a snapshot: %d instead of 1.", len(chain))
+	}
+
+	snapshot := assertCanCreateSnapshot(t, vm, "disk snapshot test", nil)
+	chain := assertCanGetDiskImageChain(t, disk)
+	if len(chain) != 2 {
+		t.Fatalf("Incorrect number of layers after taking a snapshot: %d instead of 2.", len(chain))
+	}
+	base := chain[0]
+	if base.ParentID() != nil {
+		t.Fatalf("The base layer %s has a parent (%s).", base.ID(), *base.ParentID())
+	}
+	if base.SnapshotID() == nil || *base.SnapshotID() != snapshot.ID() {
+		t.Fatalf("The base layer %s does not belong to snapshot %s.", base.ID(), snapshot.ID())
+	}
+	if base.ActualSize() == 0 {
+		t.Fatalf("The base layer %s containing the uploaded image has no actual size.", base.ID())
+	}
+	if parentID := chain[1].ParentID(); parentID == nil || *parentID != base.ID() {
+		t.Fatalf("The active layer %s is not based on the base layer %s.", chain[1].ID(), base.ID())
+	}
+
+	fetchedLayer, err := helper.GetClient().GetDiskSnapshot(disk.ID(), base.ID())
+	if err != nil {
+		t.Fatalf("Failed to fetch disk snapshot %s. (%v)", base.ID(), err)
+	}
+	if fetchedLayer.DiskID() != disk.ID() {
+		t.Fatalf("Incorrect disk ID on disk snapshot: %s instead of %s.", fetchedLayer.DiskID(), disk.ID())
+	}
+	assertStorageDomainHasDiskSnapshot(t, helper, base.ID())
+
+	if err := chain[1].Remove(); err == nil {
+		t.Fatalf("Removing the active layer of disk %s did not result in an error.", disk.ID())
+	}
+	if err := base.Remove(); err != nil {
+		t.Fatalf("Failed to remove disk snapshot %s. (%v)", base.ID(), err)
+	}
+	chain = assertCanGetDiskImageChain(t, disk)
+	if len(chain) != 1 {
+		t.Fatalf("Incorrect number of layers after removing a snapshot: %d instead of 1.", len(chain))
+	}
+	if chain[0].ParentID() != nil {
+		t.Fatalf("The remaining layer %s still has a parent (%s).", chain[0].ID(), *chain[0].ParentID())
+	}
+}
+
+func TestGetNonExistentDiskSnapshot(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+
+	disk := assertCanCreateDisk(t, helper)
+	_, err := helper.GetClient().GetDiskSnapshot(disk.ID(), ovirtclient.DiskSnapshotID(helper.GenerateRandomID(5)))
+	if !ovirtclient.HasErrorCode(err, ovirtclient.ENotFound) {
+		t.Fatalf("Fetching a non-existent disk snapshot did not result in an ENotFound error. (%v)", err)
+	}
+}
+
+func assertCanGetDiskImageChain(t *testing.T, disk ovirtclient.Disk) []ovirtclient.DiskSnapshot {
+	chain, err := disk.ImageChain()
+	if err != nil {
+		t.Fatalf("Failed to get image chain of disk %s. (%v)", disk.ID(), err)
+	}
+	return chain
+}
+
+func assertStorageDomainHasDiskSnapshot(t *testing.T, helper ovirtclient.TestHelper, id ovirtclient.DiskSnapshotID) {
+	storageDomain, err := helper.GetClient().GetStorageDomain(helper.GetStorageDomainID())
+	if err != nil {
+		t.Fatalf("Failed to fetch storage domain %s. (%v)", helper.GetStorageDomainID(), err)
+	}
+	layers, err := storageDomain.ListDiskSnapshots()
+	if err != nil {
+		t.Fatalf("Failed to list disk snapshots of storage domain %s. (%v)", storageDomain.ID(), err)
+	}
+	for _, layer := range layers {
+		if layer.ID() == id {
+			return
+		}
+	}
+	t.Fatalf("Disk snapshot %s not found on storage domain %s.", id, storageDomain.ID())
+}
@@ -96,6 +96,10 @@ The cluster to use for testing. Will be automatically chosen if not provided.
 
 ID of the blank template. Will be automatically chosen if not provided.
 
+  OVIRT_VM_POOL_TEMPLATE_ID
+
+Template to create VM pools from. The blank template is used if not provided.
+
   OVIRT_STORAGE_DOMAIN_ID
 
 Storage domain to use for testing. Will be automatically chosen if not provided.
@@ -87,9 +87,13 @@ const ELocalIO ErrorCode = "local_io_error"
 // conflicting way. For example, you tried to attach a disk that is already attached.
 const EConflict ErrorCode = "conflict"
 
-// EHotPlugFailed indicates that a disk could not be hot plugged.
+// EHotPlugFailed indicates that a disk could not be hot plugged or hot unplugged.
 const EHotPlugFailed ErrorCode = "hot_plug_failed"
 
+// EChecksumMismatch indicates that the checksum of transferred image data did not match the expected checksum or the
+// checksum calculated by the server. This usually means the data was truncated or corrupted during the transfer.
+const EChecksumMismatch ErrorCode = "checksum_mismatch"
+
 // EInvalidGrant is an error returned from the oVirt Engine when the SSO token expired. In this case we must reconnect
 // and retry the API call.
 const EInvalidGrant ErrorCode = "invalid_grant"
@@ -137,6 +141,8 @@ func (e ErrorCode) CanAutoRetry() bool {
 		return false
 	case ECannotRunVM:
 		return false
+	case EChecksumMismatch:
+		return false
 	default:
 		return true
 	}
@@ -313,8 +319,12 @@ func realIdentify(err error) EngineError {
 		return wrap(err, EDiskLocked, "the disk is locked")
 	case strings.Contains(err.Error(), "VM is locked"):
 		return wrap(err, EVMLocked, "the VM is locked")
-	case strings.Contains(err.Error(), "Failed to hot-plug disk"):
+	case strings.Contains(err.Error(), "Failed to hot-plug disk"),
+		strings.Contains(err.Error(), "Failed to HotPlugDiskVDS"):
 		return wrap(err, EHotPlugFailed, "failed to hot-plug disk")
+	case strings.Contains(err.Error(), "Failed to hot-unplug disk"),
+		strings.Contains(err.Error(), "Failed to HotUnPlugDiskVDS"):
+		return wrap(err, EHotPlugFailed, "failed to hot-unplug disk")
 	case strings.Contains(err.Error(), "Related operation is currently in progress."):
 		return wrap(err, ERelatedOperationInProgress, "a related operation is in progress")
 	case strings.Contains(err.Error(), "Disk configuration") && strings.Contains(err.Error(), " is incompatible with the storage domain type."):
@@ -4,8 +4,10 @@ go 1.20
 
 require (
 	github.com/google/uuid v1.3.0
+	github.com/klauspost/compress v1.17.0
 	github.com/ovirt/go-ovirt v0.0.0-20220427092237-114c47f2835c
 	github.com/ovirt/go-ovirt-client-log/v3 v3.0.0
+	github.com/ulikunitz/xz v0.5.15
 )
 
 require github.com/stretchr/testify v1.7.0 // indirect
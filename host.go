@@ -13,6 +13,79 @@ type HostID string
 type HostClient interface {
 	ListHosts(retries ...RetryStrategy) ([]Host, error)
 	GetHost(id HostID, retries ...RetryStrategy) (Host, error)
+
+	// DiscoverISCSITargets uses the specified host to discover the iSCSI targets offered by the portal at
+	// address:port. Pass 0 as the port to use the default iSCSI port.
+	DiscoverISCSITargets(hostID HostID, address string, port uint, retries ...RetryStrategy) ([]ISCSITarget, error)
+	// ISCSILogin logs the specified host in to an iSCSI target, making the LUNs of the target visible to the host.
+	// The params may be nil if the target does not require authentication.
+	ISCSILogin(hostID HostID, target ISCSITarget, params ISCSILoginParameters, retries ...RetryStrategy) error
+	// ListHostLUNs lists the iSCSI and Fibre Channel LUNs visible to the specified host, including their usage
+	// status. Use it to find free LUNs for block storage domains and direct LUN disks.
+	ListHostLUNs(hostID HostID, retries ...RetryStrategy) ([]LUN, error)
+}
+
+// ISCSITarget describes an iSCSI target offered by an iSCSI portal.
+type ISCSITarget struct {
+	// Address is the address of the portal offering the target.
+	Address string
+	// Port is the port of the portal offering the target.
+	Port uint
+	// Target is the iSCSI qualified name (IQN) of the target.
+	Target string
+}
+
+// ISCSILoginParameters contains the optional parameters for logging in to an iSCSI target.
+type ISCSILoginParameters interface {
+	// Username is the CHAP user name for the target.
+	Username() string
+	// Password is the CHAP password for the target.
+	Password() string
+}
+
+// BuildableISCSILoginParameters is a buildable version of ISCSILoginParameters.
+type BuildableISCSILoginParameters interface {
+	ISCSILoginParameters
+
+	// WithCredentials sets the CHAP credentials for the target.
+	WithCredentials(username string, password string) (BuildableISCSILoginParameters, error)
+	// MustWithCredentials is identical to WithCredentials, but panics instead of returning an error.
+	MustWithCredentials(username string, password string) BuildableISCSILoginParameters
+}
+
+// ISCSILoginParams creates a buildable set of parameters for HostClient.ISCSILogin.
+func ISCSILoginParams() BuildableISCSILoginParameters {
+	return &iscsiLoginParams{}
+}
+
+type iscsiLoginParams struct {
+	username string
+	password string
+}
+
+func (i *iscsiLoginParams) Username() string {
+	return i.username
+}
+
+func (i *iscsiLoginParams) Password() string {
+	return i.password
+}
+
+func (i *iscsiLoginParams) WithCredentials(username string, password string) (BuildableISCSILoginParameters, error) {
+	if username == "" {
+		return nil, newError(EBadArgument, "the user name must not be empty")
+	}
+	i.username = username
+	i.password = password
+	return i, nil
+}
+
+func (i *iscsiLoginParams) MustWithCredentials(username string, password string) BuildableISCSILoginParameters {
+	builder, err := i.WithCredentials(username, password)
+	if err != nil {
+		panic(err)
+	}
+	return builder
 }
 
 // HostData is the core of Host, providing only data access functions.
@@ -32,6 +105,13 @@ type HostData interface {
 // See https://www.ovirt.org/documentation/administration_guide/#chap-Hosts for details.
 type Host interface {
 	HostData
+
+	// DiscoverISCSITargets discovers the iSCSI targets offered by the portal at address:port using this host.
+	DiscoverISCSITargets(address string, port uint, retries ...RetryStrategy) ([]ISCSITarget, error)
+	// ISCSILogin logs this host in to an iSCSI target.
+	ISCSILogin(target ISCSITarget, params ISCSILoginParameters, retries ...RetryStrategy) error
+	// ListLUNs lists the LUNs visible to this host.
+	ListLUNs(retries ...RetryStrategy) ([]LUN, error)
 }
 
 // HostStatus represents the complex states an oVirt host can be in.
@@ -157,3 +237,15 @@ func (h host) ClusterID() ClusterID {
 func (h host) Status() HostStatus {
 	return h.status
 }
+
+func (h host) DiscoverISCSITargets(address string, port uint, retries ...RetryStrategy) ([]ISCSITarget, error) {
+	return h.client.DiscoverISCSITargets(h.id, address, port, retries...)
+}
+
+func (h host) ISCSILogin(target ISCSITarget, params ISCSILoginParameters, retries ...RetryStrategy) error {
+	return h.client.ISCSILogin(h.id, target, params, retries...)
+}
+
+func (h host) ListLUNs(retries ...RetryStrategy) ([]LUN, error) {
+	return h.client.ListHostLUNs(h.id, retries...)
+}
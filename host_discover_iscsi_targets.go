@@ -0,0 +1,120 @@
+package ovirtclient
+
+import (
+	"fmt"
+	"sort"
+
+	ovirtsdk4 "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) DiscoverISCSITargets(
+	hostID HostID,
+	address string,
+	port uint,
+	retries ...RetryStrategy,
+) (result []ISCSITarget, err error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(o))
+	if err := validateISCSIPortal(hostID, address); err != nil {
+		return nil, err
+	}
+	iscsiDetailsBuilder := ovirtsdk4.NewIscsiDetailsBuilder().Address(address)
+	if port != 0 {
+		iscsiDetailsBuilder.Port(int64(port)) //nolint:gosec
+	}
+	iscsiDetails, err := iscsiDetailsBuilder.Build()
+	if err != nil {
+		return nil, wrap(err, EBug, "failed to build iSCSI details")
+	}
+	err = retry(
+		fmt.Sprintf("discovering iSCSI targets on %s using host %s", address, hostID),
+		o.logger,
+		retries,
+		func() error {
+			response, err := o.conn.
+				SystemService().
+				HostsService().
+				HostService(string(hostID)).
+				DiscoverIscsi().
+				Iscsi(iscsiDetails).
+				Send()
+			if err != nil {
+				return wrap(err, EUnidentified, "failed to discover iSCSI targets on %s using host %s", address, hostID)
+			}
+			result = nil
+			discoveredTargets, ok := response.DiscoveredTargets()
+			if !ok {
+				return nil
+			}
+			for _, discoveredTarget := range discoveredTargets.Slice() {
+				target, err := convertSDKIscsiDetails(discoveredTarget)
+				if err != nil {
+					return err
+				}
+				result = append(result, target)
+			}
+			return nil
+		},
+	)
+	return result, err
+}
+
+func validateISCSIPortal(hostID HostID, address string) error {
+	if hostID == "" {
+		return newError(EBadArgument, "the host ID is required to access an iSCSI portal")
+	}
+	if address == "" {
+		return newError(EBadArgument, "the address of the iSCSI portal is required")
+	}
+	return nil
+}
+
+func convertSDKIscsiDetails(sdkIscsiDetails *ovirtsdk4.IscsiDetails) (ISCSITarget, error) {
+	target, ok := sdkIscsiDetails.Target()
+	if !ok {
+		return ISCSITarget{}, newFieldNotFound("discovered iSCSI target", "target")
+	}
+	result := ISCSITarget{
+		Target: target,
+	}
+	if address, ok := sdkIscsiDetails.Address(); ok {
+		result.Address = address
+	}
+	if port, ok := sdkIscsiDetails.Port(); ok {
+		result.Port = uint(port) //nolint:gosec
+	}
+	return result, nil
+}
+
+func (m *mockClient) DiscoverISCSITargets(
+	hostID HostID,
+	address string,
+	port uint,
+	_ ...RetryStrategy,
+) ([]ISCSITarget, error) {
+	if err := validateISCSIPortal(hostID, address); err != nil {
+		return nil, err
+	}
+	if port == 0 {
+		port = mockISCSIPort
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.hosts[hostID]; !ok {
+		return nil, newError(ENotFound, "host with ID %s not found", hostID)
+	}
+	var result []ISCSITarget
+	for _, target := range m.iscsiTargets {
+		if target.Address == address && target.Port == port {
+			result = append(result, target.ISCSITarget)
+		}
+	}
+	if len(result) == 0 {
+		return nil, newError(ENotFound, "no iSCSI portal found at %s:%d", address, port)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Target < result[j].Target
+	})
+	return result, nil
+}
@@ -0,0 +1,95 @@
+package ovirtclient
+
+import (
+	"fmt"
+
+	ovirtsdk4 "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) ISCSILogin(
+	hostID HostID,
+	target ISCSITarget,
+	params ISCSILoginParameters,
+	retries ...RetryStrategy,
+) error {
+	retries = defaultRetries(retries, defaultLongTimeouts(o))
+	if err := validateISCSILoginParameters(hostID, target); err != nil {
+		return err
+	}
+	iscsiDetailsBuilder := ovirtsdk4.NewIscsiDetailsBuilder().
+		Address(target.Address).
+		Target(target.Target)
+	if target.Port != 0 {
+		iscsiDetailsBuilder.Port(int64(target.Port)) //nolint:gosec
+	}
+	if params != nil && params.Username() != "" {
+		iscsiDetailsBuilder.Username(params.Username()).Password(params.Password())
+	}
+	iscsiDetails, err := iscsiDetailsBuilder.Build()
+	if err != nil {
+		return wrap(err, EBug, "failed to build iSCSI details")
+	}
+	return retry(
+		fmt.Sprintf("logging host %s in to iSCSI target %s", hostID, target.Target),
+		o.logger,
+		retries,
+		func() error {
+			_, err := o.conn.
+				SystemService().
+				HostsService().
+				HostService(string(hostID)).
+				IscsiLogin().
+				Iscsi(iscsiDetails).
+				Send()
+			if err != nil {
+				return wrap(err, EUnidentified, "failed to log host %s in to iSCSI target %s", hostID, target.Target)
+			}
+			return nil
+		},
+	)
+}
+
+func validateISCSILoginParameters(hostID HostID, target ISCSITarget) error {
+	if err := validateISCSIPortal(hostID, target.Address); err != nil {
+		return err
+	}
+	if target.Target == "" {
+		return newError(EBadArgument, "the iSCSI target name is required to log in")
+	}
+	return nil
+}
+
+func (m *mockClient) ISCSILogin(
+	hostID HostID,
+	target ISCSITarget,
+	params ISCSILoginParameters,
+	_ ...RetryStrategy,
+) error {
+	if err := validateISCSILoginParameters(hostID, target); err != nil {
+		return err
+	}
+	if target.Port == 0 {
+		target.Port = mockISCSIPort
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.hosts[hostID]; !ok {
+		return newError(ENotFound, "host with ID %s not found", hostID)
+	}
+	mockTarget, ok := m.iscsiTargets[target.Target]
+	if !ok || mockTarget.Address != target.Address || mockTarget.Port != target.Port {
+		return newError(ENotFound, "iSCSI target %s not found at %s:%d", target.Target, target.Address, target.Port)
+	}
+	if mockTarget.username != "" {
+		if params == nil || params.Username() != mockTarget.username || params.Password() != mockTarget.password {
+			return newError(EAccessDenied, "authentication to iSCSI target %s failed", target.Target)
+		}
+	}
+	if _, ok := m.hostISCSISessions[hostID]; !ok {
+		m.hostISCSISessions[hostID] = map[string]struct{}{}
+	}
+	m.hostISCSISessions[hostID][target.Target] = struct{}{}
+	return nil
+}
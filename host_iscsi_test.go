@@ -0,0 +1,173 @@
+package ovirtclient_test
+
+import (
+	"testing"
+
+	ovirtclient "github.com/dyudin0821/go-ovirt-client/v3"
+)
+
+func TestISCSIDiscoveryAndLogin(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+	if _, ok := client.(ovirtclient.MockClient); !ok {
+		t.Skipf("The test environment has no known iSCSI portal, skipping.")
+	}
+
+	hostID := assertCanFindOVAHost(t, helper)
+	lunsBefore, err := client.ListHostLUNs(hostID)
+	if err != nil {
+		t.Fatalf("Failed to list LUNs of host %s. (%v)", hostID, err)
+	}
+
+	targets, err := client.DiscoverISCSITargets(hostID, "iscsi.example.com", 0)
+	if err != nil {
+		t.Fatalf("Failed to discover iSCSI targets. (%v)", err)
+	}
+	if len(targets) != 2 {
+		t.Fatalf("Incorrect number of iSCSI targets discovered: %d instead of 2.", len(targets))
+	}
+	for _, target := range targets {
+		if target.Address != "iscsi.example.com" || target.Port != 3260 || target.Target == "" {
+			t.Fatalf("Incorrect iSCSI target discovered: %v.", target)
+		}
+	}
+	secureTarget := ovirtclient.ISCSITarget{
+		Address: "iscsi.example.com",
+		Port:    3260,
+		Target:  "iqn.2022-01.com.example:secure",
+	}
+	if err := client.ISCSILogin(hostID, secureTarget, nil); !ovirtclient.HasErrorCode(err, ovirtclient.EAccessDenied) {
+		t.Fatalf("Logging in to an iSCSI target without credentials did not result in an EAccessDenied error. (%v)", err)
+	}
+	for _, target := range targets {
+		if err := client.ISCSILogin(
+			hostID,
+			target,
+			ovirtclient.ISCSILoginParams().MustWithCredentials("test", "test"),
+		); err != nil {
+			t.Fatalf("Failed to log in to iSCSI target %s. (%v)", target.Target, err)
+		}
+	}
+
+	lunsAfter, err := client.ListHostLUNs(hostID)
+	if err != nil {
+		t.Fatalf("Failed to list LUNs of host %s. (%v)", hostID, err)
+	}
+	if len(lunsAfter) <= len(lunsBefore) {
+		t.Fatalf("No new LUNs visible after logging in to the iSCSI targets.")
+	}
+	for _, lun := range lunsAfter {
+		if lun.StorageType() != ovirtclient.LUNStorageTypeISCSI {
+			continue
+		}
+		if lun.Target() == "" || lun.Address() != "iscsi.example.com" {
+			t.Fatalf("Incorrect iSCSI details on LUN %s: %s at %s.", lun.ID(), lun.Target(), lun.Address())
+		}
+		if lun.Status() != ovirtclient.LUNStatusFree {
+			t.Fatalf("Incorrect status on LUN %s: %s.", lun.ID(), lun.Status())
+		}
+	}
+}
+
+func TestDiscoverISCSITargetsInvalidParameters(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+
+	_, err := client.DiscoverISCSITargets("", "iscsi.example.com", 0)
+	if !ovirtclient.HasErrorCode(err, ovirtclient.EBadArgument) {
+		t.Fatalf("Discovering iSCSI targets without a host ID did not result in an EBadArgument error. (%v)", err)
+	}
+	err = client.ISCSILogin("host-id", ovirtclient.ISCSITarget{Address: "iscsi.example.com"}, nil)
+	if !ovirtclient.HasErrorCode(err, ovirtclient.EBadArgument) {
+		t.Fatalf("Logging in to an iSCSI target without a name did not result in an EBadArgument error. (%v)", err)
+	}
+}
+
+func TestListHostLUNs(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+
+	hostID := assertCanFindOVAHost(t, helper)
+	luns, err := client.ListHostLUNs(hostID)
+	if err != nil {
+		t.Fatalf("Failed to list LUNs of host %s. (%v)", hostID, err)
+	}
+	for _, lun := range luns {
+		if lun.ID() == "" {
+			t.Fatalf("LUN without an ID returned for host %s.", hostID)
+		}
+		if err := lun.StorageType().Validate(); err != nil {
+			t.Fatalf("Invalid storage type on LUN %s. (%v)", lun.ID(), err)
+		}
+		if err := lun.Status().Validate(); err != nil {
+			t.Fatalf("Invalid status on LUN %s. (%v)", lun.ID(), err)
+		}
+	}
+}
+
+func TestLUNStatusChangesWhenUsed(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+	if _, ok := client.(ovirtclient.MockClient); !ok {
+		t.Skipf("Direct LUN disks require an unused LUN visible to the test host, skipping.")
+	}
+
+	hostID := assertCanFindOVAHost(t, helper)
+	freeLUN := assertCanFindFreeLUN(t, client, hostID)
+	disk, err := client.CreateLUNDisk(hostID, freeLUN.ID(), freeLUN.StorageType(), nil)
+	if err != nil {
+		t.Fatalf("Failed to create LUN disk for LUN %s. (%v)", freeLUN.ID(), err)
+	}
+	usedLUN := assertCanFindHostLUN(t, client, hostID, freeLUN.ID())
+	if usedLUN.Status() != ovirtclient.LUNStatusUsed {
+		t.Fatalf("Incorrect status on LUN %s used by disk %s: %s.", usedLUN.ID(), disk.ID(), usedLUN.Status())
+	}
+	if usedLUN.DiskID() != disk.ID() {
+		t.Fatalf("Incorrect disk ID on LUN %s: %s instead of %s.", usedLUN.ID(), usedLUN.DiskID(), disk.ID())
+	}
+
+	if err := disk.Remove(); err != nil {
+		t.Fatalf("Failed to remove LUN disk %s. (%v)", disk.ID(), err)
+	}
+	releasedLUN := assertCanFindHostLUN(t, client, hostID, freeLUN.ID())
+	if releasedLUN.Status() != ovirtclient.LUNStatusFree {
+		t.Fatalf("Incorrect status on LUN %s after removing disk %s: %s.", releasedLUN.ID(), disk.ID(), releasedLUN.Status())
+	}
+}
+
+func assertCanFindFreeLUN(t *testing.T, client ovirtclient.Client, hostID ovirtclient.HostID) ovirtclient.LUN {
+	luns, err := client.ListHostLUNs(hostID)
+	if err != nil {
+		t.Fatalf("Failed to list LUNs of host %s. (%v)", hostID, err)
+	}
+	for _, lun := range luns {
+		if lun.Status() == ovirtclient.LUNStatusFree {
+			return lun
+		}
+	}
+	t.Skipf("No free LUN visible to host %s, skipping.", hostID)
+	return nil
+}
+
+func assertCanFindHostLUN(
+	t *testing.T,
+	client ovirtclient.Client,
+	hostID ovirtclient.HostID,
+	lunID string,
+) ovirtclient.LUN {
+	luns, err := client.ListHostLUNs(hostID)
+	if err != nil {
+		t.Fatalf("Failed to list LUNs of host %s. (%v)", hostID, err)
+	}
+	for _, lun := range luns {
+		if lun.ID() == lunID {
+			return lun
+		}
+	}
+	t.Fatalf("LUN %s is not visible to host %s.", lunID, hostID)
+	return nil
+}
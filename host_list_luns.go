@@ -0,0 +1,75 @@
+package ovirtclient
+
+import (
+	"fmt"
+	"sort"
+)
+
+func (o *oVirtClient) ListHostLUNs(hostID HostID, retries ...RetryStrategy) (result []LUN, err error) {
+	retries = defaultRetries(retries, defaultReadTimeouts(o))
+	err = retry(
+		fmt.Sprintf("listing LUNs of host %s", hostID),
+		o.logger,
+		retries,
+		func() error {
+			response, err := o.conn.
+				SystemService().
+				HostsService().
+				HostService(string(hostID)).
+				StorageService().
+				List().
+				ReportStatus(true).
+				Send()
+			if err != nil {
+				return wrap(err, EUnidentified, "failed to list LUNs of host %s", hostID)
+			}
+			sdkHostStorages, ok := response.Storages()
+			if !ok {
+				return nil
+			}
+			result = nil
+			for _, sdkHostStorage := range sdkHostStorages.Slice() {
+				storageType, ok := sdkHostStorage.Type()
+				if !ok {
+					return newFieldNotFound("host storage", "type")
+				}
+				lunStorageType := LUNStorageType(storageType)
+				if lunStorageType.Validate() != nil {
+					continue
+				}
+				sdkLogicalUnits, ok := sdkHostStorage.LogicalUnits()
+				if !ok {
+					continue
+				}
+				for _, sdkLogicalUnit := range sdkLogicalUnits.Slice() {
+					l, err := convertSDKLogicalUnit(sdkLogicalUnit, lunStorageType)
+					if err != nil {
+						return wrap(err, EBug, "failed to convert LUN of host %s", hostID)
+					}
+					result = append(result, l)
+				}
+			}
+			return nil
+		},
+	)
+	return result, err
+}
+
+func (m *mockClient) ListHostLUNs(hostID HostID, _ ...RetryStrategy) ([]LUN, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.hosts[hostID]; !ok {
+		return nil, newError(ENotFound, "host with ID %s not found", hostID)
+	}
+	var result []LUN
+	for _, l := range m.luns {
+		if m.isLUNVisibleToHost(hostID, l) {
+			result = append(result, m.getLUNWithStatus(l))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID() < result[j].ID()
+	})
+	return result, nil
+}
@@ -0,0 +1,445 @@
+package ovirtclient
+
+import (
+	"bytes"
+	"io"
+	"strings"
+)
+
+// ImageInfo describes a disk image file examined by InspectImage.
+type ImageInfo interface {
+	// Format returns the format of the image. This is ImageFormatCow for QCOW2 images and ImageFormatRaw for all
+	// other images.
+	Format() ImageFormat
+	// FileSize returns the size of the image file in bytes.
+	FileSize() uint64
+	// VirtualSize returns the size of the disk represented by the image in bytes. For raw images this is the file
+	// size.
+	VirtualSize() uint64
+	// QCOW returns the details of the QCOW2 header. It is nil for raw images.
+	QCOW() QCOWImageInfo
+	// ContentType returns the content type of a disk created for the image. This is DiskContentTypeISO for ISO9660
+	// images and DiskContentTypeData for all other images.
+	ContentType() DiskContentType
+}
+
+// QCOWImageInfo contains the fields of a QCOW2 image header.
+type QCOWImageInfo interface {
+	// Version returns the QCOW version, 2 or 3.
+	Version() uint32
+	// ClusterSize returns the size of a cluster in bytes.
+	ClusterSize() uint64
+	// BackingFile returns the name of the backing file, or an empty string if the image has no backing file.
+	BackingFile() string
+	// EncryptionMethod returns the method used to encrypt the image.
+	EncryptionMethod() QCOWEncryptionMethod
+	// CompressionType returns the algorithm used for compressed clusters.
+	CompressionType() QCOWCompressionType
+	// IncompatibleFeatures returns the incompatible feature bits of the header. This is always 0 for version 2.
+	IncompatibleFeatures() uint64
+	// CompatibleFeatures returns the compatible feature bits of the header. This is always 0 for version 2.
+	CompatibleFeatures() uint64
+	// AutoclearFeatures returns the autoclear feature bits of the header. This is always 0 for version 2.
+	AutoclearFeatures() uint64
+	// Snapshots returns the number of internal snapshots in the image.
+	Snapshots() uint32
+	// L1Size returns the number of entries in the L1 table.
+	L1Size() uint32
+	// L1TableOffset returns the offset of the L1 table in the image file.
+	L1TableOffset() uint64
+}
+
+// QCOWEncryptionMethod is the encryption method of a QCOW2 image.
+type QCOWEncryptionMethod string
+
+const (
+	// QCOWEncryptionMethodNone means that the image is not encrypted.
+	QCOWEncryptionMethodNone QCOWEncryptionMethod = "none"
+	// QCOWEncryptionMethodAES means that the image is encrypted using the legacy AES method.
+	QCOWEncryptionMethodAES QCOWEncryptionMethod = "aes"
+	// QCOWEncryptionMethodLUKS means that the image is encrypted using LUKS.
+	QCOWEncryptionMethodLUKS QCOWEncryptionMethod = "luks"
+)
+
+// QCOWEncryptionMethodList is a list of QCOWEncryptionMethod.
+type QCOWEncryptionMethodList []QCOWEncryptionMethod
+
+// QCOWEncryptionMethodValues returns all possible QCOWEncryptionMethod values.
+func QCOWEncryptionMethodValues() QCOWEncryptionMethodList {
+	return []QCOWEncryptionMethod{
+		QCOWEncryptionMethodNone,
+		QCOWEncryptionMethodAES,
+		QCOWEncryptionMethodLUKS,
+	}
+}
+
+// Strings creates a string list of the values.
+func (l QCOWEncryptionMethodList) Strings() []string {
+	result := make([]string, len(l))
+	for i, method := range l {
+		result[i] = string(method)
+	}
+	return result
+}
+
+// Validate returns an error if the encryption method is not valid.
+func (m QCOWEncryptionMethod) Validate() error {
+	for _, method := range QCOWEncryptionMethodValues() {
+		if method == m {
+			return nil
+		}
+	}
+	return newError(
+		EBadArgument,
+		"invalid QCOW encryption method: %s must be one of: %s",
+		m,
+		strings.Join(QCOWEncryptionMethodValues().Strings(), ", "),
+	)
+}
+
+// QCOWCompressionType is the algorithm used for compressed clusters in a QCOW2 image.
+type QCOWCompressionType string
+
+const (
+	// QCOWCompressionTypeZlib is the default deflate compression.
+	QCOWCompressionTypeZlib QCOWCompressionType = "zlib"
+	// QCOWCompressionTypeZstd is Zstandard compression.
+	QCOWCompressionTypeZstd QCOWCompressionType = "zstd"
+)
+
+// QCOWCompressionTypeList is a list of QCOWCompressionType.
+type QCOWCompressionTypeList []QCOWCompressionType
+
+// QCOWCompressionTypeValues returns all possible QCOWCompressionType values.
+func QCOWCompressionTypeValues() QCOWCompressionTypeList {
+	return []QCOWCompressionType{
+		QCOWCompressionTypeZlib,
+		QCOWCompressionTypeZstd,
+	}
+}
+
+// Strings creates a string list of the values.
+func (l QCOWCompressionTypeList) Strings() []string {
+	result := make([]string, len(l))
+	for i, compressionType := range l {
+		result[i] = string(compressionType)
+	}
+	return result
+}
+
+// Validate returns an error if the compression type is not valid.
+func (c QCOWCompressionType) Validate() error {
+	for _, compressionType := range QCOWCompressionTypeValues() {
+		if compressionType == c {
+			return nil
+		}
+	}
+	return newError(
+		EBadArgument,
+		"invalid QCOW compression type: %s must be one of: %s",
+		c,
+		strings.Join(QCOWCompressionTypeValues().Strings(), ", "),
+	)
+}
+
+// ImageCompression is the compression of an image stream uploaded using the stream upload functions.
+type ImageCompression string
+
+const (
+	// ImageCompressionNone means that the stream contains the image without compression.
+	ImageCompressionNone ImageCompression = "none"
+	// ImageCompressionGzip means that the stream is compressed using gzip.
+	ImageCompressionGzip ImageCompression = "gzip"
+	// ImageCompressionXZ means that the stream is compressed using xz.
+	ImageCompressionXZ ImageCompression = "xz"
+	// ImageCompressionZstd means that the stream is compressed using Zstandard.
+	ImageCompressionZstd ImageCompression = "zstd"
+)
+
+// ImageCompressionList is a list of ImageCompression.
+type ImageCompressionList []ImageCompression
+
+// ImageCompressionValues returns all possible ImageCompression values.
+func ImageCompressionValues() ImageCompressionList {
+	return []ImageCompression{
+		ImageCompressionNone,
+		ImageCompressionGzip,
+		ImageCompressionXZ,
+		ImageCompressionZstd,
+	}
+}
+
+// Strings creates a string list of the values.
+func (l ImageCompressionList) Strings() []string {
+	result := make([]string, len(l))
+	for i, compression := range l {
+		result[i] = string(compression)
+	}
+	return result
+}
+
+// Validate returns an error if the compression is not valid.
+func (c ImageCompression) Validate() error {
+	for _, compression := range ImageCompressionValues() {
+		if compression == c {
+			return nil
+		}
+	}
+	return newError(
+		EBadArgument,
+		"invalid image compression: %s must be one of: %s",
+		c,
+		strings.Join(ImageCompressionValues().Strings(), ", "),
+	)
+}
+
+// InspectImage reads the header of a disk image and checks that it can be uploaded to oVirt. QCOW2 images are parsed
+// completely, including the sanity of the L1 table, other images are treated as raw images.
+//
+// The function returns an error with the EBadArgument code for QCOW2 images that have a backing file, are encrypted,
+// are marked dirty or corrupt, or have inconsistent metadata. In this case the ImageInfo is returned alongside the
+// error if the header could be read. VMDK, VHD and VHDX images are rejected with the EUnsupported code, as they need to
+// be converted before uploading. ISO9660 images are raw images with the ISO content type.
+//
+// The reader is positioned at the start of the image when the function returns.
+func InspectImage(reader io.ReadSeeker) (ImageInfo, error) {
+	fileSize, err := reader.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, wrap(err, ELocalIO, "failed to determine the size of the image")
+	}
+	info, err := inspectImage(reader, uint64(fileSize))
+	if _, seekErr := reader.Seek(0, io.SeekStart); seekErr != nil && err == nil {
+		return info, wrap(seekErr, ELocalIO, "failed to seek to the start of the image")
+	}
+	return info, err
+}
+
+// inspectImage detects the format of an image of the specified size and validates QCOW2 images.
+func inspectImage(reader io.ReadSeeker, fileSize uint64) (ImageInfo, error) {
+	if fileSize < qcowHeaderSize {
+		return nil, newError(EBadArgument, "the image is only %d bytes long, which is too small for a disk image", fileSize)
+	}
+	header, err := readImageRange(reader, 0, qcowV3HeaderSize+1, fileSize)
+	if err != nil {
+		return nil, err
+	}
+	if string(header[0:len(qcowMagicBytes)]) == qcowMagicBytes {
+		return inspectQCOWImage(reader, header, fileSize)
+	}
+	if err := checkUnsupportedImageFormat(reader, header, fileSize); err != nil {
+		return nil, err
+	}
+	contentType, err := detectImageContentType(reader, header, fileSize)
+	if err != nil {
+		return nil, err
+	}
+	return &imageInfo{
+		format:      ImageFormatRaw,
+		fileSize:    fileSize,
+		virtualSize: fileSize,
+		contentType: contentType,
+	}, nil
+}
+
+// inspectImageHeader detects the format of an image of the specified size from its first bytes only. This is used for
+// images that cannot be read randomly, so formats that can only be detected from the end of the image, such as fixed
+// size VHD images, are not detected.
+func inspectImageHeader(header []byte, size uint64) (ImageInfo, error) {
+	if size < qcowHeaderSize || len(header) < qcowHeaderSize {
+		return nil, newError(EBadArgument, "the image is only %d bytes long, which is too small for a disk image", size)
+	}
+	if string(header[0:len(qcowMagicBytes)]) == qcowMagicBytes {
+		return inspectQCOWImage(bytes.NewReader(header), header, size)
+	}
+	if err := unsupportedImageFormatError(unsupportedImageFormat(header)); err != nil {
+		return nil, err
+	}
+	contentType := DiskContentTypeData
+	if hasImageMagic(header, isoMagicOffset, isoMagicBytes) {
+		contentType = DiskContentTypeISO
+	}
+	return &imageInfo{
+		format:      ImageFormatRaw,
+		fileSize:    size,
+		virtualSize: size,
+		contentType: contentType,
+	}, nil
+}
+
+// checkUnsupportedImageFormat returns an EUnsupported error if the image is in a format that oVirt cannot use
+// directly.
+func checkUnsupportedImageFormat(reader io.ReadSeeker, header []byte, fileSize uint64) error {
+	format := unsupportedImageFormat(header)
+	if format == "" && fileSize >= vhdFooterSize {
+		// Fixed size VHD images only have a footer at the end of the file.
+		footer, err := readImageRange(reader, fileSize-vhdFooterSize, uint64(len(vhdMagicBytes)), fileSize)
+		if err != nil {
+			return err
+		}
+		if string(footer) == vhdMagicBytes {
+			format = "VHD"
+		}
+	}
+	return unsupportedImageFormatError(format)
+}
+
+// detectImageContentType returns DiskContentTypeISO if the raw image is an ISO9660 image, and DiskContentTypeData
+// otherwise.
+func detectImageContentType(reader io.ReadSeeker, header []byte, fileSize uint64) (DiskContentType, error) {
+	if hasImageMagic(header, isoMagicOffset, isoMagicBytes) {
+		return DiskContentTypeISO, nil
+	}
+	if uint64(len(header)) >= isoMagicOffset+uint64(len(isoMagicBytes)) ||
+		fileSize < isoMagicOffset+uint64(len(isoMagicBytes)) {
+		return DiskContentTypeData, nil
+	}
+	magic, err := readImageRange(reader, isoMagicOffset, uint64(len(isoMagicBytes)), fileSize)
+	if err != nil {
+		return "", err
+	}
+	if string(magic) == isoMagicBytes {
+		return DiskContentTypeISO, nil
+	}
+	return DiskContentTypeData, nil
+}
+
+// unsupportedImageFormat returns the name of the image format if the header belongs to an image format that oVirt
+// cannot use directly, or an empty string otherwise.
+func unsupportedImageFormat(header []byte) string {
+	switch {
+	case hasImageMagic(header, 0, "KDMV"), hasImageMagic(header, 0, "COWD"),
+		hasImageMagic(header, 0, "# Disk DescriptorFile"):
+		return "VMDK"
+	case hasImageMagic(header, 0, "vhdxfile"):
+		return "VHDX"
+	case hasImageMagic(header, 0, vhdMagicBytes):
+		return "VHD"
+	default:
+		return ""
+	}
+}
+
+func unsupportedImageFormatError(format string) error {
+	if format == "" {
+		return nil
+	}
+	return newError(
+		EUnsupported,
+		"unsupported image format: the image is a %s image, please convert it to raw or QCOW2 before uploading",
+		format,
+	)
+}
+
+func hasImageMagic(header []byte, offset int, magic string) bool {
+	return len(header) >= offset+len(magic) && string(header[offset:offset+len(magic)]) == magic
+}
+
+// readImageRange reads a range of an image file, truncated to the size of the file.
+func readImageRange(reader io.ReadSeeker, offset uint64, length uint64, fileSize uint64) ([]byte, error) {
+	if offset >= fileSize {
+		return nil, newError(EBadArgument, "offset %d is beyond the end of the image", offset)
+	}
+	if offset+length > fileSize {
+		length = fileSize - offset
+	}
+	data := make([]byte, length)
+	if _, err := reader.Seek(int64(offset), io.SeekStart); err != nil { //nolint:gosec
+		return nil, wrap(err, ELocalIO, "failed to seek to offset %d of the image", offset)
+	}
+	if _, err := io.ReadFull(reader, data); err != nil {
+		return nil, wrap(err, ELocalIO, "failed to read %d bytes at offset %d of the image", length, offset)
+	}
+	return data, nil
+}
+
+type imageInfo struct {
+	format      ImageFormat
+	fileSize    uint64
+	virtualSize uint64
+	qcow        *qcowImageInfo
+	contentType DiskContentType
+}
+
+func (i *imageInfo) Format() ImageFormat {
+	return i.format
+}
+
+func (i *imageInfo) FileSize() uint64 {
+	return i.fileSize
+}
+
+func (i *imageInfo) VirtualSize() uint64 {
+	return i.virtualSize
+}
+
+func (i *imageInfo) QCOW() QCOWImageInfo {
+	if i.qcow == nil {
+		return nil
+	}
+	return i.qcow
+}
+
+func (i *imageInfo) ContentType() DiskContentType {
+	if i.contentType == "" {
+		return DiskContentTypeData
+	}
+	return i.contentType
+}
+
+type qcowImageInfo struct {
+	version              uint32
+	clusterBits          uint32
+	backingFile          string
+	encryptionMethod     QCOWEncryptionMethod
+	compressionType      QCOWCompressionType
+	incompatibleFeatures uint64
+	compatibleFeatures   uint64
+	autoclearFeatures    uint64
+	snapshots            uint32
+	l1Size               uint32
+	l1TableOffset        uint64
+}
+
+func (q *qcowImageInfo) Version() uint32 {
+	return q.version
+}
+
+func (q *qcowImageInfo) ClusterSize() uint64 {
+	return uint64(1) << q.clusterBits
+}
+
+func (q *qcowImageInfo) BackingFile() string {
+	return q.backingFile
+}
+
+func (q *qcowImageInfo) EncryptionMethod() QCOWEncryptionMethod {
+	return q.encryptionMethod
+}
+
+func (q *qcowImageInfo) CompressionType() QCOWCompressionType {
+	return q.compressionType
+}
+
+func (q *qcowImageInfo) IncompatibleFeatures() uint64 {
+	return q.incompatibleFeatures
+}
+
+func (q *qcowImageInfo) CompatibleFeatures() uint64 {
+	return q.compatibleFeatures
+}
+
+func (q *qcowImageInfo) AutoclearFeatures() uint64 {
+	return q.autoclearFeatures
+}
+
+func (q *qcowImageInfo) Snapshots() uint32 {
+	return q.snapshots
+}
+
+func (q *qcowImageInfo) L1Size() uint32 {
+	return q.l1Size
+}
+
+func (q *qcowImageInfo) L1TableOffset() uint64 {
+	return q.l1TableOffset
+}
@@ -0,0 +1,242 @@
+package ovirtclient
+
+import (
+	"io"
+	"sync"
+)
+
+// ConvertedImage is a read-only view of a disk image in a different format. The contents are produced on the fly while
+// reading, so the converted image is never stored on the local disk. It is safe to read a converted image
+// concurrently using ReadAt.
+type ConvertedImage interface {
+	io.ReadSeeker
+	io.ReaderAt
+
+	// Format returns the format of the converted image.
+	Format() ImageFormat
+	// Size returns the size of the converted image in bytes.
+	Size() uint64
+	// VirtualSize returns the size of the disk represented by the image in bytes.
+	VirtualSize() uint64
+}
+
+// ConvertImage presents the image in the reader in the specified format without requiring qemu-img. Raw images are
+// converted to sparse QCOW2 version 3 images and QCOW2 images are converted to raw images.
+//
+// Converting a raw image reads the whole image once to find the clusters that only contain zeroes, so that the size of
+// the converted image is known in advance. Converting a QCOW2 image reads its metadata. Compressed clusters are
+// supported if they use zlib compression. The image is validated using InspectImage before conversion.
+//
+// The reader must not be used by the caller while the converted image is in use. If the image already has the
+// requested format, it is returned unchanged.
+func ConvertImage(reader io.ReadSeeker, format ImageFormat) (ConvertedImage, error) {
+	if err := format.Validate(); err != nil {
+		return nil, err
+	}
+	info, err := InspectImage(reader)
+	if err != nil {
+		return nil, err
+	}
+	return convertImage(reader, info, format)
+}
+
+func convertImage(reader io.ReadSeeker, info ImageInfo, format ImageFormat) (ConvertedImage, error) {
+	source := newImageSourceReader(reader)
+	var converter imageConverter
+	var err error
+	switch {
+	case info.Format() == format:
+		converter = &passthroughImageConverter{
+			source: source,
+			info:   info,
+		}
+	case format == ImageFormatCow:
+		converter, err = newRawToQCOWImageConverter(source, info.VirtualSize())
+	default:
+		converter, err = newQCOWToRawImageConverter(source, info)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &convertedImage{
+		lock:      &sync.Mutex{},
+		converter: converter,
+	}, nil
+}
+
+// imageConverter produces the contents of a converted image.
+type imageConverter interface {
+	// readAt fills p with the contents of the converted image starting at offset. The caller makes sure that the read
+	// does not extend beyond the end of the converted image.
+	readAt(p []byte, offset uint64) error
+	format() ImageFormat
+	size() uint64
+	virtualSize() uint64
+}
+
+// convertedImage implements the reader interfaces on top of an imageConverter.
+type convertedImage struct {
+	lock      *sync.Mutex
+	converter imageConverter
+	position  int64
+}
+
+func (c *convertedImage) Format() ImageFormat {
+	return c.converter.format()
+}
+
+func (c *convertedImage) Size() uint64 {
+	return c.converter.size()
+}
+
+func (c *convertedImage) VirtualSize() uint64 {
+	return c.converter.virtualSize()
+}
+
+func (c *convertedImage) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, newError(EBadArgument, "negative offset %d", off)
+	}
+	size := c.converter.size()
+	if uint64(off) >= size {
+		return 0, io.EOF
+	}
+	n := len(p)
+	if remaining := size - uint64(off); uint64(n) > remaining {
+		n = int(remaining) //nolint:gosec
+	}
+	if err := c.converter.readAt(p[:n], uint64(off)); err != nil {
+		return 0, err
+	}
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+func (c *convertedImage) Read(p []byte) (int, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	n, err := c.ReadAt(p, c.position)
+	c.position += int64(n)
+	if err == io.EOF && n > 0 {
+		err = nil
+	}
+	return n, err
+}
+
+func (c *convertedImage) Seek(offset int64, whence int) (int64, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	var position int64
+	switch whence {
+	case io.SeekStart:
+		position = offset
+	case io.SeekCurrent:
+		position = c.position + offset
+	case io.SeekEnd:
+		position = int64(c.converter.size()) + offset //nolint:gosec
+	default:
+		return 0, newError(EBadArgument, "invalid whence %d", whence)
+	}
+	if position < 0 {
+		return 0, newError(EBadArgument, "cannot seek to negative position %d", position)
+	}
+	c.position = position
+	return position, nil
+}
+
+// convertedImageCloser closes the original reader of a converted image.
+type convertedImageCloser struct {
+	ConvertedImage
+	closer io.Closer
+}
+
+func (c *convertedImageCloser) Close() error {
+	return c.closer.Close()
+}
+
+// convertUploadImage converts the image about to be uploaded to the specified format if it differs from the format of
+// the image. It returns the reader and the size of the image to upload.
+func convertUploadImage(
+	reader io.ReadSeekCloser,
+	size uint64,
+	imageFormat ImageFormat,
+	format ImageFormat,
+) (io.ReadSeekCloser, uint64, error) {
+	if format == "" || format == imageFormat {
+		return reader, size, nil
+	}
+	info, err := inspectImage(reader, size)
+	if err != nil {
+		return nil, 0, err
+	}
+	converted, err := convertImage(reader, info, format)
+	if err != nil {
+		return nil, 0, err
+	}
+	return &convertedImageCloser{converted, reader}, converted.Size(), nil
+}
+
+// passthroughImageConverter returns the source image unchanged.
+type passthroughImageConverter struct {
+	source *imageSourceReader
+	info   ImageInfo
+}
+
+func (p *passthroughImageConverter) readAt(data []byte, offset uint64) error {
+	return p.source.readAt(data, offset)
+}
+
+func (p *passthroughImageConverter) format() ImageFormat {
+	return p.info.Format()
+}
+
+func (p *passthroughImageConverter) size() uint64 {
+	return p.info.FileSize()
+}
+
+func (p *passthroughImageConverter) virtualSize() uint64 {
+	return p.info.VirtualSize()
+}
+
+// imageSourceReader reads ranges of the source image. Readers implementing io.ReaderAt are read concurrently, other
+// readers are read by seeking to the start of the range.
+type imageSourceReader struct {
+	lock   *sync.Mutex
+	reader io.ReadSeeker
+}
+
+func newImageSourceReader(reader io.ReadSeeker) *imageSourceReader {
+	return &imageSourceReader{
+		lock:   &sync.Mutex{},
+		reader: reader,
+	}
+}
+
+func (s *imageSourceReader) readAt(data []byte, offset uint64) error {
+	if readerAt, ok := s.reader.(io.ReaderAt); ok {
+		n, err := readerAt.ReadAt(data, int64(offset)) //nolint:gosec
+		if n < len(data) {
+			if err == nil || err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return s.readError(err, data, offset)
+		}
+		return nil
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if _, err := s.reader.Seek(int64(offset), io.SeekStart); err != nil { //nolint:gosec
+		return wrap(err, ELocalIO, "failed to seek to byte %d of the source image", offset)
+	}
+	if _, err := io.ReadFull(s.reader, data); err != nil {
+		return s.readError(err, data, offset)
+	}
+	return nil
+}
+
+func (s *imageSourceReader) readError(err error, data []byte, offset uint64) error {
+	return wrap(err, ELocalIO, "failed to read bytes %d-%d of the source image", offset, offset+uint64(len(data)))
+}
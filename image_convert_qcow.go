@@ -0,0 +1,224 @@
+package ovirtclient
+
+import (
+	"encoding/binary"
+	"sort"
+)
+
+// rawToQCOWImageConverter presents a raw image as a QCOW2 version 3 image. Clusters of the raw image that only contain
+// zeroes are left unallocated. The QCOW2 image is laid out as follows, in units of clusters:
+//
+//   - the header,
+//   - the refcount table,
+//   - the refcount blocks,
+//   - the L1 table,
+//   - the L2 tables, for each L1 entry that references at least one data cluster,
+//   - the data clusters, in the order of the raw image.
+//
+// The metadata is generated when it is read, only the list of allocated clusters is kept in memory.
+type rawToQCOWImageConverter struct {
+	source  *imageSourceReader
+	rawSize uint64
+
+	clusterSize  uint64
+	l2Entries    uint64
+	l1Size       uint64
+	refcountBits uint64
+	// allocated contains the sorted indexes of the raw clusters that contain data.
+	allocated []uint64
+	// l2Tables contains the sorted indexes of the L1 entries that reference an L2 table.
+	l2Tables []uint64
+
+	refcountTableStart    uint64
+	refcountTableClusters uint64
+	refcountBlockStart    uint64
+	refcountBlocks        uint64
+	l1Start               uint64
+	l2Start               uint64
+	dataStart             uint64
+	totalClusters         uint64
+}
+
+func newRawToQCOWImageConverter(source *imageSourceReader, rawSize uint64) (*rawToQCOWImageConverter, error) {
+	c := &rawToQCOWImageConverter{
+		source:       source,
+		rawSize:      rawSize,
+		clusterSize:  uint64(1) << qcowConvertClusterBits,
+		l2Entries:    (uint64(1) << qcowConvertClusterBits) / 8,
+		refcountBits: uint64(1) << qcowConvertRefcountOrder,
+	}
+	if err := c.scan(); err != nil {
+		return nil, err
+	}
+	c.layout()
+	return c, nil
+}
+
+// scan reads the raw image and records the clusters that contain data.
+func (c *rawToQCOWImageConverter) scan() error {
+	buf := make([]byte, qcowConvertScanClusters*c.clusterSize)
+	for offset := uint64(0); offset < c.rawSize; offset += uint64(len(buf)) {
+		data := buf
+		if remaining := c.rawSize - offset; remaining < uint64(len(data)) {
+			data = data[:remaining]
+		}
+		if err := c.source.readAt(data, offset); err != nil {
+			return err
+		}
+		for start := uint64(0); start < uint64(len(data)); start += c.clusterSize {
+			end := start + c.clusterSize
+			if end > uint64(len(data)) {
+				end = uint64(len(data))
+			}
+			if isZero(data[start:end]) {
+				continue
+			}
+			cluster := (offset + start) / c.clusterSize
+			c.allocated = append(c.allocated, cluster)
+			if l1Index := cluster / c.l2Entries; len(c.l2Tables) == 0 || c.l2Tables[len(c.l2Tables)-1] != l1Index {
+				c.l2Tables = append(c.l2Tables, l1Index)
+			}
+		}
+	}
+	return nil
+}
+
+// layout calculates the position of the metadata. The number of refcount blocks depends on the total number of
+// clusters, which in turn depends on the number of refcount blocks, so the calculation is repeated until it settles.
+func (c *rawToQCOWImageConverter) layout() {
+	rawClusters := ceilDiv(c.rawSize, c.clusterSize)
+	c.l1Size = ceilDiv(rawClusters, c.l2Entries)
+	l1Clusters := ceilDiv(c.l1Size*8, c.clusterSize)
+	if l1Clusters == 0 {
+		l1Clusters = 1
+	}
+	fixedClusters := 1 + l1Clusters + uint64(len(c.l2Tables)) + uint64(len(c.allocated))
+	refcountsPerBlock := c.clusterSize * 8 / c.refcountBits
+
+	c.refcountBlocks = 1
+	c.refcountTableClusters = 1
+	for {
+		total := fixedClusters + c.refcountTableClusters + c.refcountBlocks
+		refcountBlocks := ceilDiv(total, refcountsPerBlock)
+		refcountTableClusters := ceilDiv(refcountBlocks*8, c.clusterSize)
+		if refcountBlocks == c.refcountBlocks && refcountTableClusters == c.refcountTableClusters {
+			c.totalClusters = total
+			break
+		}
+		c.refcountBlocks = refcountBlocks
+		c.refcountTableClusters = refcountTableClusters
+	}
+
+	c.refcountTableStart = 1
+	c.refcountBlockStart = c.refcountTableStart + c.refcountTableClusters
+	c.l1Start = c.refcountBlockStart + c.refcountBlocks
+	c.l2Start = c.l1Start + l1Clusters
+	c.dataStart = c.l2Start + uint64(len(c.l2Tables))
+}
+
+func (c *rawToQCOWImageConverter) format() ImageFormat {
+	return ImageFormatCow
+}
+
+func (c *rawToQCOWImageConverter) size() uint64 {
+	return c.totalClusters * c.clusterSize
+}
+
+func (c *rawToQCOWImageConverter) virtualSize() uint64 {
+	return c.rawSize
+}
+
+func (c *rawToQCOWImageConverter) readAt(p []byte, offset uint64) error {
+	var metadata []byte
+	for len(p) > 0 {
+		cluster := offset / c.clusterSize
+		start := offset % c.clusterSize
+		n := c.clusterSize - start
+		if n > uint64(len(p)) {
+			n = uint64(len(p))
+		}
+		if cluster >= c.dataStart {
+			if err := c.readData(p[:n], cluster-c.dataStart, start); err != nil {
+				return err
+			}
+		} else {
+			if metadata == nil {
+				metadata = make([]byte, c.clusterSize)
+			}
+			c.metadataCluster(metadata, cluster)
+			copy(p[:n], metadata[start:start+n])
+		}
+		p = p[n:]
+		offset += n
+	}
+	return nil
+}
+
+// readData reads part of a data cluster from the raw image. The part of the last cluster beyond the end of the raw
+// image reads as zeroes.
+func (c *rawToQCOWImageConverter) readData(p []byte, index uint64, start uint64) error {
+	rawOffset := c.allocated[index]*c.clusterSize + start
+	n := uint64(len(p))
+	if rawOffset+n > c.rawSize {
+		n = c.rawSize - rawOffset
+		for i := range p[n:] {
+			p[n+uint64(i)] = 0
+		}
+	}
+	return c.source.readAt(p[:n], rawOffset)
+}
+
+// metadataCluster fills the buffer with the contents of a metadata cluster.
+func (c *rawToQCOWImageConverter) metadataCluster(buf []byte, cluster uint64) {
+	for i := range buf {
+		buf[i] = 0
+	}
+	switch {
+	case cluster == 0:
+		c.header(buf)
+	case cluster < c.refcountBlockStart:
+		first := (cluster - c.refcountTableStart) * c.clusterSize / 8
+		for i := uint64(0); i < c.clusterSize/8 && first+i < c.refcountBlocks; i++ {
+			binary.BigEndian.PutUint64(buf[i*8:], (c.refcountBlockStart+first+i)*c.clusterSize)
+		}
+	case cluster < c.l1Start:
+		// Every cluster of the image is referenced exactly once.
+		entries := c.clusterSize * 8 / c.refcountBits
+		first := (cluster - c.refcountBlockStart) * entries
+		for i := uint64(0); i < entries && first+i < c.totalClusters; i++ {
+			binary.BigEndian.PutUint16(buf[i*2:], 1)
+		}
+	case cluster < c.l2Start:
+		first := (cluster - c.l1Start) * c.clusterSize / 8
+		index := sort.Search(len(c.l2Tables), func(i int) bool { return c.l2Tables[i] >= first })
+		for ; index < len(c.l2Tables) && c.l2Tables[index] < first+c.clusterSize/8; index++ {
+			entry := (c.l2Start+uint64(index))*c.clusterSize | qcowCopiedFlag
+			binary.BigEndian.PutUint64(buf[(c.l2Tables[index]-first)*8:], entry)
+		}
+	default:
+		first := c.l2Tables[cluster-c.l2Start] * c.l2Entries
+		index := sort.Search(len(c.allocated), func(i int) bool { return c.allocated[i] >= first })
+		for ; index < len(c.allocated) && c.allocated[index] < first+c.l2Entries; index++ {
+			entry := (c.dataStart+uint64(index))*c.clusterSize | qcowCopiedFlag
+			binary.BigEndian.PutUint64(buf[(c.allocated[index]-first)*8:], entry)
+		}
+	}
+}
+
+// header writes the QCOW2 version 3 header without any header extensions.
+func (c *rawToQCOWImageConverter) header(buf []byte) {
+	copy(buf, qcowMagicBytes)
+	binary.BigEndian.PutUint32(buf[4:8], 3)
+	binary.BigEndian.PutUint32(buf[20:24], qcowConvertClusterBits)
+	binary.BigEndian.PutUint64(buf[24:32], c.rawSize)
+	binary.BigEndian.PutUint32(buf[36:40], uint32(c.l1Size)) //nolint:gosec
+	binary.BigEndian.PutUint64(buf[40:48], c.l1Start*c.clusterSize)
+	binary.BigEndian.PutUint64(buf[48:56], c.refcountTableStart*c.clusterSize)
+	binary.BigEndian.PutUint32(buf[56:60], uint32(c.refcountTableClusters)) //nolint:gosec
+	binary.BigEndian.PutUint32(buf[96:100], qcowConvertRefcountOrder)
+	binary.BigEndian.PutUint32(buf[100:104], qcowV3HeaderSize)
+}
+
+func ceilDiv(a uint64, b uint64) uint64 {
+	return (a + b - 1) / b
+}
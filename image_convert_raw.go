@@ -0,0 +1,185 @@
+package ovirtclient
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"io"
+	"math/bits"
+	"sync"
+)
+
+// qcowToRawImageConverter presents a QCOW2 image as a raw image by following the L1 and L2 tables of the active
+// image. Unallocated clusters and clusters with the zero flag read as zeroes.
+type qcowToRawImageConverter struct {
+	source      *imageSourceReader
+	fileSize    uint64
+	rawSize     uint64
+	clusterBits uint32
+	clusterSize uint64
+	l2Entries   uint64
+	l1Table     []uint64
+
+	lock *sync.Mutex
+	// l2Cache contains recently used L2 tables by their offset in the image.
+	l2Cache map[uint64][]uint64
+}
+
+func newQCOWToRawImageConverter(source *imageSourceReader, info ImageInfo) (*qcowToRawImageConverter, error) {
+	qcow := info.QCOW()
+	if qcow.IncompatibleFeatures()&qcowFeatureExtendedL2 != 0 {
+		return nil, newError(EUnsupported, "converting QCOW images with extended L2 entries is not supported")
+	}
+	if qcow.CompressionType() != QCOWCompressionTypeZlib {
+		return nil, newError(
+			EUnsupported,
+			"converting QCOW images with %s compression is not supported",
+			qcow.CompressionType(),
+		)
+	}
+	clusterSize := qcow.ClusterSize()
+	c := &qcowToRawImageConverter{
+		source:      source,
+		fileSize:    info.FileSize(),
+		rawSize:     info.VirtualSize(),
+		clusterBits: uint32(bits.TrailingZeros64(clusterSize)), //nolint:gosec
+		clusterSize: clusterSize,
+		l2Entries:   clusterSize / 8,
+		lock:        &sync.Mutex{},
+		l2Cache:     map[uint64][]uint64{},
+	}
+	l1Table, err := c.readTable(qcow.L1TableOffset(), uint64(qcow.L1Size()))
+	if err != nil {
+		return nil, err
+	}
+	c.l1Table = l1Table
+	return c, nil
+}
+
+func (c *qcowToRawImageConverter) format() ImageFormat {
+	return ImageFormatRaw
+}
+
+func (c *qcowToRawImageConverter) size() uint64 {
+	return c.rawSize
+}
+
+func (c *qcowToRawImageConverter) virtualSize() uint64 {
+	return c.rawSize
+}
+
+func (c *qcowToRawImageConverter) readAt(p []byte, offset uint64) error {
+	for len(p) > 0 {
+		cluster := offset / c.clusterSize
+		start := offset % c.clusterSize
+		n := c.clusterSize - start
+		if n > uint64(len(p)) {
+			n = uint64(len(p))
+		}
+		if err := c.readCluster(p[:n], cluster, start); err != nil {
+			return err
+		}
+		p = p[n:]
+		offset += n
+	}
+	return nil
+}
+
+// readCluster reads part of a single virtual cluster.
+func (c *qcowToRawImageConverter) readCluster(p []byte, cluster uint64, start uint64) error {
+	entry, err := c.l2Entry(cluster)
+	if err != nil {
+		return err
+	}
+	if entry&qcowCompressedFlag != 0 {
+		data, err := c.decompress(entry, cluster)
+		if err != nil {
+			return err
+		}
+		copy(p, data[start:])
+		return nil
+	}
+	offset, length := qcowDataCluster(entry, c.clusterBits)
+	if length == 0 {
+		for i := range p {
+			p[i] = 0
+		}
+		return nil
+	}
+	return c.source.readAt(p, offset+start)
+}
+
+// l2Entry returns the L2 table entry of a virtual cluster, or 0 if the cluster is not allocated.
+func (c *qcowToRawImageConverter) l2Entry(cluster uint64) (uint64, error) {
+	l1Index := cluster / c.l2Entries
+	if l1Index >= uint64(len(c.l1Table)) {
+		return 0, nil
+	}
+	l2Offset := c.l1Table[l1Index] & qcowOffsetMask
+	if l2Offset == 0 {
+		return 0, nil
+	}
+
+	c.lock.Lock()
+	l2Table, ok := c.l2Cache[l2Offset]
+	c.lock.Unlock()
+	if !ok {
+		var err error
+		if l2Table, err = c.readTable(l2Offset, c.l2Entries); err != nil {
+			return 0, err
+		}
+		c.lock.Lock()
+		if len(c.l2Cache) >= qcowConvertL2CacheSize {
+			for offset := range c.l2Cache {
+				delete(c.l2Cache, offset)
+				break
+			}
+		}
+		c.l2Cache[l2Offset] = l2Table
+		c.lock.Unlock()
+	}
+	return l2Table[cluster%c.l2Entries], nil
+}
+
+// decompress reads and decompresses a compressed cluster.
+func (c *qcowToRawImageConverter) decompress(entry uint64, cluster uint64) ([]byte, error) {
+	offset, length := qcowDataCluster(entry, c.clusterBits)
+	if offset >= c.fileSize {
+		return nil, newError(EBadArgument, "compressed cluster %d is beyond the end of the image", cluster)
+	}
+	if offset+length > c.fileSize {
+		length = c.fileSize - offset
+	}
+	compressed := make([]byte, length)
+	if err := c.source.readAt(compressed, offset); err != nil {
+		return nil, err
+	}
+	data := make([]byte, c.clusterSize)
+	decompressor := flate.NewReader(bytes.NewReader(compressed))
+	defer func() {
+		_ = decompressor.Close()
+	}()
+	if _, err := io.ReadFull(decompressor, data); err != nil {
+		return nil, wrap(err, EBadArgument, "failed to decompress cluster %d of the QCOW image", cluster)
+	}
+	return data, nil
+}
+
+// readTable reads a table of 64 bit entries from the image.
+func (c *qcowToRawImageConverter) readTable(offset uint64, entries uint64) ([]uint64, error) {
+	if entries == 0 {
+		return nil, nil
+	}
+	if offset+entries*8 > c.fileSize {
+		return nil, newError(EBadArgument, "the QCOW table at offset %d is beyond the end of the image", offset)
+	}
+	data := make([]byte, entries*8)
+	if err := c.source.readAt(data, offset); err != nil {
+		return nil, err
+	}
+	table := make([]uint64, entries)
+	for i := range table {
+		table[i] = binary.BigEndian.Uint64(data[i*8:])
+	}
+	return table, nil
+}
@@ -0,0 +1,120 @@
+package ovirtclient_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+
+	ovirtclient "github.com/dyudin0821/go-ovirt-client/v3"
+)
+
+// convertTestData returns a raw image that is not aligned to the cluster size and has data at the start and the end,
+// with zeroes in between.
+func convertTestData() []byte {
+	data := make([]byte, 1024*1024+1000)
+	for i := 0; i < 1000; i++ {
+		data[i] = byte(i%251 + 1)
+		data[len(data)-1-i] = byte(i%241 + 1)
+	}
+	return data
+}
+
+func TestConvertImage(t *testing.T) {
+	t.Parallel()
+
+	data := convertTestData()
+	qcow, err := ovirtclient.ConvertImage(bytes.NewReader(data), ovirtclient.ImageFormatCow)
+	if err != nil {
+		t.Fatalf("Failed to convert raw image to QCOW. (%v)", err)
+	}
+	if qcow.Format() != ovirtclient.ImageFormatCow {
+		t.Fatalf("Incorrect format of converted image: %s instead of %s.", qcow.Format(), ovirtclient.ImageFormatCow)
+	}
+	if qcow.VirtualSize() != uint64(len(data)) {
+		t.Fatalf("Incorrect virtual size of converted image: %d instead of %d.", qcow.VirtualSize(), len(data))
+	}
+	if qcow.Size() >= uint64(len(data)) {
+		t.Fatalf("The converted QCOW image is not sparse: %d bytes for %d bytes of raw data.", qcow.Size(), len(data))
+	}
+	qcowData, err := io.ReadAll(qcow)
+	if err != nil {
+		t.Fatalf("Failed to read converted QCOW image. (%v)", err)
+	}
+	if uint64(len(qcowData)) != qcow.Size() {
+		t.Fatalf("Incorrect number of bytes read from converted image: %d instead of %d.", len(qcowData), qcow.Size())
+	}
+	info, err := ovirtclient.InspectImage(bytes.NewReader(qcowData))
+	if err != nil {
+		t.Fatalf("The converted QCOW image is not valid. (%v)", err)
+	}
+	if info.Format() != ovirtclient.ImageFormatCow || info.VirtualSize() != uint64(len(data)) {
+		t.Fatalf("Incorrect information for converted image: %s, %d bytes.", info.Format(), info.VirtualSize())
+	}
+
+	raw, err := ovirtclient.ConvertImage(bytes.NewReader(qcowData), ovirtclient.ImageFormatRaw)
+	if err != nil {
+		t.Fatalf("Failed to convert QCOW image to raw. (%v)", err)
+	}
+	if raw.Size() != uint64(len(data)) {
+		t.Fatalf("Incorrect size of raw image: %d instead of %d.", raw.Size(), len(data))
+	}
+	rawData, err := io.ReadAll(raw)
+	if err != nil {
+		t.Fatalf("Failed to read converted raw image. (%v)", err)
+	}
+	if !bytes.Equal(rawData, data) {
+		t.Fatalf("The image converted to QCOW and back does not match the original image.")
+	}
+}
+
+func TestImageUploadConvertsFormat(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+
+	data := convertTestData()
+	imageName := fmt.Sprintf("client_test_%s", helper.GenerateRandomID(5))
+	uploadResult, err := client.UploadToNewDiskWithParams(
+		helper.GetStorageDomainID(),
+		ovirtclient.ImageFormatCow,
+		uint64(len(data)),
+		ovirtclient.CreateDiskParams().MustWithSparse(true).MustWithAlias(imageName),
+		&nopReadCloser{bytes.NewReader(data)},
+		ovirtclient.UploadImageParams().MustWithLocalConversion(true),
+	)
+	if err != nil {
+		t.Fatalf("Failed to upload raw image to QCOW disk. (%v)", err)
+	}
+	disk := uploadResult.Disk()
+	diskID := disk.ID()
+	t.Cleanup(func() {
+		if err := client.RemoveDisk(diskID); err != nil && !ovirtclient.HasErrorCode(err, ovirtclient.ENotFound) {
+			t.Fatalf("Failed to remove disk %s. (%v)", diskID, err)
+		}
+	})
+	if disk.Format() != ovirtclient.ImageFormatCow {
+		t.Fatalf("Incorrect disk format: %s instead of %s.", disk.Format(), ovirtclient.ImageFormatCow)
+	}
+
+	download, err := client.DownloadDisk(diskID, ovirtclient.ImageFormatCow)
+	if err != nil {
+		t.Fatalf("Failed to download disk %s. (%v)", diskID, err)
+	}
+	downloaded, err := io.ReadAll(download)
+	_ = download.Close()
+	if err != nil {
+		t.Fatalf("Failed to read disk %s. (%v)", diskID, err)
+	}
+	raw, err := ovirtclient.ConvertImage(bytes.NewReader(downloaded), ovirtclient.ImageFormatRaw)
+	if err != nil {
+		t.Fatalf("Failed to convert downloaded disk %s to raw. (%v)", diskID, err)
+	}
+	rawData, err := io.ReadAll(raw)
+	if err != nil {
+		t.Fatalf("Failed to read converted disk %s. (%v)", diskID, err)
+	}
+	if len(rawData) < len(data) || !bytes.Equal(rawData[:len(data)], data) {
+		t.Fatalf("The contents of disk %s do not match the uploaded image.", diskID)
+	}
+}
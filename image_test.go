@@ -0,0 +1,195 @@
+package ovirtclient_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	ovirtclient "github.com/dyudin0821/go-ovirt-client/v3"
+)
+
+const testQCOWClusterBits = 16
+
+// buildTestQCOWImage creates a minimal QCOW2 version 3 image with a 1 MiB virtual size. The modify function can
+// change the header before it is written.
+func buildTestQCOWImage(t *testing.T, modify func(header *qcowHeader)) []byte {
+	clusterSize := uint64(1) << testQCOWClusterBits
+	header := &qcowHeader{
+		Magic:                 [4]byte{'Q', 'F', 'I', 0xfb},
+		Version:               3,
+		ClusterBits:           testQCOWClusterBits,
+		Size:                  1024 * 1024,
+		L1Size:                1,
+		L1TableOffset:         3 * clusterSize,
+		RefcountTableOffset:   clusterSize,
+		RefcountTableClusters: 1,
+	}
+	if modify != nil {
+		modify(header)
+	}
+	buf := &bytes.Buffer{}
+	if err := binary.Write(buf, binary.BigEndian, header); err != nil {
+		t.Fatalf("Failed to write QCOW header. (%v)", err)
+	}
+	// Incompatible, compatible and autoclear features, refcount order and header length.
+	if err := binary.Write(buf, binary.BigEndian, []uint64{0, 0, 0}); err != nil {
+		t.Fatalf("Failed to write QCOW header. (%v)", err)
+	}
+	if err := binary.Write(buf, binary.BigEndian, []uint32{4, 104}); err != nil {
+		t.Fatalf("Failed to write QCOW header. (%v)", err)
+	}
+	image := make([]byte, 4*clusterSize)
+	copy(image, buf.Bytes())
+	return image
+}
+
+func TestInspectImage(t *testing.T) {
+	t.Parallel()
+
+	image := buildTestQCOWImage(t, nil)
+	info, err := ovirtclient.InspectImage(bytes.NewReader(image))
+	if err != nil {
+		t.Fatalf("Failed to inspect valid QCOW image. (%v)", err)
+	}
+	if info.Format() != ovirtclient.ImageFormatCow {
+		t.Fatalf("Incorrect image format: %s instead of %s.", info.Format(), ovirtclient.ImageFormatCow)
+	}
+	if info.VirtualSize() != 1024*1024 {
+		t.Fatalf("Incorrect virtual size: %d instead of %d.", info.VirtualSize(), 1024*1024)
+	}
+	if info.FileSize() != uint64(len(image)) {
+		t.Fatalf("Incorrect file size: %d instead of %d.", info.FileSize(), len(image))
+	}
+	qcow := info.QCOW()
+	if qcow == nil {
+		t.Fatalf("No QCOW details returned for QCOW image.")
+	}
+	if qcow.Version() != 3 {
+		t.Fatalf("Incorrect QCOW version: %d instead of 3.", qcow.Version())
+	}
+	if qcow.ClusterSize() != 1<<testQCOWClusterBits {
+		t.Fatalf("Incorrect cluster size: %d instead of %d.", qcow.ClusterSize(), 1<<testQCOWClusterBits)
+	}
+	if qcow.EncryptionMethod() != ovirtclient.QCOWEncryptionMethodNone {
+		t.Fatalf("Incorrect encryption method: %s.", qcow.EncryptionMethod())
+	}
+	if qcow.CompressionType() != ovirtclient.QCOWCompressionTypeZlib {
+		t.Fatalf("Incorrect compression type: %s.", qcow.CompressionType())
+	}
+
+	raw := make([]byte, 64*1024)
+	info, err = ovirtclient.InspectImage(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("Failed to inspect raw image. (%v)", err)
+	}
+	if info.Format() != ovirtclient.ImageFormatRaw || info.VirtualSize() != uint64(len(raw)) || info.QCOW() != nil {
+		t.Fatalf("Incorrect information for raw image: %s, %d bytes.", info.Format(), info.VirtualSize())
+	}
+}
+
+func TestInspectImageRejectsQCOW(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]func(t *testing.T) []byte{
+		"backing file": func(t *testing.T) []byte {
+			image := buildTestQCOWImage(t, func(header *qcowHeader) {
+				header.BackingFileOffset = 512
+				header.BackingFileSize = uint32(len("base.qcow2"))
+			})
+			copy(image[512:], "base.qcow2")
+			return image
+		},
+		"encryption": func(t *testing.T) []byte {
+			return buildTestQCOWImage(t, func(header *qcowHeader) {
+				header.CryptMethod = 2
+			})
+		},
+		"small L1 table": func(t *testing.T) []byte {
+			return buildTestQCOWImage(t, func(header *qcowHeader) {
+				header.Size = 1024 * 1024 * 1024
+			})
+		},
+		"L1 table beyond the end": func(t *testing.T) []byte {
+			return buildTestQCOWImage(t, func(header *qcowHeader) {
+				header.L1TableOffset = 8 << testQCOWClusterBits
+			})
+		},
+		"unaligned L1 table": func(t *testing.T) []byte {
+			return buildTestQCOWImage(t, func(header *qcowHeader) {
+				header.L1TableOffset = 1000
+			})
+		},
+		"invalid cluster size": func(t *testing.T) []byte {
+			return buildTestQCOWImage(t, func(header *qcowHeader) {
+				header.ClusterBits = 30
+			})
+		},
+	}
+	for name, testCase := range testCases {
+		name := name
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, err := ovirtclient.InspectImage(bytes.NewReader(testCase(t)))
+			if err == nil {
+				t.Fatalf("Inspecting a QCOW image with %s did not result in an error.", name)
+			}
+			if !ovirtclient.HasErrorCode(err, ovirtclient.EBadArgument) {
+				t.Fatalf("Inspecting a QCOW image with %s did not result in an EBadArgument error. (%v)", name, err)
+			}
+		})
+	}
+}
+
+func TestInspectImageUnsupportedFormats(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]func() []byte{
+		"VMDK": func() []byte {
+			image := make([]byte, 64*1024)
+			copy(image, "KDMV")
+			return image
+		},
+		"VHDX": func() []byte {
+			image := make([]byte, 64*1024)
+			copy(image, "vhdxfile")
+			return image
+		},
+		"VHD": func() []byte {
+			image := make([]byte, 64*1024)
+			copy(image[len(image)-512:], "conectix")
+			return image
+		},
+	}
+	for name, testCase := range testCases {
+		name := name
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, err := ovirtclient.InspectImage(bytes.NewReader(testCase()))
+			if err == nil {
+				t.Fatalf("Inspecting a %s image did not result in an error.", name)
+			}
+			if !ovirtclient.HasErrorCode(err, ovirtclient.EUnsupported) {
+				t.Fatalf("Inspecting a %s image did not result in an EUnsupported error. (%v)", name, err)
+			}
+		})
+	}
+}
+
+func TestInspectImageISO(t *testing.T) {
+	t.Parallel()
+
+	image := make([]byte, 64*1024)
+	copy(image[32769:], "CD001")
+	info, err := ovirtclient.InspectImage(bytes.NewReader(image))
+	if err != nil {
+		t.Fatalf("Failed to inspect ISO image. (%v)", err)
+	}
+	if info.Format() != ovirtclient.ImageFormatRaw {
+		t.Fatalf("Incorrect image format: %s instead of %s.", info.Format(), ovirtclient.ImageFormatRaw)
+	}
+	if info.ContentType() != ovirtclient.DiskContentTypeISO {
+		t.Fatalf("Incorrect content type: %s instead of %s.", info.ContentType(), ovirtclient.DiskContentTypeISO)
+	}
+}
@@ -0,0 +1,236 @@
+package ovirtclient
+
+import (
+	"strings"
+
+	ovirtsdk4 "github.com/ovirt/go-ovirt"
+)
+
+// LUNStorageType is the type of block storage a logical unit (LUN) is accessed through.
+type LUNStorageType string
+
+const (
+	// LUNStorageTypeISCSI is a LUN accessed over iSCSI.
+	LUNStorageTypeISCSI LUNStorageType = "iscsi"
+	// LUNStorageTypeFCP is a LUN accessed over Fibre Channel.
+	LUNStorageTypeFCP LUNStorageType = "fcp"
+)
+
+// Validate returns an error if the LUN storage type doesn't have a valid value.
+func (l LUNStorageType) Validate() error {
+	for _, storageType := range LUNStorageTypeValues() {
+		if storageType == l {
+			return nil
+		}
+	}
+	return newError(
+		EBadArgument,
+		"invalid LUN storage type: %s must be one of: %s",
+		l,
+		strings.Join(LUNStorageTypeValues().Strings(), ", "),
+	)
+}
+
+// LUNStorageTypeList is a list of LUNStorageType values.
+type LUNStorageTypeList []LUNStorageType
+
+// LUNStorageTypeValues returns all possible values for LUNStorageType.
+func LUNStorageTypeValues() LUNStorageTypeList {
+	return []LUNStorageType{
+		LUNStorageTypeISCSI,
+		LUNStorageTypeFCP,
+	}
+}
+
+// Strings returns a list of strings.
+func (l LUNStorageTypeList) Strings() []string {
+	result := make([]string, len(l))
+	for i, storageType := range l {
+		result[i] = string(storageType)
+	}
+	return result
+}
+
+// LUNStatus is the usage status of a logical unit (LUN) as reported by a host.
+type LUNStatus string
+
+const (
+	// LUNStatusFree indicates that the LUN is not used and can be used for a new storage domain or a direct LUN disk.
+	LUNStatusFree LUNStatus = "free"
+	// LUNStatusUsed indicates that the LUN is already used by a storage domain or a direct LUN disk.
+	LUNStatusUsed LUNStatus = "used"
+	// LUNStatusUnusable indicates that the LUN cannot be used, for example because it is only partially visible.
+	LUNStatusUnusable LUNStatus = "unusable"
+)
+
+// Validate returns an error if the LUN status doesn't have a valid value.
+func (l LUNStatus) Validate() error {
+	for _, status := range LUNStatusValues() {
+		if status == l {
+			return nil
+		}
+	}
+	return newError(
+		EBadArgument,
+		"invalid LUN status: %s must be one of: %s",
+		l,
+		strings.Join(LUNStatusValues().Strings(), ", "),
+	)
+}
+
+// LUNStatusList is a list of LUNStatus values.
+type LUNStatusList []LUNStatus
+
+// LUNStatusValues returns all possible values for LUNStatus.
+func LUNStatusValues() LUNStatusList {
+	return []LUNStatus{
+		LUNStatusFree,
+		LUNStatusUsed,
+		LUNStatusUnusable,
+	}
+}
+
+// Strings returns a list of strings.
+func (l LUNStatusList) Strings() []string {
+	result := make([]string, len(l))
+	for i, status := range l {
+		result[i] = string(status)
+	}
+	return result
+}
+
+// LUN is a logical unit on a block storage device, as seen by a host.
+type LUN interface {
+	// ID is the unique identifier of the LUN, typically its SCSI WWID.
+	ID() string
+	// StorageType returns if the LUN is accessed over iSCSI or Fibre Channel.
+	StorageType() LUNStorageType
+	// Size is the size of the LUN in bytes.
+	Size() uint64
+	// VendorID is the vendor identifier reported by the storage device.
+	VendorID() string
+	// ProductID is the product identifier reported by the storage device.
+	ProductID() string
+	// Serial is the serial number of the LUN.
+	Serial() string
+	// Address is the address of the iSCSI portal the LUN is accessed through. It is empty for FCP LUNs.
+	Address() string
+	// Port is the port of the iSCSI portal the LUN is accessed through. It is zero for FCP LUNs.
+	Port() uint
+	// Target is the name of the iSCSI target the LUN belongs to. It is empty for FCP LUNs.
+	Target() string
+	// Status returns if the LUN is free or already in use. The status is only reported when listing the LUNs
+	// of a host, it is empty otherwise.
+	Status() LUNStatus
+	// StorageDomainID returns the ID of the storage domain using the LUN, or an empty string if the LUN is not used
+	// by a storage domain.
+	StorageDomainID() StorageDomainID
+	// DiskID returns the ID of the direct LUN disk using the LUN, or an empty string if the LUN is not used by a
+	// disk.
+	DiskID() DiskID
+}
+
+func convertSDKLogicalUnit(sdkLogicalUnit *ovirtsdk4.LogicalUnit, storageType LUNStorageType) (LUN, error) {
+	id, ok := sdkLogicalUnit.Id()
+	if !ok {
+		return nil, newError(EFieldMissing, "logical unit does not contain an ID")
+	}
+	result := &lun{
+		id:          id,
+		storageType: storageType,
+	}
+	if size, ok := sdkLogicalUnit.Size(); ok {
+		result.size = uint64(size) //nolint:gosec
+	}
+	if vendorID, ok := sdkLogicalUnit.VendorId(); ok {
+		result.vendorID = vendorID
+	}
+	if productID, ok := sdkLogicalUnit.ProductId(); ok {
+		result.productID = productID
+	}
+	if serial, ok := sdkLogicalUnit.Serial(); ok {
+		result.serial = serial
+	}
+	if address, ok := sdkLogicalUnit.Address(); ok {
+		result.address = address
+	}
+	if port, ok := sdkLogicalUnit.Port(); ok {
+		result.port = uint(port) //nolint:gosec
+	}
+	if target, ok := sdkLogicalUnit.Target(); ok {
+		result.target = target
+	}
+	if status, ok := sdkLogicalUnit.Status(); ok {
+		result.status = LUNStatus(status)
+	}
+	if storageDomainID, ok := sdkLogicalUnit.StorageDomainId(); ok {
+		result.storageDomainID = StorageDomainID(storageDomainID)
+	}
+	if diskID, ok := sdkLogicalUnit.DiskId(); ok {
+		result.diskID = DiskID(diskID)
+	}
+	return result, nil
+}
+
+type lun struct {
+	id              string
+	storageType     LUNStorageType
+	size            uint64
+	vendorID        string
+	productID       string
+	serial          string
+	address         string
+	port            uint
+	target          string
+	status          LUNStatus
+	storageDomainID StorageDomainID
+	diskID          DiskID
+}
+
+func (l *lun) ID() string {
+	return l.id
+}
+
+func (l *lun) StorageType() LUNStorageType {
+	return l.storageType
+}
+
+func (l *lun) Size() uint64 {
+	return l.size
+}
+
+func (l *lun) VendorID() string {
+	return l.vendorID
+}
+
+func (l *lun) ProductID() string {
+	return l.productID
+}
+
+func (l *lun) Serial() string {
+	return l.serial
+}
+
+func (l *lun) Address() string {
+	return l.address
+}
+
+func (l *lun) Port() uint {
+	return l.port
+}
+
+func (l *lun) Target() string {
+	return l.target
+}
+
+func (l *lun) Status() LUNStatus {
+	return l.status
+}
+
+func (l *lun) StorageDomainID() StorageDomainID {
+	return l.storageDomainID
+}
+
+func (l *lun) DiskID() DiskID {
+	return l.diskID
+}
@@ -0,0 +1,111 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+// mockLUNSize is the size of the LUNs in the LUN inventory of the mock client.
+const mockLUNSize = 10 * 1024 * 1024 * 1024
+
+// mockISCSIPort is the default iSCSI port, used when discovering targets without a port.
+const mockISCSIPort = 3260
+
+// mockISCSITarget is an iSCSI target in the LUN inventory of the mock client.
+type mockISCSITarget struct {
+	ISCSITarget
+
+	username string
+	password string
+	lunIDs   []string
+}
+
+// generateTestLUNInventory creates the fake iSCSI targets and LUNs of the mock client. The first iSCSI target
+// requires no authentication, the second one requires CHAP authentication with the user name "test" and the password
+// "test". The FCP LUNs are visible to all hosts without logging in.
+func generateTestLUNInventory() (map[string]*mockISCSITarget, map[string]*lun) {
+	targets := map[string]*mockISCSITarget{}
+	luns := map[string]*lun{}
+	serial := 0
+	addLUN := func(storageType LUNStorageType, target *mockISCSITarget) {
+		serial++
+		l := &lun{
+			id:          fmt.Sprintf("36001405%024x", serial),
+			storageType: storageType,
+			size:        mockLUNSize,
+			vendorID:    "LIO-ORG",
+			productID:   "mock",
+			serial:      fmt.Sprintf("SLIO-ORG_mock_%08x", serial),
+		}
+		if target != nil {
+			l.address = target.Address
+			l.port = target.Port
+			l.target = target.Target
+			target.lunIDs = append(target.lunIDs, l.id)
+		}
+		luns[l.id] = l
+	}
+	for _, target := range []*mockISCSITarget{
+		{
+			ISCSITarget: ISCSITarget{
+				Address: "iscsi.example.com",
+				Port:    mockISCSIPort,
+				Target:  "iqn.2022-01.com.example:storage",
+			},
+		},
+		{
+			ISCSITarget: ISCSITarget{
+				Address: "iscsi.example.com",
+				Port:    mockISCSIPort,
+				Target:  "iqn.2022-01.com.example:secure",
+			},
+			username: "test",
+			password: "test",
+		},
+	} {
+		targets[target.Target] = target
+		for i := 0; i < 3; i++ {
+			addLUN(LUNStorageTypeISCSI, target)
+		}
+	}
+	for i := 0; i < 2; i++ {
+		addLUN(LUNStorageTypeFCP, nil)
+	}
+	return targets, luns
+}
+
+// getHostLUN returns the LUN with the specified ID if it is visible to the host. FCP LUNs are visible to all hosts,
+// iSCSI LUNs only after the host logged in to their target. The caller must hold the mock client lock.
+func (m *mockClient) getHostLUN(hostID HostID, lunID string) (*lun, error) {
+	l, ok := m.luns[lunID]
+	if !ok || !m.isLUNVisibleToHost(hostID, l) {
+		return nil, newError(ENotFound, "LUN %s is not visible to host %s", lunID, hostID)
+	}
+	return l, nil
+}
+
+// isLUNVisibleToHost returns true if the host can see the LUN. The caller must hold the mock client lock.
+func (m *mockClient) isLUNVisibleToHost(hostID HostID, l *lun) bool {
+	if l.storageType != LUNStorageTypeISCSI {
+		return true
+	}
+	_, ok := m.hostISCSISessions[hostID][l.target]
+	return ok
+}
+
+// getLUNWithStatus returns a copy of the LUN with the usage status filled in. The caller must hold the mock client
+// lock.
+func (m *mockClient) getLUNWithStatus(l *lun) *lun {
+	result := *l
+	result.status = LUNStatusFree
+	if storageDomainID, ok := m.lunStorageDomains[l.id]; ok {
+		result.status = LUNStatusUsed
+		result.storageDomainID = storageDomainID
+	}
+	for _, disk := range m.disks {
+		if disk.lun != nil && disk.lun.ID() == l.id {
+			result.status = LUNStatusUsed
+			result.diskID = disk.id
+		}
+	}
+	return &result
+}
@@ -16,6 +16,10 @@ type MockClient interface {
 
 	// GenerateUUID generates a UUID for testing purposes.
 	GenerateUUID() string
+
+	// DiskUploadExtents returns the ranges of the disk written by the last successful image upload, sorted by their
+	// start. Ranges that were sent using the zero operation instead of sending the data have the Zero flag set.
+	DiskUploadExtents(diskID DiskID) ([]DiskExtent, error)
 }
 
 type mockClient struct {
@@ -26,6 +30,7 @@ type mockClient struct {
 	nonSecureRandom                   *rand.Rand
 	vms                               map[VMID]*vm
 	storageDomains                    map[StorageDomainID]*storageDomain
+	storageDomainDatacenters          map[StorageDomainID]DatacenterID
 	disks                             map[DiskID]*diskWithData
 	clusters                          map[ClusterID]*cluster
 	hosts                             map[HostID]*host
@@ -35,7 +40,7 @@ type mockClient struct {
 	networks                          map[NetworkID]*network
 	dataCenters                       map[DatacenterID]*datacenterWithClusters
 	vmDiskAttachmentsByVM             map[VMID]map[DiskAttachmentID]*diskAttachment
-	vmDiskAttachmentsByDisk           map[DiskID]*diskAttachment
+	vmDiskAttachmentsByDisk           map[DiskID]map[VMID]*diskAttachment
 	vmCDROMsByVM                      map[VMID]map[CDROMID]*cdrom
 	templateDiskAttachmentsByTemplate map[TemplateID][]*templateDiskAttachment
 	templateDiskAttachmentsByDisk     map[DiskID]*templateDiskAttachment
@@ -45,6 +50,25 @@ type mockClient struct {
 	instanceTypes                     map[InstanceTypeID]*instanceType
 	graphicsConsolesByVM              map[VMID][]*vmGraphicsConsole
 	storageDomainFiles                map[StorageDomainID]map[FileID]*file
+	snapshotsByVM                     map[VMID]map[SnapshotID]*snapshotWithState
+	vmRunOnce                         map[VMID]*vmRunOnceState
+	vmPools                           map[VMPoolID]*vmPool
+	vmPoolAllocations                 map[VMPoolID]map[VMID]struct{}
+	ovaFilesByHost                    map[HostID]map[string][]byte
+	vmBackups                         map[VMBackupID]*vmBackupWithData
+	vmCheckpoints                     map[VMID][]*vmCheckpointWithRanges
+	diskImageChains                   map[DiskID][]*diskImageLayer
+	imageTransfers                    map[ImageTransferID]*mockImageTransfer
+	diskUploadExtents                 map[DiskID][]DiskExtent
+	iscsiTargets                      map[string]*mockISCSITarget
+	luns                              map[string]*lun
+	hostISCSISessions                 map[HostID]map[string]struct{}
+	lunStorageDomains                 map[string]StorageDomainID
+	storageConnections                map[StorageConnectionID]*storageConnection
+	storageConnectionDomains          map[StorageConnectionID]StorageDomainID
+	unregisteredDisks                 map[StorageDomainID]map[DiskID]*diskWithData
+	unregisteredVMs                   map[StorageDomainID]map[VMID]*mockUnregisteredVM
+	unregisteredTemplates             map[StorageDomainID]map[TemplateID]*mockUnregisteredTemplate
 }
 
 func (m *mockClient) WithContext(ctx context.Context) Client {
@@ -56,6 +80,7 @@ func (m *mockClient) WithContext(ctx context.Context) Client {
 		m.nonSecureRandom,
 		m.vms,
 		m.storageDomains,
+		m.storageDomainDatacenters,
 		m.disks,
 		m.clusters,
 		m.hosts,
@@ -75,6 +100,25 @@ func (m *mockClient) WithContext(ctx context.Context) Client {
 		m.instanceTypes,
 		m.graphicsConsolesByVM,
 		m.storageDomainFiles,
+		m.snapshotsByVM,
+		m.vmRunOnce,
+		m.vmPools,
+		m.vmPoolAllocations,
+		m.ovaFilesByHost,
+		m.vmBackups,
+		m.vmCheckpoints,
+		m.diskImageChains,
+		m.imageTransfers,
+		m.diskUploadExtents,
+		m.iscsiTargets,
+		m.luns,
+		m.hostISCSISessions,
+		m.lunStorageDomains,
+		m.storageConnections,
+		m.storageConnectionDomains,
+		m.unregisteredDisks,
+		m.unregisteredVMs,
+		m.unregisteredTemplates,
 	}
 }
 
@@ -21,6 +21,7 @@ func NewMock() MockClient {
 func NewMockWithLogger(logger Logger) MockClient {
 	testCluster := generateTestCluster()
 	testHost := generateTestHost(testCluster)
+	secondaryHost := generateTestHost(testCluster)
 	testStorageDomain := generateTestStorageDomain()
 	secondaryStorageDomain := generateTestStorageDomain()
 	testDatacenter := generateTestDatacenter(testCluster)
@@ -48,6 +49,7 @@ func NewMockWithLogger(logger Logger) MockClient {
 		secondaryStorageDomain,
 		testCluster,
 		testHost,
+		secondaryHost,
 		blankTemplate,
 		testVNICProfile,
 		testNetwork,
@@ -56,6 +58,7 @@ func NewMockWithLogger(logger Logger) MockClient {
 
 	testCluster.client = client
 	testHost.client = client
+	secondaryHost.client = client
 	blankTemplate.client = client
 	testStorageDomain.client = client
 	secondaryStorageDomain.client = client
@@ -72,6 +75,7 @@ func getClient(
 	secondaryStorageDomain *storageDomain,
 	testCluster *cluster,
 	testHost *host,
+	secondaryHost *host,
 	blankTemplate *template,
 	testVNICProfile *vnicProfile,
 	testNetwork *network,
@@ -89,12 +93,17 @@ func getClient(
 			testStorageDomain.ID():      testStorageDomain,
 			secondaryStorageDomain.ID(): secondaryStorageDomain,
 		},
+		storageDomainDatacenters: map[StorageDomainID]DatacenterID{
+			testStorageDomain.ID():      testDatacenter.ID(),
+			secondaryStorageDomain.ID(): testDatacenter.ID(),
+		},
 		disks: map[DiskID]*diskWithData{},
 		clusters: map[ClusterID]*cluster{
 			testCluster.ID(): testCluster,
 		},
 		hosts: map[HostID]*host{
-			testHost.ID(): testHost,
+			testHost.ID():      testHost,
+			secondaryHost.ID(): secondaryHost,
 		},
 		templates: map[TemplateID]*template{
 			blankTemplate.ID(): blankTemplate,
@@ -110,7 +119,7 @@ func getClient(
 			testDatacenter.ID(): testDatacenter,
 		},
 		vmDiskAttachmentsByVM:   map[VMID]map[DiskAttachmentID]*diskAttachment{},
-		vmDiskAttachmentsByDisk: map[DiskID]*diskAttachment{},
+		vmDiskAttachmentsByDisk: map[DiskID]map[VMID]*diskAttachment{},
 		templateDiskAttachmentsByTemplate: map[TemplateID][]*templateDiskAttachment{
 			blankTemplate.ID(): {},
 		},
@@ -118,12 +127,30 @@ func getClient(
 		affinityGroups: map[ClusterID]map[AffinityGroupID]*affinityGroup{
 			testCluster.ID(): {},
 		},
-		vmIPs:                map[VMID]map[string][]net.IP{},
-		instanceTypes:        nil,
-		graphicsConsolesByVM: map[VMID][]*vmGraphicsConsole{},
-		storageDomainFiles:   map[StorageDomainID]map[FileID]*file{},
+		vmIPs:                    map[VMID]map[string][]net.IP{},
+		instanceTypes:            nil,
+		graphicsConsolesByVM:     map[VMID][]*vmGraphicsConsole{},
+		storageDomainFiles:       map[StorageDomainID]map[FileID]*file{},
+		snapshotsByVM:            map[VMID]map[SnapshotID]*snapshotWithState{},
+		vmRunOnce:                map[VMID]*vmRunOnceState{},
+		vmPools:                  map[VMPoolID]*vmPool{},
+		vmPoolAllocations:        map[VMPoolID]map[VMID]struct{}{},
+		ovaFilesByHost:           map[HostID]map[string][]byte{},
+		vmBackups:                map[VMBackupID]*vmBackupWithData{},
+		vmCheckpoints:            map[VMID][]*vmCheckpointWithRanges{},
+		diskImageChains:          map[DiskID][]*diskImageLayer{},
+		imageTransfers:           map[ImageTransferID]*mockImageTransfer{},
+		diskUploadExtents:        map[DiskID][]DiskExtent{},
+		hostISCSISessions:        map[HostID]map[string]struct{}{},
+		lunStorageDomains:        map[string]StorageDomainID{},
+		storageConnections:       map[StorageConnectionID]*storageConnection{},
+		storageConnectionDomains: map[StorageConnectionID]StorageDomainID{},
+		unregisteredDisks:        map[StorageDomainID]map[DiskID]*diskWithData{},
+		unregisteredVMs:          map[StorageDomainID]map[VMID]*mockUnregisteredVM{},
+		unregisteredTemplates:    map[StorageDomainID]map[TemplateID]*mockUnregisteredTemplate{},
 	}
 	client.instanceTypes = getInstanceTypes(client)
+	client.iscsiTargets, client.luns = generateTestLUNInventory()
 	return client
 }
 
@@ -190,7 +217,7 @@ func generateTestStorageDomain() *storageDomain {
 	return &storageDomain{
 		id:             StorageDomainID(uuid.NewString()),
 		name:           "Test storage domain",
-		available:      10 * 1024 * 1024 * 1024,
+		available:      mockStorageDomainSize,
 		status:         StorageDomainStatusActive,
 		externalStatus: StorageDomainExternalStatusNA,
 		storageType:    StorageDomainTypeNFS,
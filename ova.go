@@ -0,0 +1,478 @@
+package ovirtclient
+
+import (
+	"archive/tar"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// OVAClient contains the methods for exporting and importing VMs and templates as Open Virtual Appliance (OVA)
+// archives.
+//
+// The ExportVMToOVA, ExportTemplateToOVA, ImportVMFromOVA, and ImportTemplateFromOVA calls are engine actions: the
+// OVA file is written to or read from the filesystem of a host. DownloadVMToOVA and UploadVMFromOVA, on the other
+// hand, build and read the OVA archive on the client side using disk image transfers, so they do not require access
+// to the filesystem of a host.
+type OVAClient interface {
+	// ExportVMToOVA exports a VM as an OVA file into the specified directory on the specified host. The VM must be
+	// down.
+	ExportVMToOVA(vmID VMID, hostID HostID, directory string, filename string, retries ...RetryStrategy) error
+	// ExportTemplateToOVA exports a template as an OVA file into the specified directory on the specified host.
+	ExportTemplateToOVA(
+		templateID TemplateID,
+		hostID HostID,
+		directory string,
+		filename string,
+		retries ...RetryStrategy,
+	) error
+	// ImportVMFromOVA imports a VM from an OVA file located on the specified host and names it with the specified
+	// name. The disks are created on the specified storage domain.
+	ImportVMFromOVA(
+		clusterID ClusterID,
+		storageDomainID StorageDomainID,
+		hostID HostID,
+		ovaPath string,
+		name string,
+		params OptionalOVAImportParameters,
+		retries ...RetryStrategy,
+	) (VM, error)
+	// ImportTemplateFromOVA imports a template from an OVA file located on the specified host and names it with the
+	// specified name. The disks are created on the specified storage domain.
+	ImportTemplateFromOVA(
+		clusterID ClusterID,
+		storageDomainID StorageDomainID,
+		hostID HostID,
+		ovaPath string,
+		name string,
+		retries ...RetryStrategy,
+	) (Template, error)
+
+	// DownloadVMToOVA writes an OVA archive of the VM to the writer. The archive contains an OVF descriptor of the VM
+	// followed by the images of the attached disks as downloaded by DownloadDisk. The VM should be down to get a
+	// consistent image.
+	DownloadVMToOVA(vmID VMID, writer io.Writer, retries ...RetryStrategy) error
+	// UploadVMFromOVA creates a VM from an OVA archive created by DownloadVMToOVA. The VM is created from the blank
+	// template with the hardware settings of the OVF descriptor, and the disk images are uploaded to new disks on the
+	// specified storage domain using UploadToNewDisk. The reader must support seeking to locate the disk images in
+	// the archive. If the upload fails, the partially created VM is removed.
+	UploadVMFromOVA(
+		clusterID ClusterID,
+		storageDomainID StorageDomainID,
+		name string,
+		reader io.ReadSeeker,
+		params OptionalOVAImportParameters,
+		retries ...RetryStrategy,
+	) (VM, error)
+}
+
+// OptionalOVAImportParameters are the optional parameters for importing a VM from an OVA file.
+type OptionalOVAImportParameters interface {
+	// Sparse returns true if the imported disks should be thin provisioned.
+	Sparse() *bool
+}
+
+// BuildableOVAImportParameters is a buildable version of OptionalOVAImportParameters.
+type BuildableOVAImportParameters interface {
+	OptionalOVAImportParameters
+
+	// WithSparse sets whether the imported disks should be thin provisioned.
+	WithSparse(sparse bool) (BuildableOVAImportParameters, error)
+	// MustWithSparse is identical to WithSparse, but panics instead of returning an error.
+	MustWithSparse(sparse bool) BuildableOVAImportParameters
+}
+
+// OVAImportParams returns a buildable set of parameters for importing VMs from OVA files.
+func OVAImportParams() BuildableOVAImportParameters {
+	return &ovaImportParams{}
+}
+
+type ovaImportParams struct {
+	sparse *bool
+}
+
+func (o *ovaImportParams) Sparse() *bool {
+	return o.sparse
+}
+
+func (o *ovaImportParams) WithSparse(sparse bool) (BuildableOVAImportParameters, error) {
+	o.sparse = &sparse
+	return o, nil
+}
+
+func (o *ovaImportParams) MustWithSparse(sparse bool) BuildableOVAImportParameters {
+	builder, err := o.WithSparse(sparse)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func validateOVAExportParameters(hostID HostID, directory string, filename string) error {
+	if hostID == "" {
+		return newError(EBadArgument, "host ID cannot be empty for OVA export")
+	}
+	if directory == "" {
+		return newError(EBadArgument, "directory cannot be empty for OVA export")
+	}
+	if filename == "" {
+		return newError(EBadArgument, "filename cannot be empty for OVA export")
+	}
+	if strings.Contains(filename, "/") {
+		return newError(EBadArgument, "filename %s must not contain a directory", filename)
+	}
+	return nil
+}
+
+func validateOVAImportParameters(clusterID ClusterID, storageDomainID StorageDomainID, name string) error {
+	if clusterID == "" {
+		return newError(EBadArgument, "cluster ID cannot be empty for OVA import")
+	}
+	if storageDomainID == "" {
+		return newError(EBadArgument, "storage domain ID cannot be empty for OVA import")
+	}
+	if name == "" {
+		return newError(EBadArgument, "name cannot be empty for OVA import")
+	}
+	return nil
+}
+
+func validateOVAHostImportParameters(
+	clusterID ClusterID,
+	storageDomainID StorageDomainID,
+	hostID HostID,
+	ovaPath string,
+	name string,
+) error {
+	if hostID == "" {
+		return newError(EBadArgument, "host ID cannot be empty for OVA import")
+	}
+	if !path.IsAbs(ovaPath) {
+		return newError(EBadArgument, "OVA path must be an absolute path on the host, got \"%s\"", ovaPath)
+	}
+	return validateOVAImportParameters(clusterID, storageDomainID, name)
+}
+
+const (
+	// ovfDescriptorName is the name of the OVF descriptor in the OVA archive. The descriptor must be the first
+	// file in the archive.
+	ovfDescriptorName = "vm.ovf"
+	// ovfMaxDescriptorSize limits the size of the OVF descriptor read from untrusted archives.
+	ovfMaxDescriptorSize = 16 * 1024 * 1024
+
+	ovfFormatCow = "http://www.gnome.org/~markmc/qcow-image-format.html"
+	ovfFormatRaw = "http://www.vmware.com/specifications/vmdk.html#sparse"
+
+	ovfResourceTypeCPU    = 3
+	ovfResourceTypeMemory = 4
+)
+
+type ovfEnvelope struct {
+	XMLName       xml.Name         `xml:"http://schemas.dmtf.org/ovf/envelope/1 Envelope"`
+	Files         []ovfFile        `xml:"References>File"`
+	Disks         []ovfDisk        `xml:"DiskSection>Disk"`
+	VirtualSystem ovfVirtualSystem `xml:"VirtualSystem"`
+}
+
+type ovfFile struct {
+	ID   string `xml:"http://schemas.dmtf.org/ovf/envelope/1 id,attr"`
+	Href string `xml:"http://schemas.dmtf.org/ovf/envelope/1 href,attr"`
+}
+
+type ovfDisk struct {
+	DiskID        string `xml:"http://schemas.dmtf.org/ovf/envelope/1 diskId,attr"`
+	FileRef       string `xml:"http://schemas.dmtf.org/ovf/envelope/1 fileRef,attr"`
+	Capacity      uint64 `xml:"http://schemas.dmtf.org/ovf/envelope/1 capacity,attr"`
+	Format        string `xml:"http://schemas.dmtf.org/ovf/envelope/1 format,attr"`
+	Alias         string `xml:"http://schemas.dmtf.org/ovf/envelope/1 disk-alias,attr"`
+	DiskInterface string `xml:"http://schemas.dmtf.org/ovf/envelope/1 disk-interface,attr"`
+	Boot          bool   `xml:"http://schemas.dmtf.org/ovf/envelope/1 boot,attr"`
+}
+
+type ovfVirtualSystem struct {
+	ID              string      `xml:"http://schemas.dmtf.org/ovf/envelope/1 id,attr"`
+	Name            string      `xml:"Name"`
+	Comment         string      `xml:"Comment,omitempty"`
+	Description     string      `xml:"Description,omitempty"`
+	IsTemplate      bool        `xml:"IsTemplate"`
+	OperatingSystem string      `xml:"OperatingSystemSection>Description,omitempty"`
+	Items           []ovfHWItem `xml:"VirtualHardwareSection>Item"`
+}
+
+type ovfHWItem struct {
+	Caption         string `xml:"http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_ResourceAllocationSettingData Caption"`
+	ResourceType    int    `xml:"http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_ResourceAllocationSettingData ResourceType"`
+	VirtualQuantity uint64 `xml:"http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_ResourceAllocationSettingData VirtualQuantity"`
+	AllocationUnits string `xml:"http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_ResourceAllocationSettingData AllocationUnits,omitempty"`
+	Sockets         uint   `xml:"http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_ResourceAllocationSettingData num_of_sockets,omitempty"`
+	CoresPerSocket  uint   `xml:"http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_ResourceAllocationSettingData cpu_per_socket,omitempty"`
+	ThreadsPerCore  uint   `xml:"http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_ResourceAllocationSettingData threads_per_cpu,omitempty"`
+}
+
+// ovaSystem describes the VM or template packaged into an OVA archive.
+type ovaSystem struct {
+	name        string
+	comment     string
+	description string
+	memory      int64
+	cpu         VMCPU
+	osType      string
+	template    bool
+	disks       []ovaDisk
+}
+
+// ovaDisk describes a disk packaged into an OVA archive.
+type ovaDisk struct {
+	id              DiskID
+	alias           string
+	provisionedSize uint64
+	format          ImageFormat
+	diskInterface   DiskInterface
+	bootable        bool
+}
+
+func (s ovaSystem) envelope() (*ovfEnvelope, error) {
+	envelope := &ovfEnvelope{
+		VirtualSystem: ovfVirtualSystem{
+			ID:              s.name,
+			Name:            s.name,
+			Comment:         s.comment,
+			Description:     s.description,
+			IsTemplate:      s.template,
+			OperatingSystem: s.osType,
+		},
+	}
+	for _, d := range s.disks {
+		format, err := ovfFormatFromImageFormat(d.format)
+		if err != nil {
+			return nil, err
+		}
+		envelope.Files = append(envelope.Files, ovfFile{ID: string(d.id), Href: string(d.id)})
+		envelope.Disks = append(envelope.Disks, ovfDisk{
+			DiskID:        string(d.id),
+			FileRef:       string(d.id),
+			Capacity:      d.provisionedSize,
+			Format:        format,
+			Alias:         d.alias,
+			DiskInterface: string(d.diskInterface),
+			Boot:          d.bootable,
+		})
+	}
+	if s.cpu != nil && s.cpu.Topo() != nil {
+		topo := s.cpu.Topo()
+		envelope.VirtualSystem.Items = append(envelope.VirtualSystem.Items, ovfHWItem{
+			Caption:         fmt.Sprintf("%d virtual CPUs", topo.Sockets()*topo.Cores()*topo.Threads()),
+			ResourceType:    ovfResourceTypeCPU,
+			VirtualQuantity: uint64(topo.Sockets() * topo.Cores() * topo.Threads()),
+			Sockets:         topo.Sockets(),
+			CoresPerSocket:  topo.Cores(),
+			ThreadsPerCore:  topo.Threads(),
+		})
+	}
+	if s.memory > 0 {
+		envelope.VirtualSystem.Items = append(envelope.VirtualSystem.Items, ovfHWItem{
+			Caption:         fmt.Sprintf("%d MB of memory", s.memory/1024/1024),
+			ResourceType:    ovfResourceTypeMemory,
+			VirtualQuantity: uint64(s.memory) / 1024 / 1024,
+			AllocationUnits: "MegaBytes",
+		})
+	}
+	return envelope, nil
+}
+
+func ovfFormatFromImageFormat(format ImageFormat) (string, error) {
+	switch format {
+	case ImageFormatCow:
+		return ovfFormatCow, nil
+	case ImageFormatRaw:
+		return ovfFormatRaw, nil
+	default:
+		return "", newError(EBadArgument, "unsupported image format for OVA: %s", format)
+	}
+}
+
+func imageFormatFromOVFFormat(format string) (ImageFormat, error) {
+	switch format {
+	case ovfFormatCow:
+		return ImageFormatCow, nil
+	case ovfFormatRaw:
+		return ImageFormatRaw, nil
+	default:
+		return "", newError(EBadArgument, "unsupported disk format in OVF descriptor: %s", format)
+	}
+}
+
+// writeOVA writes the OVF descriptor of the system followed by the disk images downloaded using the client as a tar
+// archive to the writer.
+func writeOVA(client Client, system ovaSystem, writer io.Writer, retries ...RetryStrategy) error {
+	envelope, err := system.envelope()
+	if err != nil {
+		return err
+	}
+	descriptor, err := xml.MarshalIndent(envelope, "", "  ")
+	if err != nil {
+		return wrap(err, EBug, "failed to encode OVF descriptor for %s", system.name)
+	}
+	descriptor = append([]byte(xml.Header), descriptor...)
+
+	tarWriter := tar.NewWriter(writer)
+	if err := tarWriter.WriteHeader(&tar.Header{
+		Name:     ovfDescriptorName,
+		Mode:     0o644,
+		Size:     int64(len(descriptor)),
+		Typeflag: tar.TypeReg,
+		Format:   tar.FormatUSTAR,
+	}); err != nil {
+		return wrap(err, EUnidentified, "failed to write OVF descriptor header")
+	}
+	if _, err := tarWriter.Write(descriptor); err != nil {
+		return wrap(err, EUnidentified, "failed to write OVF descriptor")
+	}
+	for _, d := range system.disks {
+		if err := writeOVADisk(client, tarWriter, d, retries...); err != nil {
+			return err
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		return wrap(err, EUnidentified, "failed to finalize OVA archive")
+	}
+	return nil
+}
+
+func writeOVADisk(client Client, tarWriter *tar.Writer, d ovaDisk, retries ...RetryStrategy) error {
+	download, err := client.DownloadDisk(d.id, d.format, retries...)
+	if err != nil {
+		return wrap(err, EUnidentified, "failed to download disk %s", d.id)
+	}
+	defer func() {
+		_ = download.Close()
+	}()
+	if err := tarWriter.WriteHeader(ovaDiskHeader(d.id, download.Size())); err != nil {
+		return wrap(err, EUnidentified, "failed to write archive header for disk %s", d.id)
+	}
+	if _, err := io.Copy(tarWriter, download); err != nil {
+		return wrap(err, EUnidentified, "failed to write image of disk %s to the archive", d.id)
+	}
+	return nil
+}
+
+// ovaDiskHeader returns the archive header for a disk image of the specified size. The format is not forced to USTAR
+// like for the descriptor, as USTAR cannot store sizes of 8 GiB or more. The tar writer falls back to PAX for these.
+func ovaDiskHeader(id DiskID, size uint64) *tar.Header {
+	return &tar.Header{
+		Name:     string(id),
+		Mode:     0o644,
+		Size:     int64(size), //nolint:gosec
+		Typeflag: tar.TypeReg,
+	}
+}
+
+// ovaEntry is the location of a file in an OVA archive.
+type ovaEntry struct {
+	offset int64
+	size   int64
+}
+
+// readOVA reads the OVF descriptor and the location of the files from an OVA archive.
+func readOVA(reader io.ReadSeeker) (*ovfEnvelope, map[string]ovaEntry, error) {
+	if _, err := reader.Seek(0, io.SeekStart); err != nil {
+		return nil, nil, wrap(err, EUnidentified, "failed to seek to the start of the OVA archive")
+	}
+	tarReader := tar.NewReader(reader)
+	header, err := tarReader.Next()
+	if err != nil {
+		return nil, nil, wrap(err, EBadArgument, "failed to read the OVA archive")
+	}
+	if path.Ext(header.Name) != ".ovf" {
+		return nil, nil, newError(
+			EBadArgument,
+			"the first file in the OVA archive must be an OVF descriptor, found %s",
+			header.Name,
+		)
+	}
+	if header.Size > ovfMaxDescriptorSize {
+		return nil, nil, newError(EBadArgument, "the OVF descriptor is too large (%d bytes)", header.Size)
+	}
+	envelope := &ovfEnvelope{}
+	if err := xml.NewDecoder(tarReader).Decode(envelope); err != nil {
+		return nil, nil, wrap(err, EBadArgument, "failed to parse OVF descriptor")
+	}
+
+	entries := map[string]ovaEntry{}
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, wrap(err, EBadArgument, "failed to read the OVA archive")
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		offset, err := reader.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, nil, wrap(err, EUnidentified, "failed to determine the position of %s", header.Name)
+		}
+		entries[header.Name] = ovaEntry{offset: offset, size: header.Size}
+	}
+	return envelope, entries, nil
+}
+
+// newOVAEntryReader returns a reader for a single file in the OVA archive. Only one entry reader may be used at a
+// time as they share the underlying reader.
+func newOVAEntryReader(reader io.ReadSeeker, entry ovaEntry) io.ReadSeekCloser {
+	return &ovaEntryReader{
+		reader: reader,
+		entry:  entry,
+	}
+}
+
+type ovaEntryReader struct {
+	reader io.ReadSeeker
+	entry  ovaEntry
+	pos    int64
+}
+
+func (o *ovaEntryReader) Read(p []byte) (int, error) {
+	if o.pos >= o.entry.size {
+		return 0, io.EOF
+	}
+	if remaining := o.entry.size - o.pos; int64(len(p)) > remaining {
+		p = p[:remaining]
+	}
+	if _, err := o.reader.Seek(o.entry.offset+o.pos, io.SeekStart); err != nil {
+		return 0, err
+	}
+	n, err := o.reader.Read(p)
+	o.pos += int64(n)
+	if err == io.EOF && o.pos < o.entry.size {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
+
+func (o *ovaEntryReader) Seek(offset int64, whence int) (int64, error) {
+	var pos int64
+	switch whence {
+	case io.SeekStart:
+		pos = offset
+	case io.SeekCurrent:
+		pos = o.pos + offset
+	case io.SeekEnd:
+		pos = o.entry.size + offset
+	default:
+		return o.pos, fmt.Errorf("invalid whence: %d", whence)
+	}
+	if pos < 0 {
+		return o.pos, fmt.Errorf("negative position: %d", pos)
+	}
+	o.pos = pos
+	return pos, nil
+}
+
+func (o *ovaEntryReader) Close() error {
+	return nil
+}
@@ -0,0 +1,28 @@
+package ovirtclient
+
+import (
+	"archive/tar"
+	"bytes"
+	"testing"
+)
+
+func TestOVADiskHeaderLargeDisk(t *testing.T) {
+	t.Parallel()
+
+	// A sparse disk is downloaded with its full virtual size, which easily exceeds the 8 GiB limit of USTAR.
+	size := uint64(10 * 1024 * 1024 * 1024)
+	archive := &bytes.Buffer{}
+	if err := tar.NewWriter(archive).WriteHeader(ovaDiskHeader("disk-id", size)); err != nil {
+		t.Fatalf("Failed to write archive header for a %d byte disk. (%v)", size, err)
+	}
+	header, err := tar.NewReader(archive).Next()
+	if err != nil {
+		t.Fatalf("Failed to read back archive header. (%v)", err)
+	}
+	if header.Name != "disk-id" {
+		t.Fatalf("Incorrect name in archive header: %s instead of %s.", header.Name, "disk-id")
+	}
+	if header.Size != int64(size) {
+		t.Fatalf("Incorrect size in archive header: %d instead of %d.", header.Size, size)
+	}
+}
@@ -0,0 +1,46 @@
+package ovirtclient
+
+import (
+	"bytes"
+	"path"
+)
+
+// checkOVAHost returns an error if the host doesn't exist or is not up. The caller must hold the mock client lock.
+func (m *mockClient) checkOVAHost(hostID HostID) error {
+	h, ok := m.hosts[hostID]
+	if !ok {
+		return newError(ENotFound, "host with ID %s not found", hostID)
+	}
+	if h.status != HostStatusUp {
+		return newError(EConflict, "host %s is \"%s\" not \"%s\"", hostID, h.status, HostStatusUp)
+	}
+	return nil
+}
+
+// storeHostOVA simulates writing an OVA file to the filesystem of a host.
+func (m *mockClient) storeHostOVA(hostID HostID, directory string, filename string, data *bytes.Buffer) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if err := m.checkOVAHost(hostID); err != nil {
+		return err
+	}
+	if m.ovaFilesByHost[hostID] == nil {
+		m.ovaFilesByHost[hostID] = map[string][]byte{}
+	}
+	m.ovaFilesByHost[hostID][path.Join(directory, filename)] = data.Bytes()
+	return nil
+}
+
+// loadHostOVA simulates reading an OVA file from the filesystem of a host.
+func (m *mockClient) loadHostOVA(hostID HostID, ovaPath string) (*bytes.Reader, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if err := m.checkOVAHost(hostID); err != nil {
+		return nil, err
+	}
+	data, ok := m.ovaFilesByHost[hostID][path.Clean(ovaPath)]
+	if !ok {
+		return nil, newError(ENotFound, "OVA file %s not found on host %s", ovaPath, hostID)
+	}
+	return bytes.NewReader(data), nil
+}
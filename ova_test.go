@@ -0,0 +1,237 @@
+package ovirtclient_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"testing"
+
+	ovirtclient "github.com/dyudin0821/go-ovirt-client/v3"
+)
+
+func TestVMOVADownloadAndUpload(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+
+	vm := assertCanCreateVM(
+		t,
+		helper,
+		helper.GenerateTestResourceName(t),
+		ovirtclient.NewCreateVMParams().MustWithComment("ova").MustWithMemory(2*1024*1024*1024),
+	)
+	disk := assertCanCreateDisk(t, helper)
+	assertCanUploadDiskImage(t, helper, disk)
+	assertCanAttachDiskWithParams(t, vm, disk, ovirtclient.CreateDiskAttachmentParams().MustWithBootable(true))
+
+	ova := &bytes.Buffer{}
+	if err := client.DownloadVMToOVA(vm.ID(), ova); err != nil {
+		t.Fatalf("Failed to download VM %s to OVA. (%v)", vm.ID(), err)
+	}
+
+	importedVM := assertCanUploadVMFromOVA(t, helper, bytes.NewReader(ova.Bytes()))
+	if importedVM.Comment() != "ova" {
+		t.Fatalf("Incorrect comment on imported VM: %s instead of %s.", importedVM.Comment(), "ova")
+	}
+	if importedVM.Memory() != vm.Memory() {
+		t.Fatalf("Incorrect memory on imported VM: %d instead of %d.", importedVM.Memory(), vm.Memory())
+	}
+	attachments := assertCanListDiskAttachments(t, importedVM)
+	if len(attachments) != 1 {
+		t.Fatalf("Incorrect number of disk attachments on imported VM: %d instead of 1.", len(attachments))
+	}
+	if attachments[0].DiskInterface() != ovirtclient.DiskInterfaceVirtIO {
+		t.Fatalf(
+			"Incorrect disk interface on imported VM: %s instead of %s.",
+			attachments[0].DiskInterface(),
+			ovirtclient.DiskInterfaceVirtIO,
+		)
+	}
+	if !attachments[0].Bootable() {
+		t.Fatalf("The imported disk is not bootable.")
+	}
+
+	testImageData, _ := getTestImageData(t)
+	download, err := client.DownloadDisk(attachments[0].DiskID(), ovirtclient.ImageFormatRaw)
+	if err != nil {
+		t.Fatalf("Failed to download imported disk %s. (%v)", attachments[0].DiskID(), err)
+	}
+	defer func() {
+		_ = download.Close()
+	}()
+	data, err := io.ReadAll(download)
+	if err != nil {
+		t.Fatalf("Failed to read imported disk %s. (%v)", attachments[0].DiskID(), err)
+	}
+	if len(data) < len(testImageData) || !bytes.Equal(data[:len(testImageData)], testImageData) {
+		t.Fatalf("The imported disk image did not match the original disk image.")
+	}
+}
+
+func TestVMOVAUploadWithoutDescriptor(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+
+	ova := &bytes.Buffer{}
+	tarWriter := tar.NewWriter(ova)
+	content := []byte("not an OVF descriptor")
+	if err := tarWriter.WriteHeader(&tar.Header{Name: "disk.img", Mode: 0o644, Size: int64(len(content))}); err != nil {
+		t.Fatalf("Failed to write tar header. (%v)", err)
+	}
+	if _, err := tarWriter.Write(content); err != nil {
+		t.Fatalf("Failed to write tar content. (%v)", err)
+	}
+	if err := tarWriter.Close(); err != nil {
+		t.Fatalf("Failed to close tar writer. (%v)", err)
+	}
+
+	_, err := helper.GetClient().UploadVMFromOVA(
+		helper.GetClusterID(),
+		helper.GetStorageDomainID(),
+		helper.GenerateTestResourceName(t),
+		bytes.NewReader(ova.Bytes()),
+		nil,
+	)
+	if !ovirtclient.HasErrorCode(err, ovirtclient.EBadArgument) {
+		t.Fatalf("Uploading an OVA without an OVF descriptor did not result in an EBadArgument error. (%v)", err)
+	}
+}
+
+func TestVMOVAExportAndImportOnHost(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+	hostID := assertCanFindOVAHost(t, helper)
+
+	vm := assertCanCreateVM(t, helper, helper.GenerateTestResourceName(t), nil)
+	disk := assertCanCreateDisk(t, helper)
+	assertCanAttachDisk(t, vm, disk)
+
+	filename := helper.GenerateTestResourceName(t) + ".ova"
+	if err := client.ExportVMToOVA(vm.ID(), hostID, "/var/tmp", filename); err != nil {
+		t.Fatalf("Failed to export VM %s to OVA. (%v)", vm.ID(), err)
+	}
+
+	importedVM, err := client.ImportVMFromOVA(
+		helper.GetClusterID(),
+		helper.GetStorageDomainID(),
+		hostID,
+		"/var/tmp/"+filename,
+		helper.GenerateTestResourceName(t),
+		ovirtclient.OVAImportParams().MustWithSparse(true),
+	)
+	if importedVM != nil {
+		t.Cleanup(func() {
+			if err := importedVM.Remove(); err != nil && !ovirtclient.HasErrorCode(err, ovirtclient.ENotFound) {
+				t.Fatalf("Failed to remove imported VM %s. (%v)", importedVM.ID(), err)
+			}
+		})
+	}
+	if err != nil {
+		t.Fatalf("Failed to import VM from OVA. (%v)", err)
+	}
+	if attachments := assertCanListDiskAttachments(t, importedVM); len(attachments) != 1 {
+		t.Fatalf("Incorrect number of disk attachments on imported VM: %d instead of 1.", len(attachments))
+	}
+}
+
+func TestTemplateOVAExportAndImportOnHost(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+	hostID := assertCanFindOVAHost(t, helper)
+
+	vm := assertCanCreateVM(t, helper, helper.GenerateTestResourceName(t), nil)
+	disk := assertCanCreateDisk(t, helper)
+	assertCanAttachDisk(t, vm, disk)
+	tpl := assertCanCreateTemplate(t, helper, vm)
+	if _, err := tpl.WaitForStatus(ovirtclient.TemplateStatusOK); err != nil {
+		t.Fatalf("Failed to wait for template %s to become ready. (%v)", tpl.ID(), err)
+	}
+
+	filename := helper.GenerateTestResourceName(t) + ".ova"
+	if err := client.ExportTemplateToOVA(tpl.ID(), hostID, "/var/tmp", filename); err != nil {
+		t.Fatalf("Failed to export template %s to OVA. (%v)", tpl.ID(), err)
+	}
+
+	name := helper.GenerateTestResourceName(t)
+	importedTemplate, err := client.ImportTemplateFromOVA(
+		helper.GetClusterID(),
+		helper.GetStorageDomainID(),
+		hostID,
+		"/var/tmp/"+filename,
+		name,
+	)
+	if importedTemplate != nil {
+		t.Cleanup(func() {
+			if err := importedTemplate.Remove(); err != nil && !ovirtclient.HasErrorCode(err, ovirtclient.ENotFound) {
+				t.Fatalf("Failed to remove imported template %s. (%v)", importedTemplate.ID(), err)
+			}
+		})
+	}
+	if err != nil {
+		t.Fatalf("Failed to import template from OVA. (%v)", err)
+	}
+	if importedTemplate.Name() != name {
+		t.Fatalf("Incorrect name on imported template: %s instead of %s.", importedTemplate.Name(), name)
+	}
+	attachments, err := importedTemplate.ListDiskAttachments()
+	if err != nil {
+		t.Fatalf("Failed to list disk attachments of imported template %s. (%v)", importedTemplate.ID(), err)
+	}
+	if len(attachments) != 1 {
+		t.Fatalf("Incorrect number of disk attachments on imported template: %d instead of 1.", len(attachments))
+	}
+}
+
+func TestVMOVAImportRelativePath(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+
+	_, err := helper.GetClient().ImportVMFromOVA(
+		helper.GetClusterID(),
+		helper.GetStorageDomainID(),
+		assertCanFindOVAHost(t, helper),
+		"relative/path.ova",
+		helper.GenerateTestResourceName(t),
+		nil,
+	)
+	if !ovirtclient.HasErrorCode(err, ovirtclient.EBadArgument) {
+		t.Fatalf("Importing an OVA from a relative path did not result in an EBadArgument error. (%v)", err)
+	}
+}
+
+func assertCanUploadVMFromOVA(t *testing.T, helper ovirtclient.TestHelper, reader io.ReadSeeker) ovirtclient.VM {
+	vm, err := helper.GetClient().UploadVMFromOVA(
+		helper.GetClusterID(),
+		helper.GetStorageDomainID(),
+		helper.GenerateTestResourceName(t),
+		reader,
+		nil,
+	)
+	if vm != nil {
+		t.Cleanup(func() {
+			if err := vm.Remove(); err != nil && !ovirtclient.HasErrorCode(err, ovirtclient.ENotFound) {
+				t.Fatalf("Failed to remove uploaded VM %s. (%v)", vm.ID(), err)
+			}
+		})
+	}
+	if err != nil {
+		t.Fatalf("Failed to upload VM from OVA. (%v)", err)
+	}
+	return vm
+}
+
+func assertCanFindOVAHost(t *testing.T, helper ovirtclient.TestHelper) ovirtclient.HostID {
+	hosts, err := helper.GetClient().ListHosts()
+	if err != nil {
+		t.Fatalf("Failed to list hosts. (%v)", err)
+	}
+	for _, host := range hosts {
+		if host.Status() == ovirtclient.HostStatusUp && host.ClusterID() == helper.GetClusterID() {
+			return host.ID()
+		}
+	}
+	t.Skipf("No host in status %s available in cluster %s.", ovirtclient.HostStatusUp, helper.GetClusterID())
+	return ""
+}
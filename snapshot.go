@@ -0,0 +1,445 @@
+package ovirtclient
+
+import (
+	"strings"
+	"time"
+
+	ovirtsdk4 "github.com/ovirt/go-ovirt"
+)
+
+// SnapshotID is the identifier for VM snapshots.
+type SnapshotID string
+
+// SnapshotClient contains the methods required for handling VM snapshots.
+type SnapshotClient interface {
+	// CreateVMSnapshot creates a snapshot of the specified VM and waits for the snapshot job to finish. Use
+	// CreateSnapshotParams to include the memory state or to restrict the snapshot to a subset of disks.
+	CreateVMSnapshot(
+		vmID VMID,
+		description string,
+		params CreateSnapshotOptionalParameters,
+		retries ...RetryStrategy,
+	) (Snapshot, error)
+	// ListVMSnapshots lists all snapshots of a VM. Note that the oVirt Engine also returns the current state of the
+	// VM as a snapshot of the type SnapshotTypeActive.
+	ListVMSnapshots(vmID VMID, retries ...RetryStrategy) ([]Snapshot, error)
+	// GetVMSnapshot returns a single snapshot of a VM.
+	GetVMSnapshot(vmID VMID, id SnapshotID, retries ...RetryStrategy) (Snapshot, error)
+	// RemoveVMSnapshot removes a snapshot from a VM and waits for the disk images to be merged.
+	RemoveVMSnapshot(vmID VMID, id SnapshotID, retries ...RetryStrategy) error
+	// RestoreVMSnapshot reverts the VM to the state stored in the snapshot. The VM must be stopped for this
+	// operation. Use RestoreSnapshotParams to restore the memory state or only a subset of disks.
+	RestoreVMSnapshot(
+		vmID VMID,
+		id SnapshotID,
+		params RestoreSnapshotOptionalParameters,
+		retries ...RetryStrategy,
+	) error
+	// PreviewVMSnapshot temporarily reverts the VM to the state stored in the snapshot. The VM must be stopped for
+	// this operation. The preview must be finished using either CommitVMSnapshot or UndoVMSnapshot.
+	PreviewVMSnapshot(
+		vmID VMID,
+		id SnapshotID,
+		params RestoreSnapshotOptionalParameters,
+		retries ...RetryStrategy,
+	) error
+	// CommitVMSnapshot makes the currently previewed snapshot the permanent state of the VM.
+	CommitVMSnapshot(vmID VMID, retries ...RetryStrategy) error
+	// UndoVMSnapshot discards the currently previewed snapshot and returns the VM to the state before the preview.
+	UndoVMSnapshot(vmID VMID, retries ...RetryStrategy) error
+	// WaitForSnapshotStatus waits for a snapshot to reach the specified status and returns the updated snapshot.
+	WaitForSnapshotStatus(
+		vmID VMID,
+		id SnapshotID,
+		status SnapshotStatus,
+		retries ...RetryStrategy,
+	) (Snapshot, error)
+}
+
+// SnapshotData is the core of Snapshot, providing only data access functions.
+type SnapshotData interface {
+	// ID returns the identifier of the snapshot.
+	ID() SnapshotID
+	// VMID returns the ID of the virtual machine this snapshot belongs to.
+	VMID() VMID
+	// Description returns the user-provided description of the snapshot.
+	Description() string
+	// Status returns the current status of the snapshot.
+	Status() SnapshotStatus
+	// Type returns the type of the snapshot.
+	Type() SnapshotType
+	// Date returns the time the snapshot was created.
+	Date() time.Time
+	// PersistMemoryState returns true if the snapshot contains the memory state of the VM.
+	PersistMemoryState() bool
+}
+
+// Snapshot is a point-in-time copy of the configuration and disks of a virtual machine.
+type Snapshot interface {
+	SnapshotData
+
+	// VM fetches the virtual machine this snapshot belongs to.
+	VM(retries ...RetryStrategy) (VM, error)
+	// Restore reverts the VM to the state stored in this snapshot.
+	Restore(params RestoreSnapshotOptionalParameters, retries ...RetryStrategy) error
+	// Preview temporarily reverts the VM to the state stored in this snapshot.
+	Preview(params RestoreSnapshotOptionalParameters, retries ...RetryStrategy) error
+	// Remove removes the current snapshot.
+	Remove(retries ...RetryStrategy) error
+	// WaitForStatus waits for the snapshot to reach the specified status and returns the updated snapshot.
+	WaitForStatus(status SnapshotStatus, retries ...RetryStrategy) (Snapshot, error)
+}
+
+// SnapshotStatus is the status of a snapshot.
+type SnapshotStatus string
+
+const (
+	// SnapshotStatusOK indicates that the snapshot is ready for use.
+	SnapshotStatusOK SnapshotStatus = "ok"
+	// SnapshotStatusLocked indicates that the snapshot is currently being created, restored or removed.
+	SnapshotStatusLocked SnapshotStatus = "locked"
+	// SnapshotStatusInPreview indicates that the VM is currently running a preview of this snapshot.
+	SnapshotStatusInPreview SnapshotStatus = "in_preview"
+)
+
+// SnapshotStatusList is a list of SnapshotStatus.
+type SnapshotStatusList []SnapshotStatus
+
+// SnapshotStatusValues returns all possible SnapshotStatus values.
+func SnapshotStatusValues() SnapshotStatusList {
+	return []SnapshotStatus{
+		SnapshotStatusOK,
+		SnapshotStatusLocked,
+		SnapshotStatusInPreview,
+	}
+}
+
+// Strings creates a string list of the values.
+func (l SnapshotStatusList) Strings() []string {
+	result := make([]string, len(l))
+	for i, status := range l {
+		result[i] = string(status)
+	}
+	return result
+}
+
+// Validate returns an error if the snapshot status is not valid.
+func (s SnapshotStatus) Validate() error {
+	for _, status := range SnapshotStatusValues() {
+		if status == s {
+			return nil
+		}
+	}
+	return newError(
+		EBadArgument,
+		"invalid snapshot status: %s must be one of: %s",
+		s,
+		strings.Join(SnapshotStatusValues().Strings(), ", "),
+	)
+}
+
+// SnapshotType is the type of a snapshot.
+type SnapshotType string
+
+const (
+	// SnapshotTypeActive is the snapshot representing the current state of the VM.
+	SnapshotTypeActive SnapshotType = "active"
+	// SnapshotTypePreview is the snapshot holding the state of the VM from before a preview was started.
+	SnapshotTypePreview SnapshotType = "preview"
+	// SnapshotTypeRegular is a snapshot created by the user.
+	SnapshotTypeRegular SnapshotType = "regular"
+	// SnapshotTypeStateless is the snapshot created when running a stateless VM.
+	SnapshotTypeStateless SnapshotType = "stateless"
+)
+
+// SnapshotTypeList is a list of SnapshotType.
+type SnapshotTypeList []SnapshotType
+
+// SnapshotTypeValues returns all possible SnapshotType values.
+func SnapshotTypeValues() SnapshotTypeList {
+	return []SnapshotType{
+		SnapshotTypeActive,
+		SnapshotTypePreview,
+		SnapshotTypeRegular,
+		SnapshotTypeStateless,
+	}
+}
+
+// Strings creates a string list of the values.
+func (l SnapshotTypeList) Strings() []string {
+	result := make([]string, len(l))
+	for i, snapshotType := range l {
+		result[i] = string(snapshotType)
+	}
+	return result
+}
+
+// Validate returns an error if the snapshot type is not valid.
+func (s SnapshotType) Validate() error {
+	for _, snapshotType := range SnapshotTypeValues() {
+		if snapshotType == s {
+			return nil
+		}
+	}
+	return newError(
+		EBadArgument,
+		"invalid snapshot type: %s must be one of: %s",
+		s,
+		strings.Join(SnapshotTypeValues().Strings(), ", "),
+	)
+}
+
+// CreateSnapshotOptionalParameters are the optional parameters for creating a snapshot.
+type CreateSnapshotOptionalParameters interface {
+	// PersistMemoryState indicates that the memory state of a running VM should be saved in the snapshot.
+	PersistMemoryState() *bool
+	// DiskIDs returns the list of disks to include in the snapshot. If empty, all disks are included.
+	DiskIDs() []DiskID
+}
+
+// BuildableCreateSnapshotParameters is a buildable version of CreateSnapshotOptionalParameters.
+type BuildableCreateSnapshotParameters interface {
+	CreateSnapshotOptionalParameters
+
+	// WithPersistMemoryState sets whether the memory state of the VM should be saved in the snapshot.
+	WithPersistMemoryState(persistMemoryState bool) (BuildableCreateSnapshotParameters, error)
+	// MustWithPersistMemoryState is the same as WithPersistMemoryState, but panics instead of returning an error.
+	MustWithPersistMemoryState(persistMemoryState bool) BuildableCreateSnapshotParameters
+
+	// WithDiskIDs sets the disks that should be included in the snapshot.
+	WithDiskIDs(diskIDs []DiskID) (BuildableCreateSnapshotParameters, error)
+	// MustWithDiskIDs is the same as WithDiskIDs, but panics instead of returning an error.
+	MustWithDiskIDs(diskIDs []DiskID) BuildableCreateSnapshotParameters
+}
+
+// CreateSnapshotParams creates a buildable set of CreateSnapshotOptionalParameters for use with
+// Client.CreateVMSnapshot.
+func CreateSnapshotParams() BuildableCreateSnapshotParameters {
+	return &createSnapshotParams{}
+}
+
+type createSnapshotParams struct {
+	persistMemoryState *bool
+	diskIDs            []DiskID
+}
+
+func (c *createSnapshotParams) PersistMemoryState() *bool {
+	return c.persistMemoryState
+}
+
+func (c *createSnapshotParams) DiskIDs() []DiskID {
+	return c.diskIDs
+}
+
+func (c *createSnapshotParams) WithPersistMemoryState(persistMemoryState bool) (
+	BuildableCreateSnapshotParameters,
+	error,
+) {
+	c.persistMemoryState = &persistMemoryState
+	return c, nil
+}
+
+func (c *createSnapshotParams) MustWithPersistMemoryState(persistMemoryState bool) BuildableCreateSnapshotParameters {
+	builder, err := c.WithPersistMemoryState(persistMemoryState)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (c *createSnapshotParams) WithDiskIDs(diskIDs []DiskID) (BuildableCreateSnapshotParameters, error) {
+	if err := validateSnapshotDiskIDs(diskIDs); err != nil {
+		return nil, err
+	}
+	c.diskIDs = diskIDs
+	return c, nil
+}
+
+func (c *createSnapshotParams) MustWithDiskIDs(diskIDs []DiskID) BuildableCreateSnapshotParameters {
+	builder, err := c.WithDiskIDs(diskIDs)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+// RestoreSnapshotOptionalParameters are the optional parameters for restoring or previewing a snapshot.
+type RestoreSnapshotOptionalParameters interface {
+	// RestoreMemory indicates that the memory state saved in the snapshot should be restored.
+	RestoreMemory() *bool
+	// DiskIDs returns the list of disks to restore from the snapshot. If empty, all disks are restored.
+	DiskIDs() []DiskID
+}
+
+// BuildableRestoreSnapshotParameters is a buildable version of RestoreSnapshotOptionalParameters.
+type BuildableRestoreSnapshotParameters interface {
+	RestoreSnapshotOptionalParameters
+
+	// WithRestoreMemory sets whether the memory state saved in the snapshot should be restored.
+	WithRestoreMemory(restoreMemory bool) (BuildableRestoreSnapshotParameters, error)
+	// MustWithRestoreMemory is the same as WithRestoreMemory, but panics instead of returning an error.
+	MustWithRestoreMemory(restoreMemory bool) BuildableRestoreSnapshotParameters
+
+	// WithDiskIDs sets the disks that should be restored from the snapshot.
+	WithDiskIDs(diskIDs []DiskID) (BuildableRestoreSnapshotParameters, error)
+	// MustWithDiskIDs is the same as WithDiskIDs, but panics instead of returning an error.
+	MustWithDiskIDs(diskIDs []DiskID) BuildableRestoreSnapshotParameters
+}
+
+// RestoreSnapshotParams creates a buildable set of RestoreSnapshotOptionalParameters for use with
+// Client.RestoreVMSnapshot and Client.PreviewVMSnapshot.
+func RestoreSnapshotParams() BuildableRestoreSnapshotParameters {
+	return &restoreSnapshotParams{}
+}
+
+type restoreSnapshotParams struct {
+	restoreMemory *bool
+	diskIDs       []DiskID
+}
+
+func (r *restoreSnapshotParams) RestoreMemory() *bool {
+	return r.restoreMemory
+}
+
+func (r *restoreSnapshotParams) DiskIDs() []DiskID {
+	return r.diskIDs
+}
+
+func (r *restoreSnapshotParams) WithRestoreMemory(restoreMemory bool) (BuildableRestoreSnapshotParameters, error) {
+	r.restoreMemory = &restoreMemory
+	return r, nil
+}
+
+func (r *restoreSnapshotParams) MustWithRestoreMemory(restoreMemory bool) BuildableRestoreSnapshotParameters {
+	builder, err := r.WithRestoreMemory(restoreMemory)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (r *restoreSnapshotParams) WithDiskIDs(diskIDs []DiskID) (BuildableRestoreSnapshotParameters, error) {
+	if err := validateSnapshotDiskIDs(diskIDs); err != nil {
+		return nil, err
+	}
+	r.diskIDs = diskIDs
+	return r, nil
+}
+
+func (r *restoreSnapshotParams) MustWithDiskIDs(diskIDs []DiskID) BuildableRestoreSnapshotParameters {
+	builder, err := r.WithDiskIDs(diskIDs)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func validateSnapshotDiskIDs(diskIDs []DiskID) error {
+	for i, diskID := range diskIDs {
+		if diskID == "" {
+			return newError(EBadArgument, "disk ID #%d is empty", i)
+		}
+	}
+	return nil
+}
+
+// sdkSnapshotDisks converts a list of disk IDs to the SDK representation used in restore and preview calls.
+func sdkSnapshotDisks(diskIDs []DiskID) []*ovirtsdk4.Disk {
+	disks := make([]*ovirtsdk4.Disk, len(diskIDs))
+	for i, diskID := range diskIDs {
+		disks[i] = ovirtsdk4.NewDiskBuilder().Id(string(diskID)).MustBuild()
+	}
+	return disks
+}
+
+func convertSDKSnapshot(sdkObject *ovirtsdk4.Snapshot, vmID VMID, client Client) (Snapshot, error) {
+	id, ok := sdkObject.Id()
+	if !ok {
+		return nil, newFieldNotFound("snapshot", "id")
+	}
+	status, ok := sdkObject.SnapshotStatus()
+	if !ok {
+		return nil, newFieldNotFound("snapshot", "snapshot status")
+	}
+	snapshotType, ok := sdkObject.SnapshotType()
+	if !ok {
+		return nil, newFieldNotFound("snapshot", "snapshot type")
+	}
+	result := &snapshot{
+		client:       client,
+		id:           SnapshotID(id),
+		vmID:         vmID,
+		status:       SnapshotStatus(status),
+		snapshotType: SnapshotType(snapshotType),
+	}
+	if description, ok := sdkObject.Description(); ok {
+		result.description = description
+	}
+	if date, ok := sdkObject.Date(); ok {
+		result.date = date
+	}
+	if persistMemoryState, ok := sdkObject.PersistMemorystate(); ok {
+		result.persistMemoryState = persistMemoryState
+	}
+	return result, nil
+}
+
+type snapshot struct {
+	client Client
+
+	id                 SnapshotID
+	vmID               VMID
+	description        string
+	status             SnapshotStatus
+	snapshotType       SnapshotType
+	date               time.Time
+	persistMemoryState bool
+}
+
+func (s *snapshot) ID() SnapshotID {
+	return s.id
+}
+
+func (s *snapshot) VMID() VMID {
+	return s.vmID
+}
+
+func (s *snapshot) Description() string {
+	return s.description
+}
+
+func (s *snapshot) Status() SnapshotStatus {
+	return s.status
+}
+
+func (s *snapshot) Type() SnapshotType {
+	return s.snapshotType
+}
+
+func (s *snapshot) Date() time.Time {
+	return s.date
+}
+
+func (s *snapshot) PersistMemoryState() bool {
+	return s.persistMemoryState
+}
+
+func (s *snapshot) VM(retries ...RetryStrategy) (VM, error) {
+	return s.client.GetVM(s.vmID, retries...)
+}
+
+func (s *snapshot) Restore(params RestoreSnapshotOptionalParameters, retries ...RetryStrategy) error {
+	return s.client.RestoreVMSnapshot(s.vmID, s.id, params, retries...)
+}
+
+func (s *snapshot) Preview(params RestoreSnapshotOptionalParameters, retries ...RetryStrategy) error {
+	return s.client.PreviewVMSnapshot(s.vmID, s.id, params, retries...)
+}
+
+func (s *snapshot) Remove(retries ...RetryStrategy) error {
+	return s.client.RemoveVMSnapshot(s.vmID, s.id, retries...)
+}
+
+func (s *snapshot) WaitForStatus(status SnapshotStatus, retries ...RetryStrategy) (Snapshot, error) {
+	return s.client.WaitForSnapshotStatus(s.vmID, s.id, status, retries...)
+}
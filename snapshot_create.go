@@ -0,0 +1,115 @@
+package ovirtclient
+
+import (
+	"fmt"
+
+	ovirtsdk "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) CreateVMSnapshot(
+	vmID VMID,
+	description string,
+	params CreateSnapshotOptionalParameters,
+	retries ...RetryStrategy,
+) (result Snapshot, err error) {
+	writeRetries := defaultRetries(retries, defaultWriteTimeouts(o))
+
+	if params == nil {
+		params = CreateSnapshotParams()
+	}
+	builder := ovirtsdk.NewSnapshotBuilder().Description(description)
+	if persistMemoryState := params.PersistMemoryState(); persistMemoryState != nil {
+		builder.PersistMemorystate(*persistMemoryState)
+	}
+	if diskIDs := params.DiskIDs(); len(diskIDs) > 0 {
+		attachments := make([]*ovirtsdk.DiskAttachment, len(diskIDs))
+		for i, diskID := range diskIDs {
+			attachments[i] = ovirtsdk.NewDiskAttachmentBuilder().
+				Disk(ovirtsdk.NewDiskBuilder().Id(string(diskID)).MustBuild()).
+				MustBuild()
+		}
+		builder.DiskAttachmentsOfAny(attachments...)
+	}
+	sdkSnapshot, err := builder.Build()
+	if err != nil {
+		return nil, wrap(err, EBug, "failed to build snapshot object")
+	}
+
+	correlationID := fmt.Sprintf("snapshot_create_%s", generateRandomID(5, o.nonSecureRandom))
+	var snapshotID SnapshotID
+	err = retry(
+		fmt.Sprintf("creating snapshot for VM %s", vmID),
+		o.logger,
+		writeRetries,
+		func() error {
+			response, err := o.conn.
+				SystemService().
+				VmsService().
+				VmService(string(vmID)).
+				SnapshotsService().
+				Add().
+				Snapshot(sdkSnapshot).
+				Query("correlation_id", correlationID).
+				Send()
+			if err != nil {
+				return err
+			}
+			object, ok := response.Snapshot()
+			if !ok {
+				return newError(EFieldMissing, "missing snapshot object from snapshot creation response")
+			}
+			id, ok := object.Id()
+			if !ok {
+				return newFieldNotFound("snapshot", "id")
+			}
+			snapshotID = SnapshotID(id)
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return o.waitForSnapshotJob(correlationID, vmID, snapshotID, SnapshotStatusOK, retries)
+}
+
+func (m *mockClient) CreateVMSnapshot(
+	vmID VMID,
+	description string,
+	params CreateSnapshotOptionalParameters,
+	_ ...RetryStrategy,
+) (Snapshot, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if params == nil {
+		params = CreateSnapshotParams()
+	}
+	if previewed, _ := m.findPreviewedSnapshot(vmID); previewed != nil {
+		return nil, newError(
+			EConflict,
+			"cannot create snapshot, VM %s is previewing snapshot %s",
+			vmID,
+			previewed.id,
+		)
+	}
+
+	persistMemoryState := false
+	if p := params.PersistMemoryState(); p != nil {
+		persistMemoryState = *p
+	}
+	if item, ok := m.vms[vmID]; ok && item.status != VMStatusUp {
+		// The memory state can only be saved for running VMs.
+		persistMemoryState = false
+	}
+
+	s, err := m.captureVMSnapshot(vmID, description, SnapshotTypeRegular, persistMemoryState, params.DiskIDs())
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := m.snapshotsByVM[vmID]; !ok {
+		m.snapshotsByVM[vmID] = map[SnapshotID]*snapshotWithState{}
+	}
+	m.snapshotsByVM[vmID][s.id] = s
+	m.addDiskSnapshotLayers(s)
+	result := s.snapshot
+	return &result, nil
+}
@@ -0,0 +1,60 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) GetVMSnapshot(vmID VMID, id SnapshotID, retries ...RetryStrategy) (result Snapshot, err error) {
+	retries = defaultRetries(retries, defaultReadTimeouts(o))
+	err = retry(
+		fmt.Sprintf("getting snapshot %s of VM %s", id, vmID),
+		o.logger,
+		retries,
+		func() error {
+			response, err := o.conn.
+				SystemService().
+				VmsService().
+				VmService(string(vmID)).
+				SnapshotsService().
+				SnapshotService(string(id)).
+				Get().
+				Send()
+			if err != nil {
+				return err
+			}
+			sdkObject, ok := response.Snapshot()
+			if !ok {
+				return newError(
+					ENotFound,
+					"no snapshot returned when getting snapshot ID %s of VM %s",
+					id,
+					vmID,
+				)
+			}
+			result, err = convertSDKSnapshot(sdkObject, vmID, o)
+			if err != nil {
+				return wrap(
+					err,
+					EBug,
+					"failed to convert snapshot %s",
+					id,
+				)
+			}
+			return nil
+		})
+	return
+}
+
+func (m *mockClient) GetVMSnapshot(vmID VMID, id SnapshotID, _ ...RetryStrategy) (Snapshot, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.vms[vmID]; !ok {
+		return nil, newError(ENotFound, "vm with ID %s not found", vmID)
+	}
+	if item, ok := m.snapshotsByVM[vmID][id]; ok {
+		result := item.snapshot
+		return &result, nil
+	}
+	return nil, newError(ENotFound, "snapshot with ID %s not found for VM %s", id, vmID)
+}
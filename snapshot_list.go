@@ -0,0 +1,50 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) ListVMSnapshots(vmID VMID, retries ...RetryStrategy) (result []Snapshot, err error) {
+	retries = defaultRetries(retries, defaultReadTimeouts(o))
+	result = []Snapshot{}
+	err = retry(
+		fmt.Sprintf("listing snapshots of VM %s", vmID),
+		o.logger,
+		retries,
+		func() error {
+			response, e := o.conn.SystemService().VmsService().VmService(string(vmID)).SnapshotsService().List().Send()
+			if e != nil {
+				return e
+			}
+			sdkObjects, ok := response.Snapshots()
+			if !ok {
+				return nil
+			}
+			result = make([]Snapshot, len(sdkObjects.Slice()))
+			for i, sdkObject := range sdkObjects.Slice() {
+				result[i], e = convertSDKSnapshot(sdkObject, vmID, o)
+				if e != nil {
+					return wrap(e, EBug, "failed to convert snapshot during listing item #%d", i)
+				}
+			}
+			return nil
+		})
+	return
+}
+
+func (m *mockClient) ListVMSnapshots(vmID VMID, _ ...RetryStrategy) ([]Snapshot, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.vms[vmID]; !ok {
+		return nil, newError(ENotFound, "vm with ID %s not found", vmID)
+	}
+	result := make([]Snapshot, len(m.snapshotsByVM[vmID]))
+	i := 0
+	for _, item := range m.snapshotsByVM[vmID] {
+		s := item.snapshot
+		result[i] = &s
+		i++
+	}
+	return result, nil
+}
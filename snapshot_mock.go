@@ -0,0 +1,138 @@
+package ovirtclient
+
+import (
+	"sync"
+	"time"
+)
+
+// snapshotWithState adds the saved VM configuration and disk contents to a snapshot for mocking purposes.
+type snapshotWithState struct {
+	snapshot
+
+	vm    vm
+	disks map[DiskID]*diskWithData
+}
+
+// captureVMSnapshot saves the current state of the VM and its disks. If diskIDs is not empty, only the listed disks
+// are saved. The caller must hold the mock client lock.
+func (m *mockClient) captureVMSnapshot(
+	vmID VMID,
+	description string,
+	snapshotType SnapshotType,
+	persistMemoryState bool,
+	diskIDs []DiskID,
+) (*snapshotWithState, error) {
+	item, ok := m.vms[vmID]
+	if !ok {
+		return nil, newError(ENotFound, "vm with ID %s not found", vmID)
+	}
+
+	attachedDisks := map[DiskID]*diskWithData{}
+	for _, attachment := range m.vmDiskAttachmentsByVM[vmID] {
+		attachedDisks[attachment.DiskID()] = m.disks[attachment.DiskID()]
+	}
+	if len(diskIDs) > 0 {
+		selectedDisks := make(map[DiskID]*diskWithData, len(diskIDs))
+		for _, diskID := range diskIDs {
+			disk, ok := attachedDisks[diskID]
+			if !ok {
+				return nil, newError(EBadArgument, "disk %s is not attached to VM %s", diskID, vmID)
+			}
+			selectedDisks[diskID] = disk
+		}
+		attachedDisks = selectedDisks
+	}
+
+	result := &snapshotWithState{
+		snapshot: snapshot{
+			client:             m,
+			id:                 SnapshotID(m.GenerateUUID()),
+			vmID:               vmID,
+			description:        description,
+			status:             SnapshotStatusOK,
+			snapshotType:       snapshotType,
+			date:               time.Now(),
+			persistMemoryState: persistMemoryState,
+		},
+		vm:    *item,
+		disks: make(map[DiskID]*diskWithData, len(attachedDisks)),
+	}
+	result.vm.tagIDs = append([]TagID(nil), item.tagIDs...)
+	for diskID, disk := range attachedDisks {
+		if disk.status != DiskStatusOK {
+			return nil, newError(EDiskLocked, "disk %s is %s", diskID, disk.status)
+		}
+		result.disks[diskID] = &diskWithData{
+			disk: disk.disk,
+			lock: &sync.Mutex{},
+			data: append([]byte(nil), disk.data...),
+		}
+	}
+	return result, nil
+}
+
+// applyVMSnapshot reverts the VM and its disks to the state saved in the snapshot. If diskIDs is not empty, only the
+// listed disks are reverted. The caller must hold the mock client lock.
+func (m *mockClient) applyVMSnapshot(s *snapshotWithState, restoreMemory bool, diskIDs []DiskID) error {
+	item, ok := m.vms[s.vmID]
+	if !ok {
+		return newError(ENotFound, "vm with ID %s not found", s.vmID)
+	}
+	if restoreMemory && !s.persistMemoryState {
+		return newError(EBadArgument, "snapshot %s does not contain a memory state", s.id)
+	}
+	disks := s.disks
+	if len(diskIDs) > 0 {
+		disks = make(map[DiskID]*diskWithData, len(diskIDs))
+		for _, diskID := range diskIDs {
+			disk, ok := s.disks[diskID]
+			if !ok {
+				return newError(ENotFound, "disk %s is not part of snapshot %s", diskID, s.id)
+			}
+			disks[diskID] = disk
+		}
+	}
+	for diskID := range disks {
+		if current, ok := m.disks[diskID]; ok && current.status != DiskStatusOK {
+			return newError(EDiskLocked, "disk %s is %s", diskID, current.status)
+		}
+	}
+
+	restored := s.vm
+	restored.status = item.status
+	restored.hostID = item.hostID
+	restored.tagIDs = append([]TagID(nil), s.vm.tagIDs...)
+	*item = restored
+
+	for diskID, saved := range disks {
+		current, ok := m.disks[diskID]
+		if !ok {
+			// The disk has been removed since the snapshot was taken.
+			continue
+		}
+		restoredDisk := saved.disk
+		restoredDisk.status = current.status
+		restoredDisk.storageDomainIDs = current.storageDomainIDs
+		m.disks[diskID] = &diskWithData{
+			disk: restoredDisk,
+			lock: current.lock,
+			data: append([]byte(nil), saved.data...),
+		}
+		m.invalidateVMCheckpoints(diskID)
+	}
+	return nil
+}
+
+// findPreviewedSnapshot returns the snapshot currently in preview and the snapshot holding the state from before the
+// preview. The caller must hold the mock client lock.
+func (m *mockClient) findPreviewedSnapshot(vmID VMID) (previewed *snapshotWithState, before *snapshotWithState) {
+	for _, s := range m.snapshotsByVM[vmID] {
+		switch {
+		case s.status == SnapshotStatusInPreview:
+			previewed = s
+		case s.snapshotType == SnapshotTypePreview:
+			before = s
+		}
+	}
+	return previewed, before
+}
@@ -0,0 +1,186 @@
+package ovirtclient
+
+import (
+	"fmt"
+
+	ovirtsdk "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) PreviewVMSnapshot(
+	vmID VMID,
+	id SnapshotID,
+	params RestoreSnapshotOptionalParameters,
+	retries ...RetryStrategy,
+) (err error) {
+	writeRetries := defaultRetries(retries, defaultWriteTimeouts(o))
+	if params == nil {
+		params = RestoreSnapshotParams()
+	}
+	correlationID := fmt.Sprintf("snapshot_preview_%s", generateRandomID(5, o.nonSecureRandom))
+	err = retry(
+		fmt.Sprintf("previewing snapshot %s of VM %s", id, vmID),
+		o.logger,
+		writeRetries,
+		func() error {
+			request := o.conn.
+				SystemService().
+				VmsService().
+				VmService(string(vmID)).
+				PreviewSnapshot().
+				Snapshot(ovirtsdk.NewSnapshotBuilder().Id(string(id)).MustBuild()).
+				Query("correlation_id", correlationID)
+			if restoreMemory := params.RestoreMemory(); restoreMemory != nil {
+				request.RestoreMemory(*restoreMemory)
+			}
+			if diskIDs := params.DiskIDs(); len(diskIDs) > 0 {
+				request.DisksOfAny(sdkSnapshotDisks(diskIDs)...)
+			}
+			_, err := request.Send()
+			return err
+		})
+	if err != nil {
+		return err
+	}
+	_, err = o.waitForSnapshotJob(correlationID, vmID, id, SnapshotStatusInPreview, retries)
+	return err
+}
+
+func (o *oVirtClient) CommitVMSnapshot(vmID VMID, retries ...RetryStrategy) (err error) {
+	writeRetries := defaultRetries(retries, defaultWriteTimeouts(o))
+	correlationID := fmt.Sprintf("snapshot_commit_%s", generateRandomID(5, o.nonSecureRandom))
+	err = retry(
+		fmt.Sprintf("committing snapshot preview of VM %s", vmID),
+		o.logger,
+		writeRetries,
+		func() error {
+			_, err := o.conn.
+				SystemService().
+				VmsService().
+				VmService(string(vmID)).
+				CommitSnapshot().
+				Query("correlation_id", correlationID).
+				Send()
+			return err
+		})
+	if err != nil {
+		return err
+	}
+	if err := o.waitForJobFinished(correlationID, defaultRetries(retries, defaultLongTimeouts(o))); err != nil {
+		return wrap(err, EUnidentified, "failed to wait for snapshot commit on VM %s", vmID)
+	}
+	return nil
+}
+
+func (o *oVirtClient) UndoVMSnapshot(vmID VMID, retries ...RetryStrategy) (err error) {
+	writeRetries := defaultRetries(retries, defaultWriteTimeouts(o))
+	correlationID := fmt.Sprintf("snapshot_undo_%s", generateRandomID(5, o.nonSecureRandom))
+	err = retry(
+		fmt.Sprintf("undoing snapshot preview of VM %s", vmID),
+		o.logger,
+		writeRetries,
+		func() error {
+			_, err := o.conn.
+				SystemService().
+				VmsService().
+				VmService(string(vmID)).
+				UndoSnapshot().
+				Query("correlation_id", correlationID).
+				Send()
+			return err
+		})
+	if err != nil {
+		return err
+	}
+	if err := o.waitForJobFinished(correlationID, defaultRetries(retries, defaultLongTimeouts(o))); err != nil {
+		return wrap(err, EUnidentified, "failed to wait for snapshot undo on VM %s", vmID)
+	}
+	return nil
+}
+
+func (m *mockClient) PreviewVMSnapshot(
+	vmID VMID,
+	id SnapshotID,
+	params RestoreSnapshotOptionalParameters,
+	_ ...RetryStrategy,
+) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if params == nil {
+		params = RestoreSnapshotParams()
+	}
+	item, err := m.getSnapshotForRestore(vmID, id)
+	if err != nil {
+		return err
+	}
+	if previewed, _ := m.findPreviewedSnapshot(vmID); previewed != nil {
+		return newError(EConflict, "VM %s is already previewing snapshot %s", vmID, previewed.id)
+	}
+	before, err := m.captureVMSnapshot(vmID, "Active VM before the preview", SnapshotTypePreview, false, nil)
+	if err != nil {
+		return err
+	}
+	restoreMemory := false
+	if r := params.RestoreMemory(); r != nil {
+		restoreMemory = *r
+	}
+	if err := m.applyVMSnapshot(item, restoreMemory, params.DiskIDs()); err != nil {
+		return err
+	}
+	m.snapshotsByVM[vmID][before.id] = before
+	item.status = SnapshotStatusInPreview
+	return nil
+}
+
+func (m *mockClient) CommitVMSnapshot(vmID VMID, _ ...RetryStrategy) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	previewed, before, err := m.getSnapshotPreview(vmID)
+	if err != nil {
+		return err
+	}
+	delete(m.snapshotsByVM[vmID], before.id)
+	previewed.status = SnapshotStatusOK
+	m.removeSnapshotsAfter(previewed)
+	return nil
+}
+
+func (m *mockClient) UndoVMSnapshot(vmID VMID, _ ...RetryStrategy) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	previewed, before, err := m.getSnapshotPreview(vmID)
+	if err != nil {
+		return err
+	}
+	if err := m.applyVMSnapshot(before, false, nil); err != nil {
+		return err
+	}
+	delete(m.snapshotsByVM[vmID], before.id)
+	previewed.status = SnapshotStatusOK
+	return nil
+}
+
+// getSnapshotPreview returns the snapshot in preview and the saved state from before the preview if the VM is in a
+// state where the preview can be committed or undone. The caller must hold the mock client lock.
+func (m *mockClient) getSnapshotPreview(vmID VMID) (*snapshotWithState, *snapshotWithState, error) {
+	vm, ok := m.vms[vmID]
+	if !ok {
+		return nil, nil, newError(ENotFound, "vm with ID %s not found", vmID)
+	}
+	previewed, before := m.findPreviewedSnapshot(vmID)
+	if previewed == nil || before == nil {
+		return nil, nil, newError(EConflict, "VM %s is not previewing a snapshot", vmID)
+	}
+	if vm.status != VMStatusDown {
+		return nil, nil, newError(
+			EConflict,
+			"cannot finish snapshot preview, VM %s is \"%s\" not \"%s\"",
+			vmID,
+			vm.status,
+			VMStatusDown,
+		)
+	}
+	return previewed, before, nil
+}
@@ -0,0 +1,52 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) RemoveVMSnapshot(vmID VMID, id SnapshotID, retries ...RetryStrategy) (err error) {
+	writeRetries := defaultRetries(retries, defaultWriteTimeouts(o))
+	correlationID := fmt.Sprintf("snapshot_remove_%s", generateRandomID(5, o.nonSecureRandom))
+	err = retry(
+		fmt.Sprintf("removing snapshot %s of VM %s", id, vmID),
+		o.logger,
+		writeRetries,
+		func() error {
+			_, err := o.conn.
+				SystemService().
+				VmsService().
+				VmService(string(vmID)).
+				SnapshotsService().
+				SnapshotService(string(id)).
+				Remove().
+				Query("correlation_id", correlationID).
+				Send()
+			return err
+		})
+	if err != nil {
+		return err
+	}
+	if err := o.waitForJobFinished(correlationID, defaultRetries(retries, defaultLongTimeouts(o))); err != nil {
+		return wrap(err, EUnidentified, "failed to wait for removal of snapshot %s", id)
+	}
+	return nil
+}
+
+func (m *mockClient) RemoveVMSnapshot(vmID VMID, id SnapshotID, _ ...RetryStrategy) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.vms[vmID]; !ok {
+		return newError(ENotFound, "vm with ID %s not found", vmID)
+	}
+	item, ok := m.snapshotsByVM[vmID][id]
+	if !ok {
+		return newError(ENotFound, "snapshot with ID %s not found for VM %s", id, vmID)
+	}
+	if item.status == SnapshotStatusInPreview || item.snapshotType == SnapshotTypePreview {
+		return newError(EConflict, "cannot remove snapshot %s while it is used in a preview", id)
+	}
+	delete(m.snapshotsByVM[vmID], id)
+	m.removeDiskSnapshotLayers(item)
+	return nil
+}
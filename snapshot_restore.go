@@ -0,0 +1,119 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) RestoreVMSnapshot(
+	vmID VMID,
+	id SnapshotID,
+	params RestoreSnapshotOptionalParameters,
+	retries ...RetryStrategy,
+) (err error) {
+	writeRetries := defaultRetries(retries, defaultWriteTimeouts(o))
+	if params == nil {
+		params = RestoreSnapshotParams()
+	}
+	correlationID := fmt.Sprintf("snapshot_restore_%s", generateRandomID(5, o.nonSecureRandom))
+	err = retry(
+		fmt.Sprintf("restoring snapshot %s of VM %s", id, vmID),
+		o.logger,
+		writeRetries,
+		func() error {
+			request := o.conn.
+				SystemService().
+				VmsService().
+				VmService(string(vmID)).
+				SnapshotsService().
+				SnapshotService(string(id)).
+				Restore().
+				Query("correlation_id", correlationID)
+			if restoreMemory := params.RestoreMemory(); restoreMemory != nil {
+				request.RestoreMemory(*restoreMemory)
+			}
+			if diskIDs := params.DiskIDs(); len(diskIDs) > 0 {
+				request.DisksOfAny(sdkSnapshotDisks(diskIDs)...)
+			}
+			_, err := request.Send()
+			return err
+		})
+	if err != nil {
+		return err
+	}
+	_, err = o.waitForSnapshotJob(correlationID, vmID, id, SnapshotStatusOK, retries)
+	return err
+}
+
+func (m *mockClient) RestoreVMSnapshot(
+	vmID VMID,
+	id SnapshotID,
+	params RestoreSnapshotOptionalParameters,
+	_ ...RetryStrategy,
+) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if params == nil {
+		params = RestoreSnapshotParams()
+	}
+	item, err := m.getSnapshotForRestore(vmID, id)
+	if err != nil {
+		return err
+	}
+	if previewed, _ := m.findPreviewedSnapshot(vmID); previewed != nil {
+		return newError(
+			EConflict,
+			"cannot restore snapshot %s, VM %s is previewing snapshot %s",
+			id,
+			vmID,
+			previewed.id,
+		)
+	}
+	restoreMemory := false
+	if r := params.RestoreMemory(); r != nil {
+		restoreMemory = *r
+	}
+	if err := m.applyVMSnapshot(item, restoreMemory, params.DiskIDs()); err != nil {
+		return err
+	}
+	m.removeSnapshotsAfter(item)
+	return nil
+}
+
+// getSnapshotForRestore returns the snapshot if the VM is in a state where the snapshot can be restored or previewed.
+// The caller must hold the mock client lock.
+func (m *mockClient) getSnapshotForRestore(vmID VMID, id SnapshotID) (*snapshotWithState, error) {
+	vm, ok := m.vms[vmID]
+	if !ok {
+		return nil, newError(ENotFound, "vm with ID %s not found", vmID)
+	}
+	item, ok := m.snapshotsByVM[vmID][id]
+	if !ok {
+		return nil, newError(ENotFound, "snapshot with ID %s not found for VM %s", id, vmID)
+	}
+	if vm.status != VMStatusDown {
+		return nil, newError(
+			EConflict,
+			"cannot restore snapshot %s, VM %s is \"%s\" not \"%s\"",
+			id,
+			vmID,
+			vm.status,
+			VMStatusDown,
+		)
+	}
+	if item.status != SnapshotStatusOK {
+		return nil, newError(EConflict, "cannot restore snapshot %s, snapshot is %s", id, item.status)
+	}
+	return item, nil
+}
+
+// removeSnapshotsAfter removes all regular snapshots taken after the specified snapshot, as the engine does when
+// a snapshot is restored or committed. The caller must hold the mock client lock.
+func (m *mockClient) removeSnapshotsAfter(s *snapshotWithState) {
+	for id, other := range m.snapshotsByVM[s.vmID] {
+		if other.snapshotType == SnapshotTypeRegular && other.date.After(s.date) {
+			delete(m.snapshotsByVM[s.vmID], id)
+			m.removeDiskSnapshotLayers(other)
+		}
+	}
+}
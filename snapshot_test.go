@@ -0,0 +1,205 @@
+package ovirtclient_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	ovirtclient "github.com/dyudin0821/go-ovirt-client/v3"
+)
+
+func TestVMSnapshotCreateAndRemove(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+
+	vm := assertCanCreateVM(t, helper, helper.GenerateTestResourceName(t), nil)
+	snapshot := assertCanCreateSnapshot(t, vm, "test snapshot", nil)
+
+	if snapshot.Description() != "test snapshot" {
+		t.Fatalf("Incorrect snapshot description: %s", snapshot.Description())
+	}
+	if snapshot.Status() != ovirtclient.SnapshotStatusOK {
+		t.Fatalf("Snapshot is in %s status instead of %s.", snapshot.Status(), ovirtclient.SnapshotStatusOK)
+	}
+	if snapshot.VMID() != vm.ID() {
+		t.Fatalf("Incorrect VM ID on snapshot: %s instead of %s.", snapshot.VMID(), vm.ID())
+	}
+
+	assertSnapshotListHasSnapshot(t, vm, snapshot.ID())
+
+	if err := snapshot.Remove(); err != nil {
+		t.Fatalf("Failed to remove snapshot %s. (%v)", snapshot.ID(), err)
+	}
+	_, err := helper.GetClient().GetVMSnapshot(vm.ID(), snapshot.ID())
+	if err == nil {
+		t.Fatalf("Snapshot %s still exists after removal.", snapshot.ID())
+	}
+	if !ovirtclient.HasErrorCode(err, ovirtclient.ENotFound) {
+		t.Fatalf("Fetching a removed snapshot did not return an %s error. (%v)", ovirtclient.ENotFound, err)
+	}
+}
+
+func TestVMSnapshotRestore(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+
+	vm := assertCanCreateVM(
+		t,
+		helper,
+		helper.GenerateTestResourceName(t),
+		ovirtclient.NewCreateVMParams().MustWithComment("before"),
+	)
+	snapshot := assertCanCreateSnapshot(t, vm, "before update", nil)
+	assertCanUpdateVMComment(t, vm, "after")
+
+	if err := snapshot.Restore(nil); err != nil {
+		t.Fatalf("Failed to restore snapshot %s. (%v)", snapshot.ID(), err)
+	}
+	assertVMComment(t, helper, vm.ID(), "before")
+}
+
+func TestVMSnapshotPreviewUndo(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+
+	vm := assertCanCreateVM(
+		t,
+		helper,
+		helper.GenerateTestResourceName(t),
+		ovirtclient.NewCreateVMParams().MustWithComment("before"),
+	)
+	snapshot := assertCanCreateSnapshot(t, vm, "before update", nil)
+	assertCanUpdateVMComment(t, vm, "after")
+
+	if err := snapshot.Preview(nil); err != nil {
+		t.Fatalf("Failed to preview snapshot %s. (%v)", snapshot.ID(), err)
+	}
+	if _, err := client.WaitForSnapshotStatus(vm.ID(), snapshot.ID(), ovirtclient.SnapshotStatusInPreview); err != nil {
+		t.Fatalf("Snapshot %s did not reach the %s status. (%v)", snapshot.ID(), ovirtclient.SnapshotStatusInPreview, err)
+	}
+	assertVMComment(t, helper, vm.ID(), "before")
+
+	if err := client.UndoVMSnapshot(vm.ID()); err != nil {
+		t.Fatalf("Failed to undo snapshot preview on VM %s. (%v)", vm.ID(), err)
+	}
+	if _, err := snapshot.WaitForStatus(ovirtclient.SnapshotStatusOK); err != nil {
+		t.Fatalf("Snapshot %s did not return to the %s status. (%v)", snapshot.ID(), ovirtclient.SnapshotStatusOK, err)
+	}
+	assertVMComment(t, helper, vm.ID(), "after")
+}
+
+func TestVMSnapshotPreviewCommit(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+
+	vm := assertCanCreateVM(
+		t,
+		helper,
+		helper.GenerateTestResourceName(t),
+		ovirtclient.NewCreateVMParams().MustWithComment("before"),
+	)
+	snapshot := assertCanCreateSnapshot(t, vm, "before update", nil)
+	assertCanUpdateVMComment(t, vm, "after")
+
+	if err := client.PreviewVMSnapshot(vm.ID(), snapshot.ID(), nil); err != nil {
+		t.Fatalf("Failed to preview snapshot %s. (%v)", snapshot.ID(), err)
+	}
+	if err := client.CommitVMSnapshot(vm.ID()); err != nil {
+		t.Fatalf("Failed to commit snapshot preview on VM %s. (%v)", vm.ID(), err)
+	}
+	if _, err := snapshot.WaitForStatus(ovirtclient.SnapshotStatusOK); err != nil {
+		t.Fatalf("Snapshot %s did not return to the %s status. (%v)", snapshot.ID(), ovirtclient.SnapshotStatusOK, err)
+	}
+	assertVMComment(t, helper, vm.ID(), "before")
+}
+
+func TestVMSnapshotRestoreDiskData(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+
+	vm := assertCanCreateVM(t, helper, helper.GenerateTestResourceName(t), nil)
+	disk := assertCanCreateDisk(t, helper)
+	assertCanAttachDisk(t, vm, disk)
+
+	snapshot := assertCanCreateSnapshot(
+		t,
+		vm,
+		"empty disk",
+		ovirtclient.CreateSnapshotParams().MustWithDiskIDs([]ovirtclient.DiskID{disk.ID()}),
+	)
+	assertCanUploadDiskImage(t, helper, disk)
+
+	if err := client.RestoreVMSnapshot(
+		vm.ID(),
+		snapshot.ID(),
+		ovirtclient.RestoreSnapshotParams().MustWithDiskIDs([]ovirtclient.DiskID{disk.ID()}),
+	); err != nil {
+		t.Fatalf("Failed to restore snapshot %s. (%v)", snapshot.ID(), err)
+	}
+
+	imageDownload, err := client.DownloadDisk(disk.ID(), ovirtclient.ImageFormatRaw)
+	if err != nil {
+		t.Fatalf("Failed to download disk %s after restore. (%v)", disk.ID(), err)
+	}
+	defer func() {
+		_ = imageDownload.Close()
+	}()
+	data, err := io.ReadAll(imageDownload)
+	if err != nil {
+		t.Fatalf("Failed to download disk %s after restore. (%v)", disk.ID(), err)
+	}
+	testImageData, _ := getTestImageData(t)
+	if len(data) >= len(testImageData) && bytes.Equal(data[:len(testImageData)], testImageData) {
+		t.Fatalf("The disk contents were not restored from snapshot %s.", snapshot.ID())
+	}
+}
+
+func assertCanCreateSnapshot(
+	t *testing.T,
+	vm ovirtclient.VM,
+	description string,
+	params ovirtclient.CreateSnapshotOptionalParameters,
+) ovirtclient.Snapshot {
+	snapshot, err := vm.CreateSnapshot(description, params)
+	if err != nil {
+		t.Fatalf("Failed to create snapshot of VM %s. (%v)", vm.ID(), err)
+	}
+	t.Cleanup(func() {
+		if err := snapshot.Remove(); err != nil && !ovirtclient.HasErrorCode(err, ovirtclient.ENotFound) {
+			t.Fatalf("Failed to clean up snapshot %s of VM %s. (%v)", snapshot.ID(), vm.ID(), err)
+		}
+	})
+	return snapshot
+}
+
+func assertSnapshotListHasSnapshot(t *testing.T, vm ovirtclient.VM, id ovirtclient.SnapshotID) {
+	snapshots, err := vm.ListSnapshots()
+	if err != nil {
+		t.Fatalf("Failed to list snapshots of VM %s. (%v)", vm.ID(), err)
+	}
+	for _, snapshot := range snapshots {
+		if snapshot.ID() == id {
+			return
+		}
+	}
+	t.Fatalf("Snapshot %s not found in snapshot list of VM %s.", id, vm.ID())
+}
+
+func assertCanUpdateVMComment(t *testing.T, vm ovirtclient.VM, comment string) {
+	if _, err := vm.Update(ovirtclient.UpdateVMParams().MustWithComment(comment)); err != nil {
+		t.Fatalf("Failed to update comment of VM %s. (%v)", vm.ID(), err)
+	}
+}
+
+func assertVMComment(t *testing.T, helper ovirtclient.TestHelper, vmID ovirtclient.VMID, comment string) {
+	vm, err := helper.GetClient().GetVM(vmID)
+	if err != nil {
+		t.Fatalf("Failed to fetch VM %s. (%v)", vmID, err)
+	}
+	if vm.Comment() != comment {
+		t.Fatalf("Incorrect VM comment: %s instead of %s.", vm.Comment(), comment)
+	}
+}
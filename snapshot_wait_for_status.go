@@ -0,0 +1,76 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) WaitForSnapshotStatus(
+	vmID VMID,
+	id SnapshotID,
+	status SnapshotStatus,
+	retries ...RetryStrategy,
+) (result Snapshot, err error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(o))
+	if err := status.Validate(); err != nil {
+		return nil, err
+	}
+	err = retry(
+		fmt.Sprintf("waiting for snapshot %s of VM %s status %s", id, vmID, status),
+		o.logger,
+		retries,
+		func() error {
+			result, err = o.GetVMSnapshot(vmID, id, retries...)
+			if err != nil {
+				return err
+			}
+			if result.Status() != status {
+				return newError(EPending, "snapshot status is %s, not %s", result.Status(), status)
+			}
+			return nil
+		})
+	return
+}
+
+// waitForSnapshotJob waits for the engine job identified by correlationID to finish, then waits for the snapshot to
+// reach the desired status. The snapshot status alone is not reliable as it may change before the disk operations
+// started by the job are complete.
+func (o *oVirtClient) waitForSnapshotJob(
+	correlationID string,
+	vmID VMID,
+	id SnapshotID,
+	status SnapshotStatus,
+	retries []RetryStrategy,
+) (Snapshot, error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(o))
+	if err := o.waitForJobFinished(correlationID, retries); err != nil {
+		return nil, wrap(err, EUnidentified, "failed to wait for snapshot %s job to finish", id)
+	}
+	return o.WaitForSnapshotStatus(vmID, id, status, retries...)
+}
+
+func (m *mockClient) WaitForSnapshotStatus(
+	vmID VMID,
+	id SnapshotID,
+	status SnapshotStatus,
+	retries ...RetryStrategy,
+) (result Snapshot, err error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(m))
+	if err := status.Validate(); err != nil {
+		return nil, err
+	}
+	err = retry(
+		fmt.Sprintf("waiting for snapshot %s of VM %s status %s", id, vmID, status),
+		m.logger,
+		retries,
+		func() error {
+			result, err = m.GetVMSnapshot(vmID, id, retries...)
+			if err != nil {
+				return err
+			}
+			if result.Status() != status {
+				return newError(EPending, "snapshot status is %s, not %s", result.Status(), status)
+			}
+			return nil
+		})
+	return
+}
@@ -0,0 +1,489 @@
+package ovirtclient
+
+import (
+	ovirtsdk4 "github.com/ovirt/go-ovirt"
+)
+
+// StorageConnectionID is the identifier of a storage connection.
+type StorageConnectionID string
+
+// StorageConnectionClient contains the API portion that deals with storage connections. Storage connections describe
+// how hosts connect to the storage backing a storage domain, for example an NFS export or an iSCSI target. The engine
+// creates them when a storage domain is created, they can be managed separately to change the connection details of
+// an existing storage domain or to prepare the connections for block storage domains.
+type StorageConnectionClient interface {
+	// ListStorageConnections lists all storage connections known to the engine.
+	ListStorageConnections(retries ...RetryStrategy) ([]StorageConnection, error)
+	// GetStorageConnection returns a single storage connection by its ID.
+	GetStorageConnection(id StorageConnectionID, retries ...RetryStrategy) (StorageConnection, error)
+	// CreateStorageConnection creates a new storage connection. Use NFSStorageConnectionParams,
+	// PosixFSStorageConnectionParams, GlusterFSStorageConnectionParams or ISCSIStorageConnectionParams to obtain
+	// the parameters.
+	CreateStorageConnection(
+		params CreateStorageConnectionParameters,
+		retries ...RetryStrategy,
+	) (StorageConnection, error)
+	// UpdateStorageConnection changes the details of a storage connection. Storage connections used by a storage
+	// domain can only be updated while the storage domain is in maintenance. Use UpdateStorageConnectionParams to
+	// obtain a buildable structure.
+	UpdateStorageConnection(
+		id StorageConnectionID,
+		params UpdateStorageConnectionParameters,
+		retries ...RetryStrategy,
+	) (StorageConnection, error)
+	// RemoveStorageConnection removes a storage connection. Storage connections used by a storage domain cannot be
+	// removed.
+	RemoveStorageConnection(id StorageConnectionID, retries ...RetryStrategy) error
+}
+
+// StorageConnectionData contains the data of a storage connection.
+type StorageConnectionData interface {
+	// ID is the unique identifier of the storage connection.
+	ID() StorageConnectionID
+	// StorageType is the type of storage the connection points to.
+	StorageType() StorageDomainType
+	// Address is the address of the NFS or GlusterFS server, or of the iSCSI portal.
+	Address() string
+	// Path is the exported path on the NFS server, the GlusterFS volume, or the device of a POSIX compliant file
+	// system.
+	Path() string
+	// VFSType is the file system type of POSIX compliant file system and GlusterFS connections.
+	VFSType() string
+	// MountOptions are the additional mount options of file storage connections.
+	MountOptions() string
+	// Port is the port of the iSCSI portal.
+	Port() uint
+	// Target is the name of the iSCSI target.
+	Target() string
+	// Username is the CHAP user name used to log in to the iSCSI target. The password is never returned.
+	Username() string
+}
+
+// StorageConnection is a connection to the storage backing storage domains.
+type StorageConnection interface {
+	StorageConnectionData
+
+	// Update changes the details of the current storage connection.
+	Update(params UpdateStorageConnectionParameters, retries ...RetryStrategy) (StorageConnection, error)
+	// Remove removes the current storage connection.
+	Remove(retries ...RetryStrategy) error
+}
+
+// CreateStorageConnectionParameters contains the parameters for creating a storage connection.
+type CreateStorageConnectionParameters interface {
+	// StorageType is the type of storage the connection points to.
+	StorageType() StorageDomainType
+	// Address is the address of the NFS or GlusterFS server, or of the iSCSI portal.
+	Address() string
+	// Path is the exported path on the NFS server, the GlusterFS volume, or the device of a POSIX compliant file
+	// system.
+	Path() string
+	// VFSType is the file system type of POSIX compliant file system and GlusterFS connections.
+	VFSType() string
+	// MountOptions are additional mount options for file storage connections.
+	MountOptions() string
+	// Port is the port of the iSCSI portal.
+	Port() uint
+	// Target is the name of the iSCSI target.
+	Target() string
+	// Username is the CHAP user name used to log in to the iSCSI target.
+	Username() string
+	// Password is the CHAP password used to log in to the iSCSI target.
+	Password() string
+}
+
+// BuildableCreateStorageConnectionParameters is a buildable version of CreateStorageConnectionParameters.
+type BuildableCreateStorageConnectionParameters interface {
+	CreateStorageConnectionParameters
+
+	// WithMountOptions sets additional mount options for file storage connections.
+	WithMountOptions(mountOptions string) (BuildableCreateStorageConnectionParameters, error)
+	// MustWithMountOptions is identical to WithMountOptions, but panics instead of returning an error.
+	MustWithMountOptions(mountOptions string) BuildableCreateStorageConnectionParameters
+
+	// WithCredentials sets the CHAP credentials used to log in to an iSCSI target.
+	WithCredentials(username string, password string) (BuildableCreateStorageConnectionParameters, error)
+	// MustWithCredentials is identical to WithCredentials, but panics instead of returning an error.
+	MustWithCredentials(username string, password string) BuildableCreateStorageConnectionParameters
+}
+
+// NFSStorageConnectionParams creates the parameters for a connection to the NFS export at address:path.
+func NFSStorageConnectionParams(address string, path string) BuildableCreateStorageConnectionParameters {
+	return &createStorageConnectionParams{
+		storageType: StorageDomainTypeNFS,
+		address:     address,
+		path:        path,
+	}
+}
+
+// PosixFSStorageConnectionParams creates the parameters for a connection to a POSIX compliant file system that is
+// mounted from path using the specified file system type.
+func PosixFSStorageConnectionParams(path string, vfsType string) BuildableCreateStorageConnectionParameters {
+	return &createStorageConnectionParams{
+		storageType: StorageDomainTypePosixFS,
+		path:        path,
+		vfsType:     vfsType,
+	}
+}
+
+// GlusterFSStorageConnectionParams creates the parameters for a connection to the GlusterFS volume served from
+// address.
+func GlusterFSStorageConnectionParams(address string, volume string) BuildableCreateStorageConnectionParameters {
+	return &createStorageConnectionParams{
+		storageType: StorageDomainTypeGlusterFS,
+		address:     address,
+		path:        volume,
+		vfsType:     "glusterfs",
+	}
+}
+
+// ISCSIStorageConnectionParams creates the parameters for a connection to an iSCSI target.
+func ISCSIStorageConnectionParams(address string, port uint, target string) BuildableCreateStorageConnectionParameters {
+	return &createStorageConnectionParams{
+		storageType: StorageDomainTypeISCSI,
+		address:     address,
+		port:        port,
+		target:      target,
+	}
+}
+
+type createStorageConnectionParams struct {
+	storageType  StorageDomainType
+	address      string
+	path         string
+	vfsType      string
+	mountOptions string
+	port         uint
+	target       string
+	username     string
+	password     string
+}
+
+func (c *createStorageConnectionParams) StorageType() StorageDomainType {
+	return c.storageType
+}
+
+func (c *createStorageConnectionParams) Address() string {
+	return c.address
+}
+
+func (c *createStorageConnectionParams) Path() string {
+	return c.path
+}
+
+func (c *createStorageConnectionParams) VFSType() string {
+	return c.vfsType
+}
+
+func (c *createStorageConnectionParams) MountOptions() string {
+	return c.mountOptions
+}
+
+func (c *createStorageConnectionParams) Port() uint {
+	return c.port
+}
+
+func (c *createStorageConnectionParams) Target() string {
+	return c.target
+}
+
+func (c *createStorageConnectionParams) Username() string {
+	return c.username
+}
+
+func (c *createStorageConnectionParams) Password() string {
+	return c.password
+}
+
+func (c *createStorageConnectionParams) WithMountOptions(
+	mountOptions string,
+) (BuildableCreateStorageConnectionParameters, error) {
+	if c.storageType == StorageDomainTypeISCSI {
+		return nil, newError(EBadArgument, "mount options cannot be set on %s storage connections", c.storageType)
+	}
+	c.mountOptions = mountOptions
+	return c, nil
+}
+
+func (c *createStorageConnectionParams) MustWithMountOptions(
+	mountOptions string,
+) BuildableCreateStorageConnectionParameters {
+	builder, err := c.WithMountOptions(mountOptions)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (c *createStorageConnectionParams) WithCredentials(
+	username string,
+	password string,
+) (BuildableCreateStorageConnectionParameters, error) {
+	if c.storageType != StorageDomainTypeISCSI {
+		return nil, newError(EBadArgument, "credentials cannot be set on %s storage connections", c.storageType)
+	}
+	c.username = username
+	c.password = password
+	return c, nil
+}
+
+func (c *createStorageConnectionParams) MustWithCredentials(
+	username string,
+	password string,
+) BuildableCreateStorageConnectionParameters {
+	builder, err := c.WithCredentials(username, password)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+// UpdateStorageConnectionParameters contains the details of a storage connection to change. Only the fields that
+// return a non-nil value are changed.
+type UpdateStorageConnectionParameters interface {
+	// Address is the new address of the NFS or GlusterFS server, or of the iSCSI portal.
+	Address() *string
+	// Path is the new path of the storage connection.
+	Path() *string
+	// MountOptions are the new mount options of a file storage connection.
+	MountOptions() *string
+	// Port is the new port of the iSCSI portal.
+	Port() *uint
+	// Target is the new name of the iSCSI target.
+	Target() *string
+}
+
+// BuildableUpdateStorageConnectionParameters is a buildable version of UpdateStorageConnectionParameters.
+type BuildableUpdateStorageConnectionParameters interface {
+	UpdateStorageConnectionParameters
+
+	// WithAddress sets the new address.
+	WithAddress(address string) (BuildableUpdateStorageConnectionParameters, error)
+	// MustWithAddress is identical to WithAddress, but panics instead of returning an error.
+	MustWithAddress(address string) BuildableUpdateStorageConnectionParameters
+
+	// WithPath sets the new path.
+	WithPath(path string) (BuildableUpdateStorageConnectionParameters, error)
+	// MustWithPath is identical to WithPath, but panics instead of returning an error.
+	MustWithPath(path string) BuildableUpdateStorageConnectionParameters
+
+	// WithMountOptions sets the new mount options.
+	WithMountOptions(mountOptions string) (BuildableUpdateStorageConnectionParameters, error)
+	// MustWithMountOptions is identical to WithMountOptions, but panics instead of returning an error.
+	MustWithMountOptions(mountOptions string) BuildableUpdateStorageConnectionParameters
+
+	// WithPort sets the new port.
+	WithPort(port uint) (BuildableUpdateStorageConnectionParameters, error)
+	// MustWithPort is identical to WithPort, but panics instead of returning an error.
+	MustWithPort(port uint) BuildableUpdateStorageConnectionParameters
+
+	// WithTarget sets the new target.
+	WithTarget(target string) (BuildableUpdateStorageConnectionParameters, error)
+	// MustWithTarget is identical to WithTarget, but panics instead of returning an error.
+	MustWithTarget(target string) BuildableUpdateStorageConnectionParameters
+}
+
+// UpdateStorageConnectionParams creates a buildable set of parameters for
+// StorageConnectionClient.UpdateStorageConnection.
+func UpdateStorageConnectionParams() BuildableUpdateStorageConnectionParameters {
+	return &updateStorageConnectionParams{}
+}
+
+type updateStorageConnectionParams struct {
+	address      *string
+	path         *string
+	mountOptions *string
+	port         *uint
+	target       *string
+}
+
+func (u *updateStorageConnectionParams) Address() *string {
+	return u.address
+}
+
+func (u *updateStorageConnectionParams) Path() *string {
+	return u.path
+}
+
+func (u *updateStorageConnectionParams) MountOptions() *string {
+	return u.mountOptions
+}
+
+func (u *updateStorageConnectionParams) Port() *uint {
+	return u.port
+}
+
+func (u *updateStorageConnectionParams) Target() *string {
+	return u.target
+}
+
+func (u *updateStorageConnectionParams) WithAddress(address string) (BuildableUpdateStorageConnectionParameters, error) {
+	u.address = &address
+	return u, nil
+}
+
+func (u *updateStorageConnectionParams) MustWithAddress(address string) BuildableUpdateStorageConnectionParameters {
+	builder, err := u.WithAddress(address)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (u *updateStorageConnectionParams) WithPath(path string) (BuildableUpdateStorageConnectionParameters, error) {
+	u.path = &path
+	return u, nil
+}
+
+func (u *updateStorageConnectionParams) MustWithPath(path string) BuildableUpdateStorageConnectionParameters {
+	builder, err := u.WithPath(path)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (u *updateStorageConnectionParams) WithMountOptions(
+	mountOptions string,
+) (BuildableUpdateStorageConnectionParameters, error) {
+	u.mountOptions = &mountOptions
+	return u, nil
+}
+
+func (u *updateStorageConnectionParams) MustWithMountOptions(
+	mountOptions string,
+) BuildableUpdateStorageConnectionParameters {
+	builder, err := u.WithMountOptions(mountOptions)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (u *updateStorageConnectionParams) WithPort(port uint) (BuildableUpdateStorageConnectionParameters, error) {
+	u.port = &port
+	return u, nil
+}
+
+func (u *updateStorageConnectionParams) MustWithPort(port uint) BuildableUpdateStorageConnectionParameters {
+	builder, err := u.WithPort(port)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (u *updateStorageConnectionParams) WithTarget(target string) (BuildableUpdateStorageConnectionParameters, error) {
+	u.target = &target
+	return u, nil
+}
+
+func (u *updateStorageConnectionParams) MustWithTarget(target string) BuildableUpdateStorageConnectionParameters {
+	builder, err := u.WithTarget(target)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func convertSDKStorageConnection(
+	sdkStorageConnection *ovirtsdk4.StorageConnection,
+	client Client,
+) (StorageConnection, error) {
+	id, ok := sdkStorageConnection.Id()
+	if !ok {
+		return nil, newFieldNotFound("storage connection", "id")
+	}
+	storageType, ok := sdkStorageConnection.Type()
+	if !ok {
+		return nil, newFieldNotFound("storage connection", "type")
+	}
+	result := &storageConnection{
+		client:      client,
+		id:          StorageConnectionID(id),
+		storageType: StorageDomainType(storageType),
+	}
+	if address, ok := sdkStorageConnection.Address(); ok {
+		result.address = address
+	}
+	if path, ok := sdkStorageConnection.Path(); ok {
+		result.path = path
+	}
+	if vfsType, ok := sdkStorageConnection.VfsType(); ok {
+		result.vfsType = vfsType
+	}
+	if mountOptions, ok := sdkStorageConnection.MountOptions(); ok {
+		result.mountOptions = mountOptions
+	}
+	if port, ok := sdkStorageConnection.Port(); ok {
+		result.port = uint(port) //nolint:gosec
+	}
+	if target, ok := sdkStorageConnection.Target(); ok {
+		result.target = target
+	}
+	if username, ok := sdkStorageConnection.Username(); ok {
+		result.username = username
+	}
+	return result, nil
+}
+
+type storageConnection struct {
+	client Client
+
+	id           StorageConnectionID
+	storageType  StorageDomainType
+	address      string
+	path         string
+	vfsType      string
+	mountOptions string
+	port         uint
+	target       string
+	username     string
+}
+
+func (s *storageConnection) ID() StorageConnectionID {
+	return s.id
+}
+
+func (s *storageConnection) StorageType() StorageDomainType {
+	return s.storageType
+}
+
+func (s *storageConnection) Address() string {
+	return s.address
+}
+
+func (s *storageConnection) Path() string {
+	return s.path
+}
+
+func (s *storageConnection) VFSType() string {
+	return s.vfsType
+}
+
+func (s *storageConnection) MountOptions() string {
+	return s.mountOptions
+}
+
+func (s *storageConnection) Port() uint {
+	return s.port
+}
+
+func (s *storageConnection) Target() string {
+	return s.target
+}
+
+func (s *storageConnection) Username() string {
+	return s.username
+}
+
+func (s *storageConnection) Update(
+	params UpdateStorageConnectionParameters,
+	retries ...RetryStrategy,
+) (StorageConnection, error) {
+	return s.client.UpdateStorageConnection(s.id, params, retries...)
+}
+
+func (s *storageConnection) Remove(retries ...RetryStrategy) error {
+	return s.client.RemoveStorageConnection(s.id, retries...)
+}
@@ -0,0 +1,142 @@
+package ovirtclient
+
+import (
+	"fmt"
+
+	ovirtsdk4 "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) CreateStorageConnection(
+	params CreateStorageConnectionParameters,
+	retries ...RetryStrategy,
+) (result StorageConnection, err error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	if err := validateCreateStorageConnectionParams(params); err != nil {
+		return nil, err
+	}
+	sdkStorageConnection, err := buildStorageConnectionObjectForCreation(params)
+	if err != nil {
+		return nil, wrap(err, EBug, "failed to construct storage connection object")
+	}
+	err = retry(
+		fmt.Sprintf("creating %s storage connection", params.StorageType()),
+		o.logger,
+		retries,
+		func() error {
+			response, err := o.conn.
+				SystemService().
+				StorageConnectionsService().
+				Add().
+				Connection(sdkStorageConnection).
+				Send()
+			if err != nil {
+				return wrap(err, EUnidentified, "failed to create %s storage connection", params.StorageType())
+			}
+			createdStorageConnection, ok := response.Connection()
+			if !ok {
+				return newFieldNotFound("storage connection add response", "connection")
+			}
+			result, err = convertSDKStorageConnection(createdStorageConnection, o)
+			if err != nil {
+				return wrap(err, EUnidentified, "failed to convert %s storage connection", params.StorageType())
+			}
+			return nil
+		},
+	)
+	return result, err
+}
+
+func validateCreateStorageConnectionParams(params CreateStorageConnectionParameters) error {
+	if params == nil {
+		return newError(EBadArgument, "parameters are required to create a storage connection")
+	}
+	switch params.StorageType() {
+	case StorageDomainTypeNFS, StorageDomainTypeGlusterFS:
+		if params.Address() == "" || params.Path() == "" {
+			return newError(
+				EBadArgument,
+				"the address and path are required to create a %s storage connection",
+				params.StorageType(),
+			)
+		}
+	case StorageDomainTypePosixFS:
+		if params.Path() == "" || params.VFSType() == "" {
+			return newError(
+				EBadArgument,
+				"the path and file system type are required to create a %s storage connection",
+				params.StorageType(),
+			)
+		}
+	case StorageDomainTypeISCSI:
+		if params.Address() == "" || params.Target() == "" {
+			return newError(
+				EBadArgument,
+				"the portal address and target are required to create a %s storage connection",
+				params.StorageType(),
+			)
+		}
+	default:
+		return newError(EBadArgument, "cannot create a storage connection with storage type %s", params.StorageType())
+	}
+	return nil
+}
+
+func buildStorageConnectionObjectForCreation(
+	params CreateStorageConnectionParameters,
+) (*ovirtsdk4.StorageConnection, error) {
+	builder := ovirtsdk4.NewStorageConnectionBuilder().Type(ovirtsdk4.StorageType(params.StorageType()))
+	if address := params.Address(); address != "" {
+		builder.Address(address)
+	}
+	if path := params.Path(); path != "" {
+		builder.Path(path)
+	}
+	if vfsType := params.VFSType(); vfsType != "" {
+		builder.VfsType(vfsType)
+	}
+	if mountOptions := params.MountOptions(); mountOptions != "" {
+		builder.MountOptions(mountOptions)
+	}
+	if port := params.Port(); port != 0 {
+		builder.Port(int64(port)) //nolint:gosec
+	}
+	if target := params.Target(); target != "" {
+		builder.Target(target)
+	}
+	if username := params.Username(); username != "" {
+		builder.Username(username).Password(params.Password())
+	}
+	return builder.Build()
+}
+
+func (m *mockClient) CreateStorageConnection(
+	params CreateStorageConnectionParameters,
+	_ ...RetryStrategy,
+) (StorageConnection, error) {
+	if err := validateCreateStorageConnectionParams(params); err != nil {
+		return nil, err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	result := &storageConnection{
+		client:       m,
+		id:           StorageConnectionID(m.GenerateUUID()),
+		storageType:  params.StorageType(),
+		address:      params.Address(),
+		path:         params.Path(),
+		vfsType:      params.VFSType(),
+		mountOptions: params.MountOptions(),
+		port:         params.Port(),
+		target:       params.Target(),
+		username:     params.Username(),
+	}
+	if result.storageType == StorageDomainTypeISCSI && result.port == 0 {
+		result.port = mockISCSIPort
+	}
+	if err := m.addStorageConnection(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,56 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) GetStorageConnection(
+	id StorageConnectionID,
+	retries ...RetryStrategy,
+) (result StorageConnection, err error) {
+	retries = defaultRetries(retries, defaultReadTimeouts(o))
+	err = retry(
+		fmt.Sprintf("getting storage connection %s", id),
+		o.logger,
+		retries,
+		func() error {
+			response, err := o.conn.
+				SystemService().
+				StorageConnectionsService().
+				StorageConnectionService(string(id)).
+				Get().
+				Send()
+			if err != nil {
+				return err
+			}
+			// The SDK misspells the name of the response field.
+			sdkObject, ok := response.Conection()
+			if !ok {
+				return newError(
+					ENotFound,
+					"no storage connection returned when getting storage connection ID %s",
+					id,
+				)
+			}
+			result, err = convertSDKStorageConnection(sdkObject, o)
+			if err != nil {
+				return wrap(
+					err,
+					EBug,
+					"failed to convert storage connection %s",
+					id,
+				)
+			}
+			return nil
+		})
+	return
+}
+
+func (m *mockClient) GetStorageConnection(id StorageConnectionID, _ ...RetryStrategy) (StorageConnection, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if item, ok := m.storageConnections[id]; ok {
+		return item, nil
+	}
+	return nil, newError(ENotFound, "storage connection with ID %s not found", id)
+}
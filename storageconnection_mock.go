@@ -0,0 +1,27 @@
+package ovirtclient
+
+// addStorageConnection adds a storage connection to the mock client unless a connection to the same storage already
+// exists. The caller must hold the mock client lock.
+func (m *mockClient) addStorageConnection(connection *storageConnection) error {
+	for _, existing := range m.storageConnections {
+		if existing.storageType == connection.storageType &&
+			existing.address == connection.address &&
+			existing.path == connection.path &&
+			existing.target == connection.target {
+			return newError(EConflict, "storage connection %s already connects to the same storage", existing.id)
+		}
+	}
+	m.storageConnections[connection.id] = connection
+	return nil
+}
+
+// removeStorageDomainConnections removes the storage connections used by the specified storage domain. The caller
+// must hold the mock client lock.
+func (m *mockClient) removeStorageDomainConnections(id StorageDomainID) {
+	for connectionID, storageDomainID := range m.storageConnectionDomains {
+		if storageDomainID == id {
+			delete(m.storageConnectionDomains, connectionID)
+			delete(m.storageConnections, connectionID)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) RemoveStorageConnection(id StorageConnectionID, retries ...RetryStrategy) error {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	return retry(
+		fmt.Sprintf("removing storage connection %s", id),
+		o.logger,
+		retries,
+		func() error {
+			_, err := o.conn.
+				SystemService().
+				StorageConnectionsService().
+				StorageConnectionService(string(id)).
+				Remove().
+				Send()
+			if err != nil {
+				return wrap(err, EUnidentified, "failed to remove storage connection %s", id)
+			}
+			return nil
+		},
+	)
+}
+
+func (m *mockClient) RemoveStorageConnection(id StorageConnectionID, _ ...RetryStrategy) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.storageConnections[id]; !ok {
+		return newError(ENotFound, "storage connection with ID %s not found", id)
+	}
+	if storageDomainID, ok := m.storageConnectionDomains[id]; ok {
+		return newError(EConflict, "storage connection %s is used by storage domain %s", id, storageDomainID)
+	}
+	delete(m.storageConnections, id)
+	return nil
+}
@@ -0,0 +1,183 @@
+package ovirtclient_test
+
+import (
+	"fmt"
+	"testing"
+
+	ovirtclient "github.com/dyudin0821/go-ovirt-client/v3"
+)
+
+func TestStorageConnectionLifecycle(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+	if _, ok := client.(ovirtclient.MockClient); !ok {
+		t.Skipf("Creating storage connections requires dedicated storage for the test environment, skipping.")
+	}
+
+	path := fmt.Sprintf("/exports/connection_test_%s", helper.GenerateRandomID(5))
+	connection, err := client.CreateStorageConnection(
+		ovirtclient.NFSStorageConnectionParams("nfs.example.com", path).MustWithMountOptions("soft"),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create storage connection. (%v)", err)
+	}
+	if connection.StorageType() != ovirtclient.StorageDomainTypeNFS {
+		t.Fatalf("Incorrect storage type on storage connection %s: %s.", connection.ID(), connection.StorageType())
+	}
+	if connection.Address() != "nfs.example.com" || connection.Path() != path {
+		t.Fatalf(
+			"Incorrect address or path on storage connection %s: %s:%s.",
+			connection.ID(),
+			connection.Address(),
+			connection.Path(),
+		)
+	}
+	if connection.MountOptions() != "soft" {
+		t.Fatalf("Incorrect mount options on storage connection %s: %s.", connection.ID(), connection.MountOptions())
+	}
+
+	_, err = client.CreateStorageConnection(ovirtclient.NFSStorageConnectionParams("nfs.example.com", path))
+	if !ovirtclient.HasErrorCode(err, ovirtclient.EConflict) {
+		t.Fatalf("Creating a duplicate storage connection did not result in an EConflict error. (%v)", err)
+	}
+
+	connections, err := client.ListStorageConnections()
+	if err != nil {
+		t.Fatalf("Failed to list storage connections. (%v)", err)
+	}
+	found := false
+	for _, listedConnection := range connections {
+		if listedConnection.ID() == connection.ID() {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("Storage connection %s not found in the storage connection list.", connection.ID())
+	}
+
+	newPath := path + "_updated"
+	updated, err := connection.Update(ovirtclient.UpdateStorageConnectionParams().MustWithPath(newPath))
+	if err != nil {
+		t.Fatalf("Failed to update storage connection %s. (%v)", connection.ID(), err)
+	}
+	if updated.Path() != newPath {
+		t.Fatalf("Incorrect path on updated storage connection %s: %s instead of %s.", updated.ID(), updated.Path(), newPath)
+	}
+	fetched, err := client.GetStorageConnection(connection.ID())
+	if err != nil {
+		t.Fatalf("Failed to get storage connection %s. (%v)", connection.ID(), err)
+	}
+	if fetched.Path() != newPath {
+		t.Fatalf("Update to storage connection %s was not persisted.", connection.ID())
+	}
+	_, err = connection.Update(ovirtclient.UpdateStorageConnectionParams().MustWithTarget("iqn.2022-01.com.example:nfs"))
+	if !ovirtclient.HasErrorCode(err, ovirtclient.EBadArgument) {
+		t.Fatalf("Setting a target on an NFS storage connection did not result in an EBadArgument error. (%v)", err)
+	}
+
+	if err := connection.Remove(); err != nil {
+		t.Fatalf("Failed to remove storage connection %s. (%v)", connection.ID(), err)
+	}
+	if _, err := client.GetStorageConnection(connection.ID()); !ovirtclient.HasErrorCode(err, ovirtclient.ENotFound) {
+		t.Fatalf("Removed storage connection %s can still be fetched. (%v)", connection.ID(), err)
+	}
+}
+
+func TestStorageConnectionOfStorageDomain(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+	if _, ok := client.(ovirtclient.MockClient); !ok {
+		t.Skipf("Creating storage domains requires dedicated storage for the test environment, skipping.")
+	}
+
+	hostID := assertCanFindOVAHost(t, helper)
+	datacenterID := assertCanFindTestDatacenter(t, helper)
+	path := fmt.Sprintf("/exports/connection_test_%s", helper.GenerateRandomID(5))
+	storageDomain, err := client.CreateStorageDomain(
+		ovirtclient.NFSStorageDomainParams(
+			fmt.Sprintf("sd_connection_test_%s", helper.GenerateRandomID(5)),
+			hostID,
+			"nfs.example.com",
+			path,
+		),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create storage domain. (%v)", err)
+	}
+	connection := assertCanFindStorageConnection(t, client, path)
+
+	if err := connection.Remove(); !ovirtclient.HasErrorCode(err, ovirtclient.EConflict) {
+		t.Fatalf("Removing storage connection %s in use did not result in an EConflict error. (%v)", connection.ID(), err)
+	}
+	if err := storageDomain.AttachToDatacenter(datacenterID); err != nil {
+		t.Fatalf("Failed to attach storage domain %s to datacenter %s. (%v)", storageDomain.ID(), datacenterID, err)
+	}
+	_, err = connection.Update(ovirtclient.UpdateStorageConnectionParams().MustWithAddress("nfs2.example.com"))
+	if !ovirtclient.HasErrorCode(err, ovirtclient.EConflict) {
+		t.Fatalf(
+			"Updating storage connection %s of an active storage domain did not result in an EConflict error. (%v)",
+			connection.ID(),
+			err,
+		)
+	}
+	if err := storageDomain.Deactivate(datacenterID); err != nil {
+		t.Fatalf("Failed to deactivate storage domain %s. (%v)", storageDomain.ID(), err)
+	}
+	updated, err := connection.Update(ovirtclient.UpdateStorageConnectionParams().MustWithAddress("nfs2.example.com"))
+	if err != nil {
+		t.Fatalf("Failed to update storage connection %s in maintenance. (%v)", connection.ID(), err)
+	}
+	if updated.Address() != "nfs2.example.com" {
+		t.Fatalf("Incorrect address on updated storage connection %s: %s.", updated.ID(), updated.Address())
+	}
+
+	if err := storageDomain.Detach(datacenterID); err != nil {
+		t.Fatalf("Failed to detach storage domain %s. (%v)", storageDomain.ID(), err)
+	}
+	if err := storageDomain.Remove(ovirtclient.RemoveStorageDomainParams().MustWithHostID(hostID)); err != nil {
+		t.Fatalf("Failed to remove storage domain %s. (%v)", storageDomain.ID(), err)
+	}
+	if _, err := client.GetStorageConnection(connection.ID()); !ovirtclient.HasErrorCode(err, ovirtclient.ENotFound) {
+		t.Fatalf("Storage connection %s still exists after removing its storage domain. (%v)", connection.ID(), err)
+	}
+}
+
+func TestCreateStorageConnectionInvalidParameters(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+
+	_, err := client.CreateStorageConnection(ovirtclient.NFSStorageConnectionParams("nfs.example.com", ""))
+	if !ovirtclient.HasErrorCode(err, ovirtclient.EBadArgument) {
+		t.Fatalf("Creating an NFS storage connection without a path did not result in an EBadArgument error. (%v)", err)
+	}
+	_, err = ovirtclient.NFSStorageConnectionParams("nfs.example.com", "/exports/data").WithCredentials("test", "test")
+	if !ovirtclient.HasErrorCode(err, ovirtclient.EBadArgument) {
+		t.Fatalf("Setting credentials on an NFS storage connection did not result in an EBadArgument error. (%v)", err)
+	}
+	_, err = ovirtclient.ISCSIStorageConnectionParams("iscsi.example.com", 3260, "iqn.2022-01.com.example:storage").
+		WithMountOptions("soft")
+	if !ovirtclient.HasErrorCode(err, ovirtclient.EBadArgument) {
+		t.Fatalf("Setting mount options on an iSCSI storage connection did not result in an EBadArgument error. (%v)", err)
+	}
+}
+
+func assertCanFindStorageConnection(
+	t *testing.T,
+	client ovirtclient.Client,
+	path string,
+) ovirtclient.StorageConnection {
+	connections, err := client.ListStorageConnections()
+	if err != nil {
+		t.Fatalf("Failed to list storage connections. (%v)", err)
+	}
+	for _, connection := range connections {
+		if connection.Path() == path {
+			return connection
+		}
+	}
+	t.Fatalf("No storage connection found for path %s.", path)
+	return nil
+}
@@ -0,0 +1,134 @@
+package ovirtclient
+
+import (
+	"fmt"
+
+	ovirtsdk4 "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) UpdateStorageConnection(
+	id StorageConnectionID,
+	params UpdateStorageConnectionParameters,
+	retries ...RetryStrategy,
+) (result StorageConnection, err error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	if params == nil {
+		return nil, newError(EBadArgument, "parameters are required to update storage connection %s", id)
+	}
+	sdkStorageConnection, err := buildStorageConnectionObjectForUpdate(params)
+	if err != nil {
+		return nil, wrap(err, EBug, "failed to construct storage connection object")
+	}
+	err = retry(
+		fmt.Sprintf("updating storage connection %s", id),
+		o.logger,
+		retries,
+		func() error {
+			response, err := o.conn.
+				SystemService().
+				StorageConnectionsService().
+				StorageConnectionService(string(id)).
+				Update().
+				Connection(sdkStorageConnection).
+				Send()
+			if err != nil {
+				return wrap(err, EUnidentified, "failed to update storage connection %s", id)
+			}
+			updatedStorageConnection, ok := response.Connection()
+			if !ok {
+				return newFieldNotFound("storage connection update response", "connection")
+			}
+			result, err = convertSDKStorageConnection(updatedStorageConnection, o)
+			if err != nil {
+				return wrap(err, EUnidentified, "failed to convert storage connection %s", id)
+			}
+			return nil
+		},
+	)
+	return result, err
+}
+
+func buildStorageConnectionObjectForUpdate(
+	params UpdateStorageConnectionParameters,
+) (*ovirtsdk4.StorageConnection, error) {
+	builder := ovirtsdk4.NewStorageConnectionBuilder()
+	if address := params.Address(); address != nil {
+		builder.Address(*address)
+	}
+	if path := params.Path(); path != nil {
+		builder.Path(*path)
+	}
+	if mountOptions := params.MountOptions(); mountOptions != nil {
+		builder.MountOptions(*mountOptions)
+	}
+	if port := params.Port(); port != nil {
+		builder.Port(int64(*port)) //nolint:gosec
+	}
+	if target := params.Target(); target != nil {
+		builder.Target(*target)
+	}
+	return builder.Build()
+}
+
+func (m *mockClient) UpdateStorageConnection(
+	id StorageConnectionID,
+	params UpdateStorageConnectionParameters,
+	_ ...RetryStrategy,
+) (StorageConnection, error) {
+	if params == nil {
+		return nil, newError(EBadArgument, "parameters are required to update storage connection %s", id)
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	connection, ok := m.storageConnections[id]
+	if !ok {
+		return nil, newError(ENotFound, "storage connection with ID %s not found", id)
+	}
+	if storageDomainID, ok := m.storageConnectionDomains[id]; ok {
+		sd := m.storageDomains[storageDomainID]
+		if sd.status != StorageDomainStatusMaintenance && sd.status != StorageDomainStatusUnattached {
+			return nil, newError(
+				EConflict,
+				"storage connection %s is used by storage domain %s in status %s, the storage domain must be in "+
+					"maintenance to update the connection",
+				id,
+				storageDomainID,
+				sd.status,
+			)
+		}
+	}
+	isISCSI := connection.storageType == StorageDomainTypeISCSI
+	if isISCSI && params.MountOptions() != nil {
+		return nil, newError(EBadArgument, "mount options cannot be set on %s storage connections", connection.storageType)
+	}
+	if !isISCSI && (params.Port() != nil || params.Target() != nil) {
+		return nil, newError(
+			EBadArgument,
+			"the port and target cannot be set on %s storage connections",
+			connection.storageType,
+		)
+	}
+
+	// Replace the connection with a copy so connections returned earlier keep their details like with the real
+	// client.
+	updated := *connection
+	if address := params.Address(); address != nil {
+		updated.address = *address
+	}
+	if path := params.Path(); path != nil {
+		updated.path = *path
+	}
+	if mountOptions := params.MountOptions(); mountOptions != nil {
+		updated.mountOptions = *mountOptions
+	}
+	if port := params.Port(); port != nil {
+		updated.port = *port
+	}
+	if target := params.Target(); target != nil {
+		updated.target = *target
+	}
+	m.storageConnections[id] = &updated
+	return &updated, nil
+}
@@ -26,6 +26,71 @@ type StorageDomainClient interface {
 	ListStorageDomainFiles(id StorageDomainID, refresh bool, retries ...RetryStrategy) (FileList, error)
 	// GetStorageDomainFile returns a single file from a storage domain by its ID.
 	GetStorageDomainFile(storageDomainID StorageDomainID, fileID FileID, retries ...RetryStrategy) (File, error)
+	// ListStorageDomainDiskSnapshots lists all disk layers stored on a storage domain. This includes layers that do
+	// not belong to any disk or VM snapshot anymore, which can be used to find orphaned layers that take up space.
+	ListStorageDomainDiskSnapshots(storageDomainID StorageDomainID, retries ...RetryStrategy) ([]DiskSnapshot, error)
+
+	// CreateStorageDomain creates a new data storage domain using the host specified in the parameters to connect to
+	// the storage. Use NFSStorageDomainParams, PosixFSStorageDomainParams, GlusterFSStorageDomainParams,
+	// ISCSIStorageDomainParams or FCPStorageDomainParams to obtain the parameters. The created storage domain is
+	// unattached and needs to be attached to a datacenter using AttachStorageDomainToDatacenter before use.
+	CreateStorageDomain(params CreateStorageDomainParameters, retries ...RetryStrategy) (StorageDomain, error)
+	// AttachStorageDomainToDatacenter attaches an unattached storage domain to a datacenter. The engine activates the
+	// storage domain after attaching it, use WaitForStorageDomainStatus to wait for it to become active.
+	AttachStorageDomainToDatacenter(
+		id StorageDomainID,
+		datacenterID DatacenterID,
+		retries ...RetryStrategy,
+	) error
+	// ActivateStorageDomain activates a storage domain in maintenance in the specified datacenter.
+	ActivateStorageDomain(id StorageDomainID, datacenterID DatacenterID, retries ...RetryStrategy) error
+	// DeactivateStorageDomain moves an active storage domain in the specified datacenter to maintenance. This fails
+	// with an EConflict error while running VMs use disks on the storage domain.
+	DeactivateStorageDomain(id StorageDomainID, datacenterID DatacenterID, retries ...RetryStrategy) error
+	// DetachStorageDomain detaches a storage domain in maintenance from the specified datacenter. The VMs, templates
+	// and disks on the storage domain are removed from the engine, they can be registered again after attaching the
+	// storage domain to a datacenter using RegisterVM, RegisterTemplate and RegisterDisk.
+	DetachStorageDomain(id StorageDomainID, datacenterID DatacenterID, retries ...RetryStrategy) error
+	// RemoveStorageDomain removes an unattached storage domain from the engine. Use RemoveStorageDomainParams to
+	// specify the host used for the removal and if the storage should be formatted. A storage domain that is no
+	// longer reachable can be removed from the engine database only by setting the destroy option.
+	RemoveStorageDomain(id StorageDomainID, params RemoveStorageDomainParameters, retries ...RetryStrategy) error
+	// WaitForStorageDomainStatus waits for a storage domain to reach the specified status and returns the storage
+	// domain. For storage domains attached to a datacenter the status in the datacenter is used.
+	WaitForStorageDomainStatus(
+		id StorageDomainID,
+		status StorageDomainStatus,
+		retries ...RetryStrategy,
+	) (StorageDomain, error)
+
+	// ListUnregisteredDisks lists the disks present on a storage domain that are not known to the engine, for example
+	// because the storage domain was detached from a data center and attached again. The storage domain must be
+	// active.
+	ListUnregisteredDisks(id StorageDomainID, retries ...RetryStrategy) ([]Disk, error)
+	// ListUnregisteredVMs lists the VMs stored on a storage domain that are not known to the engine.
+	ListUnregisteredVMs(id StorageDomainID, retries ...RetryStrategy) ([]VM, error)
+	// ListUnregisteredTemplates lists the templates stored on a storage domain that are not known to the engine.
+	ListUnregisteredTemplates(id StorageDomainID, retries ...RetryStrategy) ([]Template, error)
+	// RegisterDisk registers an unregistered disk from a storage domain with the engine and returns the registered
+	// disk.
+	RegisterDisk(id StorageDomainID, diskID DiskID, retries ...RetryStrategy) (Disk, error)
+	// RegisterVM registers an unregistered VM from a storage domain with the engine in the cluster specified in the
+	// parameters and returns the registered VM. Use RegistrationParams to obtain the parameters.
+	RegisterVM(
+		id StorageDomainID,
+		vmID VMID,
+		params RegistrationParameters,
+		retries ...RetryStrategy,
+	) (VM, error)
+	// RegisterTemplate registers an unregistered template from a storage domain with the engine in the cluster
+	// specified in the parameters and returns the registered template. Use RegistrationParams to obtain the
+	// parameters.
+	RegisterTemplate(
+		id StorageDomainID,
+		templateID TemplateID,
+		params RegistrationParameters,
+		retries ...RetryStrategy,
+	) (Template, error)
 }
 
 // StorageDomainData is the core of StorageDomain, providing only data access functions.
@@ -48,6 +113,37 @@ type StorageDomainData interface {
 // StorageDomain represents a storage domain returned from the oVirt Engine API.
 type StorageDomain interface {
 	StorageDomainData
+
+	// ListDiskSnapshots lists all disk layers stored on the current storage domain, including layers that no longer
+	// belong to a disk or VM snapshot.
+	ListDiskSnapshots(retries ...RetryStrategy) ([]DiskSnapshot, error)
+
+	// AttachToDatacenter attaches the current storage domain to a datacenter. See
+	// StorageDomainClient.AttachStorageDomainToDatacenter for details.
+	AttachToDatacenter(datacenterID DatacenterID, retries ...RetryStrategy) error
+	// Activate activates the current storage domain in the specified datacenter.
+	Activate(datacenterID DatacenterID, retries ...RetryStrategy) error
+	// Deactivate moves the current storage domain in the specified datacenter to maintenance.
+	Deactivate(datacenterID DatacenterID, retries ...RetryStrategy) error
+	// Detach detaches the current storage domain from the specified datacenter.
+	Detach(datacenterID DatacenterID, retries ...RetryStrategy) error
+	// Remove removes the current storage domain. See StorageDomainClient.RemoveStorageDomain for details.
+	Remove(params RemoveStorageDomainParameters, retries ...RetryStrategy) error
+	// WaitForStatus waits for the current storage domain to reach the specified status.
+	WaitForStatus(status StorageDomainStatus, retries ...RetryStrategy) (StorageDomain, error)
+
+	// ListUnregisteredDisks lists the disks on the current storage domain that are not known to the engine.
+	ListUnregisteredDisks(retries ...RetryStrategy) ([]Disk, error)
+	// ListUnregisteredVMs lists the VMs on the current storage domain that are not known to the engine.
+	ListUnregisteredVMs(retries ...RetryStrategy) ([]VM, error)
+	// ListUnregisteredTemplates lists the templates on the current storage domain that are not known to the engine.
+	ListUnregisteredTemplates(retries ...RetryStrategy) ([]Template, error)
+	// RegisterDisk registers an unregistered disk from the current storage domain.
+	RegisterDisk(diskID DiskID, retries ...RetryStrategy) (Disk, error)
+	// RegisterVM registers an unregistered VM from the current storage domain.
+	RegisterVM(vmID VMID, params RegistrationParameters, retries ...RetryStrategy) (VM, error)
+	// RegisterTemplate registers an unregistered template from the current storage domain.
+	RegisterTemplate(templateID TemplateID, params RegistrationParameters, retries ...RetryStrategy) (Template, error)
 }
 
 // StorageDomainList represents a list of storage domains.
@@ -119,6 +215,427 @@ func StorageDomainTypeValues() StorageDomainTypeList {
 	}
 }
 
+// CreateStorageDomainParameters contains the parameters for creating a storage domain. Use
+// NFSStorageDomainParams, PosixFSStorageDomainParams, GlusterFSStorageDomainParams, ISCSIStorageDomainParams or
+// FCPStorageDomainParams to obtain a buildable structure for the storage type.
+type CreateStorageDomainParameters interface {
+	// Name is the name of the new storage domain.
+	Name() string
+	// HostID is the host used to connect to the storage and initialize the storage domain.
+	HostID() HostID
+	// StorageType is the type of storage the storage domain is created on.
+	StorageType() StorageDomainType
+	// Description is an optional description of the storage domain.
+	Description() string
+	// Address is the address of the NFS or GlusterFS server, or of the iSCSI portal.
+	Address() string
+	// Path is the exported path on the NFS server, the GlusterFS volume, or the device to mount for POSIX compliant
+	// file systems.
+	Path() string
+	// VFSType is the file system type of a POSIX compliant file system storage domain.
+	VFSType() string
+	// MountOptions are additional mount options for file storage domains.
+	MountOptions() string
+	// Port is the port of the iSCSI portal.
+	Port() uint
+	// Target is the name of the iSCSI target the LUNs belong to.
+	Target() string
+	// LUNIDs are the IDs of the LUNs the storage domain is created on for iSCSI and FCP storage domains.
+	LUNIDs() []string
+	// OverrideLUNs indicates that LUNs already containing data should be used for the storage domain.
+	OverrideLUNs() bool
+}
+
+// BuildableCreateStorageDomainParameters is a buildable version of CreateStorageDomainParameters.
+type BuildableCreateStorageDomainParameters interface {
+	CreateStorageDomainParameters
+
+	// WithDescription sets the description of the storage domain.
+	WithDescription(description string) (BuildableCreateStorageDomainParameters, error)
+	// MustWithDescription is identical to WithDescription, but panics instead of returning an error.
+	MustWithDescription(description string) BuildableCreateStorageDomainParameters
+
+	// WithMountOptions sets additional mount options for file storage domains.
+	WithMountOptions(mountOptions string) (BuildableCreateStorageDomainParameters, error)
+	// MustWithMountOptions is identical to WithMountOptions, but panics instead of returning an error.
+	MustWithMountOptions(mountOptions string) BuildableCreateStorageDomainParameters
+
+	// WithOverrideLUNs sets if LUNs already containing data should be used for iSCSI and FCP storage domains.
+	WithOverrideLUNs(overrideLUNs bool) (BuildableCreateStorageDomainParameters, error)
+	// MustWithOverrideLUNs is identical to WithOverrideLUNs, but panics instead of returning an error.
+	MustWithOverrideLUNs(overrideLUNs bool) BuildableCreateStorageDomainParameters
+}
+
+// NFSStorageDomainParams creates the parameters for a storage domain on the NFS export at address:path.
+func NFSStorageDomainParams(
+	name string,
+	hostID HostID,
+	address string,
+	path string,
+) BuildableCreateStorageDomainParameters {
+	return &createStorageDomainParams{
+		name:        name,
+		hostID:      hostID,
+		storageType: StorageDomainTypeNFS,
+		address:     address,
+		path:        path,
+	}
+}
+
+// PosixFSStorageDomainParams creates the parameters for a storage domain on a POSIX compliant file system that is
+// mounted from path using the specified file system type, for example cephfs.
+func PosixFSStorageDomainParams(
+	name string,
+	hostID HostID,
+	path string,
+	vfsType string,
+) BuildableCreateStorageDomainParameters {
+	return &createStorageDomainParams{
+		name:        name,
+		hostID:      hostID,
+		storageType: StorageDomainTypePosixFS,
+		path:        path,
+		vfsType:     vfsType,
+	}
+}
+
+// GlusterFSStorageDomainParams creates the parameters for a storage domain on the GlusterFS volume served from
+// address.
+func GlusterFSStorageDomainParams(
+	name string,
+	hostID HostID,
+	address string,
+	volume string,
+) BuildableCreateStorageDomainParameters {
+	return &createStorageDomainParams{
+		name:        name,
+		hostID:      hostID,
+		storageType: StorageDomainTypeGlusterFS,
+		address:     address,
+		path:        volume,
+		vfsType:     "glusterfs",
+	}
+}
+
+// ISCSIStorageDomainParams creates the parameters for a storage domain on the specified LUNs of an iSCSI target.
+// The host must be logged in to the target.
+func ISCSIStorageDomainParams(
+	name string,
+	hostID HostID,
+	address string,
+	port uint,
+	target string,
+	lunIDs []string,
+) BuildableCreateStorageDomainParameters {
+	return &createStorageDomainParams{
+		name:        name,
+		hostID:      hostID,
+		storageType: StorageDomainTypeISCSI,
+		address:     address,
+		port:        port,
+		target:      target,
+		lunIDs:      lunIDs,
+	}
+}
+
+// FCPStorageDomainParams creates the parameters for a storage domain on the specified Fibre Channel LUNs.
+func FCPStorageDomainParams(name string, hostID HostID, lunIDs []string) BuildableCreateStorageDomainParameters {
+	return &createStorageDomainParams{
+		name:        name,
+		hostID:      hostID,
+		storageType: StorageDomainTypeFCP,
+		lunIDs:      lunIDs,
+	}
+}
+
+type createStorageDomainParams struct {
+	name         string
+	hostID       HostID
+	storageType  StorageDomainType
+	description  string
+	address      string
+	path         string
+	vfsType      string
+	mountOptions string
+	port         uint
+	target       string
+	lunIDs       []string
+	overrideLUNs bool
+}
+
+func (c *createStorageDomainParams) Name() string {
+	return c.name
+}
+
+func (c *createStorageDomainParams) HostID() HostID {
+	return c.hostID
+}
+
+func (c *createStorageDomainParams) StorageType() StorageDomainType {
+	return c.storageType
+}
+
+func (c *createStorageDomainParams) Description() string {
+	return c.description
+}
+
+func (c *createStorageDomainParams) Address() string {
+	return c.address
+}
+
+func (c *createStorageDomainParams) Path() string {
+	return c.path
+}
+
+func (c *createStorageDomainParams) VFSType() string {
+	return c.vfsType
+}
+
+func (c *createStorageDomainParams) MountOptions() string {
+	return c.mountOptions
+}
+
+func (c *createStorageDomainParams) Port() uint {
+	return c.port
+}
+
+func (c *createStorageDomainParams) Target() string {
+	return c.target
+}
+
+func (c *createStorageDomainParams) LUNIDs() []string {
+	return c.lunIDs
+}
+
+func (c *createStorageDomainParams) OverrideLUNs() bool {
+	return c.overrideLUNs
+}
+
+func (c *createStorageDomainParams) WithDescription(
+	description string,
+) (BuildableCreateStorageDomainParameters, error) {
+	c.description = description
+	return c, nil
+}
+
+func (c *createStorageDomainParams) MustWithDescription(description string) BuildableCreateStorageDomainParameters {
+	builder, err := c.WithDescription(description)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (c *createStorageDomainParams) WithMountOptions(
+	mountOptions string,
+) (BuildableCreateStorageDomainParameters, error) {
+	if !c.isFileStorage() {
+		return nil, newError(EBadArgument, "mount options cannot be set on %s storage domains", c.storageType)
+	}
+	c.mountOptions = mountOptions
+	return c, nil
+}
+
+func (c *createStorageDomainParams) MustWithMountOptions(mountOptions string) BuildableCreateStorageDomainParameters {
+	builder, err := c.WithMountOptions(mountOptions)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (c *createStorageDomainParams) WithOverrideLUNs(
+	overrideLUNs bool,
+) (BuildableCreateStorageDomainParameters, error) {
+	if c.isFileStorage() {
+		return nil, newError(EBadArgument, "LUNs cannot be overridden on %s storage domains", c.storageType)
+	}
+	c.overrideLUNs = overrideLUNs
+	return c, nil
+}
+
+func (c *createStorageDomainParams) MustWithOverrideLUNs(overrideLUNs bool) BuildableCreateStorageDomainParameters {
+	builder, err := c.WithOverrideLUNs(overrideLUNs)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (c *createStorageDomainParams) isFileStorage() bool {
+	for _, storageType := range FileStorageDomainTypeValues() {
+		if storageType == c.storageType {
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveStorageDomainParameters contains the optional parameters for removing a storage domain.
+type RemoveStorageDomainParameters interface {
+	// HostID is the host used to remove the storage domain. It is required unless the storage domain is destroyed.
+	HostID() HostID
+	// Format indicates that the storage should be formatted, removing all data from the storage domain.
+	Format() bool
+	// Destroy indicates that the storage domain should only be removed from the engine database without accessing
+	// the storage. This is used for storage domains that are no longer reachable.
+	Destroy() bool
+}
+
+// BuildableRemoveStorageDomainParameters is a buildable version of RemoveStorageDomainParameters.
+type BuildableRemoveStorageDomainParameters interface {
+	RemoveStorageDomainParameters
+
+	// WithHostID sets the host used to remove the storage domain.
+	WithHostID(hostID HostID) (BuildableRemoveStorageDomainParameters, error)
+	// MustWithHostID is identical to WithHostID, but panics instead of returning an error.
+	MustWithHostID(hostID HostID) BuildableRemoveStorageDomainParameters
+
+	// WithFormat sets if the storage should be formatted.
+	WithFormat(format bool) (BuildableRemoveStorageDomainParameters, error)
+	// MustWithFormat is identical to WithFormat, but panics instead of returning an error.
+	MustWithFormat(format bool) BuildableRemoveStorageDomainParameters
+
+	// WithDestroy sets if the storage domain should only be removed from the engine database.
+	WithDestroy(destroy bool) (BuildableRemoveStorageDomainParameters, error)
+	// MustWithDestroy is identical to WithDestroy, but panics instead of returning an error.
+	MustWithDestroy(destroy bool) BuildableRemoveStorageDomainParameters
+}
+
+// RemoveStorageDomainParams creates a buildable set of parameters for StorageDomainClient.RemoveStorageDomain.
+func RemoveStorageDomainParams() BuildableRemoveStorageDomainParameters {
+	return &removeStorageDomainParams{}
+}
+
+type removeStorageDomainParams struct {
+	hostID  HostID
+	format  bool
+	destroy bool
+}
+
+func (r *removeStorageDomainParams) HostID() HostID {
+	return r.hostID
+}
+
+func (r *removeStorageDomainParams) Format() bool {
+	return r.format
+}
+
+func (r *removeStorageDomainParams) Destroy() bool {
+	return r.destroy
+}
+
+func (r *removeStorageDomainParams) WithHostID(hostID HostID) (BuildableRemoveStorageDomainParameters, error) {
+	r.hostID = hostID
+	return r, nil
+}
+
+func (r *removeStorageDomainParams) MustWithHostID(hostID HostID) BuildableRemoveStorageDomainParameters {
+	builder, err := r.WithHostID(hostID)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (r *removeStorageDomainParams) WithFormat(format bool) (BuildableRemoveStorageDomainParameters, error) {
+	r.format = format
+	return r, nil
+}
+
+func (r *removeStorageDomainParams) MustWithFormat(format bool) BuildableRemoveStorageDomainParameters {
+	builder, err := r.WithFormat(format)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (r *removeStorageDomainParams) WithDestroy(destroy bool) (BuildableRemoveStorageDomainParameters, error) {
+	r.destroy = destroy
+	return r, nil
+}
+
+func (r *removeStorageDomainParams) MustWithDestroy(destroy bool) BuildableRemoveStorageDomainParameters {
+	builder, err := r.WithDestroy(destroy)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+// VNICProfileMapping maps the VNIC profile a NIC of an unregistered VM or template used in its original environment to
+// a VNIC profile in the current environment. The source profile is identified by its name and the name of its
+// network, as the IDs from the original environment are not meaningful.
+type VNICProfileMapping struct {
+	// SourceNetworkName is the name of the network of the VNIC profile in the original environment.
+	SourceNetworkName string
+	// SourceProfileName is the name of the VNIC profile in the original environment.
+	SourceProfileName string
+	// TargetVNICProfileID is the ID of the VNIC profile to use instead.
+	TargetVNICProfileID VNICProfileID
+}
+
+// RegistrationParameters contains the parameters for registering an unregistered VM or template from a storage
+// domain.
+type RegistrationParameters interface {
+	// ClusterID is the cluster the VM or template is registered in.
+	ClusterID() ClusterID
+	// VNICProfileMappings returns the VNIC profiles to use for NICs whose VNIC profile doesn't exist in the current
+	// environment.
+	VNICProfileMappings() []VNICProfileMapping
+}
+
+// BuildableRegistrationParameters is a buildable version of RegistrationParameters.
+type BuildableRegistrationParameters interface {
+	RegistrationParameters
+
+	// WithVNICProfileMapping adds a VNIC profile mapping.
+	WithVNICProfileMapping(mapping VNICProfileMapping) (BuildableRegistrationParameters, error)
+	// MustWithVNICProfileMapping is identical to WithVNICProfileMapping, but panics instead of returning an error.
+	MustWithVNICProfileMapping(mapping VNICProfileMapping) BuildableRegistrationParameters
+}
+
+// RegistrationParams creates the parameters for StorageDomainClient.RegisterVM and
+// StorageDomainClient.RegisterTemplate, registering the VM or template in the specified cluster.
+func RegistrationParams(clusterID ClusterID) BuildableRegistrationParameters {
+	return &registrationParams{
+		clusterID: clusterID,
+	}
+}
+
+type registrationParams struct {
+	clusterID           ClusterID
+	vnicProfileMappings []VNICProfileMapping
+}
+
+func (r *registrationParams) ClusterID() ClusterID {
+	return r.clusterID
+}
+
+func (r *registrationParams) VNICProfileMappings() []VNICProfileMapping {
+	return r.vnicProfileMappings
+}
+
+func (r *registrationParams) WithVNICProfileMapping(mapping VNICProfileMapping) (BuildableRegistrationParameters, error) {
+	if mapping.SourceNetworkName == "" || mapping.SourceProfileName == "" {
+		return nil, newError(EBadArgument, "the source network and profile names are required for a VNIC profile mapping")
+	}
+	if mapping.TargetVNICProfileID == "" {
+		return nil, newError(EBadArgument, "the target VNIC profile ID is required for a VNIC profile mapping")
+	}
+	r.vnicProfileMappings = append(r.vnicProfileMappings, mapping)
+	return r, nil
+}
+
+func (r *registrationParams) MustWithVNICProfileMapping(mapping VNICProfileMapping) BuildableRegistrationParameters {
+	builder, err := r.WithVNICProfileMapping(mapping)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
 // StorageDomainStatus represents the status a domain can be in. Either this status field, or the
 // StorageDomainExternalStatus must be set.
 //
@@ -327,6 +844,62 @@ func (s storageDomain) ExternalStatus() StorageDomainExternalStatus {
 	return s.externalStatus
 }
 
+func (s storageDomain) ListDiskSnapshots(retries ...RetryStrategy) ([]DiskSnapshot, error) {
+	return s.client.ListStorageDomainDiskSnapshots(s.id, retries...)
+}
+
+func (s storageDomain) AttachToDatacenter(datacenterID DatacenterID, retries ...RetryStrategy) error {
+	return s.client.AttachStorageDomainToDatacenter(s.id, datacenterID, retries...)
+}
+
+func (s storageDomain) Activate(datacenterID DatacenterID, retries ...RetryStrategy) error {
+	return s.client.ActivateStorageDomain(s.id, datacenterID, retries...)
+}
+
+func (s storageDomain) Deactivate(datacenterID DatacenterID, retries ...RetryStrategy) error {
+	return s.client.DeactivateStorageDomain(s.id, datacenterID, retries...)
+}
+
+func (s storageDomain) Detach(datacenterID DatacenterID, retries ...RetryStrategy) error {
+	return s.client.DetachStorageDomain(s.id, datacenterID, retries...)
+}
+
+func (s storageDomain) Remove(params RemoveStorageDomainParameters, retries ...RetryStrategy) error {
+	return s.client.RemoveStorageDomain(s.id, params, retries...)
+}
+
+func (s storageDomain) WaitForStatus(status StorageDomainStatus, retries ...RetryStrategy) (StorageDomain, error) {
+	return s.client.WaitForStorageDomainStatus(s.id, status, retries...)
+}
+
+func (s storageDomain) ListUnregisteredDisks(retries ...RetryStrategy) ([]Disk, error) {
+	return s.client.ListUnregisteredDisks(s.id, retries...)
+}
+
+func (s storageDomain) ListUnregisteredVMs(retries ...RetryStrategy) ([]VM, error) {
+	return s.client.ListUnregisteredVMs(s.id, retries...)
+}
+
+func (s storageDomain) ListUnregisteredTemplates(retries ...RetryStrategy) ([]Template, error) {
+	return s.client.ListUnregisteredTemplates(s.id, retries...)
+}
+
+func (s storageDomain) RegisterDisk(diskID DiskID, retries ...RetryStrategy) (Disk, error) {
+	return s.client.RegisterDisk(s.id, diskID, retries...)
+}
+
+func (s storageDomain) RegisterVM(vmID VMID, params RegistrationParameters, retries ...RetryStrategy) (VM, error) {
+	return s.client.RegisterVM(s.id, vmID, params, retries...)
+}
+
+func (s storageDomain) RegisterTemplate(
+	templateID TemplateID,
+	params RegistrationParameters,
+	retries ...RetryStrategy,
+) (Template, error) {
+	return s.client.RegisterTemplate(s.id, templateID, params, retries...)
+}
+
 type storageDomainDiskWait struct {
 	client        *oVirtClient
 	disk          Disk
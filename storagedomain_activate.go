@@ -0,0 +1,63 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) ActivateStorageDomain(
+	id StorageDomainID,
+	datacenterID DatacenterID,
+	retries ...RetryStrategy,
+) error {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	return retry(
+		fmt.Sprintf("activating storage domain %s in datacenter %s", id, datacenterID),
+		o.logger,
+		retries,
+		func() error {
+			_, err := o.conn.
+				SystemService().
+				DataCentersService().
+				DataCenterService(string(datacenterID)).
+				StorageDomainsService().
+				StorageDomainService(string(id)).
+				Activate().
+				Send()
+			if err != nil {
+				return wrap(
+					err,
+					EUnidentified,
+					"failed to activate storage domain %s in datacenter %s",
+					id,
+					datacenterID,
+				)
+			}
+			return nil
+		},
+	)
+}
+
+func (m *mockClient) ActivateStorageDomain(
+	id StorageDomainID,
+	datacenterID DatacenterID,
+	_ ...RetryStrategy,
+) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	sd, err := m.getAttachedStorageDomain(id, datacenterID)
+	if err != nil {
+		return err
+	}
+	if sd.status != StorageDomainStatusMaintenance && sd.status != StorageDomainStatusInactive {
+		return newError(
+			EConflict,
+			"storage domain %s is %s, only storage domains in %s can be activated",
+			id,
+			sd.status,
+			StorageDomainStatusMaintenance,
+		)
+	}
+	m.setStorageDomainStatus(sd, StorageDomainStatusActive)
+	return nil
+}
@@ -0,0 +1,73 @@
+package ovirtclient
+
+import (
+	"fmt"
+
+	ovirtsdk4 "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) AttachStorageDomainToDatacenter(
+	id StorageDomainID,
+	datacenterID DatacenterID,
+	retries ...RetryStrategy,
+) error {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	return retry(
+		fmt.Sprintf("attaching storage domain %s to datacenter %s", id, datacenterID),
+		o.logger,
+		retries,
+		func() error {
+			_, err := o.conn.
+				SystemService().
+				DataCentersService().
+				DataCenterService(string(datacenterID)).
+				StorageDomainsService().
+				Add().
+				StorageDomain(ovirtsdk4.NewStorageDomainBuilder().Id(string(id)).MustBuild()).
+				Send()
+			if err != nil {
+				return wrap(
+					err,
+					EUnidentified,
+					"failed to attach storage domain %s to datacenter %s",
+					id,
+					datacenterID,
+				)
+			}
+			return nil
+		},
+	)
+}
+
+func (m *mockClient) AttachStorageDomainToDatacenter(
+	id StorageDomainID,
+	datacenterID DatacenterID,
+	_ ...RetryStrategy,
+) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	sd, ok := m.storageDomains[id]
+	if !ok {
+		return newError(ENotFound, "storage domain with ID %s not found", id)
+	}
+	if _, ok := m.dataCenters[datacenterID]; !ok {
+		return newError(ENotFound, "datacenter with ID %s not found", datacenterID)
+	}
+	if attachedDatacenterID, ok := m.storageDomainDatacenters[id]; ok {
+		return newError(
+			EConflict,
+			"storage domain %s is already attached to datacenter %s",
+			id,
+			attachedDatacenterID,
+		)
+	}
+	if sd.status != StorageDomainStatusUnattached {
+		return newError(EConflict, "storage domain %s is %s, not %s", id, sd.status, StorageDomainStatusUnattached)
+	}
+
+	m.storageDomainDatacenters[id] = datacenterID
+	// The engine activates data domains after attaching them.
+	m.setStorageDomainStatus(sd, StorageDomainStatusActive)
+	return nil
+}
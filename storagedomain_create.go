@@ -0,0 +1,227 @@
+package ovirtclient
+
+import (
+	"fmt"
+
+	ovirtsdk4 "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) CreateStorageDomain(
+	params CreateStorageDomainParameters,
+	retries ...RetryStrategy,
+) (result StorageDomain, err error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(o))
+	if err := validateCreateStorageDomainParams(params); err != nil {
+		return nil, err
+	}
+	sdkStorageDomain, err := buildStorageDomainObjectForCreation(params)
+	if err != nil {
+		return nil, wrap(err, EBug, "failed to construct storage domain object")
+	}
+	err = retry(
+		fmt.Sprintf("creating storage domain %s", params.Name()),
+		o.logger,
+		retries,
+		func() error {
+			response, err := o.conn.
+				SystemService().
+				StorageDomainsService().
+				Add().
+				StorageDomain(sdkStorageDomain).
+				Send()
+			if err != nil {
+				return wrap(err, EUnidentified, "failed to create storage domain %s", params.Name())
+			}
+			createdStorageDomain, ok := response.StorageDomain()
+			if !ok {
+				return newFieldNotFound("storage domain add response", "storage domain")
+			}
+			result, err = convertSDKStorageDomain(createdStorageDomain, o)
+			if err != nil {
+				return wrap(err, EUnidentified, "failed to convert storage domain %s", params.Name())
+			}
+			return nil
+		},
+	)
+	return result, err
+}
+
+func validateCreateStorageDomainParams(params CreateStorageDomainParameters) error {
+	if params == nil {
+		return newError(EBadArgument, "parameters are required to create a storage domain")
+	}
+	if params.Name() == "" {
+		return newError(EBadArgument, "the name is required to create a storage domain")
+	}
+	if params.HostID() == "" {
+		return newError(EBadArgument, "the host ID is required to create storage domain %s", params.Name())
+	}
+	switch params.StorageType() {
+	case StorageDomainTypeNFS, StorageDomainTypeGlusterFS:
+		if params.Address() == "" || params.Path() == "" {
+			return newError(
+				EBadArgument,
+				"the address and path are required to create %s storage domain %s",
+				params.StorageType(),
+				params.Name(),
+			)
+		}
+	case StorageDomainTypePosixFS:
+		if params.Path() == "" || params.VFSType() == "" {
+			return newError(
+				EBadArgument,
+				"the path and file system type are required to create %s storage domain %s",
+				params.StorageType(),
+				params.Name(),
+			)
+		}
+	case StorageDomainTypeISCSI:
+		if params.Address() == "" || params.Target() == "" {
+			return newError(
+				EBadArgument,
+				"the portal address and target are required to create %s storage domain %s",
+				params.StorageType(),
+				params.Name(),
+			)
+		}
+		if len(params.LUNIDs()) == 0 {
+			return newError(EBadArgument, "at least one LUN is required to create storage domain %s", params.Name())
+		}
+	case StorageDomainTypeFCP:
+		if len(params.LUNIDs()) == 0 {
+			return newError(EBadArgument, "at least one LUN is required to create storage domain %s", params.Name())
+		}
+	default:
+		return newError(
+			EBadArgument,
+			"cannot create storage domain %s with storage type %s",
+			params.Name(),
+			params.StorageType(),
+		)
+	}
+	return nil
+}
+
+func buildStorageDomainObjectForCreation(params CreateStorageDomainParameters) (*ovirtsdk4.StorageDomain, error) {
+	hostStorageBuilder := ovirtsdk4.NewHostStorageBuilder().Type(ovirtsdk4.StorageType(params.StorageType()))
+	switch params.StorageType() {
+	case StorageDomainTypeISCSI, StorageDomainTypeFCP:
+		for _, lunID := range params.LUNIDs() {
+			logicalUnitBuilder := ovirtsdk4.NewLogicalUnitBuilder().Id(lunID)
+			if params.StorageType() == StorageDomainTypeISCSI {
+				logicalUnitBuilder.
+					Address(params.Address()).
+					Port(int64(params.Port())). //nolint:gosec
+					Target(params.Target())
+			}
+			logicalUnit, err := logicalUnitBuilder.Build()
+			if err != nil {
+				return nil, err
+			}
+			hostStorageBuilder.LogicalUnitsOfAny(logicalUnit)
+		}
+		hostStorageBuilder.OverrideLuns(params.OverrideLUNs())
+	default:
+		if address := params.Address(); address != "" {
+			hostStorageBuilder.Address(address)
+		}
+		hostStorageBuilder.Path(params.Path())
+		if vfsType := params.VFSType(); vfsType != "" {
+			hostStorageBuilder.VfsType(vfsType)
+		}
+		if mountOptions := params.MountOptions(); mountOptions != "" {
+			hostStorageBuilder.MountOptions(mountOptions)
+		}
+	}
+	hostStorage, err := hostStorageBuilder.Build()
+	if err != nil {
+		return nil, err
+	}
+	storageDomainBuilder := ovirtsdk4.NewStorageDomainBuilder().
+		Name(params.Name()).
+		Type(ovirtsdk4.STORAGEDOMAINTYPE_DATA).
+		Host(ovirtsdk4.NewHostBuilder().Id(string(params.HostID())).MustBuild()).
+		Storage(hostStorage)
+	if description := params.Description(); description != "" {
+		storageDomainBuilder.Description(description)
+	}
+	return storageDomainBuilder.Build()
+}
+
+func (m *mockClient) CreateStorageDomain(
+	params CreateStorageDomainParameters,
+	_ ...RetryStrategy,
+) (StorageDomain, error) {
+	if err := validateCreateStorageDomainParams(params); err != nil {
+		return nil, err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.hosts[params.HostID()]; !ok {
+		return nil, newError(ENotFound, "host with ID %s not found", params.HostID())
+	}
+	for _, existing := range m.storageDomains {
+		if existing.name == params.Name() {
+			return nil, newError(EConflict, "a storage domain with the name %s already exists", params.Name())
+		}
+	}
+
+	isBlockStorage := params.StorageType() == StorageDomainTypeISCSI || params.StorageType() == StorageDomainTypeFCP
+	if isBlockStorage {
+		if err := m.validateStorageDomainLUNs(params); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &storageDomain{
+		client:         m,
+		id:             StorageDomainID(m.GenerateUUID()),
+		name:           params.Name(),
+		available:      mockStorageDomainSize,
+		storageType:    params.StorageType(),
+		status:         StorageDomainStatusUnattached,
+		externalStatus: StorageDomainExternalStatusNA,
+	}
+	if isBlockStorage {
+		result.available = uint64(len(params.LUNIDs())) * mockLUNSize
+		for _, lunID := range params.LUNIDs() {
+			m.lunStorageDomains[lunID] = result.id
+		}
+	} else {
+		connection := &storageConnection{
+			client:       m,
+			id:           StorageConnectionID(m.GenerateUUID()),
+			storageType:  params.StorageType(),
+			address:      params.Address(),
+			path:         params.Path(),
+			vfsType:      params.VFSType(),
+			mountOptions: params.MountOptions(),
+		}
+		if err := m.addStorageConnection(connection); err != nil {
+			return nil, err
+		}
+		m.storageConnectionDomains[connection.id] = result.id
+	}
+	m.storageDomains[result.id] = result
+	return result, nil
+}
+
+// validateStorageDomainLUNs checks that the LUNs for a new block storage domain exist in the LUN inventory, have the
+// right storage type, and are not used yet. The caller must hold the mock client lock.
+func (m *mockClient) validateStorageDomainLUNs(params CreateStorageDomainParameters) error {
+	for _, lunID := range params.LUNIDs() {
+		l, ok := m.luns[lunID]
+		if !ok || string(l.storageType) != string(params.StorageType()) {
+			return newError(ENotFound, "%s LUN %s not found", params.StorageType(), lunID)
+		}
+		if params.StorageType() == StorageDomainTypeISCSI && l.target != params.Target() {
+			return newError(ENotFound, "LUN %s not found on iSCSI target %s", lunID, params.Target())
+		}
+		if status := m.getLUNWithStatus(l); status.Status() != LUNStatusFree {
+			return newError(EConflict, "LUN %s is already in use", lunID)
+		}
+	}
+	return nil
+}
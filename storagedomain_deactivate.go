@@ -0,0 +1,74 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) DeactivateStorageDomain(
+	id StorageDomainID,
+	datacenterID DatacenterID,
+	retries ...RetryStrategy,
+) error {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	return retry(
+		fmt.Sprintf("deactivating storage domain %s in datacenter %s", id, datacenterID),
+		o.logger,
+		retries,
+		func() error {
+			_, err := o.conn.
+				SystemService().
+				DataCentersService().
+				DataCenterService(string(datacenterID)).
+				StorageDomainsService().
+				StorageDomainService(string(id)).
+				Deactivate().
+				Send()
+			if err != nil {
+				return wrap(
+					err,
+					EUnidentified,
+					"failed to deactivate storage domain %s in datacenter %s",
+					id,
+					datacenterID,
+				)
+			}
+			return nil
+		},
+	)
+}
+
+func (m *mockClient) DeactivateStorageDomain(
+	id StorageDomainID,
+	datacenterID DatacenterID,
+	_ ...RetryStrategy,
+) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	sd, err := m.getAttachedStorageDomain(id, datacenterID)
+	if err != nil {
+		return err
+	}
+	if sd.status != StorageDomainStatusActive {
+		return newError(EConflict, "storage domain %s is %s, not %s", id, sd.status, StorageDomainStatusActive)
+	}
+	for diskID, disk := range m.disks {
+		if !disk.isOnStorageDomain(id) {
+			continue
+		}
+		for vmID := range m.vmDiskAttachmentsByDisk[diskID] {
+			if vm := m.vms[vmID]; vm.status != VMStatusDown {
+				return newError(
+					EConflict,
+					"cannot deactivate storage domain %s, disk %s is used by VM %s, which is %s",
+					id,
+					diskID,
+					vmID,
+					vm.status,
+				)
+			}
+		}
+	}
+	m.setStorageDomainStatus(sd, StorageDomainStatusMaintenance)
+	return nil
+}
@@ -0,0 +1,67 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) DetachStorageDomain(
+	id StorageDomainID,
+	datacenterID DatacenterID,
+	retries ...RetryStrategy,
+) error {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	return retry(
+		fmt.Sprintf("detaching storage domain %s from datacenter %s", id, datacenterID),
+		o.logger,
+		retries,
+		func() error {
+			_, err := o.conn.
+				SystemService().
+				DataCentersService().
+				DataCenterService(string(datacenterID)).
+				StorageDomainsService().
+				StorageDomainService(string(id)).
+				Remove().
+				Send()
+			if err != nil {
+				return wrap(
+					err,
+					EUnidentified,
+					"failed to detach storage domain %s from datacenter %s",
+					id,
+					datacenterID,
+				)
+			}
+			return nil
+		},
+	)
+}
+
+func (m *mockClient) DetachStorageDomain(
+	id StorageDomainID,
+	datacenterID DatacenterID,
+	_ ...RetryStrategy,
+) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	sd, err := m.getAttachedStorageDomain(id, datacenterID)
+	if err != nil {
+		return err
+	}
+	if sd.status != StorageDomainStatusMaintenance {
+		return newError(
+			EConflict,
+			"storage domain %s is %s, only storage domains in %s can be detached",
+			id,
+			sd.status,
+			StorageDomainStatusMaintenance,
+		)
+	}
+	if err := m.unregisterStorageDomainEntities(id); err != nil {
+		return err
+	}
+	delete(m.storageDomainDatacenters, id)
+	m.setStorageDomainStatus(sd, StorageDomainStatusUnattached)
+	return nil
+}
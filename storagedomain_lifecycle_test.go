@@ -0,0 +1,235 @@
+package ovirtclient_test
+
+import (
+	"fmt"
+	"testing"
+
+	ovirtclient "github.com/dyudin0821/go-ovirt-client/v3"
+)
+
+func TestStorageDomainLifecycle(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+	if _, ok := client.(ovirtclient.MockClient); !ok {
+		t.Skipf("Creating storage domains requires dedicated storage for the test environment, skipping.")
+	}
+
+	hostID := assertCanFindOVAHost(t, helper)
+	datacenterID := assertCanFindTestDatacenter(t, helper)
+	storageDomain, err := client.CreateStorageDomain(
+		ovirtclient.NFSStorageDomainParams(
+			fmt.Sprintf("sd_lifecycle_test_%s", helper.GenerateRandomID(5)),
+			hostID,
+			"nfs.example.com",
+			"/exports/data",
+		).MustWithMountOptions("soft"),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create storage domain. (%v)", err)
+	}
+	if storageDomain.StorageType() != ovirtclient.StorageDomainTypeNFS {
+		t.Fatalf("Incorrect storage type on storage domain %s: %s.", storageDomain.ID(), storageDomain.StorageType())
+	}
+	assertStorageDomainStatus(t, storageDomain, ovirtclient.StorageDomainStatusUnattached)
+
+	if err := storageDomain.AttachToDatacenter(datacenterID); err != nil {
+		t.Fatalf("Failed to attach storage domain %s to datacenter %s. (%v)", storageDomain.ID(), datacenterID, err)
+	}
+	assertStorageDomainStatus(t, storageDomain, ovirtclient.StorageDomainStatusActive)
+
+	err = storageDomain.Remove(ovirtclient.RemoveStorageDomainParams().MustWithHostID(hostID))
+	if !ovirtclient.HasErrorCode(err, ovirtclient.EConflict) {
+		t.Fatalf("Removing attached storage domain %s did not result in an EConflict error. (%v)", storageDomain.ID(), err)
+	}
+
+	if err := storageDomain.Deactivate(datacenterID); err != nil {
+		t.Fatalf("Failed to deactivate storage domain %s. (%v)", storageDomain.ID(), err)
+	}
+	assertStorageDomainStatus(t, storageDomain, ovirtclient.StorageDomainStatusMaintenance)
+	if err := storageDomain.Activate(datacenterID); err != nil {
+		t.Fatalf("Failed to activate storage domain %s. (%v)", storageDomain.ID(), err)
+	}
+	assertStorageDomainStatus(t, storageDomain, ovirtclient.StorageDomainStatusActive)
+
+	if err := storageDomain.Deactivate(datacenterID); err != nil {
+		t.Fatalf("Failed to deactivate storage domain %s. (%v)", storageDomain.ID(), err)
+	}
+	if err := storageDomain.Detach(datacenterID); err != nil {
+		t.Fatalf("Failed to detach storage domain %s. (%v)", storageDomain.ID(), err)
+	}
+	assertStorageDomainStatus(t, storageDomain, ovirtclient.StorageDomainStatusUnattached)
+
+	err = storageDomain.Remove(ovirtclient.RemoveStorageDomainParams())
+	if !ovirtclient.HasErrorCode(err, ovirtclient.EBadArgument) {
+		t.Fatalf(
+			"Removing storage domain %s without a host did not result in an EBadArgument error. (%v)",
+			storageDomain.ID(),
+			err,
+		)
+	}
+	removeParams := ovirtclient.RemoveStorageDomainParams().MustWithHostID(hostID).MustWithFormat(true)
+	if err := storageDomain.Remove(removeParams); err != nil {
+		t.Fatalf("Failed to remove storage domain %s. (%v)", storageDomain.ID(), err)
+	}
+	if _, err := client.GetStorageDomain(storageDomain.ID()); !ovirtclient.HasErrorCode(err, ovirtclient.ENotFound) {
+		t.Fatalf("Removed storage domain %s can still be fetched. (%v)", storageDomain.ID(), err)
+	}
+}
+
+func TestStorageDomainCannotBeDeactivatedWithRunningVM(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+	if _, ok := client.(ovirtclient.MockClient); !ok {
+		t.Skipf("Deactivating the test storage domain would disrupt the test environment, skipping.")
+	}
+
+	datacenterID := assertCanFindTestDatacenter(t, helper)
+	vm := assertCanCreateVM(
+		t,
+		helper,
+		fmt.Sprintf("sd_deactivate_test_%s", helper.GenerateRandomID(5)),
+		ovirtclient.CreateVMParams(),
+	)
+	disk := assertCanCreateDisk(t, helper)
+	attachment := assertCanAttachDisk(t, vm, disk)
+	_, err := attachment.Update(ovirtclient.UpdateDiskAttachmentParams().MustWithBootable(true).MustWithActive(true))
+	if err != nil {
+		t.Fatalf("Failed to activate disk attachment %s. (%v)", attachment.ID(), err)
+	}
+	assertCanStartVM(t, helper, vm)
+
+	err = client.DeactivateStorageDomain(helper.GetStorageDomainID(), datacenterID)
+	if !ovirtclient.HasErrorCode(err, ovirtclient.EConflict) {
+		t.Fatalf(
+			"Deactivating storage domain %s with a running VM did not result in an EConflict error. (%v)",
+			helper.GetStorageDomainID(),
+			err,
+		)
+	}
+}
+
+func TestCreateISCSIStorageDomainUsesLUNs(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+	if _, ok := client.(ovirtclient.MockClient); !ok {
+		t.Skipf("Creating storage domains requires dedicated storage for the test environment, skipping.")
+	}
+
+	hostID := assertCanFindOVAHost(t, helper)
+	targets, err := client.DiscoverISCSITargets(hostID, "iscsi.example.com", 0)
+	if err != nil {
+		t.Fatalf("Failed to discover iSCSI targets. (%v)", err)
+	}
+	target := targets[0]
+	if err := client.ISCSILogin(
+		hostID,
+		target,
+		ovirtclient.ISCSILoginParams().MustWithCredentials("test", "test"),
+	); err != nil {
+		t.Fatalf("Failed to log in to iSCSI target %s. (%v)", target.Target, err)
+	}
+	luns, err := client.ListHostLUNs(hostID)
+	if err != nil {
+		t.Fatalf("Failed to list LUNs of host %s. (%v)", hostID, err)
+	}
+	var lun ovirtclient.LUN
+	for _, candidate := range luns {
+		if candidate.Target() == target.Target && candidate.Status() == ovirtclient.LUNStatusFree {
+			lun = candidate
+			break
+		}
+	}
+	if lun == nil {
+		t.Fatalf("No free LUN found on iSCSI target %s.", target.Target)
+	}
+
+	storageDomain, err := client.CreateStorageDomain(
+		ovirtclient.ISCSIStorageDomainParams(
+			fmt.Sprintf("sd_iscsi_test_%s", helper.GenerateRandomID(5)),
+			hostID,
+			target.Address,
+			target.Port,
+			target.Target,
+			[]string{lun.ID()},
+		),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create iSCSI storage domain on LUN %s. (%v)", lun.ID(), err)
+	}
+	usedLUN := assertCanFindHostLUN(t, client, hostID, lun.ID())
+	if usedLUN.Status() != ovirtclient.LUNStatusUsed || usedLUN.StorageDomainID() != storageDomain.ID() {
+		t.Fatalf(
+			"LUN %s is not used by storage domain %s (status: %s, storage domain: %s).",
+			lun.ID(),
+			storageDomain.ID(),
+			usedLUN.Status(),
+			usedLUN.StorageDomainID(),
+		)
+	}
+	_, err = client.CreateLUNDisk(hostID, lun.ID(), ovirtclient.LUNStorageTypeISCSI, nil)
+	if !ovirtclient.HasErrorCode(err, ovirtclient.EConflict) {
+		t.Fatalf("Creating a LUN disk on a storage domain LUN did not result in an EConflict error. (%v)", err)
+	}
+
+	if err := storageDomain.Remove(ovirtclient.RemoveStorageDomainParams().MustWithHostID(hostID)); err != nil {
+		t.Fatalf("Failed to remove storage domain %s. (%v)", storageDomain.ID(), err)
+	}
+	releasedLUN := assertCanFindHostLUN(t, client, hostID, lun.ID())
+	if releasedLUN.Status() != ovirtclient.LUNStatusFree {
+		t.Fatalf("LUN %s is not free after removing storage domain %s.", lun.ID(), storageDomain.ID())
+	}
+}
+
+func TestCreateStorageDomainInvalidParameters(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+
+	_, err := client.CreateStorageDomain(ovirtclient.NFSStorageDomainParams("invalid", "host-id", "nfs.example.com", ""))
+	if !ovirtclient.HasErrorCode(err, ovirtclient.EBadArgument) {
+		t.Fatalf("Creating an NFS storage domain without a path did not result in an EBadArgument error. (%v)", err)
+	}
+	_, err = client.CreateStorageDomain(ovirtclient.FCPStorageDomainParams("invalid", "host-id", nil))
+	if !ovirtclient.HasErrorCode(err, ovirtclient.EBadArgument) {
+		t.Fatalf("Creating an FCP storage domain without LUNs did not result in an EBadArgument error. (%v)", err)
+	}
+	_, err = ovirtclient.FCPStorageDomainParams("invalid", "host-id", []string{"lun-id"}).WithMountOptions("soft")
+	if !ovirtclient.HasErrorCode(err, ovirtclient.EBadArgument) {
+		t.Fatalf("Setting mount options on an FCP storage domain did not result in an EBadArgument error. (%v)", err)
+	}
+}
+
+func assertCanFindTestDatacenter(t *testing.T, helper ovirtclient.TestHelper) ovirtclient.DatacenterID {
+	datacenters, err := helper.GetClient().ListDatacenters()
+	if err != nil {
+		t.Fatalf("Failed to list datacenters. (%v)", err)
+	}
+	for _, datacenter := range datacenters {
+		hasCluster, err := datacenter.HasCluster(helper.GetClusterID())
+		if err != nil {
+			t.Fatalf("Failed to check if datacenter %s has cluster %s. (%v)", datacenter.ID(), helper.GetClusterID(), err)
+		}
+		if hasCluster {
+			return datacenter.ID()
+		}
+	}
+	t.Fatalf("No datacenter found for cluster %s.", helper.GetClusterID())
+	return ""
+}
+
+func assertStorageDomainStatus(
+	t *testing.T,
+	storageDomain ovirtclient.StorageDomain,
+	status ovirtclient.StorageDomainStatus,
+) {
+	updated, err := storageDomain.WaitForStatus(status)
+	if err != nil {
+		t.Fatalf("Storage domain %s did not reach status %s. (%v)", storageDomain.ID(), status, err)
+	}
+	if updated.Status() != status {
+		t.Fatalf("Incorrect status on storage domain %s: %s instead of %s.", storageDomain.ID(), updated.Status(), status)
+	}
+}
@@ -0,0 +1,73 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) ListStorageDomainDiskSnapshots(
+	storageDomainID StorageDomainID,
+	retries ...RetryStrategy,
+) (result []DiskSnapshot, err error) {
+	retries = defaultRetries(retries, defaultReadTimeouts(o))
+	result = []DiskSnapshot{}
+	err = retry(
+		fmt.Sprintf("listing disk snapshots in storage domain %s", storageDomainID),
+		o.logger,
+		retries,
+		func() error {
+			response, e := o.conn.
+				SystemService().
+				StorageDomainsService().
+				StorageDomainService(string(storageDomainID)).
+				DiskSnapshotsService().
+				List().
+				IncludeActive(true).
+				Send()
+			if e != nil {
+				return e
+			}
+			sdkObjects, ok := response.Snapshots()
+			if !ok {
+				return nil
+			}
+			result = make([]DiskSnapshot, len(sdkObjects.Slice()))
+			for i, sdkObject := range sdkObjects.Slice() {
+				result[i], e = convertSDKDiskSnapshot(sdkObject, o)
+				if e != nil {
+					return wrap(
+						e,
+						EBug,
+						"failed to convert disk snapshot during listing item #%d in storage domain %s",
+						i,
+						storageDomainID,
+					)
+				}
+			}
+			return nil
+		})
+	return result, err
+}
+
+func (m *mockClient) ListStorageDomainDiskSnapshots(
+	storageDomainID StorageDomainID,
+	_ ...RetryStrategy,
+) ([]DiskSnapshot, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.storageDomains[storageDomainID]; !ok {
+		return nil, newError(ENotFound, "storage domain with ID %s not found", storageDomainID)
+	}
+	var result []DiskSnapshot
+	for diskID := range m.disks {
+		for _, layer := range m.diskSnapshotLayers(diskID) {
+			for _, id := range layer.StorageDomainIDs() {
+				if id == storageDomainID {
+					result = append(result, layer)
+					break
+				}
+			}
+		}
+	}
+	return result, nil
+}
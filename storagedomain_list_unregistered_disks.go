@@ -0,0 +1,58 @@
+package ovirtclient
+
+import (
+	"fmt"
+	"sort"
+)
+
+func (o *oVirtClient) ListUnregisteredDisks(id StorageDomainID, retries ...RetryStrategy) (result []Disk, err error) {
+	retries = defaultRetries(retries, defaultReadTimeouts(o))
+	result = []Disk{}
+	err = retry(
+		fmt.Sprintf("listing unregistered disks on storage domain %s", id),
+		o.logger,
+		retries,
+		func() error {
+			response, e := o.conn.
+				SystemService().
+				StorageDomainsService().
+				StorageDomainService(string(id)).
+				DisksService().
+				List().
+				Unregistered(true).
+				Send()
+			if e != nil {
+				return wrap(e, EUnidentified, "failed to list unregistered disks on storage domain %s", id)
+			}
+			sdkObjects, ok := response.Disks()
+			if !ok {
+				return nil
+			}
+			result = make([]Disk, len(sdkObjects.Slice()))
+			for i, sdkObject := range sdkObjects.Slice() {
+				result[i], e = convertSDKDisk(sdkObject, o)
+				if e != nil {
+					return wrap(e, EBug, "failed to convert unregistered disk during listing item #%d", i)
+				}
+			}
+			return nil
+		})
+	return result, err
+}
+
+func (m *mockClient) ListUnregisteredDisks(id StorageDomainID, _ ...RetryStrategy) ([]Disk, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if err := m.checkStorageDomainActive(id); err != nil {
+		return nil, err
+	}
+	result := make([]Disk, 0, len(m.unregisteredDisks[id]))
+	for _, disk := range m.unregisteredDisks[id] {
+		result = append(result, disk)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID() < result[j].ID()
+	})
+	return result, nil
+}
@@ -0,0 +1,61 @@
+package ovirtclient
+
+import (
+	"fmt"
+	"sort"
+)
+
+func (o *oVirtClient) ListUnregisteredTemplates(
+	id StorageDomainID,
+	retries ...RetryStrategy,
+) (result []Template, err error) {
+	retries = defaultRetries(retries, defaultReadTimeouts(o))
+	result = []Template{}
+	err = retry(
+		fmt.Sprintf("listing unregistered templates on storage domain %s", id),
+		o.logger,
+		retries,
+		func() error {
+			response, e := o.conn.
+				SystemService().
+				StorageDomainsService().
+				StorageDomainService(string(id)).
+				TemplatesService().
+				List().
+				Unregistered(true).
+				Send()
+			if e != nil {
+				return wrap(e, EUnidentified, "failed to list unregistered templates on storage domain %s", id)
+			}
+			sdkObjects, ok := response.Templates()
+			if !ok {
+				return nil
+			}
+			result = make([]Template, len(sdkObjects.Slice()))
+			for i, sdkObject := range sdkObjects.Slice() {
+				result[i], e = convertSDKTemplate(sdkObject, o)
+				if e != nil {
+					return wrap(e, EBug, "failed to convert unregistered template during listing item #%d", i)
+				}
+			}
+			return nil
+		})
+	return result, err
+}
+
+func (m *mockClient) ListUnregisteredTemplates(id StorageDomainID, _ ...RetryStrategy) ([]Template, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if err := m.checkStorageDomainActive(id); err != nil {
+		return nil, err
+	}
+	result := make([]Template, 0, len(m.unregisteredTemplates[id]))
+	for _, unregisteredTemplate := range m.unregisteredTemplates[id] {
+		result = append(result, unregisteredTemplate.template)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID() < result[j].ID()
+	})
+	return result, nil
+}
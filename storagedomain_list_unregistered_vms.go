@@ -0,0 +1,59 @@
+package ovirtclient
+
+import (
+	"fmt"
+	"sort"
+)
+
+func (o *oVirtClient) ListUnregisteredVMs(id StorageDomainID, retries ...RetryStrategy) (result []VM, err error) {
+	retries = defaultRetries(retries, defaultReadTimeouts(o))
+	result = []VM{}
+	err = retry(
+		fmt.Sprintf("listing unregistered VMs on storage domain %s", id),
+		o.logger,
+		retries,
+		func() error {
+			response, e := o.conn.
+				SystemService().
+				StorageDomainsService().
+				StorageDomainService(string(id)).
+				VmsService().
+				List().
+				Unregistered(true).
+				Send()
+			if e != nil {
+				return wrap(e, EUnidentified, "failed to list unregistered VMs on storage domain %s", id)
+			}
+			// The SDK uses the singular for the list of VMs in the response.
+			sdkObjects, ok := response.Vm()
+			if !ok {
+				return nil
+			}
+			result = make([]VM, len(sdkObjects.Slice()))
+			for i, sdkObject := range sdkObjects.Slice() {
+				result[i], e = convertSDKVM(sdkObject, o)
+				if e != nil {
+					return wrap(e, EBug, "failed to convert unregistered VM during listing item #%d", i)
+				}
+			}
+			return nil
+		})
+	return result, err
+}
+
+func (m *mockClient) ListUnregisteredVMs(id StorageDomainID, _ ...RetryStrategy) ([]VM, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if err := m.checkStorageDomainActive(id); err != nil {
+		return nil, err
+	}
+	result := make([]VM, 0, len(m.unregisteredVMs[id]))
+	for _, unregisteredVM := range m.unregisteredVMs[id] {
+		result = append(result, unregisteredVM.vm)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID() < result[j].ID()
+	})
+	return result, nil
+}
@@ -0,0 +1,328 @@
+package ovirtclient
+
+// mockStorageDomainSize is the number of available bytes on the storage domains of the mock client.
+const mockStorageDomainSize = 10 * 1024 * 1024 * 1024
+
+// getAttachedStorageDomain returns the storage domain if it is attached to the specified datacenter. The caller must
+// hold the mock client lock.
+func (m *mockClient) getAttachedStorageDomain(
+	id StorageDomainID,
+	datacenterID DatacenterID,
+) (*storageDomain, error) {
+	sd, ok := m.storageDomains[id]
+	if !ok {
+		return nil, newError(ENotFound, "storage domain with ID %s not found", id)
+	}
+	if _, ok := m.dataCenters[datacenterID]; !ok {
+		return nil, newError(ENotFound, "datacenter with ID %s not found", datacenterID)
+	}
+	if attachedDatacenterID, ok := m.storageDomainDatacenters[id]; !ok || attachedDatacenterID != datacenterID {
+		return nil, newError(ENotFound, "storage domain %s is not attached to datacenter %s", id, datacenterID)
+	}
+	return sd, nil
+}
+
+// setStorageDomainStatus replaces the storage domain with a copy in the new status, so storage domains returned
+// earlier keep their status like with the real client. The caller must hold the mock client lock.
+func (m *mockClient) setStorageDomainStatus(sd *storageDomain, status StorageDomainStatus) {
+	updated := *sd
+	updated.status = status
+	m.storageDomains[sd.id] = &updated
+}
+
+// removeStorageDomainDisks removes the disks that only reside on the specified storage domain, and removes the
+// storage domain from the other disks. The caller must hold the mock client lock.
+func (m *mockClient) removeStorageDomainDisks(id StorageDomainID) {
+	for diskID, disk := range m.disks {
+		var remaining []StorageDomainID
+		for _, storageDomainID := range disk.storageDomainIDs {
+			if storageDomainID != id {
+				remaining = append(remaining, storageDomainID)
+			}
+		}
+		if len(remaining) == len(disk.storageDomainIDs) {
+			continue
+		}
+		if len(remaining) > 0 {
+			disk.storageDomainIDs = remaining
+			continue
+		}
+		for _, diskAttachment := range m.vmDiskAttachmentsByDisk[diskID] {
+			delete(m.vmDiskAttachmentsByVM[diskAttachment.vmid], diskAttachment.id)
+		}
+		delete(m.vmDiskAttachmentsByDisk, diskID)
+		delete(m.disks, diskID)
+		delete(m.diskImageChains, diskID)
+		delete(m.diskUploadExtents, diskID)
+	}
+	delete(m.storageDomainFiles, id)
+}
+
+// mockUnregisteredVM is a VM stored on a storage domain that is not known to the mock engine.
+type mockUnregisteredVM struct {
+	vm              *vm
+	nics            []*mockUnregisteredNIC
+	diskAttachments []*diskAttachment
+	disks           []*diskWithData
+}
+
+// mockUnregisteredNIC is a NIC of an unregistered VM. The names of the VNIC profile and network are recorded as the
+// real engine stores them in the OVF on the storage domain.
+type mockUnregisteredNIC struct {
+	nic         *nic
+	networkName string
+	profileName string
+}
+
+// mockUnregisteredTemplate is a template stored on a storage domain that is not known to the mock engine.
+type mockUnregisteredTemplate struct {
+	template        *template
+	diskAttachments []*templateDiskAttachment
+	disks           []*diskWithData
+}
+
+// checkStorageDomainActive returns an error if the storage domain doesn't exist or is not active. The caller must
+// hold the mock client lock.
+func (m *mockClient) checkStorageDomainActive(id StorageDomainID) error {
+	sd, ok := m.storageDomains[id]
+	if !ok {
+		return newError(ENotFound, "storage domain with ID %s not found", id)
+	}
+	if sd.status != StorageDomainStatusActive {
+		return newError(EConflict, "storage domain %s is %s, not %s", id, sd.status, StorageDomainStatusActive)
+	}
+	return nil
+}
+
+// checkRegistrationCluster returns an error if the cluster doesn't exist or is not in the datacenter the storage
+// domain is attached to. The caller must hold the mock client lock.
+func (m *mockClient) checkRegistrationCluster(id StorageDomainID, clusterID ClusterID) error {
+	if _, ok := m.clusters[clusterID]; !ok {
+		return newError(ENotFound, "cluster with ID %s not found", clusterID)
+	}
+	if datacenter, ok := m.dataCenters[m.storageDomainDatacenters[id]]; ok {
+		for _, datacenterClusterID := range datacenter.clusters {
+			if datacenterClusterID == clusterID {
+				return nil
+			}
+		}
+	}
+	return newError(
+		EBadArgument,
+		"cluster %s is not in the datacenter storage domain %s is attached to",
+		clusterID,
+		id,
+	)
+}
+
+// mapRegistrationVNICProfiles returns the VNIC profile IDs the NICs of an unregistered VM use after registration.
+// NICs keep their VNIC profile if it still exists and no mapping applies. The caller must hold the mock client lock.
+func (m *mockClient) mapRegistrationVNICProfiles(
+	nics []*mockUnregisteredNIC,
+	mappings []VNICProfileMapping,
+) ([]VNICProfileID, error) {
+	for _, mapping := range mappings {
+		if _, ok := m.vnicProfiles[mapping.TargetVNICProfileID]; !ok {
+			return nil, newError(ENotFound, "VNIC profile with ID %s not found", mapping.TargetVNICProfileID)
+		}
+	}
+	result := make([]VNICProfileID, len(nics))
+nics:
+	for i, unregisteredNIC := range nics {
+		for _, mapping := range mappings {
+			if mapping.SourceNetworkName == unregisteredNIC.networkName &&
+				mapping.SourceProfileName == unregisteredNIC.profileName {
+				result[i] = mapping.TargetVNICProfileID
+				continue nics
+			}
+		}
+		if _, ok := m.vnicProfiles[unregisteredNIC.nic.vnicProfileID]; !ok {
+			return nil, newError(
+				ENotFound,
+				"VNIC profile %s on network %s of NIC %s does not exist, a VNIC profile mapping is required",
+				unregisteredNIC.profileName,
+				unregisteredNIC.networkName,
+				unregisteredNIC.nic.name,
+			)
+		}
+		result[i] = unregisteredNIC.nic.vnicProfileID
+	}
+	return result, nil
+}
+
+// unregisterStorageDomainEntities removes the VMs, templates and disks that reside on the storage domain from the
+// mock engine and records them as unregistered, like the real engine does when detaching a storage domain. VMs and
+// templates with disks both on this storage domain and elsewhere result in an EConflict error. The caller must hold
+// the mock client lock.
+func (m *mockClient) unregisterStorageDomainEntities(id StorageDomainID) error {
+	vmIDs, err := m.findStorageDomainVMs(id)
+	if err != nil {
+		return err
+	}
+	templateIDs, err := m.findStorageDomainTemplates(id)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := m.unregisteredVMs[id]; !ok {
+		m.unregisteredVMs[id] = map[VMID]*mockUnregisteredVM{}
+	}
+	for _, vmID := range vmIDs {
+		m.unregisteredVMs[id][vmID] = m.unregisterVM(vmID)
+	}
+	if _, ok := m.unregisteredTemplates[id]; !ok {
+		m.unregisteredTemplates[id] = map[TemplateID]*mockUnregisteredTemplate{}
+	}
+	for _, templateID := range templateIDs {
+		m.unregisteredTemplates[id][templateID] = m.unregisterTemplate(templateID)
+	}
+	if _, ok := m.unregisteredDisks[id]; !ok {
+		m.unregisteredDisks[id] = map[DiskID]*diskWithData{}
+	}
+	for diskID, disk := range m.disks {
+		if !disk.isOnStorageDomain(id) {
+			continue
+		}
+		if len(disk.storageDomainIDs) > 1 {
+			disk.storageDomainIDs = removeStorageDomainID(disk.storageDomainIDs, id)
+			continue
+		}
+		if len(m.vmDiskAttachmentsByDisk[diskID]) > 0 {
+			continue
+		}
+		m.unregisteredDisks[id][diskID] = disk
+		delete(m.disks, diskID)
+	}
+	return nil
+}
+
+// findStorageDomainVMs returns the VMs that have disks only on the storage domain. The caller must hold the mock
+// client lock.
+func (m *mockClient) findStorageDomainVMs(id StorageDomainID) ([]VMID, error) {
+	var result []VMID
+	for vmID, attachments := range m.vmDiskAttachmentsByVM {
+		var diskIDs []DiskID
+		for _, attachment := range attachments {
+			diskIDs = append(diskIDs, attachment.diskID)
+		}
+		onStorageDomain, err := m.hasDisksOnlyOnStorageDomain(id, diskIDs)
+		if err != nil {
+			return nil, wrap(err, EConflict, "VM %s cannot be unregistered", vmID)
+		}
+		if onStorageDomain {
+			result = append(result, vmID)
+		}
+	}
+	return result, nil
+}
+
+// findStorageDomainTemplates returns the templates that have disks only on the storage domain. The caller must hold
+// the mock client lock.
+func (m *mockClient) findStorageDomainTemplates(id StorageDomainID) ([]TemplateID, error) {
+	var result []TemplateID
+	for templateID, attachments := range m.templateDiskAttachmentsByTemplate {
+		var diskIDs []DiskID
+		for _, attachment := range attachments {
+			diskIDs = append(diskIDs, attachment.diskID)
+		}
+		onStorageDomain, err := m.hasDisksOnlyOnStorageDomain(id, diskIDs)
+		if err != nil {
+			return nil, wrap(err, EConflict, "template %s cannot be unregistered", templateID)
+		}
+		if onStorageDomain {
+			result = append(result, templateID)
+		}
+	}
+	return result, nil
+}
+
+// hasDisksOnlyOnStorageDomain returns true if at least one of the disks resides only on the storage domain. It
+// returns an error if other disks reside only on other storage. The caller must hold the mock client lock.
+func (m *mockClient) hasDisksOnlyOnStorageDomain(id StorageDomainID, diskIDs []DiskID) (bool, error) {
+	var onlyHere, elsewhere []DiskID
+	for _, diskID := range diskIDs {
+		disk, ok := m.disks[diskID]
+		if !ok {
+			continue
+		}
+		switch {
+		case len(disk.storageDomainIDs) == 1 && disk.storageDomainIDs[0] == id:
+			onlyHere = append(onlyHere, diskID)
+		case !disk.isOnStorageDomain(id):
+			elsewhere = append(elsewhere, diskID)
+		}
+	}
+	if len(onlyHere) > 0 && len(elsewhere) > 0 {
+		return false, newError(
+			EConflict,
+			"disk %s is on storage domain %s, but disk %s is on other storage",
+			onlyHere[0],
+			id,
+			elsewhere[0],
+		)
+	}
+	return len(onlyHere) > 0, nil
+}
+
+// unregisterVM removes the VM from the mock engine and returns its unregistered version. The caller must hold the
+// mock client lock.
+func (m *mockClient) unregisterVM(vmID VMID) *mockUnregisteredVM {
+	result := &mockUnregisteredVM{
+		vm: m.vms[vmID],
+	}
+	for _, n := range m.nics {
+		if n.vmid != vmID {
+			continue
+		}
+		unregisteredNIC := &mockUnregisteredNIC{
+			nic: n,
+		}
+		if profile, ok := m.vnicProfiles[n.vnicProfileID]; ok {
+			unregisteredNIC.profileName = profile.name
+			if network, ok := m.networks[profile.networkID]; ok {
+				unregisteredNIC.networkName = network.name
+			}
+		}
+		result.nics = append(result.nics, unregisteredNIC)
+	}
+	for _, attachment := range m.vmDiskAttachmentsByVM[vmID] {
+		result.diskAttachments = append(result.diskAttachments, attachment)
+		if disk, ok := m.disks[attachment.diskID]; ok {
+			result.disks = append(result.disks, disk)
+		}
+	}
+	m.removeVM(vmID)
+	for _, disk := range result.disks {
+		delete(m.disks, disk.id)
+	}
+	return result
+}
+
+// unregisterTemplate removes the template from the mock engine and returns its unregistered version. The caller must
+// hold the mock client lock.
+func (m *mockClient) unregisterTemplate(templateID TemplateID) *mockUnregisteredTemplate {
+	result := &mockUnregisteredTemplate{
+		template:        m.templates[templateID],
+		diskAttachments: m.templateDiskAttachmentsByTemplate[templateID],
+	}
+	for _, attachment := range result.diskAttachments {
+		if disk, ok := m.disks[attachment.diskID]; ok {
+			result.disks = append(result.disks, disk)
+			delete(m.disks, disk.id)
+		}
+		delete(m.templateDiskAttachmentsByDisk, attachment.diskID)
+	}
+	delete(m.templateDiskAttachmentsByTemplate, templateID)
+	delete(m.templates, templateID)
+	return result
+}
+
+func removeStorageDomainID(storageDomainIDs []StorageDomainID, id StorageDomainID) []StorageDomainID {
+	var result []StorageDomainID
+	for _, storageDomainID := range storageDomainIDs {
+		if storageDomainID != id {
+			result = append(result, storageDomainID)
+		}
+	}
+	return result
+}
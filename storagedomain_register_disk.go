@@ -0,0 +1,60 @@
+package ovirtclient
+
+import (
+	"fmt"
+
+	ovirtsdk4 "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) RegisterDisk(id StorageDomainID, diskID DiskID, retries ...RetryStrategy) (result Disk, err error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	sdkDisk, err := ovirtsdk4.NewDiskBuilder().Id(string(diskID)).Build()
+	if err != nil {
+		return nil, wrap(err, EBug, "failed to build disk object")
+	}
+	err = retry(
+		fmt.Sprintf("registering disk %s from storage domain %s", diskID, id),
+		o.logger,
+		retries,
+		func() error {
+			response, err := o.conn.
+				SystemService().
+				StorageDomainsService().
+				StorageDomainService(string(id)).
+				DisksService().
+				Add().
+				Disk(sdkDisk).
+				Unregistered(true).
+				Send()
+			if err != nil {
+				return wrap(err, EUnidentified, "failed to register disk %s from storage domain %s", diskID, id)
+			}
+			registeredDisk, ok := response.Disk()
+			if !ok {
+				return newFieldNotFound("disk registration response", "disk")
+			}
+			result, err = convertSDKDisk(registeredDisk, o)
+			if err != nil {
+				return wrap(err, EUnidentified, "failed to convert registered disk %s", diskID)
+			}
+			return nil
+		},
+	)
+	return result, err
+}
+
+func (m *mockClient) RegisterDisk(id StorageDomainID, diskID DiskID, _ ...RetryStrategy) (Disk, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if err := m.checkStorageDomainActive(id); err != nil {
+		return nil, err
+	}
+	disk, ok := m.unregisteredDisks[id][diskID]
+	if !ok {
+		return nil, newError(ENotFound, "unregistered disk %s not found on storage domain %s", diskID, id)
+	}
+	delete(m.unregisteredDisks[id], diskID)
+	m.disks[diskID] = disk
+	return disk, nil
+}
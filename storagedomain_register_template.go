@@ -0,0 +1,93 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) RegisterTemplate(
+	id StorageDomainID,
+	templateID TemplateID,
+	params RegistrationParameters,
+	retries ...RetryStrategy,
+) (Template, error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(o))
+	if err := validateRegistrationParams(params); err != nil {
+		return nil, err
+	}
+	sdkCluster, registrationConfiguration, err := buildRegistrationObjects(params)
+	if err != nil {
+		return nil, wrap(err, EBug, "failed to build registration objects")
+	}
+	err = retry(
+		fmt.Sprintf("registering template %s from storage domain %s", templateID, id),
+		o.logger,
+		retries,
+		func() error {
+			request := o.conn.
+				SystemService().
+				StorageDomainsService().
+				StorageDomainService(string(id)).
+				TemplatesService().
+				TemplateService(string(templateID)).
+				Register().
+				Cluster(sdkCluster)
+			if registrationConfiguration != nil {
+				request.RegistrationConfiguration(registrationConfiguration)
+			}
+			if _, err := request.Send(); err != nil {
+				return wrap(err, EUnidentified, "failed to register template %s from storage domain %s", templateID, id)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return o.GetTemplate(templateID, retries...)
+}
+
+func (m *mockClient) RegisterTemplate(
+	id StorageDomainID,
+	templateID TemplateID,
+	params RegistrationParameters,
+	_ ...RetryStrategy,
+) (Template, error) {
+	if err := validateRegistrationParams(params); err != nil {
+		return nil, err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if err := m.checkStorageDomainActive(id); err != nil {
+		return nil, err
+	}
+	unregisteredTemplate, ok := m.unregisteredTemplates[id][templateID]
+	if !ok {
+		return nil, newError(ENotFound, "unregistered template %s not found on storage domain %s", templateID, id)
+	}
+	if err := m.checkRegistrationCluster(id, params.ClusterID()); err != nil {
+		return nil, err
+	}
+	for _, existingTemplate := range m.templates {
+		if existingTemplate.name == unregisteredTemplate.template.name {
+			return nil, newError(EConflict, "a template with the name %s already exists", existingTemplate.name)
+		}
+	}
+	if _, err := m.mapRegistrationVNICProfiles(nil, params.VNICProfileMappings()); err != nil {
+		return nil, err
+	}
+
+	registeredTemplate := *unregisteredTemplate.template
+	registeredTemplate.status = TemplateStatusOK
+	m.templates[templateID] = &registeredTemplate
+	m.templateDiskAttachmentsByTemplate[templateID] = unregisteredTemplate.diskAttachments
+	for _, attachment := range unregisteredTemplate.diskAttachments {
+		m.templateDiskAttachmentsByDisk[attachment.diskID] = attachment
+	}
+	for _, disk := range unregisteredTemplate.disks {
+		m.disks[disk.id] = disk
+	}
+	delete(m.unregisteredTemplates[id], templateID)
+	return &registeredTemplate, nil
+}
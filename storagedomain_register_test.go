@@ -0,0 +1,249 @@
+package ovirtclient_test
+
+import (
+	"fmt"
+	"testing"
+
+	ovirtclient "github.com/dyudin0821/go-ovirt-client/v3"
+)
+
+func TestRegisterEntitiesFromReattachedStorageDomain(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+	if _, ok := client.(ovirtclient.MockClient); !ok {
+		t.Skipf("Detaching storage domains requires dedicated storage for the test environment, skipping.")
+	}
+
+	hostID := assertCanFindOVAHost(t, helper)
+	datacenterID := assertCanFindTestDatacenter(t, helper)
+	storageDomain, err := client.CreateStorageDomain(
+		ovirtclient.NFSStorageDomainParams(
+			fmt.Sprintf("sd_register_test_%s", helper.GenerateRandomID(5)),
+			hostID,
+			"nfs.example.com",
+			fmt.Sprintf("/exports/register_test_%s", helper.GenerateRandomID(5)),
+		),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create storage domain. (%v)", err)
+	}
+	if err := storageDomain.AttachToDatacenter(datacenterID); err != nil {
+		t.Fatalf("Failed to attach storage domain %s to datacenter %s. (%v)", storageDomain.ID(), datacenterID, err)
+	}
+
+	vm := assertCanCreateVM(
+		t,
+		helper,
+		fmt.Sprintf("register_test_%s", helper.GenerateRandomID(5)),
+		ovirtclient.CreateVMParams(),
+	)
+	vmDisk := assertCanCreateDiskOnStorageDomain(t, client, storageDomain.ID())
+	assertCanAttachDisk(t, vm, vmDisk)
+	testVNICProfile, err := client.GetVNICProfile(helper.GetVNICProfileID())
+	if err != nil {
+		t.Fatalf("Failed to get test VNIC profile. (%v)", err)
+	}
+	network, err := testVNICProfile.Network()
+	if err != nil {
+		t.Fatalf("Failed to get network of VNIC profile %s. (%v)", testVNICProfile.ID(), err)
+	}
+	vnicProfile, err := client.CreateVNICProfile(
+		fmt.Sprintf("register_test_%s", helper.GenerateRandomID(5)),
+		network.ID(),
+		ovirtclient.CreateVNICProfileParams(),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create VNIC profile. (%v)", err)
+	}
+	nic, err := vm.CreateNIC("eth0", vnicProfile.ID(), nil)
+	if err != nil {
+		t.Fatalf("Failed to create NIC on VM %s. (%v)", vm.ID(), err)
+	}
+	template := assertCanCreateTemplate(t, helper, vm)
+	if _, err := template.WaitForStatus(ovirtclient.TemplateStatusOK); err != nil {
+		t.Fatalf("Template %s did not reach status %s. (%v)", template.ID(), ovirtclient.TemplateStatusOK, err)
+	}
+	floatingDisk := assertCanCreateDiskOnStorageDomain(t, client, storageDomain.ID())
+
+	if err := storageDomain.Deactivate(datacenterID); err != nil {
+		t.Fatalf("Failed to deactivate storage domain %s. (%v)", storageDomain.ID(), err)
+	}
+	if err := storageDomain.Detach(datacenterID); err != nil {
+		t.Fatalf("Failed to detach storage domain %s. (%v)", storageDomain.ID(), err)
+	}
+	if _, err := client.GetVM(vm.ID()); !ovirtclient.HasErrorCode(err, ovirtclient.ENotFound) {
+		t.Fatalf("VM %s still exists after detaching its storage domain. (%v)", vm.ID(), err)
+	}
+	if _, err := client.GetDisk(floatingDisk.ID()); !ovirtclient.HasErrorCode(err, ovirtclient.ENotFound) {
+		t.Fatalf("Disk %s still exists after detaching its storage domain. (%v)", floatingDisk.ID(), err)
+	}
+	if _, err := storageDomain.ListUnregisteredVMs(); !ovirtclient.HasErrorCode(err, ovirtclient.EConflict) {
+		t.Fatalf("Listing unregistered VMs on a detached storage domain did not result in an EConflict error. (%v)", err)
+	}
+	if err := vnicProfile.Remove(); err != nil {
+		t.Fatalf("Failed to remove VNIC profile %s. (%v)", vnicProfile.ID(), err)
+	}
+	if err := storageDomain.AttachToDatacenter(datacenterID); err != nil {
+		t.Fatalf("Failed to attach storage domain %s to datacenter %s. (%v)", storageDomain.ID(), datacenterID, err)
+	}
+
+	unregisteredDisks, err := storageDomain.ListUnregisteredDisks()
+	if err != nil {
+		t.Fatalf("Failed to list unregistered disks on storage domain %s. (%v)", storageDomain.ID(), err)
+	}
+	if len(unregisteredDisks) != 1 || unregisteredDisks[0].ID() != floatingDisk.ID() {
+		t.Fatalf("Incorrect unregistered disks on storage domain %s: %v.", storageDomain.ID(), unregisteredDisks)
+	}
+	registeredDisk, err := storageDomain.RegisterDisk(floatingDisk.ID())
+	if err != nil {
+		t.Fatalf("Failed to register disk %s. (%v)", floatingDisk.ID(), err)
+	}
+	if _, err := client.GetDisk(registeredDisk.ID()); err != nil {
+		t.Fatalf("Failed to get registered disk %s. (%v)", registeredDisk.ID(), err)
+	}
+
+	unregisteredVMs, err := storageDomain.ListUnregisteredVMs()
+	if err != nil {
+		t.Fatalf("Failed to list unregistered VMs on storage domain %s. (%v)", storageDomain.ID(), err)
+	}
+	if len(unregisteredVMs) != 1 || unregisteredVMs[0].ID() != vm.ID() {
+		t.Fatalf("Incorrect unregistered VMs on storage domain %s: %v.", storageDomain.ID(), unregisteredVMs)
+	}
+	_, err = storageDomain.RegisterVM(vm.ID(), ovirtclient.RegistrationParams(helper.GetClusterID()))
+	if !ovirtclient.HasErrorCode(err, ovirtclient.ENotFound) {
+		t.Fatalf("Registering a VM with a removed VNIC profile did not result in an ENotFound error. (%v)", err)
+	}
+	registeredVM, err := storageDomain.RegisterVM(
+		vm.ID(),
+		ovirtclient.RegistrationParams(helper.GetClusterID()).MustWithVNICProfileMapping(
+			ovirtclient.VNICProfileMapping{
+				SourceNetworkName:   network.Name(),
+				SourceProfileName:   vnicProfile.Name(),
+				TargetVNICProfileID: helper.GetVNICProfileID(),
+			},
+		),
+	)
+	if err != nil {
+		t.Fatalf("Failed to register VM %s. (%v)", vm.ID(), err)
+	}
+	registeredNIC, err := registeredVM.GetNIC(nic.ID())
+	if err != nil {
+		t.Fatalf("Failed to get NIC %s of registered VM %s. (%v)", nic.ID(), registeredVM.ID(), err)
+	}
+	if registeredNIC.VNICProfileID() != helper.GetVNICProfileID() {
+		t.Fatalf(
+			"Incorrect VNIC profile on NIC %s of registered VM %s: %s instead of %s.",
+			registeredNIC.ID(),
+			registeredVM.ID(),
+			registeredNIC.VNICProfileID(),
+			helper.GetVNICProfileID(),
+		)
+	}
+	attachments, err := registeredVM.ListDiskAttachments()
+	if err != nil {
+		t.Fatalf("Failed to list disk attachments of registered VM %s. (%v)", registeredVM.ID(), err)
+	}
+	if len(attachments) != 1 || attachments[0].DiskID() != vmDisk.ID() {
+		t.Fatalf("Incorrect disk attachments on registered VM %s: %v.", registeredVM.ID(), attachments)
+	}
+
+	unregisteredTemplates, err := storageDomain.ListUnregisteredTemplates()
+	if err != nil {
+		t.Fatalf("Failed to list unregistered templates on storage domain %s. (%v)", storageDomain.ID(), err)
+	}
+	if len(unregisteredTemplates) != 1 || unregisteredTemplates[0].ID() != template.ID() {
+		t.Fatalf("Incorrect unregistered templates on storage domain %s: %v.", storageDomain.ID(), unregisteredTemplates)
+	}
+	registeredTemplate, err := storageDomain.RegisterTemplate(
+		template.ID(),
+		ovirtclient.RegistrationParams(helper.GetClusterID()),
+	)
+	if err != nil {
+		t.Fatalf("Failed to register template %s. (%v)", template.ID(), err)
+	}
+	if registeredTemplate.Status() != ovirtclient.TemplateStatusOK {
+		t.Fatalf("Incorrect status on registered template %s: %s.", registeredTemplate.ID(), registeredTemplate.Status())
+	}
+	if _, err := storageDomain.RegisterTemplate(
+		template.ID(),
+		ovirtclient.RegistrationParams(helper.GetClusterID()),
+	); !ovirtclient.HasErrorCode(err, ovirtclient.ENotFound) {
+		t.Fatalf("Registering template %s twice did not result in an ENotFound error. (%v)", template.ID(), err)
+	}
+}
+
+func TestDetachStorageDomainWithVMOnOtherStorage(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+	if _, ok := client.(ovirtclient.MockClient); !ok {
+		t.Skipf("Detaching storage domains requires dedicated storage for the test environment, skipping.")
+	}
+
+	hostID := assertCanFindOVAHost(t, helper)
+	datacenterID := assertCanFindTestDatacenter(t, helper)
+	storageDomain, err := client.CreateStorageDomain(
+		ovirtclient.NFSStorageDomainParams(
+			fmt.Sprintf("sd_register_test_%s", helper.GenerateRandomID(5)),
+			hostID,
+			"nfs.example.com",
+			fmt.Sprintf("/exports/register_test_%s", helper.GenerateRandomID(5)),
+		),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create storage domain. (%v)", err)
+	}
+	if err := storageDomain.AttachToDatacenter(datacenterID); err != nil {
+		t.Fatalf("Failed to attach storage domain %s to datacenter %s. (%v)", storageDomain.ID(), datacenterID, err)
+	}
+	vm := assertCanCreateVM(
+		t,
+		helper,
+		fmt.Sprintf("register_test_%s", helper.GenerateRandomID(5)),
+		ovirtclient.CreateVMParams(),
+	)
+	assertCanAttachDisk(t, vm, assertCanCreateDiskOnStorageDomain(t, client, storageDomain.ID()))
+	assertCanAttachDisk(t, vm, assertCanCreateDiskOnStorageDomain(t, client, helper.GetStorageDomainID()))
+
+	if err := storageDomain.Deactivate(datacenterID); err != nil {
+		t.Fatalf("Failed to deactivate storage domain %s. (%v)", storageDomain.ID(), err)
+	}
+	if err := storageDomain.Detach(datacenterID); !ovirtclient.HasErrorCode(err, ovirtclient.EConflict) {
+		t.Fatalf(
+			"Detaching storage domain %s with a VM that has disks on other storage did not result in an EConflict "+
+				"error. (%v)",
+			storageDomain.ID(),
+			err,
+		)
+	}
+	if _, err := client.GetVM(vm.ID()); err != nil {
+		t.Fatalf("VM %s is gone after a failed storage domain detach. (%v)", vm.ID(), err)
+	}
+}
+
+func TestRegistrationParamsInvalidVNICProfileMapping(t *testing.T) {
+	t.Parallel()
+
+	_, err := ovirtclient.RegistrationParams("cluster-id").WithVNICProfileMapping(
+		ovirtclient.VNICProfileMapping{
+			SourceNetworkName:   "ovirtmgmt",
+			TargetVNICProfileID: "vnic-profile-id",
+		},
+	)
+	if !ovirtclient.HasErrorCode(err, ovirtclient.EBadArgument) {
+		t.Fatalf("Adding a VNIC profile mapping without a source profile did not result in an EBadArgument error. (%v)", err)
+	}
+}
+
+func assertCanCreateDiskOnStorageDomain(
+	t *testing.T,
+	client ovirtclient.Client,
+	storageDomainID ovirtclient.StorageDomainID,
+) ovirtclient.Disk {
+	disk, err := client.CreateDisk(storageDomainID, ovirtclient.ImageFormatRaw, 1024*1024, nil)
+	if err != nil {
+		t.Fatalf("Failed to create disk on storage domain %s. (%v)", storageDomainID, err)
+	}
+	return disk
+}
@@ -0,0 +1,151 @@
+package ovirtclient
+
+import (
+	"fmt"
+	"net"
+
+	ovirtsdk4 "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) RegisterVM(
+	id StorageDomainID,
+	vmID VMID,
+	params RegistrationParameters,
+	retries ...RetryStrategy,
+) (VM, error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(o))
+	if err := validateRegistrationParams(params); err != nil {
+		return nil, err
+	}
+	sdkCluster, registrationConfiguration, err := buildRegistrationObjects(params)
+	if err != nil {
+		return nil, wrap(err, EBug, "failed to build registration objects")
+	}
+	err = retry(
+		fmt.Sprintf("registering VM %s from storage domain %s", vmID, id),
+		o.logger,
+		retries,
+		func() error {
+			request := o.conn.
+				SystemService().
+				StorageDomainsService().
+				StorageDomainService(string(id)).
+				VmsService().
+				VmService(string(vmID)).
+				Register().
+				Cluster(sdkCluster)
+			if registrationConfiguration != nil {
+				request.RegistrationConfiguration(registrationConfiguration)
+			}
+			if _, err := request.Send(); err != nil {
+				return wrap(err, EUnidentified, "failed to register VM %s from storage domain %s", vmID, id)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return o.GetVM(vmID, retries...)
+}
+
+func validateRegistrationParams(params RegistrationParameters) error {
+	if params == nil {
+		return newError(EBadArgument, "parameters are required for registration")
+	}
+	if params.ClusterID() == "" {
+		return newError(EBadArgument, "the cluster ID is required for registration")
+	}
+	return nil
+}
+
+func buildRegistrationObjects(
+	params RegistrationParameters,
+) (*ovirtsdk4.Cluster, *ovirtsdk4.RegistrationConfiguration, error) {
+	sdkCluster, err := ovirtsdk4.NewClusterBuilder().Id(string(params.ClusterID())).Build()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(params.VNICProfileMappings()) == 0 {
+		return sdkCluster, nil, nil
+	}
+	registrationConfigurationBuilder := ovirtsdk4.NewRegistrationConfigurationBuilder()
+	for _, mapping := range params.VNICProfileMappings() {
+		sdkMapping, err := ovirtsdk4.NewRegistrationVnicProfileMappingBuilder().
+			From(
+				ovirtsdk4.NewVnicProfileBuilder().
+					Name(mapping.SourceProfileName).
+					Network(ovirtsdk4.NewNetworkBuilder().Name(mapping.SourceNetworkName).MustBuild()).
+					MustBuild(),
+			).
+			To(ovirtsdk4.NewVnicProfileBuilder().Id(string(mapping.TargetVNICProfileID)).MustBuild()).
+			Build()
+		if err != nil {
+			return nil, nil, err
+		}
+		registrationConfigurationBuilder.VnicProfileMappingsOfAny(sdkMapping)
+	}
+	registrationConfiguration, err := registrationConfigurationBuilder.Build()
+	if err != nil {
+		return nil, nil, err
+	}
+	return sdkCluster, registrationConfiguration, nil
+}
+
+func (m *mockClient) RegisterVM(
+	id StorageDomainID,
+	vmID VMID,
+	params RegistrationParameters,
+	_ ...RetryStrategy,
+) (VM, error) {
+	if err := validateRegistrationParams(params); err != nil {
+		return nil, err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if err := m.checkStorageDomainActive(id); err != nil {
+		return nil, err
+	}
+	unregisteredVM, ok := m.unregisteredVMs[id][vmID]
+	if !ok {
+		return nil, newError(ENotFound, "unregistered VM %s not found on storage domain %s", vmID, id)
+	}
+	if err := m.checkRegistrationCluster(id, params.ClusterID()); err != nil {
+		return nil, err
+	}
+	for _, existingVM := range m.vms {
+		if existingVM.name == unregisteredVM.vm.name {
+			return nil, newError(EConflict, "a VM with the name %s already exists", existingVM.name)
+		}
+	}
+	vnicProfileIDs, err := m.mapRegistrationVNICProfiles(unregisteredVM.nics, params.VNICProfileMappings())
+	if err != nil {
+		return nil, err
+	}
+
+	registeredVM := *unregisteredVM.vm
+	registeredVM.clusterID = params.ClusterID()
+	registeredVM.hostID = nil
+	registeredVM.vmPoolID = nil
+	registeredVM.status = VMStatusDown
+	m.vms[vmID] = &registeredVM
+	for i, unregisteredNIC := range unregisteredVM.nics {
+		registeredNIC := *unregisteredNIC.nic
+		registeredNIC.vnicProfileID = vnicProfileIDs[i]
+		m.nics[registeredNIC.id] = &registeredNIC
+	}
+	m.vmDiskAttachmentsByVM[vmID] = map[DiskAttachmentID]*diskAttachment{}
+	for _, attachment := range unregisteredVM.diskAttachments {
+		m.vmDiskAttachmentsByVM[vmID][attachment.id] = attachment
+		m.addVMDiskAttachmentByDisk(attachment)
+	}
+	for _, disk := range unregisteredVM.disks {
+		m.disks[disk.id] = disk
+	}
+	m.vmIPs[vmID] = map[string][]net.IP{}
+	m.addGraphicsConsoles(&registeredVM)
+	delete(m.unregisteredVMs[id], vmID)
+	return &registeredVM, nil
+}
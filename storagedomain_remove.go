@@ -0,0 +1,100 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) RemoveStorageDomain(
+	id StorageDomainID,
+	params RemoveStorageDomainParameters,
+	retries ...RetryStrategy,
+) error {
+	retries = defaultRetries(retries, defaultLongTimeouts(o))
+	if err := validateRemoveStorageDomainParams(id, params); err != nil {
+		return err
+	}
+	return retry(
+		fmt.Sprintf("removing storage domain %s", id),
+		o.logger,
+		retries,
+		func() error {
+			request := o.conn.
+				SystemService().
+				StorageDomainsService().
+				StorageDomainService(string(id)).
+				Remove()
+			if hostID := params.HostID(); hostID != "" {
+				request.Host(string(hostID))
+			}
+			if params.Format() {
+				request.Format(true)
+			}
+			if params.Destroy() {
+				request.Destroy(true)
+			}
+			if _, err := request.Send(); err != nil {
+				return wrap(err, EUnidentified, "failed to remove storage domain %s", id)
+			}
+			return nil
+		},
+	)
+}
+
+func validateRemoveStorageDomainParams(id StorageDomainID, params RemoveStorageDomainParameters) error {
+	if params == nil {
+		return newError(EBadArgument, "parameters are required to remove storage domain %s", id)
+	}
+	if params.HostID() == "" && !params.Destroy() {
+		return newError(EBadArgument, "the host ID is required to remove storage domain %s without destroying it", id)
+	}
+	return nil
+}
+
+func (m *mockClient) RemoveStorageDomain(
+	id StorageDomainID,
+	params RemoveStorageDomainParameters,
+	_ ...RetryStrategy,
+) error {
+	if err := validateRemoveStorageDomainParams(id, params); err != nil {
+		return err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	sd, ok := m.storageDomains[id]
+	if !ok {
+		return newError(ENotFound, "storage domain with ID %s not found", id)
+	}
+	if hostID := params.HostID(); hostID != "" {
+		if _, ok := m.hosts[hostID]; !ok {
+			return newError(ENotFound, "host with ID %s not found", hostID)
+		}
+	}
+	if params.Destroy() {
+		if sd.status == StorageDomainStatusActive {
+			return newError(EConflict, "storage domain %s is %s and cannot be destroyed", id, sd.status)
+		}
+	} else if datacenterID, ok := m.storageDomainDatacenters[id]; ok {
+		return newError(
+			EConflict,
+			"storage domain %s is attached to datacenter %s and must be detached before removal",
+			id,
+			datacenterID,
+		)
+	}
+
+	m.removeStorageDomainDisks(id)
+	m.removeStorageDomainConnections(id)
+	delete(m.unregisteredDisks, id)
+	delete(m.unregisteredVMs, id)
+	delete(m.unregisteredTemplates, id)
+	for lunID, storageDomainID := range m.lunStorageDomains {
+		if storageDomainID == id {
+			delete(m.lunStorageDomains, lunID)
+		}
+	}
+	delete(m.storageDomainDatacenters, id)
+	delete(m.storageDomains, id)
+	return nil
+}
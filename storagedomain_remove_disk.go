@@ -36,6 +36,8 @@ func (m *mockClient) RemoveDiskFromStorageDomain(id StorageDomainID, diskID Disk
 	// if there is only 1 domain just delete the disk
 	if len(domains) == 1 {
 		delete(m.disks, diskID)
+		delete(m.diskImageChains, diskID)
+		delete(m.diskUploadExtents, diskID)
 		return nil
 	}
 
@@ -0,0 +1,99 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) WaitForStorageDomainStatus(
+	id StorageDomainID,
+	status StorageDomainStatus,
+	retries ...RetryStrategy,
+) (result StorageDomain, err error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(o))
+	err = retry(
+		fmt.Sprintf("waiting for storage domain %s status %s", id, status),
+		o.logger,
+		retries,
+		func() error {
+			result, err = o.getStorageDomainWithDatacenterStatus(id)
+			if err != nil {
+				return err
+			}
+			if result.Status() != status {
+				return newError(EPending, "storage domain status is %s, not %s", result.Status(), status)
+			}
+			return nil
+		},
+	)
+	return result, err
+}
+
+// getStorageDomainWithDatacenterStatus fetches a storage domain. The engine only reports the status of storage
+// domains attached to a datacenter in the context of the datacenter, so the storage domain is fetched from the
+// datacenter in this case.
+func (o *oVirtClient) getStorageDomainWithDatacenterStatus(id StorageDomainID) (StorageDomain, error) {
+	response, err := o.conn.SystemService().StorageDomainsService().StorageDomainService(string(id)).Get().Send()
+	if err != nil {
+		return nil, wrap(err, EUnidentified, "failed to fetch storage domain %s", id)
+	}
+	sdkStorageDomain, ok := response.StorageDomain()
+	if !ok {
+		return nil, newError(ENotFound, "no storage domain returned when getting storage domain ID %s", id)
+	}
+	if _, ok := sdkStorageDomain.Status(); ok {
+		return convertSDKStorageDomain(sdkStorageDomain, o)
+	}
+	datacenters, ok := sdkStorageDomain.DataCenters()
+	if !ok || len(datacenters.Slice()) == 0 {
+		return convertSDKStorageDomain(sdkStorageDomain, o)
+	}
+	datacenterID, ok := datacenters.Slice()[0].Id()
+	if !ok {
+		return nil, newFieldNotFound("datacenter of storage domain", "id")
+	}
+	attachedResponse, err := o.conn.
+		SystemService().
+		DataCentersService().
+		DataCenterService(datacenterID).
+		StorageDomainsService().
+		StorageDomainService(string(id)).
+		Get().
+		Send()
+	if err != nil {
+		return nil, wrap(err, EUnidentified, "failed to fetch storage domain %s from datacenter %s", id, datacenterID)
+	}
+	sdkStorageDomain, ok = attachedResponse.StorageDomain()
+	if !ok {
+		return nil, newError(
+			ENotFound,
+			"no storage domain returned when getting storage domain %s from datacenter %s",
+			id,
+			datacenterID,
+		)
+	}
+	return convertSDKStorageDomain(sdkStorageDomain, o)
+}
+
+func (m *mockClient) WaitForStorageDomainStatus(
+	id StorageDomainID,
+	status StorageDomainStatus,
+	retries ...RetryStrategy,
+) (result StorageDomain, err error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(m))
+	err = retry(
+		fmt.Sprintf("waiting for storage domain %s status %s", id, status),
+		m.logger,
+		retries,
+		func() error {
+			result, err = m.GetStorageDomain(id, retries...)
+			if err != nil {
+				return err
+			}
+			if result.Status() != status {
+				return newError(EPending, "storage domain status is %s, not %s", result.Status(), status)
+			}
+			return nil
+		},
+	)
+	return result, err
+}
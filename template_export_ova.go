@@ -0,0 +1,97 @@
+package ovirtclient
+
+import (
+	"bytes"
+	"fmt"
+
+	ovirtsdk "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) ExportTemplateToOVA(
+	templateID TemplateID,
+	hostID HostID,
+	directory string,
+	filename string,
+	retries ...RetryStrategy,
+) (err error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(o))
+	if err := validateOVAExportParameters(hostID, directory, filename); err != nil {
+		return err
+	}
+	correlationID := fmt.Sprintf("template_export_ova_%s", generateRandomID(5, o.nonSecureRandom))
+	err = retry(
+		fmt.Sprintf("exporting template %s to OVA on host %s", templateID, hostID),
+		o.logger,
+		retries,
+		func() error {
+			_, err := o.conn.
+				SystemService().
+				TemplatesService().
+				TemplateService(string(templateID)).
+				ExportToPathOnHost().
+				Host(ovirtsdk.NewHostBuilder().Id(string(hostID)).MustBuild()).
+				Directory(directory).
+				Filename(filename).
+				Query("correlation_id", correlationID).
+				Send()
+			return err
+		})
+	if err != nil {
+		return err
+	}
+	if err := o.waitForJobFinished(correlationID, retries); err != nil {
+		return wrap(err, EUnidentified, "failed to wait for template %s to be exported to OVA", templateID)
+	}
+	return nil
+}
+
+func (m *mockClient) ExportTemplateToOVA(
+	templateID TemplateID,
+	hostID HostID,
+	directory string,
+	filename string,
+	retries ...RetryStrategy,
+) error {
+	retries = defaultRetries(retries, defaultLongTimeouts(m))
+	if err := validateOVAExportParameters(hostID, directory, filename); err != nil {
+		return err
+	}
+	m.lock.Lock()
+	tpl, ok := m.templates[templateID]
+	if !ok {
+		m.lock.Unlock()
+		return newError(ENotFound, "template with ID %s not found", templateID)
+	}
+	if tpl.status != TemplateStatusOK {
+		m.lock.Unlock()
+		return newError(EConflict, "template in status \"%s\"", tpl.status)
+	}
+	if err := m.checkOVAHost(hostID); err != nil {
+		m.lock.Unlock()
+		return err
+	}
+	system := ovaSystem{
+		name:        tpl.name,
+		description: tpl.description,
+		cpu:         tpl.cpu,
+		template:    true,
+	}
+	for _, attachment := range m.templateDiskAttachmentsByTemplate[templateID] {
+		disk := m.disks[attachment.diskID]
+		system.disks = append(system.disks, ovaDisk{
+			id:              disk.id,
+			alias:           disk.alias,
+			provisionedSize: disk.provisionedSize,
+			format:          disk.format,
+			diskInterface:   attachment.diskInterface,
+			bootable:        attachment.bootable,
+		})
+	}
+	m.lock.Unlock()
+
+	data := &bytes.Buffer{}
+	if err := writeOVA(m, system, data, retries...); err != nil {
+		return err
+	}
+	return m.storeHostOVA(hostID, directory, filename, data)
+}
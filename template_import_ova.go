@@ -0,0 +1,96 @@
+package ovirtclient
+
+import (
+	"fmt"
+
+	ovirtsdk "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) ImportTemplateFromOVA(
+	clusterID ClusterID,
+	storageDomainID StorageDomainID,
+	hostID HostID,
+	ovaPath string,
+	name string,
+	retries ...RetryStrategy,
+) (Template, error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(o))
+	if err := validateOVAHostImportParameters(clusterID, storageDomainID, hostID, ovaPath, name); err != nil {
+		return nil, err
+	}
+	sdkImport, err := ovirtsdk.NewExternalTemplateImportBuilder().
+		Url(fmt.Sprintf("ova://%s", ovaPath)).
+		HostBuilder(ovirtsdk.NewHostBuilder().Id(string(hostID))).
+		ClusterBuilder(ovirtsdk.NewClusterBuilder().Id(string(clusterID))).
+		StorageDomainBuilder(ovirtsdk.NewStorageDomainBuilder().Id(string(storageDomainID))).
+		TemplateBuilder(ovirtsdk.NewTemplateBuilder().Name(name)).
+		Build()
+	if err != nil {
+		return nil, wrap(err, EBug, "failed to build OVA import")
+	}
+
+	correlationID := fmt.Sprintf("template_import_ova_%s", generateRandomID(5, o.nonSecureRandom))
+	err = retry(
+		fmt.Sprintf("importing template %s from OVA %s on host %s", name, ovaPath, hostID),
+		o.logger,
+		retries,
+		func() error {
+			_, err := o.conn.
+				SystemService().
+				ExternalTemplateImportsService().
+				Add().
+				Import(sdkImport).
+				Query("correlation_id", correlationID).
+				Send()
+			return err
+		})
+	if err != nil {
+		return nil, err
+	}
+	if err := o.waitForJobFinished(correlationID, retries); err != nil {
+		return nil, wrap(err, EUnidentified, "failed to wait for template %s to be imported from OVA", name)
+	}
+	return o.GetTemplateByName(name, retries...)
+}
+
+func (m *mockClient) ImportTemplateFromOVA(
+	clusterID ClusterID,
+	storageDomainID StorageDomainID,
+	hostID HostID,
+	ovaPath string,
+	name string,
+	retries ...RetryStrategy,
+) (Template, error) {
+	if err := validateOVAHostImportParameters(clusterID, storageDomainID, hostID, ovaPath, name); err != nil {
+		return nil, err
+	}
+	reader, err := m.loadHostOVA(hostID, ovaPath)
+	if err != nil {
+		return nil, err
+	}
+	envelope, _, err := readOVA(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	// The engine imports the disks and creates the template directly. The mock builds an intermediate VM instead
+	// and creates the template from it.
+	intermediateName := fmt.Sprintf("%s-ova-%s", name, generateRandomID(5, m.nonSecureRandom))
+	vm, err := m.UploadVMFromOVA(clusterID, storageDomainID, intermediateName, reader, nil, retries...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = m.RemoveVM(vm.ID(), retries...)
+	}()
+	tpl, err := m.CreateTemplate(
+		vm.ID(),
+		name,
+		TemplateCreateParams().MustWithDescription(envelope.VirtualSystem.Description),
+		retries...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return m.WaitForTemplateStatus(tpl.ID(), TemplateStatusOK, retries...)
+}
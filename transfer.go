@@ -0,0 +1,168 @@
+package ovirtclient
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// defaultTransferProgressInterval is the interval in which the progress callback is called if no interval is
+// specified.
+const defaultTransferProgressInterval = time.Second
+
+// transferRateWindow is the time span over which the throughput of a transfer is averaged.
+const transferRateWindow = 5 * time.Second
+
+// TransferOptions contains the options shared by image uploads and downloads for controlling the transfer of the data.
+type TransferOptions interface {
+	// RateLimiter returns the limiter the transferred data is passed through, or nil if the transfer is not limited.
+	RateLimiter() TransferRateLimiter
+	// ProgressCallback returns the function called periodically with the progress of the transfer, or nil if no
+	// progress should be reported.
+	ProgressCallback() TransferProgressCallback
+	// ProgressInterval returns the interval in which the progress callback is called.
+	ProgressInterval() time.Duration
+	// InactivityTimeout returns the time after which a transfer that has not transferred any data is aborted, or 0 if
+	// stalled transfers should not be aborted.
+	InactivityTimeout() time.Duration
+}
+
+// TransferProgress is the progress of an image transfer passed to the TransferProgressCallback.
+type TransferProgress struct {
+	// TransferredBytes is the number of bytes transferred so far.
+	TransferredBytes uint64
+	// TotalBytes is the total number of bytes to transfer. It is 0 if the size of the transfer is not known yet.
+	TotalBytes uint64
+	// Elapsed is the time since the transfer of the data started.
+	Elapsed time.Duration
+	// BytesPerSecond is the throughput of the transfer averaged over the last few seconds.
+	BytesPerSecond float64
+	// ETA is the estimated time until the transfer is complete. It is 0 if it cannot be estimated.
+	ETA time.Duration
+	// Done is true for the last report of a transfer, which is sent when the transfer completes or fails.
+	Done bool
+}
+
+// TransferProgressCallback is called with the progress of an image transfer. Calls for a single transfer do not
+// overlap, but the callback must not block for long, as it delays the detection of stalled transfers.
+type TransferProgressCallback func(progress TransferProgress)
+
+// TransferRateLimiter limits the rate of image transfers. A single limiter can be shared across concurrent uploads and
+// downloads, in which case the limit applies to their total throughput. It is safe for concurrent use.
+type TransferRateLimiter interface {
+	// Limit returns the current limit in bytes per second. 0 means unlimited.
+	Limit() uint64
+	// SetLimit changes the limit in bytes per second. 0 disables the limit. The change applies to transfers in
+	// progress, so the limit can be raised and lowered based on the time of day.
+	SetLimit(bytesPerSecond uint64)
+	// WaitN blocks until n bytes may be transferred or the context is canceled.
+	WaitN(ctx context.Context, n int) error
+}
+
+// NewTransferRateLimiter creates a TransferRateLimiter with the specified limit in bytes per second. The limiter
+// allows bursts of up to one second worth of data after idle periods.
+func NewTransferRateLimiter(bytesPerSecond uint64) TransferRateLimiter {
+	return &transferRateLimiter{
+		lock:  &sync.Mutex{},
+		limit: bytesPerSecond,
+	}
+}
+
+type transferRateLimiter struct {
+	lock  *sync.Mutex
+	limit uint64
+	// next is the time at which all bytes requested so far may have been transferred. Each request moves it forward by
+	// the time transferring its bytes takes at the current limit and waits until then.
+	next time.Time
+}
+
+func (t *transferRateLimiter) Limit() uint64 {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.limit
+}
+
+func (t *transferRateLimiter) SetLimit(bytesPerSecond uint64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.limit = bytesPerSecond
+}
+
+func (t *transferRateLimiter) WaitN(ctx context.Context, n int) error {
+	t.lock.Lock()
+	if t.limit == 0 || n <= 0 {
+		t.lock.Unlock()
+		return nil
+	}
+	now := time.Now()
+	if earliest := now.Add(-time.Second); t.next.Before(earliest) {
+		t.next = earliest
+	}
+	t.next = t.next.Add(time.Duration(float64(n) / float64(t.limit) * float64(time.Second)))
+	wait := t.next.Sub(now)
+	t.lock.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return wrap(ctx.Err(), ETimeout, "canceled while waiting for the transfer rate limit")
+	}
+}
+
+// transferOptions implements the getters of TransferOptions for the parameter structures.
+type transferOptions struct {
+	rateLimiter       TransferRateLimiter
+	progressCallback  TransferProgressCallback
+	progressInterval  time.Duration
+	inactivityTimeout time.Duration
+}
+
+func (t *transferOptions) RateLimiter() TransferRateLimiter {
+	return t.rateLimiter
+}
+
+func (t *transferOptions) ProgressCallback() TransferProgressCallback {
+	return t.progressCallback
+}
+
+func (t *transferOptions) ProgressInterval() time.Duration {
+	if t.progressInterval == 0 {
+		return defaultTransferProgressInterval
+	}
+	return t.progressInterval
+}
+
+func (t *transferOptions) InactivityTimeout() time.Duration {
+	return t.inactivityTimeout
+}
+
+func (t *transferOptions) setProgressCallback(callback TransferProgressCallback, interval time.Duration) error {
+	if interval < 0 {
+		return newError(EBadArgument, "the progress interval must not be negative")
+	}
+	t.progressCallback = callback
+	t.progressInterval = interval
+	return nil
+}
+
+func (t *transferOptions) setInactivityTimeout(timeout time.Duration) error {
+	if timeout < 0 {
+		return newError(EBadArgument, "the inactivity timeout must not be negative")
+	}
+	t.inactivityTimeout = timeout
+	return nil
+}
+
+// waitTransferRate waits for the rate limiter of the options, if any.
+func waitTransferRate(ctx context.Context, options TransferOptions, n int) error {
+	if options == nil || options.RateLimiter() == nil {
+		return nil
+	}
+	return options.RateLimiter().WaitN(ctx, n)
+}
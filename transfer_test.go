@@ -0,0 +1,199 @@
+package ovirtclient_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	ovirtclient "github.com/dyudin0821/go-ovirt-client/v3"
+)
+
+// progressRecorder collects the progress reports of a transfer.
+type progressRecorder struct {
+	lock    *sync.Mutex
+	reports []ovirtclient.TransferProgress
+}
+
+func newProgressRecorder() *progressRecorder {
+	return &progressRecorder{lock: &sync.Mutex{}}
+}
+
+func (p *progressRecorder) record(progress ovirtclient.TransferProgress) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.reports = append(p.reports, progress)
+}
+
+// assertDone checks that the last report is marked as done and covers the specified number of bytes.
+func (p *progressRecorder) assertDone(t *testing.T, transferred uint64) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if len(p.reports) == 0 {
+		t.Fatalf("The progress callback was not called.")
+	}
+	last := p.reports[len(p.reports)-1]
+	if !last.Done {
+		t.Fatalf("The last progress report is not marked as done.")
+	}
+	if last.TransferredBytes != transferred {
+		t.Fatalf(
+			"Incorrect number of transferred bytes in the last progress report: %d instead of %d.",
+			last.TransferredBytes,
+			transferred,
+		)
+	}
+}
+
+func TestTransferRateLimiter(t *testing.T) {
+	t.Parallel()
+	limiter := ovirtclient.NewTransferRateLimiter(1024 * 1024)
+	ctx := context.Background()
+
+	start := time.Now()
+	// The first second worth of data is allowed as a burst.
+	if err := limiter.WaitN(ctx, 1024*1024); err != nil {
+		t.Fatalf("Failed to wait for the rate limiter. (%v)", err)
+	}
+	if err := limiter.WaitN(ctx, 512*1024); err != nil {
+		t.Fatalf("Failed to wait for the rate limiter. (%v)", err)
+	}
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Fatalf("The rate limiter allowed 1.5 MiB at 1 MiB/s in %s.", elapsed)
+	}
+
+	limiter.SetLimit(0)
+	start = time.Now()
+	if err := limiter.WaitN(ctx, 100*1024*1024); err != nil {
+		t.Fatalf("Failed to wait for the rate limiter. (%v)", err)
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Fatalf("The rate limiter without a limit waited for %s.", elapsed)
+	}
+}
+
+func TestImageUploadRateLimitAndProgress(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+
+	data := streamTestData()
+	recorder := newProgressRecorder()
+	disk := assertCanCreateDisk(t, helper)
+	diskID := disk.ID()
+	start := time.Now()
+	err := client.UploadStreamToDisk(
+		diskID,
+		&streamReader{bytes.NewReader(data)},
+		ovirtclient.UploadStreamParams(uint64(len(data))),
+		ovirtclient.UploadImageParams().
+			MustWithChunkSize(256*1024).
+			MustWithZeroDetection(false).
+			MustWithRateLimiter(ovirtclient.NewTransferRateLimiter(512*1024)).
+			MustWithProgressCallback(recorder.record, 100*time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("Failed to upload rate limited stream to disk %s. (%v)", diskID, err)
+	}
+	// The first 512 KiB are allowed as a burst, the rest takes a second.
+	if elapsed := time.Since(start); elapsed < 700*time.Millisecond {
+		t.Fatalf("The upload of 1 MiB limited to 512 KiB/s completed in %s.", elapsed)
+	}
+	recorder.assertDone(t, uint64(len(data)))
+}
+
+func TestDownloadDiskToFileRateLimitAndProgress(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+
+	disk := assertCanCreateDisk(t, helper)
+	diskID := disk.ID()
+	assertCanUploadDiskData(t, client, disk, streamTestData())
+	recorder := newProgressRecorder()
+	path := filepath.Join(t.TempDir(), "disk.raw")
+	start := time.Now()
+	downloaded, err := client.DownloadDiskToFile(
+		diskID,
+		ovirtclient.ImageFormatRaw,
+		path,
+		ovirtclient.DownloadDiskToFileParams().
+			MustWithChunkSize(64*1024).
+			MustWithRateLimiter(ovirtclient.NewTransferRateLimiter(256*1024)).
+			MustWithProgressCallback(recorder.record, 100*time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("Failed to download disk %s to %s with a rate limit. (%v)", diskID, path, err)
+	}
+	// The test data contains 512 KiB of non-zero data. The first 256 KiB are allowed as a burst, the rest takes a
+	// second.
+	if elapsed := time.Since(start); elapsed < 700*time.Millisecond {
+		t.Fatalf("The download of %d bytes limited to 256 KiB/s completed in %s.", downloaded, elapsed)
+	}
+	recorder.assertDone(t, downloaded)
+}
+
+func TestImageUploadInactivityTimeout(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+
+	data := streamTestData()
+	reader, writer := io.Pipe()
+	defer func() {
+		_ = writer.Close()
+	}()
+	go func() {
+		// Send the first half of the image, then stall.
+		_, _ = writer.Write(data[:len(data)/2])
+	}()
+
+	disk := assertCanCreateDisk(t, helper)
+	diskID := disk.ID()
+	err := client.UploadStreamToDisk(
+		diskID,
+		reader,
+		ovirtclient.UploadStreamParams(uint64(len(data))),
+		ovirtclient.UploadImageParams().
+			MustWithChunkSize(256*1024).
+			MustWithInactivityTimeout(500*time.Millisecond),
+	)
+	if err == nil {
+		t.Fatalf("Uploading a stalled stream to disk %s did not result in an error.", diskID)
+	}
+	if !ovirtclient.HasErrorCode(err, ovirtclient.ETimeout) {
+		t.Fatalf("Uploading a stalled stream to disk %s did not result in an ETimeout error. (%v)", diskID, err)
+	}
+}
+
+func TestImageDownloadProgress(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+
+	disk := assertCanCreateDisk(t, helper)
+	diskID := disk.ID()
+	recorder := newProgressRecorder()
+	download, err := client.DownloadDiskWithParams(
+		diskID,
+		ovirtclient.ImageFormatRaw,
+		ovirtclient.DownloadImageParams().
+			MustWithRateLimiter(ovirtclient.NewTransferRateLimiter(100*1024*1024)).
+			MustWithProgressCallback(recorder.record, 100*time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("Failed to download disk %s. (%v)", diskID, err)
+	}
+	data, err := io.ReadAll(download)
+	if err != nil {
+		_ = download.Close()
+		t.Fatalf("Failed to read disk %s. (%v)", diskID, err)
+	}
+	if err := download.Close(); err != nil {
+		t.Fatalf("Failed to close the download of disk %s. (%v)", diskID, err)
+	}
+	recorder.assertDone(t, uint64(len(data)))
+}
@@ -3,31 +3,328 @@ package ovirtclient
 import (
 	"encoding/binary"
 	"io"
+	"sort"
 )
 
+// extractQCOWParameters inspects an image that is about to be uploaded and returns its format and virtual size. Images
+// that cannot be uploaded are rejected, see InspectImage for details.
 func extractQCOWParameters(fileSize uint64, reader io.ReadSeekCloser) (
 	ImageFormat,
 	uint64,
 	error,
 ) {
-	format := ImageFormatCow
-	qcowSize := fileSize
-	header := make([]byte, qcowHeaderSize)
+	info, err := extractImageInfo(fileSize, reader)
+	if err != nil {
+		return "", 0, err
+	}
+	return info.Format(), info.VirtualSize(), nil
+}
+
+// extractImageInfo inspects an image that is about to be uploaded like extractQCOWParameters, but returns all details
+// of the image.
+func extractImageInfo(fileSize uint64, reader io.ReadSeekCloser) (ImageInfo, error) {
+	info, err := inspectImage(reader, fileSize)
+	if err != nil {
+		return nil, err
+	}
+	if info.VirtualSize() == 0 {
+		return nil, newError(EBadArgument, "expected positive image size, got 0 instead")
+	}
+	return info, nil
+}
+
+// inspectQCOWImage parses the header of a QCOW2 image and validates that it can be uploaded. The passed header
+// contains the first bytes of the image, up to the end of the version 3 header.
+//
+// See https://gitlab.com/qemu-project/qemu/-/blob/master/docs/interop/qcow2.txt for the format.
+func inspectQCOWImage(reader io.ReadSeeker, header []byte, fileSize uint64) (ImageInfo, error) {
+	if len(header) < qcowV2HeaderSize {
+		return nil, newError(EBadArgument, "the QCOW header is truncated")
+	}
+	qcow := &qcowImageInfo{
+		version:          binary.BigEndian.Uint32(header[4:8]),
+		clusterBits:      binary.BigEndian.Uint32(header[20:24]),
+		encryptionMethod: QCOWEncryptionMethodNone,
+		compressionType:  QCOWCompressionTypeZlib,
+		snapshots:        binary.BigEndian.Uint32(header[60:64]),
+		l1Size:           binary.BigEndian.Uint32(header[36:40]),
+		l1TableOffset:    binary.BigEndian.Uint64(header[40:48]),
+	}
+	info := &imageInfo{
+		format:      ImageFormatCow,
+		fileSize:    fileSize,
+		virtualSize: binary.BigEndian.Uint64(header[qcowSizeStartByte : qcowSizeStartByte+8]),
+		qcow:        qcow,
+	}
+	if qcow.version < 2 || qcow.version > 3 {
+		return nil, newError(EUnsupported, "unsupported QCOW version %d", qcow.version)
+	}
+	if qcow.clusterBits < 9 || qcow.clusterBits > 21 {
+		return nil, newError(EBadArgument, "invalid QCOW cluster size of 2^%d bytes", qcow.clusterBits)
+	}
+	if err := parseQCOWV3Header(qcow, header); err != nil {
+		return nil, err
+	}
+	switch binary.BigEndian.Uint32(header[32:36]) {
+	case 0:
+	case 1:
+		qcow.encryptionMethod = QCOWEncryptionMethodAES
+	case 2:
+		qcow.encryptionMethod = QCOWEncryptionMethodLUKS
+	default:
+		return nil, newError(EBadArgument, "invalid QCOW encryption method %d", binary.BigEndian.Uint32(header[32:36]))
+	}
+	if err := readQCOWBackingFile(reader, qcow, header, fileSize); err != nil {
+		return nil, err
+	}
+	return info, validateQCOWImage(info, header)
+}
+
+// parseQCOWV3Header parses the fields only present in version 3 headers.
+func parseQCOWV3Header(qcow *qcowImageInfo, header []byte) error {
+	if qcow.version < 3 {
+		return nil
+	}
+	if len(header) < qcowV3HeaderSize {
+		return newError(EBadArgument, "the QCOW header is truncated")
+	}
+	qcow.incompatibleFeatures = binary.BigEndian.Uint64(header[72:80])
+	qcow.compatibleFeatures = binary.BigEndian.Uint64(header[80:88])
+	qcow.autoclearFeatures = binary.BigEndian.Uint64(header[88:96])
+	headerLength := binary.BigEndian.Uint32(header[100:104])
+	if headerLength < qcowV3HeaderSize {
+		return newError(EBadArgument, "invalid QCOW header length %d", headerLength)
+	}
+	if qcow.incompatibleFeatures&qcowFeatureCompressionType == 0 {
+		return nil
+	}
+	if headerLength <= qcowV3HeaderSize || len(header) <= qcowV3HeaderSize {
+		return newError(EBadArgument, "the QCOW header has the compression type feature, but no compression type")
+	}
+	switch header[qcowV3HeaderSize] {
+	case 0:
+	case 1:
+		qcow.compressionType = QCOWCompressionTypeZstd
+	default:
+		return newError(EBadArgument, "invalid QCOW compression type %d", header[qcowV3HeaderSize])
+	}
+	return nil
+}
+
+// readQCOWBackingFile reads the name of the backing file if the image has one.
+func readQCOWBackingFile(reader io.ReadSeeker, qcow *qcowImageInfo, header []byte, fileSize uint64) error {
+	offset := binary.BigEndian.Uint64(header[8:16])
+	if offset == 0 {
+		return nil
+	}
+	size := binary.BigEndian.Uint32(header[16:20])
+	if size == 0 || size > qcowMaxBackingFileNameSize || offset+uint64(size) > fileSize {
+		return newError(EBadArgument, "invalid QCOW backing file name of %d bytes at offset %d", size, offset)
+	}
+	name, err := readImageRange(reader, offset, uint64(size), fileSize)
+	if err != nil {
+		return err
+	}
+	qcow.backingFile = string(name)
+	return nil
+}
+
+// validateQCOWImage checks that a parsed QCOW2 image can be uploaded to oVirt and that its tables are consistent with
+// the header.
+func validateQCOWImage(info *imageInfo, header []byte) error {
+	qcow := info.qcow
+	switch {
+	case qcow.backingFile != "":
+		return newError(
+			EBadArgument,
+			"the QCOW image has a backing file (%s), please merge it into a standalone image before uploading",
+			qcow.backingFile,
+		)
+	case qcow.encryptionMethod != QCOWEncryptionMethodNone:
+		return newError(EBadArgument, "the QCOW image is encrypted using %s, which is not supported", qcow.encryptionMethod)
+	case qcow.incompatibleFeatures&qcowFeatureCorrupt != 0:
+		return newError(EBadArgument, "the QCOW image is marked as corrupt")
+	case qcow.incompatibleFeatures&qcowFeatureDirty != 0:
+		return newError(EBadArgument, "the QCOW image was not closed cleanly, please repair it with qemu-img check -r all")
+	case qcow.incompatibleFeatures&qcowFeatureExternalData != 0:
+		return newError(EBadArgument, "the QCOW image stores its data in an external data file, which is not supported")
+	case qcow.incompatibleFeatures&^qcowFeaturesKnown != 0:
+		return newError(
+			EUnsupported,
+			"the QCOW image uses unknown incompatible features (%#x)",
+			qcow.incompatibleFeatures&^qcowFeaturesKnown,
+		)
+	}
+
+	clusterSize := qcow.ClusterSize()
+	entrySize := uint64(8)
+	if qcow.incompatibleFeatures&qcowFeatureExtendedL2 != 0 {
+		entrySize = 16
+	}
+	bytesPerL2 := clusterSize / entrySize * clusterSize
+	requiredL1Size := (info.virtualSize + bytesPerL2 - 1) / bytesPerL2
+	l1End := qcow.l1TableOffset + uint64(qcow.l1Size)*8
+	switch {
+	case qcow.l1Size > qcowMaxL1Size:
+		return newError(EBadArgument, "the QCOW L1 table has %d entries, which is too large", qcow.l1Size)
+	case uint64(qcow.l1Size) < requiredL1Size:
+		return newError(
+			EBadArgument,
+			"the QCOW L1 table has %d entries, but %d are required for a virtual size of %d bytes",
+			qcow.l1Size,
+			requiredL1Size,
+			info.virtualSize,
+		)
+	case qcow.l1Size > 0 && (qcow.l1TableOffset == 0 || qcow.l1TableOffset%clusterSize != 0):
+		return newError(EBadArgument, "the QCOW L1 table offset %d is not aligned to a cluster", qcow.l1TableOffset)
+	case qcow.l1Size > 0 && l1End > info.fileSize:
+		return newError(EBadArgument, "the QCOW L1 table ends at %d, beyond the end of the image", l1End)
+	}
+
+	refcountTableOffset := binary.BigEndian.Uint64(header[48:56])
+	if refcountTableOffset == 0 || refcountTableOffset%clusterSize != 0 || refcountTableOffset >= info.fileSize {
+		return newError(EBadArgument, "invalid QCOW refcount table offset %d", refcountTableOffset)
+	}
+	return nil
+}
 
-	_, err := io.ReadAtLeast(reader, header, qcowHeaderSize)
+// qcowAllocation reads the metadata of a QCOW2 image and returns the extents of the image file. The Zero flag is set
+// on the ranges of the file that are not referenced by the header, the L1, L2 and refcount tables, as QEMU never reads
+// these ranges and they can be sent as zeroes. Data clusters that have the zero flag set in their L2 entry are also
+// treated as unreferenced.
+//
+// If the image uses features that store metadata elsewhere, such as internal snapshots, bitmaps, encryption or an
+// external data file, nil is returned and the whole file has to be treated as referenced.
+//
+// See https://gitlab.com/qemu-project/qemu/-/blob/master/docs/interop/qcow2.txt for the format.
+func qcowAllocation(reader io.ReadSeeker, fileSize uint64) ([]DiskExtent, error) {
+	header, err := readImageRange(reader, 0, qcowV3HeaderSize, fileSize)
 	if err != nil {
-		return "", 0, wrap(err, EBadArgument, "failed to read QCOW header")
+		return nil, err
+	}
+	if len(header) < qcowV2HeaderSize || string(header[0:len(qcowMagicBytes)]) != qcowMagicBytes {
+		return nil, newError(EBadArgument, "the image is not a QCOW2 image")
+	}
+	version := binary.BigEndian.Uint32(header[4:8])
+	clusterBits := binary.BigEndian.Uint32(header[20:24])
+	if version < 2 || version > 3 || clusterBits < 9 || clusterBits > 21 {
+		return nil, newError(
+			EBadArgument,
+			"unsupported QCOW image version %d with %d cluster bits",
+			version,
+			clusterBits,
+		)
+	}
+	cryptMethod := binary.BigEndian.Uint32(header[32:36])
+	snapshots := binary.BigEndian.Uint32(header[60:64])
+	if cryptMethod != 0 || snapshots != 0 {
+		return nil, nil
 	}
+	if version == 3 {
+		if len(header) < qcowV3HeaderSize {
+			return nil, newError(EBadArgument, "the QCOW header is truncated")
+		}
+		incompatibleFeatures := binary.BigEndian.Uint64(header[72:80])
+		autoclearFeatures := binary.BigEndian.Uint64(header[88:96])
+		if incompatibleFeatures&^qcowIncompatibleFeaturesUnderstood != 0 || autoclearFeatures != 0 {
+			return nil, nil
+		}
+	}
+
+	clusterSize := uint64(1) << clusterBits
+	var referenced []DiskExtent
+	reference := func(start uint64, length uint64) {
+		if start < fileSize && length > 0 {
+			if start+length > fileSize {
+				length = fileSize - start
+			}
+			referenced = append(referenced, DiskExtent{Start: start, Length: length})
+		}
+	}
+
+	reference(0, clusterSize)
+	if backingFileOffset := binary.BigEndian.Uint64(header[8:16]); backingFileOffset != 0 {
+		reference(backingFileOffset, uint64(binary.BigEndian.Uint32(header[16:20])))
+	}
+
+	refcountTableOffset := binary.BigEndian.Uint64(header[48:56])
+	refcountTableSize := uint64(binary.BigEndian.Uint32(header[56:60])) * clusterSize
+	reference(refcountTableOffset, refcountTableSize)
+	refcountTable, err := readImageRange(reader, refcountTableOffset, refcountTableSize, fileSize)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i+8 <= len(refcountTable); i += 8 {
+		if offset := binary.BigEndian.Uint64(refcountTable[i:i+8]) &^ (clusterSize - 1); offset != 0 {
+			reference(offset, clusterSize)
+		}
+	}
+
+	l1Offset := binary.BigEndian.Uint64(header[40:48])
+	l1Size := uint64(binary.BigEndian.Uint32(header[36:40])) * 8
+	reference(l1Offset, l1Size)
+	l1Table, err := readImageRange(reader, l1Offset, l1Size, fileSize)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i+8 <= len(l1Table); i += 8 {
+		l2Offset := binary.BigEndian.Uint64(l1Table[i:i+8]) & qcowOffsetMask
+		if l2Offset == 0 {
+			continue
+		}
+		reference(l2Offset, clusterSize)
+		l2Table, err := readImageRange(reader, l2Offset, clusterSize, fileSize)
+		if err != nil {
+			return nil, err
+		}
+		for j := 0; j+8 <= len(l2Table); j += 8 {
+			reference(qcowDataCluster(binary.BigEndian.Uint64(l2Table[j:j+8]), clusterBits))
+		}
+	}
+
+	return qcowFillExtents(referenced, fileSize), nil
+}
+
+// qcowDataCluster returns the range of the image file referenced by an L2 table entry. It returns a zero length if the
+// entry does not reference any data in the file.
+func qcowDataCluster(entry uint64, clusterBits uint32) (uint64, uint64) {
+	if entry&qcowCompressedFlag != 0 {
+		sizeShift := 62 - (clusterBits - 8)
+		sizeMask := uint64(1)<<(clusterBits-8) - 1
+		offset := entry & (uint64(1)<<sizeShift - 1)
+		sectors := (entry>>sizeShift)&sizeMask + 1
+		return offset, (offset &^ 511) + sectors*512 - offset
+	}
+	offset := entry & qcowOffsetMask
+	if offset == 0 || entry&qcowZeroFlag != 0 {
+		return 0, 0
+	}
+	return offset, uint64(1) << clusterBits
+}
 
-	isQCOW := string(header[0:len(qcowMagicBytes)]) == qcowMagicBytes
-	if !isQCOW {
-		format = ImageFormatRaw
-	} else {
-		// See https://people.gnome.org/~markmc/qcow-image-format.html
-		qcowSize = binary.BigEndian.Uint64(header[qcowSizeStartByte : qcowSizeStartByte+8])
+// qcowFillExtents sorts and merges the referenced ranges of an image file and fills the gaps between them with zero
+// extents.
+func qcowFillExtents(referenced []DiskExtent, fileSize uint64) []DiskExtent {
+	sort.Slice(referenced, func(i, j int) bool {
+		return referenced[i].Start < referenced[j].Start
+	})
+	var extents []DiskExtent
+	offset := uint64(0)
+	for _, extent := range referenced {
+		end := extent.Start + extent.Length
+		if end <= offset {
+			continue
+		}
+		if extent.Start > offset {
+			extents = appendDiskExtent(extents, DiskExtent{Start: offset, Length: extent.Start - offset, Zero: true})
+		} else {
+			extent.Start = offset
+		}
+		extents = appendDiskExtent(extents, DiskExtent{Start: extent.Start, Length: end - extent.Start})
+		offset = end
 	}
-	if qcowSize <= 0 {
-		return format, 0, newError(EBadArgument, "expected positive image size, got %d instead", qcowSize)
+	if offset < fileSize {
+		extents = appendDiskExtent(extents, DiskExtent{Start: offset, Length: fileSize - offset, Zero: true})
 	}
-	return format, qcowSize, err
+	return extents
 }
@@ -24,6 +24,10 @@ type TestHelper interface {
 	// GetBlankTemplateID returns the ID of the blank template that can be used for creating dummy VMs.
 	GetBlankTemplateID() TemplateID
 
+	// GetVMPoolTemplateID returns the ID of the template VM pools should be created from. Defaults to the blank
+	// template.
+	GetVMPoolTemplateID() TemplateID
+
 	// GetStorageDomainID returns the ID of the storage domain to create the images on.
 	GetStorageDomainID() StorageDomainID
 
@@ -118,6 +122,11 @@ func NewTestHelper(
 		return nil, err
 	}
 
+	vmPoolTemplateID, err := setupVMPoolTemplateID(params.VMPoolTemplateID(), blankTemplateID, client)
+	if err != nil {
+		return nil, err
+	}
+
 	vnicProfileID, err := setupVNICProfileID(params.VNICProfileID(), clusterID, client)
 	if err != nil {
 		return nil, err
@@ -132,6 +141,7 @@ func NewTestHelper(
 		storageDomainID:          storageDomainID,
 		secondaryStorageDomainID: secondaryStorageDomainID,
 		blankTemplateID:          blankTemplateID,
+		vmPoolTemplateID:         vmPoolTemplateID,
 		vnicProfileID:            vnicProfileID,
 		// We are suppressing gosec linting here since rand is not used in a security-relevant context,
 		// only to generate random ID's for testing.
@@ -163,6 +173,10 @@ type TestHelperParameters interface {
 	// for testing. It may return an empty string if no template is provided.
 	BlankTemplateID() TemplateID
 
+	// VMPoolTemplateID returns an ID to a template that can be used for creating VM pools. It may return an empty
+	// string, in which case the blank template is used.
+	VMPoolTemplateID() TemplateID
+
 	// VNICProfileID returns an ID to a VNIC profile designated for testing. It may return
 	// an empty string, in which case an arbitrary VNIC profile is selected.
 	VNICProfileID() VNICProfileID
@@ -181,6 +195,8 @@ type BuildableTestHelperParameters interface {
 	WithSecondaryStorageDomainID(StorageDomainID) BuildableTestHelperParameters
 	// WithBlankTemplateID sets the blank template that can be used for testing.
 	WithBlankTemplateID(TemplateID) BuildableTestHelperParameters
+	// WithVMPoolTemplateID sets the template that can be used for creating VM pools.
+	WithVMPoolTemplateID(TemplateID) BuildableTestHelperParameters
 	// WithVNICProfileID sets the ID of the VNIC profile that can be used for testing.
 	WithVNICProfileID(VNICProfileID) BuildableTestHelperParameters
 }
@@ -190,6 +206,7 @@ type testHelperParameters struct {
 	storageDomainID          StorageDomainID
 	secondaryStorageDomainID StorageDomainID
 	blankTemplateID          TemplateID
+	vmPoolTemplateID         TemplateID
 	vnicProfileID            VNICProfileID
 }
 
@@ -214,6 +231,10 @@ func (t *testHelperParameters) BlankTemplateID() TemplateID {
 	return t.blankTemplateID
 }
 
+func (t *testHelperParameters) VMPoolTemplateID() TemplateID {
+	return t.vmPoolTemplateID
+}
+
 func (t *testHelperParameters) VNICProfileID() VNICProfileID {
 	return t.vnicProfileID
 }
@@ -233,6 +254,11 @@ func (t *testHelperParameters) WithBlankTemplateID(s TemplateID) BuildableTestHe
 	return t
 }
 
+func (t *testHelperParameters) WithVMPoolTemplateID(s TemplateID) BuildableTestHelperParameters {
+	t.vmPoolTemplateID = s
+	return t
+}
+
 func (t *testHelperParameters) WithVNICProfileID(s VNICProfileID) BuildableTestHelperParameters {
 	t.vnicProfileID = s
 	return t
@@ -282,6 +308,20 @@ func setupBlankTemplateID(blankTemplateID TemplateID, client Client) (id Templat
 	return blankTemplateID, nil
 }
 
+func setupVMPoolTemplateID(
+	vmPoolTemplateID TemplateID,
+	blankTemplateID TemplateID,
+	client Client,
+) (TemplateID, error) {
+	if vmPoolTemplateID == "" {
+		return blankTemplateID, nil
+	}
+	if _, err := client.GetTemplate(vmPoolTemplateID); err != nil {
+		return "", fmt.Errorf("failed to verify VM pool template ID %s (%w)", vmPoolTemplateID, err)
+	}
+	return vmPoolTemplateID, nil
+}
+
 func setupTestStorageDomainID(storageDomainID StorageDomainID, client Client) (id StorageDomainID, err error) {
 	if storageDomainID == "" {
 		storageDomainID, err = findTestStorageDomainID("", client)
@@ -430,6 +470,7 @@ type testHelper struct {
 	clusterID                ClusterID
 	storageDomainID          StorageDomainID
 	blankTemplateID          TemplateID
+	vmPoolTemplateID         TemplateID
 	vnicProfileID            VNICProfileID
 	secondaryStorageDomainID StorageDomainID
 	password                 string
@@ -477,6 +518,10 @@ func (t *testHelper) GetBlankTemplateID() TemplateID {
 	return t.blankTemplateID
 }
 
+func (t *testHelper) GetVMPoolTemplateID() TemplateID {
+	return t.vmPoolTemplateID
+}
+
 func (t *testHelper) GetStorageDomainID() StorageDomainID {
 	return t.storageDomainID
 }
@@ -562,6 +607,7 @@ func NewLiveTestHelperFromEnv(logger ovirtclientlog.Logger) (TestHelper, error)
 	params := TestHelperParams()
 	params.WithClusterID(ClusterID(os.Getenv("OVIRT_CLUSTER_ID")))
 	params.WithBlankTemplateID(TemplateID(os.Getenv("OVIRT_BLANK_TEMPLATE_ID")))
+	params.WithVMPoolTemplateID(TemplateID(os.Getenv("OVIRT_VM_POOL_TEMPLATE_ID")))
 	params.WithStorageDomainID(StorageDomainID(os.Getenv("OVIRT_STORAGE_DOMAIN_ID")))
 	params.WithSecondaryStorageDomainID(StorageDomainID(os.Getenv("OVIRT_SECONDARY_STORAGE_DOMAIN_ID")))
 	params.WithVNICProfileID(VNICProfileID(os.Getenv("OVIRT_VNIC_PROFILE_ID")))
@@ -33,9 +33,12 @@ type VMClient interface {
 	UpdateVM(id VMID, params UpdateVMParameters, retries ...RetryStrategy) (VM, error)
 	// AutoOptimizeVMCPUPinningSettings sets the CPU settings to optimized.
 	AutoOptimizeVMCPUPinningSettings(id VMID, optimize bool, retries ...RetryStrategy) error
-	// StartVM triggers a VM start. The actual VM startup will take time and should be waited for via the
-	// WaitForVMStatus call.
+	// StartVM triggers a VM start. If the VM is paused or suspended, it will be resumed. The actual VM startup will
+	// take time and should be waited for via the WaitForVMStatus call.
 	StartVM(id VMID, retries ...RetryStrategy) error
+	// StartVMWithParameters triggers a "run once" start of a VM. The parameters only apply to the current run and
+	// the VM configuration is reverted to its persistent state when the VM is shut down.
+	StartVMWithParameters(id VMID, params OptionalVMStartParameters, retries ...RetryStrategy) error
 	// StopVM triggers a VM power-off. The actual VM stop will take time and should be waited for via the
 	// WaitForVMStatus call. The force parameter will cause the shutdown to proceed even if a backup is currently
 	// running.
@@ -44,6 +47,30 @@ type VMClient interface {
 	// WaitForVMStatus call. The force parameter will cause the shutdown to proceed even if a backup is currently
 	// running.
 	ShutdownVM(id VMID, force bool, retries ...RetryStrategy) error
+	// CloneVM creates a new VM with the specified name as a copy of the source VM, including its disks, without
+	// the need to create a template first. The call waits for the disks of the new VM to be ready.
+	CloneVM(sourceVMID VMID, name string, params OptionalCloneVMParameters, retries ...RetryStrategy) (VM, error)
+	// SuspendVM triggers a VM suspend (hibernation). The memory state of the VM is saved to the storage and the VM
+	// process is stopped. The VM passes through VMStatusSavingState before reaching VMStatusSuspended, which should be
+	// waited for via the WaitForVMStatus call. Use StartVM to resume the VM.
+	SuspendVM(id VMID, retries ...RetryStrategy) error
+	// RebootVM triggers a reboot of the guest operating system. The force parameter will cause the reboot to
+	// proceed even if a backup is currently running.
+	RebootVM(id VMID, force bool, retries ...RetryStrategy) error
+	// ResetVM triggers a hard reset of the VM, similar to pressing the reset button on a physical machine. The guest
+	// operating system is not notified.
+	ResetVM(id VMID, retries ...RetryStrategy) error
+	// MigrateVM triggers a live migration of a running VM to another host. If no target host is set in params, the
+	// engine selects a suitable host in the cluster of the VM. The migration takes time and should be waited for
+	// via the WaitForVMMigration call.
+	MigrateVM(id VMID, params MigrateVMParameters, retries ...RetryStrategy) error
+	// CancelVMMigration cancels a migration that is currently in progress. The VM remains on the source host.
+	CancelVMMigration(id VMID, retries ...RetryStrategy) error
+	// WaitForVMMigration waits for a migration of the VM to finish and returns the updated VM. The host the VM has
+	// been migrated to is available from VM.HostID(). The migration is finished when the VM is up on a different host
+	// than when the wait started, or is up again after it has been seen migrating. It should therefore be called
+	// right after MigrateVM. After CancelVMMigration use WaitForVMStatus instead, as the VM stays on its host.
+	WaitForVMMigration(id VMID, retries ...RetryStrategy) (VM, error)
 	// WaitForVMStatus waits for the VM to reach the desired status.
 	WaitForVMStatus(id VMID, status VMStatus, retries ...RetryStrategy) (VM, error)
 	// ListVMs returns a list of all virtual machines.
@@ -235,6 +262,8 @@ type VMData interface {
 	InstanceTypeID() *InstanceTypeID
 	// VMType returns the VM type for the current VM.
 	VMType() VMType
+	// VMPoolID returns the ID of the VM pool this VM belongs to, or nil if the VM is not part of a pool.
+	VMPoolID() *VMPoolID
 
 	// OS returns the operating system structure.
 	OS() VMOS
@@ -458,7 +487,32 @@ func VMHugePagesValues() VMHugePagesList {
 type Initialization interface {
 	CustomScript() string
 	HostName() string
+	// NicConfiguration returns the first NIC configuration, or nil if no NIC configuration is set. Use
+	// NicConfigurations to get all NIC configurations.
 	NicConfiguration() NicConfiguration
+	// NicConfigurations returns all NIC configurations used at boot time.
+	NicConfigurations() []NicConfiguration
+	// AuthorizedSSHKeys returns the SSH public keys that should be authorized for the user.
+	AuthorizedSSHKeys() []string
+	// UserName returns the name of the user that should be created or whose password should be set. If empty, the
+	// root user (or Administrator on Windows) is used.
+	UserName() string
+	// RootPassword returns the password for the user.
+	RootPassword() string
+	// DNSServers returns the DNS servers the VM should use.
+	DNSServers() []string
+	// DNSSearch returns the DNS search domains the VM should use.
+	DNSSearch() []string
+	// Timezone returns the timezone of the VM, for example Etc/UTC or GMT Standard Time on Windows.
+	Timezone() string
+	// RegenerateSSHKeys indicates that the SSH host keys of the VM should be regenerated.
+	RegenerateSSHKeys() bool
+	// Domain returns the Active Directory domain a Windows VM should join via sysprep.
+	Domain() string
+	// OrgName returns the organization name set via sysprep.
+	OrgName() string
+	// WindowsLicenseKey returns the Windows product key set via sysprep.
+	WindowsLicenseKey() string
 }
 
 // BuildableInitialization is a buildable version of Initialization.
@@ -466,25 +520,49 @@ type BuildableInitialization interface {
 	Initialization
 	WithCustomScript(customScript string) BuildableInitialization
 	WithHostname(hostname string) BuildableInitialization
+	// WithNicConfiguration replaces all NIC configurations with the specified NIC configuration.
 	WithNicConfiguration(nic NicConfiguration) BuildableInitialization
+	// WithNicConfigurations replaces all NIC configurations.
+	WithNicConfigurations(nics []NicConfiguration) BuildableInitialization
+	WithAuthorizedSSHKeys(keys []string) BuildableInitialization
+	WithUserName(userName string) BuildableInitialization
+	WithRootPassword(password string) BuildableInitialization
+	WithDNSServers(servers []string) BuildableInitialization
+	WithDNSSearch(domains []string) BuildableInitialization
+	WithTimezone(timezone string) BuildableInitialization
+	WithRegenerateSSHKeys(regenerate bool) BuildableInitialization
+	WithDomain(domain string) BuildableInitialization
+	WithOrgName(orgName string) BuildableInitialization
+	WithWindowsLicenseKey(key string) BuildableInitialization
 }
 
 // initialization defines to the virtual machine’s initialization configuration.
 // customScript - Cloud-init script which will be executed on Virtual Machine when deployed.
 // hostname - Hostname to be set to Virtual Machine when deployed.
-// nicConfiguration - Optional. The nic configuration used on boot time.
+// nicConfigurations - Optional. The nic configurations used on boot time.
+// The remaining fields are passed to cloud-init or sysprep as-is.
 type initialization struct {
-	customScript     string
-	hostname         string
-	nicConfiguration NicConfiguration
+	customScript      string
+	hostname          string
+	nicConfigurations []NicConfiguration
+	authorizedSSHKeys []string
+	userName          string
+	rootPassword      string
+	dnsServers        []string
+	dnsSearch         []string
+	timezone          string
+	regenerateSSHKeys bool
+	domain            string
+	orgName           string
+	windowsLicenseKey string
 }
 
 // NewInitialization creates a new Initialization from the specified parameters.
 func NewInitialization(customScript, hostname string) BuildableInitialization {
 	return &initialization{
-		customScript:     customScript,
-		hostname:         hostname,
-		nicConfiguration: nil,
+		customScript:      customScript,
+		hostname:          hostname,
+		nicConfigurations: nil,
 	}
 }
 
@@ -497,7 +575,54 @@ func (i *initialization) HostName() string {
 }
 
 func (i *initialization) NicConfiguration() NicConfiguration {
-	return i.nicConfiguration
+	if len(i.nicConfigurations) == 0 {
+		return nil
+	}
+	return i.nicConfigurations[0]
+}
+
+func (i *initialization) NicConfigurations() []NicConfiguration {
+	return i.nicConfigurations
+}
+
+func (i *initialization) AuthorizedSSHKeys() []string {
+	return i.authorizedSSHKeys
+}
+
+func (i *initialization) UserName() string {
+	return i.userName
+}
+
+func (i *initialization) RootPassword() string {
+	return i.rootPassword
+}
+
+func (i *initialization) DNSServers() []string {
+	return i.dnsServers
+}
+
+func (i *initialization) DNSSearch() []string {
+	return i.dnsSearch
+}
+
+func (i *initialization) Timezone() string {
+	return i.timezone
+}
+
+func (i *initialization) RegenerateSSHKeys() bool {
+	return i.regenerateSSHKeys
+}
+
+func (i *initialization) Domain() string {
+	return i.domain
+}
+
+func (i *initialization) OrgName() string {
+	return i.orgName
+}
+
+func (i *initialization) WindowsLicenseKey() string {
+	return i.windowsLicenseKey
 }
 
 func (i *initialization) WithCustomScript(customScript string) BuildableInitialization {
@@ -511,10 +636,90 @@ func (i *initialization) WithHostname(hostname string) BuildableInitialization {
 }
 
 func (i *initialization) WithNicConfiguration(nic NicConfiguration) BuildableInitialization {
-	i.nicConfiguration = nic
+	if nic == nil {
+		i.nicConfigurations = nil
+		return i
+	}
+	i.nicConfigurations = []NicConfiguration{nic}
+	return i
+}
+
+func (i *initialization) WithNicConfigurations(nics []NicConfiguration) BuildableInitialization {
+	i.nicConfigurations = nics
+	return i
+}
+
+func (i *initialization) WithAuthorizedSSHKeys(keys []string) BuildableInitialization {
+	i.authorizedSSHKeys = keys
+	return i
+}
+
+func (i *initialization) WithUserName(userName string) BuildableInitialization {
+	i.userName = userName
 	return i
 }
 
+func (i *initialization) WithRootPassword(password string) BuildableInitialization {
+	i.rootPassword = password
+	return i
+}
+
+func (i *initialization) WithDNSServers(servers []string) BuildableInitialization {
+	i.dnsServers = servers
+	return i
+}
+
+func (i *initialization) WithDNSSearch(domains []string) BuildableInitialization {
+	i.dnsSearch = domains
+	return i
+}
+
+func (i *initialization) WithTimezone(timezone string) BuildableInitialization {
+	i.timezone = timezone
+	return i
+}
+
+func (i *initialization) WithRegenerateSSHKeys(regenerate bool) BuildableInitialization {
+	i.regenerateSSHKeys = regenerate
+	return i
+}
+
+func (i *initialization) WithDomain(domain string) BuildableInitialization {
+	i.domain = domain
+	return i
+}
+
+func (i *initialization) WithOrgName(orgName string) BuildableInitialization {
+	i.orgName = orgName
+	return i
+}
+
+func (i *initialization) WithWindowsLicenseKey(key string) BuildableInitialization {
+	i.windowsLicenseKey = key
+	return i
+}
+
+// validateInitialization checks the boot protocols of the NIC configurations since the NIC configuration builder
+// does not return errors.
+func validateInitialization(init Initialization) error {
+	if init == nil {
+		return nil
+	}
+	for _, nicConf := range init.NicConfigurations() {
+		if protocol := nicConf.BootProtocol(); protocol != "" {
+			if err := protocol.Validate(); err != nil {
+				return wrap(err, EBadArgument, "invalid boot protocol for NIC %s", nicConf.Name())
+			}
+		}
+		if protocol := nicConf.IPV6BootProtocol(); protocol != "" {
+			if err := protocol.Validate(); err != nil {
+				return wrap(err, EBadArgument, "invalid IPv6 boot protocol for NIC %s", nicConf.Name())
+			}
+		}
+	}
+	return nil
+}
+
 // IPVersion represents the IP protocol version (v4 or v6).
 type IPVersion string
 
@@ -543,11 +748,69 @@ func (ip IP) IsIPv6() bool {
 	return ip.Version == IPVersionV6
 }
 
+// BootProtocol defines how a network interface obtains its address when the VM is initialized.
+type BootProtocol string
+
+const (
+	// BootProtocolStatic configures the address specified in the NIC configuration.
+	BootProtocolStatic BootProtocol = "static"
+	// BootProtocolDHCP obtains the address via DHCP (DHCPv6 for IPv6).
+	BootProtocolDHCP BootProtocol = "dhcp"
+	// BootProtocolAutoconf obtains an IPv6 address via stateless address autoconfiguration.
+	BootProtocolAutoconf BootProtocol = "autoconf"
+	// BootProtocolPolyDHCPAutoconf obtains an IPv6 address via DHCPv6 and stateless address autoconfiguration.
+	BootProtocolPolyDHCPAutoconf BootProtocol = "poly_dhcp_autoconf"
+	// BootProtocolNone leaves the address unconfigured.
+	BootProtocolNone BootProtocol = "none"
+)
+
+// Validate returns an error if the boot protocol doesn't have a valid value.
+func (b BootProtocol) Validate() error {
+	for _, protocol := range BootProtocolValues() {
+		if protocol == b {
+			return nil
+		}
+	}
+	return newError(
+		EBadArgument,
+		"Invalid value for boot protocol: %s must be one of: %s",
+		b,
+		strings.Join(BootProtocolValues().Strings(), ", "),
+	)
+}
+
+// BootProtocolList is a list of BootProtocol.
+type BootProtocolList []BootProtocol
+
+// Strings creates a string list of the values.
+func (l BootProtocolList) Strings() []string {
+	result := make([]string, len(l))
+	for i, protocol := range l {
+		result[i] = string(protocol)
+	}
+	return result
+}
+
+// BootProtocolValues returns all possible BootProtocol values.
+func BootProtocolValues() BootProtocolList {
+	return []BootProtocol{
+		BootProtocolStatic,
+		BootProtocolDHCP,
+		BootProtocolAutoconf,
+		BootProtocolPolyDHCPAutoconf,
+		BootProtocolNone,
+	}
+}
+
 // NicConfiguration defines a virtual machine’s initialization nic configuration.
 type NicConfiguration interface {
 	Name() string
 	IP() IP
 	IPV6() *IP
+	// BootProtocol returns the IPv4 boot protocol of the NIC. Defaults to BootProtocolStatic.
+	BootProtocol() BootProtocol
+	// IPV6BootProtocol returns the IPv6 boot protocol of the NIC. If empty, the IPv6 boot protocol is not set.
+	IPV6BootProtocol() BootProtocol
 }
 
 // BuildableNicConfiguration is a buildable version of NicConfiguration.
@@ -556,20 +819,34 @@ type BuildableNicConfiguration interface {
 	WithName(name string) BuildableNicConfiguration
 	WithIP(ip IP) BuildableNicConfiguration
 	WithIPV6(ip IP) BuildableNicConfiguration
+	WithBootProtocol(protocol BootProtocol) BuildableNicConfiguration
+	WithIPV6BootProtocol(protocol BootProtocol) BuildableNicConfiguration
 }
 
 type nicConfiguration struct {
-	name string
-	ip   IP
-	ipv6 *IP
+	name             string
+	ip               IP
+	ipv6             *IP
+	bootProtocol     BootProtocol
+	ipv6BootProtocol BootProtocol
 }
 
 // NewNicConfiguration creates a new NicConfiguration from the specified parameters.
 func NewNicConfiguration(name string, ip IP) BuildableNicConfiguration {
 	return &nicConfiguration{
-		name: name,
-		ip:   ip,
-		ipv6: nil,
+		name:         name,
+		ip:           ip,
+		ipv6:         nil,
+		bootProtocol: BootProtocolStatic,
+	}
+}
+
+// NewDHCPNicConfiguration creates a new NicConfiguration that obtains its IPv4 address via DHCP.
+func NewDHCPNicConfiguration(name string) BuildableNicConfiguration {
+	return &nicConfiguration{
+		name:         name,
+		ip:           IP{Version: IPVersionV4},
+		bootProtocol: BootProtocolDHCP,
 	}
 }
 
@@ -585,6 +862,14 @@ func (i *nicConfiguration) IPV6() *IP {
 	return i.ipv6
 }
 
+func (i *nicConfiguration) BootProtocol() BootProtocol {
+	return i.bootProtocol
+}
+
+func (i *nicConfiguration) IPV6BootProtocol() BootProtocol {
+	return i.ipv6BootProtocol
+}
+
 func (i *nicConfiguration) WithName(name string) BuildableNicConfiguration {
 	i.name = name
 	return i
@@ -600,6 +885,16 @@ func (i *nicConfiguration) WithIPV6(ip IP) BuildableNicConfiguration {
 	return i
 }
 
+func (i *nicConfiguration) WithBootProtocol(protocol BootProtocol) BuildableNicConfiguration {
+	i.bootProtocol = protocol
+	return i
+}
+
+func (i *nicConfiguration) WithIPV6BootProtocol(protocol BootProtocol) BuildableNicConfiguration {
+	i.ipv6BootProtocol = protocol
+	return i
+}
+
 // convertSDKInitialization converts the initialization of a VM. We keep the error return in case we need it later
 // as errors may happen as we extend this function and we don't want to touch other functions.
 func convertSDKInitialization(sdkObject *ovirtsdk.Vm) (*initialization, error) { //nolint:unparam
@@ -619,22 +914,75 @@ func convertSDKInitialization(sdkObject *ovirtsdk.Vm) (*initialization, error) {
 		init.hostname = hostname
 	}
 	nicConfigs, ok := initializationSDK.NicConfigurations()
-	if ok && len(nicConfigs.Slice()) >= 1 {
-		init.nicConfiguration = convertSDKNicConfiguration(nicConfigs.Slice()[0])
+	if ok {
+		for _, nicConfig := range nicConfigs.Slice() {
+			init.nicConfigurations = append(init.nicConfigurations, convertSDKNicConfiguration(nicConfig))
+		}
+	}
+	if authorizedSSHKeys, ok := initializationSDK.AuthorizedSshKeys(); ok {
+		init.authorizedSSHKeys = splitInitializationList(authorizedSSHKeys, "\n")
+	}
+	if userName, ok := initializationSDK.UserName(); ok {
+		init.userName = userName
+	}
+	if rootPassword, ok := initializationSDK.RootPassword(); ok {
+		init.rootPassword = rootPassword
+	}
+	if dnsServers, ok := initializationSDK.DnsServers(); ok {
+		init.dnsServers = splitInitializationList(dnsServers, " ")
+	}
+	if dnsSearch, ok := initializationSDK.DnsSearch(); ok {
+		init.dnsSearch = splitInitializationList(dnsSearch, " ")
+	}
+	if timezone, ok := initializationSDK.Timezone(); ok {
+		init.timezone = timezone
+	}
+	if regenerateSSHKeys, ok := initializationSDK.RegenerateSshKeys(); ok {
+		init.regenerateSSHKeys = regenerateSSHKeys
+	}
+	if domain, ok := initializationSDK.Domain(); ok {
+		init.domain = domain
+	}
+	if orgName, ok := initializationSDK.OrgName(); ok {
+		init.orgName = orgName
+	}
+	if windowsLicenseKey, ok := initializationSDK.WindowsLicenseKey(); ok {
+		init.windowsLicenseKey = windowsLicenseKey
 	}
 	return &init, nil
 }
 
+// splitInitializationList splits a list field of the initialization, such as the DNS servers, which the engine
+// stores as a single string.
+func splitInitializationList(value string, separator string) []string {
+	var result []string
+	for _, item := range strings.Split(value, separator) {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
 func convertSDKNicConfiguration(sdkObject *ovirtsdk.NicConfiguration) NicConfiguration {
-	ipv4 := sdkObject.MustIp()
-	nicConfiguration := NewNicConfiguration(
-		sdkObject.MustName(), IP{
-			Address: ipv4.MustAddress(),
-			Gateway: ipv4.MustGateway(),
-			Netmask: ipv4.MustNetmask(),
-			Version: IPVersionV4,
-		},
-	)
+	name, _ := sdkObject.Name()
+	nicConfiguration := NewNicConfiguration(name, IP{Version: IPVersionV4})
+	if bootProtocol, ok := sdkObject.BootProtocol(); ok {
+		nicConfiguration = nicConfiguration.WithBootProtocol(BootProtocol(bootProtocol))
+	}
+	if ipv4, ok := sdkObject.Ip(); ok {
+		address, _ := ipv4.Address()
+		gateway, _ := ipv4.Gateway()
+		netmask, _ := ipv4.Netmask()
+		nicConfiguration = nicConfiguration.WithIP(
+			IP{
+				Address: address,
+				Gateway: gateway,
+				Netmask: netmask,
+				Version: IPVersionV4,
+			},
+		)
+	}
 
 	ipv6, ok := sdkObject.Ipv6()
 	if ok {
@@ -652,6 +1000,9 @@ func convertSDKNicConfiguration(sdkObject *ovirtsdk.NicConfiguration) NicConfigu
 			},
 		)
 	}
+	if ipv6BootProtocol, ok := sdkObject.Ipv6BootProtocol(); ok {
+		nicConfiguration = nicConfiguration.WithIPV6BootProtocol(BootProtocol(ipv6BootProtocol))
+	}
 	return nicConfiguration
 }
 
@@ -667,12 +1018,30 @@ type VM interface {
 
 	// Start will cause a VM to start. The actual start process takes some time and should be checked via WaitForStatus.
 	Start(retries ...RetryStrategy) error
+	// StartWithParameters will cause a "run once" start of the VM with the specified temporary options.
+	StartWithParameters(params OptionalVMStartParameters, retries ...RetryStrategy) error
 	// Stop will cause the VM to power-off. The force parameter will cause the VM to stop even if a backup is currently
 	// running.
 	Stop(force bool, retries ...RetryStrategy) error
 	// Shutdown will cause the VM to shut down. The force parameter will cause the VM to shut down even if a backup
 	// is currently running.
 	Shutdown(force bool, retries ...RetryStrategy) error
+	// Clone creates a copy of the current VM with the specified name. This involves an API call and may be slow.
+	Clone(name string, params OptionalCloneVMParameters, retries ...RetryStrategy) (VM, error)
+	// Suspend will cause the VM to be suspended (hibernated). Use Start to resume the VM.
+	Suspend(retries ...RetryStrategy) error
+	// Reboot will cause the guest operating system to reboot. The force parameter will cause the VM to reboot even
+	// if a backup is currently running.
+	Reboot(force bool, retries ...RetryStrategy) error
+	// Reset will cause a hard reset of the VM.
+	Reset(retries ...RetryStrategy) error
+	// Migrate will cause the VM to be live migrated to another host. The actual migration takes some time and should
+	// be checked via WaitForMigration.
+	Migrate(params MigrateVMParameters, retries ...RetryStrategy) error
+	// CancelMigration cancels the migration of the VM that is currently in progress.
+	CancelMigration(retries ...RetryStrategy) error
+	// WaitForMigration waits for the migration of the VM to finish and returns the updated VM object.
+	WaitForMigration(retries ...RetryStrategy) (VM, error)
 	// WaitForStatus will wait until the VM reaches the desired status. If the status is not reached within the
 	// specified amount of retries, an error will be returned. If the VM enters the desired state, an updated VM
 	// object will be returned.
@@ -701,6 +1070,13 @@ type VM interface {
 		diskAttachmentID DiskAttachmentID,
 		retries ...RetryStrategy,
 	) error
+	// UpdateDiskAttachment changes a specific disk attachment of the current VM, for example to hot plug or hot unplug
+	// the disk.
+	UpdateDiskAttachment(
+		diskAttachmentID DiskAttachmentID,
+		params UpdateDiskAttachmentParameters,
+		retries ...RetryStrategy,
+	) (DiskAttachment, error)
 
 	// AttachISO attaches an ISO image to this VM's CDROM.
 	AttachISO(isoImageID string, retries ...RetryStrategy) (CDROM, error)
@@ -713,6 +1089,15 @@ type VM interface {
 	// EjectISO ejects the ISO from a CDROM attachment.
 	EjectISO(cdromID CDROMID, retries ...RetryStrategy) (CDROM, error)
 
+	// CreateSnapshot creates a snapshot of the current VM and waits for the snapshot to be ready.
+	CreateSnapshot(
+		description string,
+		params CreateSnapshotOptionalParameters,
+		retries ...RetryStrategy,
+	) (Snapshot, error)
+	// ListSnapshots lists all snapshots of the current VM.
+	ListSnapshots(retries ...RetryStrategy) ([]Snapshot, error)
+
 	// Tags list all tags for the current VM
 	Tags(retries ...RetryStrategy) ([]Tag, error)
 
@@ -1783,6 +2168,9 @@ type UpdateVMParameters interface {
 	// Kernel returns path to custom kernel on ISO storage domain if Linux operating system is used.
 	// Not used for hosts.
 	Kernel() *string
+	// Initialization returns the new initialization (cloud-init or sysprep) configuration for the VM. Return nil if
+	// the initialization should not be changed.
+	Initialization() Initialization
 }
 
 // VMCPUTopo contains the CPU topology information about a VM.
@@ -1906,6 +2294,12 @@ type BuildableUpdateVMParameters interface {
 
 	// MustWithKernel is identical to WithKernel, but panics instead of returning an error.
 	MustWithKernel(kernel string) BuildableUpdateVMParameters
+
+	// WithInitialization replaces the initialization configuration of the VM.
+	WithInitialization(initialization Initialization) (BuildableUpdateVMParameters, error)
+
+	// MustWithInitialization is identical to WithInitialization, but panics instead of returning an error.
+	MustWithInitialization(initialization Initialization) BuildableUpdateVMParameters
 }
 
 // UpdateVMParams returns a buildable set of update parameters.
@@ -1922,6 +2316,7 @@ type updateVMParams struct {
 	customKernelCmdline *string
 	initrd              *string
 	kernel              *string
+	initialization      Initialization
 }
 
 func (u *updateVMParams) MustWithName(name string) BuildableUpdateVMParameters {
@@ -2068,6 +2463,26 @@ func (u *updateVMParams) MustWithKernel(kernel string) BuildableUpdateVMParamete
 	return builder
 }
 
+func (u *updateVMParams) Initialization() Initialization {
+	return u.initialization
+}
+
+func (u *updateVMParams) WithInitialization(initialization Initialization) (BuildableUpdateVMParameters, error) {
+	if err := validateInitialization(initialization); err != nil {
+		return nil, err
+	}
+	u.initialization = initialization
+	return u, nil
+}
+
+func (u *updateVMParams) MustWithInitialization(initialization Initialization) BuildableUpdateVMParameters {
+	builder, err := u.WithInitialization(initialization)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
 // NewCreateVMParams creates a set of BuildableVMParameters that can be used to construct the optional VM parameters.
 func NewCreateVMParams() BuildableVMParameters {
 	return &vmParams{
@@ -2283,6 +2698,9 @@ func (v *vmParams) Initialization() Initialization {
 }
 
 func (v *vmParams) WithInitialization(initialization Initialization) (BuildableVMParameters, error) {
+	if err := validateInitialization(initialization); err != nil {
+		return nil, err
+	}
 	v.initialization = initialization
 	return v, nil
 }
@@ -2424,6 +2842,7 @@ type vm struct {
 	os               *vmOS
 	serialConsole    bool
 	soundcardEnabled bool
+	vmPoolID         *VMPoolID
 }
 
 func (v *vm) SoundcardEnabled() bool {
@@ -2450,6 +2869,10 @@ func (v *vm) InstanceTypeID() *InstanceTypeID {
 	return v.instanceTypeID
 }
 
+func (v *vm) VMPoolID() *VMPoolID {
+	return v.vmPoolID
+}
+
 func (v *vm) AddTag(tagID TagID, retries ...RetryStrategy) (err error) {
 	return v.client.AddTagToVM(v.id, tagID, retries...)
 }
@@ -2506,6 +2929,10 @@ func (v *vm) Start(retries ...RetryStrategy) error {
 	return v.client.StartVM(v.id, retries...)
 }
 
+func (v *vm) StartWithParameters(params OptionalVMStartParameters, retries ...RetryStrategy) error {
+	return v.client.StartVMWithParameters(v.id, params, retries...)
+}
+
 func (v *vm) Stop(force bool, retries ...RetryStrategy) error {
 	return v.client.StopVM(v.id, force, retries...)
 }
@@ -2514,6 +2941,34 @@ func (v *vm) Shutdown(force bool, retries ...RetryStrategy) error {
 	return v.client.ShutdownVM(v.id, force, retries...)
 }
 
+func (v *vm) Suspend(retries ...RetryStrategy) error {
+	return v.client.SuspendVM(v.id, retries...)
+}
+
+func (v *vm) Reboot(force bool, retries ...RetryStrategy) error {
+	return v.client.RebootVM(v.id, force, retries...)
+}
+
+func (v *vm) Reset(retries ...RetryStrategy) error {
+	return v.client.ResetVM(v.id, retries...)
+}
+
+func (v *vm) Clone(name string, params OptionalCloneVMParameters, retries ...RetryStrategy) (VM, error) {
+	return v.client.CloneVM(v.id, name, params, retries...)
+}
+
+func (v *vm) Migrate(params MigrateVMParameters, retries ...RetryStrategy) error {
+	return v.client.MigrateVM(v.id, params, retries...)
+}
+
+func (v *vm) CancelMigration(retries ...RetryStrategy) error {
+	return v.client.CancelVMMigration(v.id, retries...)
+}
+
+func (v *vm) WaitForMigration(retries ...RetryStrategy) (VM, error) {
+	return v.client.WaitForVMMigration(v.id, retries...)
+}
+
 func (v *vm) WaitForStatus(status VMStatus, retries ...RetryStrategy) (VM, error) {
 	return v.client.WaitForVMStatus(v.id, status, retries...)
 }
@@ -2555,6 +3010,7 @@ func (v *vm) withName(name string) *vm {
 		v.os,
 		v.serialConsole,
 		v.soundcardEnabled,
+		v.vmPoolID,
 	}
 }
 
@@ -2583,6 +3039,7 @@ func (v *vm) withComment(comment string) *vm {
 		v.os,
 		v.serialConsole,
 		v.soundcardEnabled,
+		v.vmPoolID,
 	}
 }
 
@@ -2611,6 +3068,7 @@ func (v *vm) withDescription(description string) *vm {
 		v.os,
 		v.serialConsole,
 		v.soundcardEnabled,
+		v.vmPoolID,
 	}
 }
 
@@ -2643,6 +3101,14 @@ func (v *vm) DetachDisk(diskAttachmentID DiskAttachmentID, retries ...RetryStrat
 	return v.client.RemoveDiskAttachment(v.id, diskAttachmentID, retries...)
 }
 
+func (v *vm) UpdateDiskAttachment(
+	diskAttachmentID DiskAttachmentID,
+	params UpdateDiskAttachmentParameters,
+	retries ...RetryStrategy,
+) (DiskAttachment, error) {
+	return v.client.UpdateDiskAttachment(v.id, diskAttachmentID, params, retries...)
+}
+
 func (v *vm) AttachISO(isoImageID string, retries ...RetryStrategy) (CDROM, error) {
 	return v.client.AttachCDROM(v.id, isoImageID, retries...)
 }
@@ -2663,6 +3129,18 @@ func (v *vm) EjectISO(cdromID CDROMID, retries ...RetryStrategy) (CDROM, error)
 	return v.client.EjectCDROM(v.id, cdromID, retries...)
 }
 
+func (v *vm) CreateSnapshot(
+	description string,
+	params CreateSnapshotOptionalParameters,
+	retries ...RetryStrategy,
+) (Snapshot, error) {
+	return v.client.CreateVMSnapshot(v.id, description, params, retries...)
+}
+
+func (v *vm) ListSnapshots(retries ...RetryStrategy) ([]Snapshot, error) {
+	return v.client.ListVMSnapshots(v.id, retries...)
+}
+
 func (v *vm) Remove(retries ...RetryStrategy) error {
 	return v.client.RemoveVM(v.id, retries...)
 }
@@ -2763,6 +3241,7 @@ func convertSDKVM(sdkObject *ovirtsdk.Vm, client Client) (VM, error) {
 		vmOSConverter,
 		vmSoundcardEnabledConverter,
 		vmSerialConsoleConverter,
+		vmPoolConverter,
 	}
 	for _, converter := range vmConverters {
 		if err := converter(sdkObject, vmObject); err != nil {
@@ -2860,6 +3339,14 @@ func vmInstanceTypeIDConverter(object *ovirtsdk.Vm, v *vm) error {
 	return nil
 }
 
+func vmPoolConverter(object *ovirtsdk.Vm, v *vm) error {
+	if pool, ok := object.VmPool(); ok {
+		vmPoolID := VMPoolID(pool.MustId())
+		v.vmPoolID = &vmPoolID
+	}
+	return nil
+}
+
 func vmMemoryPolicyConverter(object *ovirtsdk.Vm, v *vm) error {
 	memPolicy, ok := object.MemoryPolicy()
 	if !ok {
@@ -3074,6 +3561,366 @@ func convertSDKVMCPU(sdkObject *ovirtsdk.Vm) (*vmCPU, error) {
 	return cpu, nil
 }
 
+// MigrateVMParameters are the optional parameters for migrating a VM. If no host is specified, the engine selects
+// a suitable host in the cluster of the VM.
+type MigrateVMParameters interface {
+	// HostID returns the ID of the host the VM should be migrated to. If nil, any suitable host in the cluster
+	// is used.
+	HostID() *HostID
+	// ClusterID returns the ID of the cluster the VM should be migrated to. If nil, the VM stays in its current
+	// cluster.
+	ClusterID() *ClusterID
+	// Force indicates that the VM should be migrated even if it is pinned to a host.
+	Force() *bool
+}
+
+// BuildableMigrateVMParameters is a buildable version of MigrateVMParameters.
+type BuildableMigrateVMParameters interface {
+	MigrateVMParameters
+
+	// WithHostID sets the host the VM should be migrated to.
+	WithHostID(hostID HostID) (BuildableMigrateVMParameters, error)
+	// MustWithHostID is identical to WithHostID, but panics instead of returning an error.
+	MustWithHostID(hostID HostID) BuildableMigrateVMParameters
+
+	// WithClusterID sets the cluster the VM should be migrated to.
+	WithClusterID(clusterID ClusterID) (BuildableMigrateVMParameters, error)
+	// MustWithClusterID is identical to WithClusterID, but panics instead of returning an error.
+	MustWithClusterID(clusterID ClusterID) BuildableMigrateVMParameters
+
+	// WithForce sets whether the VM should be migrated even if it is pinned to a host.
+	WithForce(force bool) (BuildableMigrateVMParameters, error)
+	// MustWithForce is identical to WithForce, but panics instead of returning an error.
+	MustWithForce(force bool) BuildableMigrateVMParameters
+}
+
+// MigrateVMParams returns a buildable set of migration parameters.
+func MigrateVMParams() BuildableMigrateVMParameters {
+	return &migrateVMParams{}
+}
+
+type migrateVMParams struct {
+	hostID    *HostID
+	clusterID *ClusterID
+	force     *bool
+}
+
+func (m *migrateVMParams) HostID() *HostID {
+	return m.hostID
+}
+
+func (m *migrateVMParams) ClusterID() *ClusterID {
+	return m.clusterID
+}
+
+func (m *migrateVMParams) Force() *bool {
+	return m.force
+}
+
+func (m *migrateVMParams) WithHostID(hostID HostID) (BuildableMigrateVMParameters, error) {
+	if hostID == "" {
+		return nil, newError(EBadArgument, "host ID cannot be empty")
+	}
+	m.hostID = &hostID
+	return m, nil
+}
+
+func (m *migrateVMParams) MustWithHostID(hostID HostID) BuildableMigrateVMParameters {
+	builder, err := m.WithHostID(hostID)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (m *migrateVMParams) WithClusterID(clusterID ClusterID) (BuildableMigrateVMParameters, error) {
+	if clusterID == "" {
+		return nil, newError(EBadArgument, "cluster ID cannot be empty")
+	}
+	m.clusterID = &clusterID
+	return m, nil
+}
+
+func (m *migrateVMParams) MustWithClusterID(clusterID ClusterID) BuildableMigrateVMParameters {
+	builder, err := m.WithClusterID(clusterID)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (m *migrateVMParams) WithForce(force bool) (BuildableMigrateVMParameters, error) {
+	m.force = &force
+	return m, nil
+}
+
+func (m *migrateVMParams) MustWithForce(force bool) BuildableMigrateVMParameters {
+	builder, err := m.WithForce(force)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+// OptionalCloneVMParameters are the optional parameters for cloning a VM.
+type OptionalCloneVMParameters interface {
+	// StorageDomainID returns the storage domain the disks of the cloned VM should be placed on, unless a different
+	// storage domain is set for a disk in Disks.
+	StorageDomainID() *StorageDomainID
+	// Disks returns the per-disk parameters for the disks of the cloned VM. The disk IDs refer to the disks of the
+	// source VM.
+	Disks() []OptionalVMDiskParameters
+	// DiscardSnapshots indicates that the snapshots of the source VM should not be copied to the clone.
+	DiscardSnapshots() *bool
+}
+
+// BuildableCloneVMParameters is a buildable version of OptionalCloneVMParameters.
+type BuildableCloneVMParameters interface {
+	OptionalCloneVMParameters
+
+	// WithStorageDomainID sets the storage domain for the disks of the cloned VM.
+	WithStorageDomainID(storageDomainID StorageDomainID) (BuildableCloneVMParameters, error)
+	// MustWithStorageDomainID is identical to WithStorageDomainID, but panics instead of returning an error.
+	MustWithStorageDomainID(storageDomainID StorageDomainID) BuildableCloneVMParameters
+
+	// WithDisks sets the per-disk parameters for the disks of the cloned VM.
+	WithDisks(disks []OptionalVMDiskParameters) (BuildableCloneVMParameters, error)
+	// MustWithDisks is identical to WithDisks, but panics instead of returning an error.
+	MustWithDisks(disks []OptionalVMDiskParameters) BuildableCloneVMParameters
+
+	// WithDiscardSnapshots sets whether the snapshots of the source VM should be left out of the clone.
+	WithDiscardSnapshots(discardSnapshots bool) (BuildableCloneVMParameters, error)
+	// MustWithDiscardSnapshots is identical to WithDiscardSnapshots, but panics instead of returning an error.
+	MustWithDiscardSnapshots(discardSnapshots bool) BuildableCloneVMParameters
+}
+
+// CloneVMParams returns a buildable set of parameters for cloning a VM.
+func CloneVMParams() BuildableCloneVMParameters {
+	return &cloneVMParams{}
+}
+
+type cloneVMParams struct {
+	storageDomainID  *StorageDomainID
+	disks            []OptionalVMDiskParameters
+	discardSnapshots *bool
+}
+
+func (c *cloneVMParams) StorageDomainID() *StorageDomainID {
+	return c.storageDomainID
+}
+
+func (c *cloneVMParams) Disks() []OptionalVMDiskParameters {
+	return c.disks
+}
+
+func (c *cloneVMParams) DiscardSnapshots() *bool {
+	return c.discardSnapshots
+}
+
+func (c *cloneVMParams) WithStorageDomainID(storageDomainID StorageDomainID) (BuildableCloneVMParameters, error) {
+	if storageDomainID == "" {
+		return nil, newError(EBadArgument, "storage domain ID cannot be empty")
+	}
+	c.storageDomainID = &storageDomainID
+	return c, nil
+}
+
+func (c *cloneVMParams) MustWithStorageDomainID(storageDomainID StorageDomainID) BuildableCloneVMParameters {
+	builder, err := c.WithStorageDomainID(storageDomainID)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (c *cloneVMParams) WithDisks(disks []OptionalVMDiskParameters) (BuildableCloneVMParameters, error) {
+	diskIDs := map[DiskID]int{}
+	for i, d := range disks {
+		if previousID, ok := diskIDs[d.DiskID()]; ok {
+			return nil, newError(
+				EBadArgument,
+				"Disk %s appears twice, in position %d and %d.",
+				d.DiskID(),
+				previousID,
+				i,
+			)
+		}
+		diskIDs[d.DiskID()] = i
+	}
+	c.disks = disks
+	return c, nil
+}
+
+func (c *cloneVMParams) MustWithDisks(disks []OptionalVMDiskParameters) BuildableCloneVMParameters {
+	builder, err := c.WithDisks(disks)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (c *cloneVMParams) WithDiscardSnapshots(discardSnapshots bool) (BuildableCloneVMParameters, error) {
+	c.discardSnapshots = &discardSnapshots
+	return c, nil
+}
+
+func (c *cloneVMParams) MustWithDiscardSnapshots(discardSnapshots bool) BuildableCloneVMParameters {
+	builder, err := c.WithDiscardSnapshots(discardSnapshots)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+// OptionalVMStartParameters are the optional parameters for a "run once" start of a VM. The options only apply to
+// the current run of the VM and are reverted when the VM is shut down.
+type OptionalVMStartParameters interface {
+	// OS returns the operating system parameters, such as the boot sequence or a custom kernel, for this run.
+	OS() VMOSParameters
+	// Initialization returns the initialization (cloud-init) payload to use for this run.
+	Initialization() Initialization
+	// CDROMFileID returns the ID of the ISO file that should be inserted into the CD-ROM drive for this run.
+	CDROMFileID() *string
+	// Pause indicates that the VM should be started in the paused state.
+	Pause() *bool
+	// Volatile indicates that changes to the VM disks during this run should be discarded when the VM is shut down.
+	Volatile() *bool
+}
+
+// BuildableVMStartParameters is a buildable version of OptionalVMStartParameters.
+type BuildableVMStartParameters interface {
+	OptionalVMStartParameters
+
+	// WithOS sets the operating system parameters for this run.
+	WithOS(os VMOSParameters) (BuildableVMStartParameters, error)
+	// MustWithOS is identical to WithOS, but panics instead of returning an error.
+	MustWithOS(os VMOSParameters) BuildableVMStartParameters
+
+	// WithInitialization sets the initialization payload for this run.
+	WithInitialization(initialization Initialization) (BuildableVMStartParameters, error)
+	// MustWithInitialization is identical to WithInitialization, but panics instead of returning an error.
+	MustWithInitialization(initialization Initialization) BuildableVMStartParameters
+
+	// WithCDROMFileID sets the ISO file to insert into the CD-ROM drive for this run.
+	WithCDROMFileID(fileID string) (BuildableVMStartParameters, error)
+	// MustWithCDROMFileID is identical to WithCDROMFileID, but panics instead of returning an error.
+	MustWithCDROMFileID(fileID string) BuildableVMStartParameters
+
+	// WithPause sets whether the VM should be started in the paused state.
+	WithPause(pause bool) (BuildableVMStartParameters, error)
+	// MustWithPause is identical to WithPause, but panics instead of returning an error.
+	MustWithPause(pause bool) BuildableVMStartParameters
+
+	// WithVolatile sets whether changes to the VM disks during this run should be discarded.
+	WithVolatile(volatile bool) (BuildableVMStartParameters, error)
+	// MustWithVolatile is identical to WithVolatile, but panics instead of returning an error.
+	MustWithVolatile(volatile bool) BuildableVMStartParameters
+}
+
+// StartVMParams returns a buildable set of parameters for a "run once" start of a VM.
+func StartVMParams() BuildableVMStartParameters {
+	return &vmStartParams{}
+}
+
+type vmStartParams struct {
+	os             VMOSParameters
+	initialization Initialization
+	cdromFileID    *string
+	pause          *bool
+	volatile       *bool
+}
+
+func (v *vmStartParams) OS() VMOSParameters {
+	return v.os
+}
+
+func (v *vmStartParams) Initialization() Initialization {
+	return v.initialization
+}
+
+func (v *vmStartParams) CDROMFileID() *string {
+	return v.cdromFileID
+}
+
+func (v *vmStartParams) Pause() *bool {
+	return v.pause
+}
+
+func (v *vmStartParams) Volatile() *bool {
+	return v.volatile
+}
+
+func (v *vmStartParams) WithOS(os VMOSParameters) (BuildableVMStartParameters, error) {
+	v.os = os
+	return v, nil
+}
+
+func (v *vmStartParams) MustWithOS(os VMOSParameters) BuildableVMStartParameters {
+	builder, err := v.WithOS(os)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (v *vmStartParams) WithInitialization(initialization Initialization) (BuildableVMStartParameters, error) {
+	if err := validateInitialization(initialization); err != nil {
+		return nil, err
+	}
+	v.initialization = initialization
+	return v, nil
+}
+
+func (v *vmStartParams) MustWithInitialization(initialization Initialization) BuildableVMStartParameters {
+	builder, err := v.WithInitialization(initialization)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (v *vmStartParams) WithCDROMFileID(fileID string) (BuildableVMStartParameters, error) {
+	if fileID == "" {
+		return nil, newError(EBadArgument, "CD-ROM file ID cannot be empty")
+	}
+	v.cdromFileID = &fileID
+	return v, nil
+}
+
+func (v *vmStartParams) MustWithCDROMFileID(fileID string) BuildableVMStartParameters {
+	builder, err := v.WithCDROMFileID(fileID)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (v *vmStartParams) WithPause(pause bool) (BuildableVMStartParameters, error) {
+	v.pause = &pause
+	return v, nil
+}
+
+func (v *vmStartParams) MustWithPause(pause bool) BuildableVMStartParameters {
+	builder, err := v.WithPause(pause)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (v *vmStartParams) WithVolatile(volatile bool) (BuildableVMStartParameters, error) {
+	v.volatile = &volatile
+	return v, nil
+}
+
+func (v *vmStartParams) MustWithVolatile(volatile bool) BuildableVMStartParameters {
+	builder, err := v.WithVolatile(volatile)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
 // VMStatus represents the status of a VM.
 type VMStatus string
 
@@ -0,0 +1,33 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) CancelVMMigration(id VMID, retries ...RetryStrategy) (err error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	err = retry(
+		fmt.Sprintf("canceling migration of VM %s", id),
+		o.logger,
+		retries,
+		func() error {
+			_, err := o.conn.SystemService().VmsService().VmService(string(id)).CancelMigration().Send()
+			return err
+		})
+	return
+}
+
+func (m *mockClient) CancelVMMigration(id VMID, _ ...RetryStrategy) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	item, ok := m.vms[id]
+	if !ok {
+		return newError(ENotFound, "vm with ID %s not found", id)
+	}
+	if item.status != VMStatusMigrating {
+		return newError(EConflict, "VM %s is not migrating, its status is \"%s\"", id, item.status)
+	}
+	item.status = VMStatusUp
+	return nil
+}
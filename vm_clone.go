@@ -0,0 +1,268 @@
+package ovirtclient
+
+import (
+	"fmt"
+	"net"
+	"sync"
+
+	ovirtsdk "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) CloneVM(
+	sourceVMID VMID,
+	name string,
+	params OptionalCloneVMParameters,
+	retries ...RetryStrategy,
+) (result VM, err error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(o))
+	if err := validateVMCloneParameters(sourceVMID, name); err != nil {
+		return nil, err
+	}
+	if params == nil {
+		params = CloneVMParams()
+	}
+	sdkVM, err := createSDKVMForClone(name, params)
+	if err != nil {
+		return nil, err
+	}
+
+	correlationID := fmt.Sprintf("vm_clone_%s", generateRandomID(5, o.nonSecureRandom))
+	err = retry(
+		fmt.Sprintf("cloning VM %s to %s", sourceVMID, name),
+		o.logger,
+		retries,
+		func() error {
+			request := o.conn.
+				SystemService().
+				VmsService().
+				VmService(string(sourceVMID)).
+				Clone().
+				Vm(sdkVM).
+				Query("correlation_id", correlationID)
+			if storageDomainID := params.StorageDomainID(); storageDomainID != nil {
+				request.StorageDomain(ovirtsdk.NewStorageDomainBuilder().Id(string(*storageDomainID)).MustBuild())
+			}
+			if discardSnapshots := params.DiscardSnapshots(); discardSnapshots != nil {
+				request.DiscardSnapshots(*discardSnapshots)
+			}
+			_, err := request.Send()
+			return err
+		})
+	if err != nil {
+		return nil, err
+	}
+	if err := o.waitForJobFinished(correlationID, retries); err != nil {
+		return nil, wrap(err, EUnidentified, "failed to wait for VM %s to be cloned", sourceVMID)
+	}
+
+	newVM, err := o.GetVMByName(name, retries...)
+	if err != nil {
+		return nil, wrap(err, EUnidentified, "failed to fetch cloned VM %s", name)
+	}
+	attachments, err := o.ListDiskAttachments(newVM.ID(), retries...)
+	if err != nil {
+		return nil, wrap(err, EUnidentified, "failed to list disks of cloned VM %s", newVM.ID())
+	}
+	for _, attachment := range attachments {
+		if _, err := o.WaitForDiskOK(attachment.DiskID(), retries...); err != nil {
+			return nil, wrap(err, EUnidentified, "failed to wait for disk %s of cloned VM %s", attachment.DiskID(), name)
+		}
+	}
+	return o.GetVM(newVM.ID(), retries...)
+}
+
+func createSDKVMForClone(name string, params OptionalCloneVMParameters) (*ovirtsdk.Vm, error) {
+	builder := ovirtsdk.NewVmBuilder().Name(name)
+	var diskAttachments []*ovirtsdk.DiskAttachment
+	for i, d := range params.Disks() {
+		diskBuilder := ovirtsdk.NewDiskBuilder()
+		diskBuilder.Id(string(d.DiskID()))
+		if sparse := d.Sparse(); sparse != nil {
+			diskBuilder.Sparse(*sparse)
+		}
+		if format := d.Format(); format != nil {
+			diskBuilder.Format(ovirtsdk.DiskFormat(*format))
+		}
+		if storageDomainID := d.StorageDomainID(); storageDomainID != nil {
+			diskBuilder.StorageDomainsBuilderOfAny(*ovirtsdk.NewStorageDomainBuilder().Id(string(*storageDomainID)))
+		}
+		diskAttachment, err := ovirtsdk.NewDiskAttachmentBuilder().DiskBuilder(diskBuilder).Build()
+		if err != nil {
+			return nil, wrap(err, EBadArgument, "Failed to convert disk %d.", i)
+		}
+		diskAttachments = append(diskAttachments, diskAttachment)
+	}
+	if len(diskAttachments) > 0 {
+		builder.DiskAttachmentsOfAny(diskAttachments...)
+	}
+	vm, err := builder.Build()
+	if err != nil {
+		return nil, wrap(err, EBug, "failed to build VM")
+	}
+	return vm, nil
+}
+
+func validateVMCloneParameters(sourceVMID VMID, name string) error {
+	if sourceVMID == "" {
+		return newError(EBadArgument, "source VM ID cannot be empty for VM cloning")
+	}
+	if name == "" {
+		return newError(EBadArgument, "name cannot be empty for VM cloning")
+	}
+	return nil
+}
+
+func (m *mockClient) CloneVM(
+	sourceVMID VMID,
+	name string,
+	params OptionalCloneVMParameters,
+	retries ...RetryStrategy,
+) (VM, error) {
+	if err := validateVMCloneParameters(sourceVMID, name); err != nil {
+		return nil, err
+	}
+	if params == nil {
+		params = CloneVMParams()
+	}
+
+	newVM, diskIDs, err := m.cloneVM(sourceVMID, name, params)
+	if err != nil {
+		return nil, err
+	}
+	for _, diskID := range diskIDs {
+		if _, err := m.WaitForDiskOK(diskID, retries...); err != nil {
+			return nil, wrap(err, EUnidentified, "failed to wait for disk %s of cloned VM %s", diskID, name)
+		}
+	}
+	return m.GetVM(newVM.id, retries...)
+}
+
+// cloneVM creates a deep copy of the source VM including its disks, disk attachments and NICs. The disks of the new
+// VM are returned in a locked state.
+func (m *mockClient) cloneVM(sourceVMID VMID, name string, params OptionalCloneVMParameters) (*vm, []DiskID, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	source, ok := m.vms[sourceVMID]
+	if !ok {
+		return nil, nil, newError(ENotFound, "vm with ID %s not found", sourceVMID)
+	}
+	if source.status != VMStatusDown && source.status != VMStatusUp {
+		return nil, nil, newError(EConflict, "cannot clone VM %s in status \"%s\"", sourceVMID, source.status)
+	}
+	for _, existingVM := range m.vms {
+		if existingVM.name == name {
+			return nil, nil, newError(EConflict, "A VM with the name \"%s\" already exists.", name)
+		}
+	}
+	if sd := params.StorageDomainID(); sd != nil {
+		if _, ok := m.storageDomains[*sd]; !ok {
+			return nil, nil, newError(ENotFound, "storage domain with ID %s not found", *sd)
+		}
+	}
+	diskParams := map[DiskID]OptionalVMDiskParameters{}
+	for _, d := range params.Disks() {
+		found := false
+		for _, attachment := range m.vmDiskAttachmentsByVM[sourceVMID] {
+			if attachment.diskID == d.DiskID() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, nil, newError(EBadArgument, "disk %s is not attached to VM %s", d.DiskID(), sourceVMID)
+		}
+		if sd := d.StorageDomainID(); sd != nil {
+			if _, ok := m.storageDomains[*sd]; !ok {
+				return nil, nil, newError(ENotFound, "storage domain with ID %s not found", *sd)
+			}
+		}
+		diskParams[d.DiskID()] = d
+	}
+	for _, attachment := range m.vmDiskAttachmentsByVM[sourceVMID] {
+		if disk := m.disks[attachment.diskID]; disk.status != DiskStatusOK {
+			return nil, nil, newError(EDiskLocked, "disk %s is %s", disk.id, disk.status)
+		}
+	}
+
+	newVM := *source
+	newVM.id = VMID(m.GenerateUUID())
+	newVM.name = name
+	newVM.status = VMStatusDown
+	newVM.hostID = nil
+	newVM.tagIDs = nil
+	newVM.templateID = DefaultBlankTemplateID
+	newVM.vmPoolID = nil
+	m.vms[newVM.id] = &newVM
+	m.vmIPs[newVM.id] = map[string][]net.IP{}
+	m.addGraphicsConsoles(&newVM)
+
+	diskIDs := make([]DiskID, 0, len(m.vmDiskAttachmentsByVM[sourceVMID]))
+	m.vmDiskAttachmentsByVM[newVM.id] = make(
+		map[DiskAttachmentID]*diskAttachment,
+		len(m.vmDiskAttachmentsByVM[sourceVMID]),
+	)
+	for _, attachment := range m.vmDiskAttachmentsByVM[sourceVMID] {
+		newDisk := m.cloneVMDisk(m.disks[attachment.diskID], params.StorageDomainID(), diskParams[attachment.diskID])
+		m.disks[newDisk.id] = newDisk
+		diskIDs = append(diskIDs, newDisk.id)
+
+		newAttachment := *attachment
+		newAttachment.id = DiskAttachmentID(m.GenerateUUID())
+		newAttachment.vmid = newVM.id
+		newAttachment.diskID = newDisk.id
+		m.vmDiskAttachmentsByVM[newVM.id][newAttachment.id] = &newAttachment
+		m.addVMDiskAttachmentByDisk(&newAttachment)
+	}
+
+	for _, sourceNIC := range m.nics {
+		if sourceNIC.vmid != sourceVMID {
+			continue
+		}
+		newNIC := &nic{
+			client:        m,
+			id:            NICID(m.GenerateUUID()),
+			name:          sourceNIC.name,
+			vmid:          newVM.id,
+			vnicProfileID: sourceNIC.vnicProfileID,
+		}
+		m.nics[newNIC.id] = newNIC
+	}
+
+	return &newVM, diskIDs, nil
+}
+
+// cloneVMDisk creates a locked deep copy of a disk, applying the storage domain and disk parameters if set.
+func (m *mockClient) cloneVMDisk(
+	source *diskWithData,
+	storageDomainID *StorageDomainID,
+	params OptionalVMDiskParameters,
+) *diskWithData {
+	newDisk := &diskWithData{
+		disk: source.disk,
+		lock: &sync.Mutex{},
+		data: append([]byte(nil), source.data...),
+	}
+	newDisk.id = DiskID(m.GenerateUUID())
+	newDisk.status = DiskStatusLocked
+	if storageDomainID != nil {
+		newDisk.storageDomainIDs = []StorageDomainID{*storageDomainID}
+	}
+	if params != nil {
+		if sparse := params.Sparse(); sparse != nil {
+			newDisk.sparse = *sparse
+		}
+		if format := params.Format(); format != nil && *format != newDisk.format {
+			m.logger.Warningf(
+				"the VM clone client requested a conversion from %s to %s; the mock library does not support this and the source image data will be used unmodified which may lead to errors",
+				newDisk.format,
+				*format,
+			)
+			newDisk.format = *format
+		}
+		if sd := params.StorageDomainID(); sd != nil {
+			newDisk.storageDomainIDs = []StorageDomainID{*sd}
+		}
+	}
+	return newDisk
+}
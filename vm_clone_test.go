@@ -0,0 +1,138 @@
+package ovirtclient_test
+
+import (
+	"testing"
+
+	ovirtclient "github.com/dyudin0821/go-ovirt-client/v3"
+)
+
+func TestVMClone(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+
+	vm := assertCanCreateVM(
+		t,
+		helper,
+		helper.GenerateTestResourceName(t),
+		ovirtclient.NewCreateVMParams().MustWithComment("source"),
+	)
+	disk := assertCanCreateDisk(t, helper)
+	assertCanAttachDisk(t, vm, disk)
+
+	clonedVM := assertCanCloneVM(t, helper, vm, nil)
+	if clonedVM.ID() == vm.ID() {
+		t.Fatalf("The cloned VM has the same ID as the source VM.")
+	}
+	if clonedVM.Comment() != "source" {
+		t.Fatalf("Incorrect comment on cloned VM: %s instead of %s.", clonedVM.Comment(), "source")
+	}
+	if clonedVM.Status() != ovirtclient.VMStatusDown {
+		t.Fatalf("Cloned VM is in status %s instead of %s.", clonedVM.Status(), ovirtclient.VMStatusDown)
+	}
+
+	attachments := assertCanListDiskAttachments(t, clonedVM)
+	if len(attachments) != 1 {
+		t.Fatalf("Incorrect number of disk attachments on cloned VM: %d instead of 1.", len(attachments))
+	}
+	clonedDisk := assertCanGetDiskFromAttachment(t, attachments[0])
+	if clonedDisk.ID() == disk.ID() {
+		t.Fatalf("The cloned VM uses the disk of the source VM.")
+	}
+	if clonedDisk.Status() != ovirtclient.DiskStatusOK {
+		t.Fatalf("The cloned disk is in status %s instead of %s.", clonedDisk.Status(), ovirtclient.DiskStatusOK)
+	}
+}
+
+func TestVMCloneKeepsDiskAttachmentSettings(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+
+	vm := assertCanCreateVM(t, helper, helper.GenerateTestResourceName(t), nil)
+	disk := assertCanCreateDisk(t, helper)
+	attachment := assertCanAttachDisk(t, vm, disk)
+	if _, err := attachment.Update(
+		ovirtclient.UpdateDiskAttachmentParams().
+			MustWithDiskInterface(ovirtclient.DiskInterfaceVirtIOSCSI).
+			MustWithReadOnly(true).
+			MustWithPassDiscard(true),
+	); err != nil {
+		t.Fatalf("Failed to update disk attachment %s on VM %s. (%v)", attachment.ID(), vm.ID(), err)
+	}
+
+	clonedVM := assertCanCloneVM(t, helper, vm, nil)
+	attachments := assertCanListDiskAttachments(t, clonedVM)
+	if len(attachments) != 1 {
+		t.Fatalf("Incorrect number of disk attachments on cloned VM: %d instead of 1.", len(attachments))
+	}
+	if attachments[0].DiskInterface() != ovirtclient.DiskInterfaceVirtIOSCSI {
+		t.Fatalf(
+			"Incorrect disk interface on cloned disk attachment: %s instead of %s.",
+			attachments[0].DiskInterface(),
+			ovirtclient.DiskInterfaceVirtIOSCSI,
+		)
+	}
+	if !attachments[0].ReadOnly() || !attachments[0].PassDiscard() {
+		t.Fatalf("The read-only and pass discard settings were not copied to the cloned disk attachment.")
+	}
+}
+
+func TestVMCloneWithDiskStorageDomain(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+
+	targetStorageDomainID := helper.GetSecondaryStorageDomainID(t)
+	vm := assertCanCreateVM(t, helper, helper.GenerateTestResourceName(t), nil)
+	disk := assertCanCreateDisk(t, helper)
+	assertCanAttachDisk(t, vm, disk)
+
+	clonedVM := assertCanCloneVM(
+		t,
+		helper,
+		vm,
+		ovirtclient.CloneVMParams().MustWithDisks(
+			[]ovirtclient.OptionalVMDiskParameters{
+				ovirtclient.MustNewBuildableVMDiskParameters(disk.ID()).
+					MustWithStorageDomainID(targetStorageDomainID),
+			},
+		),
+	)
+	attachments := assertCanListDiskAttachments(t, clonedVM)
+	if len(attachments) != 1 {
+		t.Fatalf("Incorrect number of disk attachments on cloned VM: %d instead of 1.", len(attachments))
+	}
+	clonedDisk := assertCanGetDiskFromAttachment(t, attachments[0])
+	storageDomainIDs := clonedDisk.StorageDomainIDs()
+	if len(storageDomainIDs) != 1 || storageDomainIDs[0] != targetStorageDomainID {
+		t.Fatalf("The cloned disk is on storage domains %v instead of %s.", storageDomainIDs, targetStorageDomainID)
+	}
+}
+
+func TestVMCloneDuplicateName(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+
+	vm := assertCanCreateVM(t, helper, helper.GenerateTestResourceName(t), nil)
+	if _, err := vm.Clone(vm.Name(), nil); err == nil {
+		t.Fatalf("Cloning a VM with the name of an existing VM did not result in an error.")
+	}
+}
+
+func assertCanCloneVM(
+	t *testing.T,
+	helper ovirtclient.TestHelper,
+	vm ovirtclient.VM,
+	params ovirtclient.OptionalCloneVMParameters,
+) ovirtclient.VM {
+	clonedVM, err := vm.Clone(helper.GenerateTestResourceName(t), params)
+	if clonedVM != nil {
+		t.Cleanup(func() {
+			if err := clonedVM.Remove(); err != nil && !ovirtclient.HasErrorCode(err, ovirtclient.ENotFound) {
+				t.Fatalf("Failed to remove cloned VM %s. (%v)", clonedVM.ID(), err)
+			}
+		})
+	}
+	if err != nil {
+		t.Fatalf("Failed to clone VM %s. (%v)", vm.ID(), err)
+	}
+	return clonedVM
+}
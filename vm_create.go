@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -69,7 +70,10 @@ func vmBuilderInitialization(params OptionalVMParameters, builder *ovirtsdk.VmBu
 		return
 	}
 
-	init := params.Initialization()
+	builder.InitializationBuilder(newSDKInitializationBuilder(params.Initialization()))
+}
+
+func newSDKInitializationBuilder(init Initialization) *ovirtsdk.InitializationBuilder {
 	initBuilder := ovirtsdk.NewInitializationBuilder()
 
 	if init.CustomScript() != "" {
@@ -78,33 +82,77 @@ func vmBuilderInitialization(params OptionalVMParameters, builder *ovirtsdk.VmBu
 	if init.HostName() != "" {
 		initBuilder.HostName(init.HostName())
 	}
-	if nicConf := init.NicConfiguration(); nicConf != nil {
+	if nicConfs := init.NicConfigurations(); len(nicConfs) > 0 {
+		sdkNicConfs := make([]*ovirtsdk.NicConfiguration, len(nicConfs))
+		for i, nicConf := range nicConfs {
+			sdkNicConfs[i] = newSDKNicConfiguration(nicConf)
+		}
+		initBuilder.NicConfigurationsOfAny(sdkNicConfs...)
+	}
+	if keys := init.AuthorizedSSHKeys(); len(keys) > 0 {
+		initBuilder.AuthorizedSshKeys(strings.Join(keys, "\n"))
+	}
+	if init.UserName() != "" {
+		initBuilder.UserName(init.UserName())
+	}
+	if init.RootPassword() != "" {
+		initBuilder.RootPassword(init.RootPassword())
+	}
+	if servers := init.DNSServers(); len(servers) > 0 {
+		initBuilder.DnsServers(strings.Join(servers, " "))
+	}
+	if domains := init.DNSSearch(); len(domains) > 0 {
+		initBuilder.DnsSearch(strings.Join(domains, " "))
+	}
+	if init.Timezone() != "" {
+		initBuilder.Timezone(init.Timezone())
+	}
+	if init.RegenerateSSHKeys() {
+		initBuilder.RegenerateSshKeys(true)
+	}
+	if init.Domain() != "" {
+		initBuilder.Domain(init.Domain())
+	}
+	if init.OrgName() != "" {
+		initBuilder.OrgName(init.OrgName())
+	}
+	if init.WindowsLicenseKey() != "" {
+		initBuilder.WindowsLicenseKey(init.WindowsLicenseKey())
+	}
+	return initBuilder
+}
 
-		nicBuilder := ovirtsdk.NewNicConfigurationBuilder()
-		nicBuilder.BootProtocol(ovirtsdk.BOOTPROTOCOL_STATIC)
-		nicBuilder.OnBoot(true)
-		nicBuilder.Name(nicConf.Name())
+func newSDKNicConfiguration(nicConf NicConfiguration) *ovirtsdk.NicConfiguration {
+	nicBuilder := ovirtsdk.NewNicConfigurationBuilder()
+	bootProtocol := nicConf.BootProtocol()
+	if bootProtocol == "" {
+		bootProtocol = BootProtocolStatic
+	}
+	nicBuilder.BootProtocol(ovirtsdk.BootProtocol(bootProtocol))
+	nicBuilder.OnBoot(true)
+	nicBuilder.Name(nicConf.Name())
 
+	if bootProtocol == BootProtocolStatic || nicConf.IP().Address != "" {
 		ipBuilder := ovirtsdk.NewIpBuilder().
 			Address(nicConf.IP().Address).
 			Gateway(nicConf.IP().Gateway).
 			Netmask(nicConf.IP().Netmask).
 			Version(ovirtsdk.IPVERSION_V4)
 		nicBuilder.Ip(ipBuilder.MustBuild())
+	}
 
-		if nicConf.IPV6() != nil {
-			ipV6Builder := ovirtsdk.NewIpBuilder().
-				Address(nicConf.IPV6().Address).
-				Gateway(nicConf.IPV6().Gateway).
-				Netmask(nicConf.IPV6().Netmask).
-				Version(ovirtsdk.IPVERSION_V6)
-			nicBuilder.Ipv6(ipV6Builder.MustBuild())
-
-		}
-
-		initBuilder.NicConfigurationsOfAny(nicBuilder.MustBuild())
+	if nicConf.IPV6() != nil {
+		ipV6Builder := ovirtsdk.NewIpBuilder().
+			Address(nicConf.IPV6().Address).
+			Gateway(nicConf.IPV6().Gateway).
+			Netmask(nicConf.IPV6().Netmask).
+			Version(ovirtsdk.IPVERSION_V6)
+		nicBuilder.Ipv6(ipV6Builder.MustBuild())
 	}
-	builder.InitializationBuilder(initBuilder)
+	if ipv6BootProtocol := nicConf.IPV6BootProtocol(); ipv6BootProtocol != "" {
+		nicBuilder.Ipv6BootProtocol(ovirtsdk.BootProtocol(ipv6BootProtocol))
+	}
+	return nicBuilder.MustBuild()
 }
 
 func vmPlacementPolicyParameterConverter(params OptionalVMParameters, builder *ovirtsdk.VmBuilder) {
@@ -505,6 +553,7 @@ func (m *mockClient) createVM(
 		m.createVMOS(params),
 		console,
 		soundcardEnabled,
+		nil,
 	}
 	m.vms[VMID(id)] = vm
 	return vm
@@ -613,7 +662,7 @@ func (m *mockClient) attachVMDisksFromTemplate(tpl *template, vm *vm, params Opt
 			active:        attachment.active,
 		}
 		m.vmDiskAttachmentsByVM[vm.id][diskAttachment.id] = diskAttachment
-		m.vmDiskAttachmentsByDisk[disk.id] = diskAttachment
+		m.addVMDiskAttachmentByDisk(diskAttachment)
 	}
 }
 
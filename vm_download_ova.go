@@ -0,0 +1,59 @@
+package ovirtclient
+
+import (
+	"io"
+)
+
+func (o *oVirtClient) DownloadVMToOVA(vmID VMID, writer io.Writer, retries ...RetryStrategy) error {
+	retries = defaultRetries(retries, defaultLongTimeouts(o))
+	return downloadVMToOVA(o, vmID, writer, retries...)
+}
+
+func (m *mockClient) DownloadVMToOVA(vmID VMID, writer io.Writer, retries ...RetryStrategy) error {
+	retries = defaultRetries(retries, defaultLongTimeouts(m))
+	return downloadVMToOVA(m, vmID, writer, retries...)
+}
+
+func downloadVMToOVA(client Client, vmID VMID, writer io.Writer, retries ...RetryStrategy) error {
+	system, err := newOVASystemFromVM(client, vmID, retries...)
+	if err != nil {
+		return err
+	}
+	return writeOVA(client, system, writer, retries...)
+}
+
+func newOVASystemFromVM(client Client, vmID VMID, retries ...RetryStrategy) (ovaSystem, error) {
+	vm, err := client.GetVM(vmID, retries...)
+	if err != nil {
+		return ovaSystem{}, wrap(err, EUnidentified, "failed to fetch VM %s for OVA export", vmID)
+	}
+	attachments, err := client.ListDiskAttachments(vmID, retries...)
+	if err != nil {
+		return ovaSystem{}, wrap(err, EUnidentified, "failed to list disks of VM %s for OVA export", vmID)
+	}
+	system := ovaSystem{
+		name:        vm.Name(),
+		comment:     vm.Comment(),
+		description: vm.Description(),
+		memory:      vm.Memory(),
+		cpu:         vm.CPU(),
+	}
+	if os := vm.OS(); os != nil {
+		system.osType = os.Type()
+	}
+	for _, attachment := range attachments {
+		disk, err := client.GetDisk(attachment.DiskID(), retries...)
+		if err != nil {
+			return ovaSystem{}, wrap(err, EUnidentified, "failed to fetch disk %s for OVA export", attachment.DiskID())
+		}
+		system.disks = append(system.disks, ovaDisk{
+			id:              disk.ID(),
+			alias:           disk.Alias(),
+			provisionedSize: disk.ProvisionedSize(),
+			format:          disk.Format(),
+			diskInterface:   attachment.DiskInterface(),
+			bootable:        attachment.Bootable(),
+		})
+	}
+	return system, nil
+}
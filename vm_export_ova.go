@@ -0,0 +1,85 @@
+package ovirtclient
+
+import (
+	"bytes"
+	"fmt"
+
+	ovirtsdk "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) ExportVMToOVA(
+	vmID VMID,
+	hostID HostID,
+	directory string,
+	filename string,
+	retries ...RetryStrategy,
+) (err error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(o))
+	if err := validateOVAExportParameters(hostID, directory, filename); err != nil {
+		return err
+	}
+	correlationID := fmt.Sprintf("vm_export_ova_%s", generateRandomID(5, o.nonSecureRandom))
+	err = retry(
+		fmt.Sprintf("exporting VM %s to OVA on host %s", vmID, hostID),
+		o.logger,
+		retries,
+		func() error {
+			_, err := o.conn.
+				SystemService().
+				VmsService().
+				VmService(string(vmID)).
+				ExportToPathOnHost().
+				Host(ovirtsdk.NewHostBuilder().Id(string(hostID)).MustBuild()).
+				Directory(directory).
+				Filename(filename).
+				Query("correlation_id", correlationID).
+				Send()
+			return err
+		})
+	if err != nil {
+		return err
+	}
+	if err := o.waitForJobFinished(correlationID, retries); err != nil {
+		return wrap(err, EUnidentified, "failed to wait for VM %s to be exported to OVA", vmID)
+	}
+	return nil
+}
+
+func (m *mockClient) ExportVMToOVA(
+	vmID VMID,
+	hostID HostID,
+	directory string,
+	filename string,
+	retries ...RetryStrategy,
+) error {
+	if err := validateOVAExportParameters(hostID, directory, filename); err != nil {
+		return err
+	}
+	m.lock.Lock()
+	item, ok := m.vms[vmID]
+	if !ok {
+		m.lock.Unlock()
+		return newError(ENotFound, "vm with ID %s not found", vmID)
+	}
+	if item.status != VMStatusDown {
+		m.lock.Unlock()
+		return newError(
+			EConflict,
+			"cannot export VM %s to OVA, VM is \"%s\" not \"%s\"",
+			vmID,
+			item.status,
+			VMStatusDown,
+		)
+	}
+	if err := m.checkOVAHost(hostID); err != nil {
+		m.lock.Unlock()
+		return err
+	}
+	m.lock.Unlock()
+
+	data := &bytes.Buffer{}
+	if err := m.DownloadVMToOVA(vmID, data, retries...); err != nil {
+		return err
+	}
+	return m.storeHostOVA(hostID, directory, filename, data)
+}
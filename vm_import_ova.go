@@ -0,0 +1,82 @@
+package ovirtclient
+
+import (
+	"fmt"
+
+	ovirtsdk "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) ImportVMFromOVA(
+	clusterID ClusterID,
+	storageDomainID StorageDomainID,
+	hostID HostID,
+	ovaPath string,
+	name string,
+	params OptionalOVAImportParameters,
+	retries ...RetryStrategy,
+) (VM, error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(o))
+	if err := validateOVAHostImportParameters(clusterID, storageDomainID, hostID, ovaPath, name); err != nil {
+		return nil, err
+	}
+	if params == nil {
+		params = OVAImportParams()
+	}
+	builder := ovirtsdk.NewExternalVmImportBuilder().
+		Name(name).
+		Provider(ovirtsdk.EXTERNALVMPROVIDERTYPE_KVM).
+		Url(fmt.Sprintf("ova://%s", ovaPath)).
+		HostBuilder(ovirtsdk.NewHostBuilder().Id(string(hostID))).
+		ClusterBuilder(ovirtsdk.NewClusterBuilder().Id(string(clusterID))).
+		StorageDomainBuilder(ovirtsdk.NewStorageDomainBuilder().Id(string(storageDomainID))).
+		VmBuilder(ovirtsdk.NewVmBuilder().Name(name))
+	if sparse := params.Sparse(); sparse != nil {
+		builder.Sparse(*sparse)
+	}
+	sdkImport, err := builder.Build()
+	if err != nil {
+		return nil, wrap(err, EBug, "failed to build OVA import")
+	}
+
+	correlationID := fmt.Sprintf("vm_import_ova_%s", generateRandomID(5, o.nonSecureRandom))
+	err = retry(
+		fmt.Sprintf("importing VM %s from OVA %s on host %s", name, ovaPath, hostID),
+		o.logger,
+		retries,
+		func() error {
+			_, err := o.conn.
+				SystemService().
+				ExternalVmImportsService().
+				Add().
+				Import(sdkImport).
+				Query("correlation_id", correlationID).
+				Send()
+			return err
+		})
+	if err != nil {
+		return nil, err
+	}
+	if err := o.waitForJobFinished(correlationID, retries); err != nil {
+		return nil, wrap(err, EUnidentified, "failed to wait for VM %s to be imported from OVA", name)
+	}
+	return o.GetVMByName(name, retries...)
+}
+
+func (m *mockClient) ImportVMFromOVA(
+	clusterID ClusterID,
+	storageDomainID StorageDomainID,
+	hostID HostID,
+	ovaPath string,
+	name string,
+	params OptionalOVAImportParameters,
+	retries ...RetryStrategy,
+) (VM, error) {
+	if err := validateOVAHostImportParameters(clusterID, storageDomainID, hostID, ovaPath, name); err != nil {
+		return nil, err
+	}
+	reader, err := m.loadHostOVA(hostID, ovaPath)
+	if err != nil {
+		return nil, err
+	}
+	return m.UploadVMFromOVA(clusterID, storageDomainID, name, reader, params, retries...)
+}
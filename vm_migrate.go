@@ -0,0 +1,124 @@
+package ovirtclient
+
+import (
+	"fmt"
+	"time"
+
+	ovirtsdk "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) MigrateVM(id VMID, params MigrateVMParameters, retries ...RetryStrategy) (err error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	if params == nil {
+		params = MigrateVMParams()
+	}
+	err = retry(
+		fmt.Sprintf("migrating VM %s", id),
+		o.logger,
+		retries,
+		func() error {
+			request := o.conn.SystemService().VmsService().VmService(string(id)).Migrate()
+			if hostID := params.HostID(); hostID != nil {
+				request.Host(ovirtsdk.NewHostBuilder().Id(string(*hostID)).MustBuild())
+			}
+			if clusterID := params.ClusterID(); clusterID != nil {
+				request.Cluster(ovirtsdk.NewClusterBuilder().Id(string(*clusterID)).MustBuild())
+			}
+			if force := params.Force(); force != nil {
+				request.Force(*force)
+			}
+			_, err := request.Send()
+			return err
+		})
+	return
+}
+
+func (m *mockClient) MigrateVM(id VMID, params MigrateVMParameters, _ ...RetryStrategy) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	item, ok := m.vms[id]
+	if !ok {
+		return newError(ENotFound, "vm with ID %s not found", id)
+	}
+	if item.status != VMStatusUp || item.hostID == nil {
+		return newError(
+			EConflict,
+			"cannot migrate VM %s, VM is \"%s\" not \"%s\"",
+			id,
+			item.status,
+			VMStatusUp,
+		)
+	}
+	if params == nil {
+		params = MigrateVMParams()
+	}
+
+	force := params.Force() != nil && *params.Force()
+	if pp := item.placementPolicy; pp != nil && pp.affinity != nil && *pp.affinity == VMAffinityPinned && !force {
+		return newError(EConflict, "cannot migrate VM %s, VM is pinned to its host", id)
+	}
+
+	clusterID := item.clusterID
+	if c := params.ClusterID(); c != nil {
+		if _, ok := m.clusters[*c]; !ok {
+			return newError(ENotFound, "cluster with ID %s not found", *c)
+		}
+		clusterID = *c
+	}
+
+	targetHostID, err := m.findMigrationTargetHost(item, clusterID, params.HostID())
+	if err != nil {
+		return err
+	}
+
+	item.status = VMStatusMigrating
+	go func() {
+		time.Sleep(2 * time.Second)
+		m.lock.Lock()
+		defer m.lock.Unlock()
+		if item.status != VMStatusMigrating {
+			return
+		}
+		item.hostID = &targetHostID
+		item.clusterID = clusterID
+		item.status = VMStatusUp
+	}()
+	return nil
+}
+
+// findMigrationTargetHost returns the host a VM should be migrated to. If hostID is nil, a suitable host in the
+// cluster is selected. The caller must hold the mock client lock.
+func (m *mockClient) findMigrationTargetHost(item *vm, clusterID ClusterID, hostID *HostID) (HostID, error) {
+	if hostID != nil {
+		targetHost, ok := m.hosts[*hostID]
+		if !ok {
+			return "", newError(ENotFound, "host with ID %s not found", *hostID)
+		}
+		if targetHost.id == *item.hostID {
+			return "", newError(EConflict, "VM %s is already running on host %s", item.id, targetHost.id)
+		}
+		if targetHost.clusterID != clusterID {
+			return "", newError(EBadArgument, "host %s is not in cluster %s", targetHost.id, clusterID)
+		}
+		if targetHost.status != HostStatusUp {
+			return "", newError(
+				EConflict,
+				"cannot migrate VM %s to host %s, host is \"%s\" not \"%s\"",
+				item.id,
+				targetHost.id,
+				targetHost.status,
+				HostStatusUp,
+			)
+		}
+		return targetHost.id, nil
+	}
+
+	excludedHostIDs := []HostID{*item.hostID}
+	for _, h := range m.hosts {
+		if h.clusterID != clusterID || h.status != HostStatusUp {
+			excludedHostIDs = append(excludedHostIDs, h.id)
+		}
+	}
+	return m.findSuitableHost(item.id, excludedHostIDs...)
+}
@@ -0,0 +1,104 @@
+package ovirtclient_test
+
+import (
+	"testing"
+
+	ovirtclient "github.com/dyudin0821/go-ovirt-client/v3"
+)
+
+func TestVMMigrationToAnyHost(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	assertHasMultipleHosts(t, helper)
+
+	vm := assertCanCreateBootableVM(t, helper)
+	assertCanStartVM(t, helper, vm)
+	vm = assertVMWillStart(t, vm)
+	sourceHostID := *vm.HostID()
+
+	if err := vm.Migrate(nil); err != nil {
+		t.Fatalf("Failed to migrate VM %s. (%v)", vm.ID(), err)
+	}
+	vm = assertVMWillMigrate(t, vm)
+	if *vm.HostID() == sourceHostID {
+		t.Fatalf("VM %s is still on host %s after migration.", vm.ID(), sourceHostID)
+	}
+}
+
+func TestVMMigrationToSpecificHost(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	hosts := assertHasMultipleHosts(t, helper)
+
+	vm := assertCanCreateBootableVM(t, helper)
+	assertCanStartVM(t, helper, vm)
+	vm = assertVMWillStart(t, vm)
+
+	var targetHostID ovirtclient.HostID
+	for _, host := range hosts {
+		if host.ID() != *vm.HostID() && host.ClusterID() == vm.ClusterID() {
+			targetHostID = host.ID()
+			break
+		}
+	}
+	if targetHostID == "" {
+		t.Skipf("No other host in cluster %s, skipping migration test.", vm.ClusterID())
+	}
+
+	if err := vm.Migrate(ovirtclient.MigrateVMParams().MustWithHostID(targetHostID)); err != nil {
+		t.Fatalf("Failed to migrate VM %s to host %s. (%v)", vm.ID(), targetHostID, err)
+	}
+	vm = assertVMWillMigrate(t, vm)
+	if *vm.HostID() != targetHostID {
+		t.Fatalf("VM %s is on host %s instead of %s after migration.", vm.ID(), *vm.HostID(), targetHostID)
+	}
+}
+
+func TestVMMigrationCancel(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	assertHasMultipleHosts(t, helper)
+
+	vm := assertCanCreateBootableVM(t, helper)
+	assertCanStartVM(t, helper, vm)
+	vm = assertVMWillStart(t, vm)
+
+	if err := vm.Migrate(nil); err != nil {
+		t.Fatalf("Failed to migrate VM %s. (%v)", vm.ID(), err)
+	}
+	if err := vm.CancelMigration(); err != nil && !ovirtclient.HasErrorCode(err, ovirtclient.EConflict) {
+		t.Fatalf("Failed to cancel migration of VM %s. (%v)", vm.ID(), err)
+	}
+	if _, err := vm.WaitForStatus(ovirtclient.VMStatusUp); err != nil {
+		t.Fatalf("VM %s did not return to the %s status after canceling the migration. (%v)", vm.ID(), ovirtclient.VMStatusUp, err)
+	}
+}
+
+func TestStoppedVMCannotBeMigrated(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+
+	vm := assertCanCreateVM(t, helper, helper.GenerateTestResourceName(t), nil)
+	if err := vm.Migrate(nil); err == nil {
+		t.Fatalf("Migrating a stopped VM did not result in an error.")
+	}
+}
+
+func assertHasMultipleHosts(t *testing.T, helper ovirtclient.TestHelper) []ovirtclient.Host {
+	hosts, err := helper.GetClient().ListHosts()
+	if err != nil {
+		t.Fatalf("Failed to list hosts. (%v)", err)
+	}
+	if len(hosts) < 2 {
+		t.Skipf("At least two hosts are required for migration tests, found %d.", len(hosts))
+	}
+	return hosts
+}
+
+func assertVMWillMigrate(t *testing.T, vm ovirtclient.VM) ovirtclient.VM {
+	migratedVM, err := vm.WaitForMigration()
+	if err != nil {
+		t.Fatalf("Failed to wait for VM %s migration to finish. (%v)", vm.ID(), err)
+	}
+	return migratedVM
+}
@@ -0,0 +1,61 @@
+package ovirtclient
+
+import (
+	"fmt"
+	"time"
+)
+
+func (o *oVirtClient) RebootVM(id VMID, force bool, retries ...RetryStrategy) (err error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	err = retry(
+		fmt.Sprintf("rebooting VM %s", id),
+		o.logger,
+		retries,
+		func() error {
+			_, err := o.conn.SystemService().VmsService().VmService(string(id)).Reboot().Force(force).Send()
+			return err
+		})
+	return
+}
+
+func (m *mockClient) RebootVM(id VMID, force bool, _ ...RetryStrategy) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	item, ok := m.vms[id]
+	if !ok {
+		return newError(ENotFound, "vm with ID %s not found", id)
+	}
+	if (item.status == VMStatusSavingState || item.status == VMStatusRestoringState) && !force {
+		return newError(EConflict, "VM is currently backing up or restoring.")
+	}
+	return m.restartVM(item)
+}
+
+// restartVM simulates a reboot of a running VM by passing through VMStatusRebooting. The caller must hold the mock
+// client lock.
+func (m *mockClient) restartVM(item *vm) error {
+	if item.status == VMStatusRebooting {
+		return nil
+	}
+	if item.status != VMStatusUp {
+		return newError(
+			EConflict,
+			"cannot reboot VM %s, VM is \"%s\" not \"%s\"",
+			item.id,
+			item.status,
+			VMStatusUp,
+		)
+	}
+	item.status = VMStatusRebooting
+	go func() {
+		time.Sleep(2 * time.Second)
+		m.lock.Lock()
+		if item.status != VMStatusRebooting {
+			m.lock.Unlock()
+			return
+		}
+		item.status = VMStatusUp
+		m.lock.Unlock()
+	}()
+	return nil
+}
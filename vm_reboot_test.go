@@ -0,0 +1,33 @@
+package ovirtclient_test
+
+import (
+	"testing"
+)
+
+func TestVMReboot(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+
+	vm := assertCanCreateBootableVM(t, helper)
+	assertCanStartVM(t, helper, vm)
+	vm = assertVMWillStart(t, vm)
+
+	if err := vm.Reboot(false); err != nil {
+		t.Fatalf("Failed to reboot VM %s. (%v)", vm.ID(), err)
+	}
+	assertVMWillStart(t, vm)
+}
+
+func TestVMReset(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+
+	vm := assertCanCreateBootableVM(t, helper)
+	assertCanStartVM(t, helper, vm)
+	vm = assertVMWillStart(t, vm)
+
+	if err := vm.Reset(); err != nil {
+		t.Fatalf("Failed to reset VM %s. (%v)", vm.ID(), err)
+	}
+	assertVMWillStart(t, vm)
+}
@@ -32,27 +32,60 @@ func (m *mockClient) RemoveVM(id VMID, retries ...RetryStrategy) error {
 			m.lock.Lock()
 			defer m.lock.Unlock()
 
-			if _, ok := m.vms[id]; !ok {
+			item, ok := m.vms[id]
+			if !ok {
 				return newError(ENotFound, "VM with ID %s not found", id)
 			}
-
-			for _, diskAttachment := range m.vmDiskAttachmentsByVM[id] {
-				if m.disks[diskAttachment.DiskID()].status == DiskStatusLocked {
-					return newError(EConflict, "Cannot delete VM, disk %s is locked.", diskAttachment.DiskID())
-				}
-				delete(m.disks, diskAttachment.DiskID())
-				delete(m.vmDiskAttachmentsByDisk, diskAttachment.DiskID())
+			if item.vmPoolID != nil {
+				return newError(EBadArgument, "Cannot delete VM, it is part of VM pool %s.", *item.vmPoolID)
 			}
-			for nicID, nic := range m.nics {
-				if nic.VMID() == id {
-					delete(m.nics, nicID)
-				}
+			if err := m.checkVMDisksUnlocked(id); err != nil {
+				return err
 			}
-			delete(m.vmIPs, id)
-			delete(m.vmDiskAttachmentsByVM, id)
-			delete(m.graphicsConsolesByVM, id)
-			delete(m.vms, id)
+			m.removeVM(id)
 
 			return nil
 		})
 }
+
+// checkVMDisksUnlocked returns an error if any disk attached to the VM is locked. The caller must hold the mock client
+// lock.
+func (m *mockClient) checkVMDisksUnlocked(id VMID) error {
+	for _, diskAttachment := range m.vmDiskAttachmentsByVM[id] {
+		if m.disks[diskAttachment.DiskID()].status == DiskStatusLocked {
+			return newError(EConflict, "Cannot delete VM, disk %s is locked.", diskAttachment.DiskID())
+		}
+	}
+	return nil
+}
+
+// removeVM removes the VM and all of its disks and devices. The caller must hold the mock client lock.
+func (m *mockClient) removeVM(id VMID) {
+	for _, diskAttachment := range m.vmDiskAttachmentsByVM[id] {
+		m.removeVMDiskAttachmentByDisk(diskAttachment)
+		// Shareable disks are only detached as other VMs may still use them.
+		if disk, ok := m.disks[diskAttachment.DiskID()]; ok && disk.shareable {
+			continue
+		}
+		delete(m.disks, diskAttachment.DiskID())
+		delete(m.diskImageChains, diskAttachment.DiskID())
+		delete(m.diskUploadExtents, diskAttachment.DiskID())
+	}
+	for nicID, nic := range m.nics {
+		if nic.VMID() == id {
+			delete(m.nics, nicID)
+		}
+	}
+	delete(m.vmIPs, id)
+	delete(m.vmDiskAttachmentsByVM, id)
+	delete(m.graphicsConsolesByVM, id)
+	delete(m.snapshotsByVM, id)
+	delete(m.vmRunOnce, id)
+	for backupID, backup := range m.vmBackups {
+		if backup.vmID == id {
+			delete(m.vmBackups, backupID)
+		}
+	}
+	delete(m.vmCheckpoints, id)
+	delete(m.vms, id)
+}
@@ -0,0 +1,28 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) ResetVM(id VMID, retries ...RetryStrategy) (err error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	err = retry(
+		fmt.Sprintf("resetting VM %s", id),
+		o.logger,
+		retries,
+		func() error {
+			_, err := o.conn.SystemService().VmsService().VmService(string(id)).Reset().Send()
+			return err
+		})
+	return
+}
+
+func (m *mockClient) ResetVM(id VMID, _ ...RetryStrategy) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	item, ok := m.vms[id]
+	if !ok {
+		return newError(ENotFound, "vm with ID %s not found", id)
+	}
+	return m.restartVM(item)
+}
@@ -32,6 +32,7 @@ func (m *mockClient) ShutdownVM(id VMID, force bool, _ ...RetryStrategy) error {
 				m.lock.Lock()
 				defer m.lock.Unlock()
 				item.status = VMStatusDown
+				m.revertVMRunOnce(item)
 			}()
 		}
 		return nil
@@ -27,11 +27,23 @@ func (m *mockClient) StartVM(id VMID, _ ...RetryStrategy) error {
 		return newError(ENotFound, "vm with ID %s not found", id)
 	}
 
-	if item.Status() == VMStatusUp {
+	switch item.Status() {
+	case VMStatusUp:
 		return nil
+	case VMStatusPaused:
+		item.status = VMStatusUp
+		return nil
+	case VMStatusSuspended:
+		return m.resumeSuspendedVM(item)
 	}
 
-	hostID, err := m.findSuitableHost(id)
+	return m.bootVM(item, false)
+}
+
+// bootVM powers up a VM that is down, passing through the launch and power up statuses. If pause is true, the VM
+// ends up in VMStatusPaused instead of VMStatusUp. The caller must hold the mock client lock.
+func (m *mockClient) bootVM(item *vm, pause bool) error {
+	hostID, err := m.findSuitableHost(item.id)
 	if err != nil {
 		return err
 	}
@@ -52,34 +64,68 @@ func (m *mockClient) StartVM(id VMID, _ ...RetryStrategy) error {
 			m.lock.Unlock()
 			return
 		}
+		if pause {
+			item.status = VMStatusPaused
+			m.lock.Unlock()
+			return
+		}
 		item.status = VMStatusUp
 		m.lock.Unlock()
-		time.Sleep(10 * time.Second)
+		m.addVMIPsAfterBoot(item)
+	}()
+	return nil
+}
+
+// resumeSuspendedVM restores a suspended VM on a suitable host. The caller must hold the mock client lock.
+func (m *mockClient) resumeSuspendedVM(item *vm) error {
+	hostID, err := m.findSuitableHost(item.id)
+	if err != nil {
+		return err
+	}
+	item.hostID = &hostID
+	item.status = VMStatusRestoringState
+	go func() {
+		time.Sleep(2 * time.Second)
 		m.lock.Lock()
-		if item.status == VMStatusUp {
-			m.vmIPs[item.id] = map[string][]net.IP{
-				"lo": {
-					net.ParseIP("::1"),
-					net.ParseIP("127.0.0.1"),
-				},
-			}
-			i := 0
-			for _, nic := range m.nics {
-				if nic.vmid == item.id {
-					m.vmIPs[item.id][fmt.Sprintf("eth%d", i)] = []net.IP{
-						net.ParseIP("192.168.0.123"),
-						net.ParseIP("fe80::123"),
-					}
-					i++
-				}
-			}
+		if item.status != VMStatusRestoringState {
+			m.lock.Unlock()
+			return
 		}
+		item.status = VMStatusUp
 		m.lock.Unlock()
+		m.addVMIPsAfterBoot(item)
 	}()
 	return nil
 }
 
-func (m *mockClient) findSuitableHost(vmID VMID) (HostID, error) {
+// addVMIPsAfterBoot waits for the simulated guest agent to report the IP addresses of the VM. It must be called
+// without holding the mock client lock.
+func (m *mockClient) addVMIPsAfterBoot(item *vm) {
+	time.Sleep(10 * time.Second)
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if item.status != VMStatusUp {
+		return
+	}
+	m.vmIPs[item.id] = map[string][]net.IP{
+		"lo": {
+			net.ParseIP("::1"),
+			net.ParseIP("127.0.0.1"),
+		},
+	}
+	i := 0
+	for _, nic := range m.nics {
+		if nic.vmid == item.id {
+			m.vmIPs[item.id][fmt.Sprintf("eth%d", i)] = []net.IP{
+				net.ParseIP("192.168.0.123"),
+				net.ParseIP("fe80::123"),
+			}
+			i++
+		}
+	}
+}
+
+func (m *mockClient) findSuitableHost(vmID VMID, excludedHostIDs ...HostID) (HostID, error) {
 	var affectedAffinityGroups []*affinityGroup
 	for _, clusterAffinityGroups := range m.affinityGroups {
 		for _, affinityGroup := range clusterAffinityGroups {
@@ -91,6 +137,9 @@ func (m *mockClient) findSuitableHost(vmID VMID) (HostID, error) {
 	// Try to find a host that is suitable.
 	var foundHost *host
 	for _, host := range m.hosts {
+		if hostIDInList(host.id, excludedHostIDs) {
+			continue
+		}
 		hostSuitable := true
 	loop:
 		for _, vm := range m.vms {
@@ -116,3 +165,12 @@ func (m *mockClient) findSuitableHost(vmID VMID) (HostID, error) {
 	hostID := foundHost.ID()
 	return hostID, nil
 }
+
+func hostIDInList(hostID HostID, hostIDs []HostID) bool {
+	for _, id := range hostIDs {
+		if id == hostID {
+			return true
+		}
+	}
+	return false
+}
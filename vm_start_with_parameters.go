@@ -0,0 +1,206 @@
+package ovirtclient
+
+import (
+	"fmt"
+
+	ovirtsdk "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) StartVMWithParameters(
+	id VMID,
+	params OptionalVMStartParameters,
+	retries ...RetryStrategy,
+) (err error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	if params == nil {
+		params = StartVMParams()
+	}
+	sdkVM, err := createSDKVMForStart(params)
+	if err != nil {
+		return err
+	}
+	err = retry(
+		fmt.Sprintf("starting VM %s with parameters", id),
+		o.logger,
+		retries,
+		func() error {
+			request := o.conn.SystemService().VmsService().VmService(string(id)).Start().Vm(sdkVM)
+			if params.Initialization() != nil {
+				request.UseInitialization(true)
+			}
+			if pause := params.Pause(); pause != nil {
+				request.Pause(*pause)
+			}
+			if volatile := params.Volatile(); volatile != nil {
+				request.Volatile(*volatile)
+			}
+			_, err := request.Send()
+			return err
+		})
+	return
+}
+
+func createSDKVMForStart(params OptionalVMStartParameters) (*ovirtsdk.Vm, error) {
+	builder := ovirtsdk.NewVmBuilder()
+	if os := params.OS(); os != nil {
+		osBuilder := ovirtsdk.NewOperatingSystemBuilder()
+		if t := os.Type(); t != nil {
+			osBuilder.Type(*t)
+		}
+		addBootDevicesToOS(os, osBuilder)
+		addKernelParamsToOS(os, osBuilder)
+		builder.OsBuilder(osBuilder)
+	}
+	if init := params.Initialization(); init != nil {
+		builder.InitializationBuilder(newSDKInitializationBuilder(init))
+	}
+	if fileID := params.CDROMFileID(); fileID != nil {
+		builder.CdromsBuilderOfAny(*ovirtsdk.NewCdromBuilder().FileBuilder(ovirtsdk.NewFileBuilder().Id(*fileID)))
+	}
+	vm, err := builder.Build()
+	if err != nil {
+		return nil, wrap(err, EBug, "failed to build VM for start")
+	}
+	return vm, nil
+}
+
+// vmRunOnceState holds the persistent configuration of a VM that was started with temporary options in the mock
+// client, so it can be restored when the VM is shut down.
+type vmRunOnceState struct {
+	os             *vmOS
+	initialization Initialization
+	// cdromID is the CD-ROM the run once ISO was inserted into.
+	cdromID *CDROMID
+	// cdromFileID and cdromFileName describe the file that was in the CD-ROM before the run.
+	cdromFileID   string
+	cdromFileName string
+	// cdromCreated indicates that the CD-ROM was created for this run only.
+	cdromCreated bool
+}
+
+func (m *mockClient) StartVMWithParameters(
+	id VMID,
+	params OptionalVMStartParameters,
+	_ ...RetryStrategy,
+) error {
+	if params == nil {
+		params = StartVMParams()
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	item, ok := m.vms[id]
+	if !ok {
+		return newError(ENotFound, "vm with ID %s not found", id)
+	}
+	if item.status != VMStatusDown {
+		return newError(
+			EConflict,
+			"cannot start VM %s with parameters, VM is \"%s\" not \"%s\"",
+			id,
+			item.status,
+			VMStatusDown,
+		)
+	}
+
+	m.applyVMRunOnce(item, params)
+	pause := params.Pause() != nil && *params.Pause()
+	if err := m.bootVM(item, pause); err != nil {
+		m.revertVMRunOnce(item)
+		return err
+	}
+	return nil
+}
+
+// applyVMRunOnce applies the temporary start options to the VM and records the persistent configuration. The caller
+// must hold the mock client lock.
+func (m *mockClient) applyVMRunOnce(item *vm, params OptionalVMStartParameters) {
+	state := &vmRunOnceState{
+		os:             item.os,
+		initialization: item.initialization,
+	}
+	if os := params.OS(); os != nil {
+		if t := os.Type(); t != nil {
+			m.updateVMOSField(item, func(vmOS *vmOS) { vmOS.t = *t })
+		}
+		if bootDevices := os.BootDevices(); len(bootDevices) > 0 {
+			m.updateVMBootDevices(item, bootDevices)
+		}
+		if cmdline := os.Cmdline(); cmdline != nil {
+			m.updateVMOSField(item, func(vmOS *vmOS) { vmOS.cmdline = cmdline })
+		}
+		if customKernelCmdline := os.CustomKernelCmdline(); customKernelCmdline != nil {
+			m.updateVMOSField(item, func(vmOS *vmOS) { vmOS.customKernelCmdline = customKernelCmdline })
+		}
+		if initrd := os.Initrd(); initrd != nil {
+			m.updateVMOSField(item, func(vmOS *vmOS) { vmOS.initrd = initrd })
+		}
+		if kernel := os.Kernel(); kernel != nil {
+			m.updateVMOSField(item, func(vmOS *vmOS) { vmOS.kernel = kernel })
+		}
+	}
+	if init := params.Initialization(); init != nil {
+		item.initialization = init
+	}
+	if fileID := params.CDROMFileID(); fileID != nil {
+		m.insertRunOnceCDROM(item.id, *fileID, state)
+	}
+	m.vmRunOnce[item.id] = state
+}
+
+// insertRunOnceCDROM inserts the ISO file into the CD-ROM of the VM, or creates a CD-ROM if the VM has none.
+// The caller must hold the mock client lock.
+func (m *mockClient) insertRunOnceCDROM(vmID VMID, fileID string, state *vmRunOnceState) {
+	if m.vmCDROMsByVM == nil {
+		m.vmCDROMsByVM = make(map[VMID]map[CDROMID]*cdrom)
+	}
+	if m.vmCDROMsByVM[vmID] == nil {
+		m.vmCDROMsByVM[vmID] = make(map[CDROMID]*cdrom)
+	}
+	var existingCDROM *cdrom
+	for _, c := range m.vmCDROMsByVM[vmID] {
+		if existingCDROM == nil || c.id < existingCDROM.id {
+			existingCDROM = c
+		}
+	}
+	if existingCDROM != nil {
+		state.cdromID = &existingCDROM.id
+		state.cdromFileID = existingCDROM.fileID
+		state.cdromFileName = existingCDROM.fileName
+		existingCDROM.fileID = fileID
+		existingCDROM.fileName = fmt.Sprintf("iso-%s.iso", fileID)
+		return
+	}
+	cdromID := CDROMID(m.GenerateUUID())
+	m.vmCDROMsByVM[vmID][cdromID] = &cdrom{
+		client:   m,
+		id:       cdromID,
+		vmid:     vmID,
+		fileID:   fileID,
+		fileName: fmt.Sprintf("iso-%s.iso", fileID),
+	}
+	state.cdromID = &cdromID
+	state.cdromCreated = true
+}
+
+// revertVMRunOnce restores the persistent configuration of a VM that was started with temporary options. It does
+// nothing if the VM was started normally. The caller must hold the mock client lock.
+func (m *mockClient) revertVMRunOnce(item *vm) {
+	state, ok := m.vmRunOnce[item.id]
+	if !ok {
+		return
+	}
+	delete(m.vmRunOnce, item.id)
+	item.os = state.os
+	item.initialization = state.initialization
+	if state.cdromID == nil {
+		return
+	}
+	if state.cdromCreated {
+		delete(m.vmCDROMsByVM[item.id], *state.cdromID)
+		return
+	}
+	if existingCDROM, ok := m.vmCDROMsByVM[item.id][*state.cdromID]; ok {
+		existingCDROM.fileID = state.cdromFileID
+		existingCDROM.fileName = state.cdromFileName
+	}
+}
@@ -0,0 +1,68 @@
+package ovirtclient_test
+
+import (
+	"testing"
+
+	ovirtclient "github.com/dyudin0821/go-ovirt-client/v3"
+)
+
+func TestVMStartWithParametersRevertsAtShutdown(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+
+	vm := assertCanCreateBootableVM(t, helper)
+	if _, err := vm.Update(
+		ovirtclient.UpdateVMParams().MustWithBootDevices([]ovirtclient.BootDevice{ovirtclient.BootDeviceHD}),
+	); err != nil {
+		t.Fatalf("Failed to set boot devices on VM %s. (%v)", vm.ID(), err)
+	}
+
+	params := ovirtclient.StartVMParams().MustWithOS(
+		ovirtclient.NewVMOSParameters().
+			MustWithBootDevices([]ovirtclient.BootDevice{ovirtclient.BootDeviceNetwork, ovirtclient.BootDeviceHD}).
+			MustWithCmdline("console=ttyS0"),
+	)
+	if err := vm.StartWithParameters(params); err != nil {
+		t.Fatalf("Failed to start VM %s with parameters. (%v)", vm.ID(), err)
+	}
+	assertVMWillStart(t, vm)
+	assertCanStopVM(t, vm)
+	assertVMWillStop(t, vm)
+
+	vm, err := helper.GetClient().GetVM(vm.ID())
+	if err != nil {
+		t.Fatalf("Failed to fetch VM after shutdown. (%v)", err)
+	}
+	bootDevices := vm.OS().BootDevices()
+	if len(bootDevices) != 1 || bootDevices[0] != ovirtclient.BootDeviceHD {
+		t.Fatalf("Boot devices were not reverted after shutdown: %v", bootDevices)
+	}
+	if cmdline := vm.OS().Cmdline(); cmdline != nil && *cmdline == "console=ttyS0" {
+		t.Fatalf("Kernel command line was not reverted after shutdown.")
+	}
+}
+
+func TestVMStartPaused(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+
+	vm := assertCanCreateBootableVM(t, helper)
+	if err := vm.StartWithParameters(ovirtclient.StartVMParams().MustWithPause(true)); err != nil {
+		t.Fatalf("Failed to start VM %s paused. (%v)", vm.ID(), err)
+	}
+	t.Cleanup(func() {
+		if err := vm.Stop(true); err != nil {
+			t.Fatalf("Failed to stop VM %s after test. (%v)", vm.ID(), err)
+		}
+		if _, err := vm.WaitForStatus(ovirtclient.VMStatusDown); err != nil {
+			t.Fatalf("Failed to wait for VM %s to stop. (%v)", vm.ID(), err)
+		}
+	})
+	if _, err := vm.WaitForStatus(ovirtclient.VMStatusPaused); err != nil {
+		t.Fatalf("VM %s did not reach the %s status. (%v)", vm.ID(), ovirtclient.VMStatusPaused, err)
+	}
+	if err := vm.Start(); err != nil {
+		t.Fatalf("Failed to resume paused VM %s. (%v)", vm.ID(), err)
+	}
+	assertVMWillStart(t, vm)
+}
@@ -38,6 +38,7 @@ func (m *mockClient) StopVM(id VMID, force bool, _ ...RetryStrategy) error {
 				}
 				item.status = VMStatusDown
 				item.hostID = nil
+				m.revertVMRunOnce(item)
 			}()
 		}
 		return nil
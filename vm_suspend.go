@@ -0,0 +1,55 @@
+package ovirtclient
+
+import (
+	"fmt"
+	"net"
+	"time"
+)
+
+func (o *oVirtClient) SuspendVM(id VMID, retries ...RetryStrategy) (err error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	err = retry(
+		fmt.Sprintf("suspending VM %s", id),
+		o.logger,
+		retries,
+		func() error {
+			_, err := o.conn.SystemService().VmsService().VmService(string(id)).Suspend().Send()
+			return err
+		})
+	return
+}
+
+func (m *mockClient) SuspendVM(id VMID, _ ...RetryStrategy) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	item, ok := m.vms[id]
+	if !ok {
+		return newError(ENotFound, "vm with ID %s not found", id)
+	}
+	switch item.status {
+	case VMStatusSuspended, VMStatusSavingState:
+		return nil
+	case VMStatusUp, VMStatusPaused:
+	default:
+		return newError(
+			EConflict,
+			"cannot suspend VM %s, VM is \"%s\" not \"%s\"",
+			id,
+			item.status,
+			VMStatusUp,
+		)
+	}
+	item.status = VMStatusSavingState
+	m.vmIPs[id] = map[string][]net.IP{}
+	go func() {
+		time.Sleep(2 * time.Second)
+		m.lock.Lock()
+		defer m.lock.Unlock()
+		if item.status != VMStatusSavingState {
+			return
+		}
+		item.status = VMStatusSuspended
+		item.hostID = nil
+	}()
+	return nil
+}
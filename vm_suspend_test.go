@@ -0,0 +1,38 @@
+package ovirtclient_test
+
+import (
+	"testing"
+
+	ovirtclient "github.com/dyudin0821/go-ovirt-client/v3"
+)
+
+func TestVMSuspendAndResume(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+
+	vm := assertCanCreateBootableVM(t, helper)
+	assertCanStartVM(t, helper, vm)
+	vm = assertVMWillStart(t, vm)
+
+	if err := vm.Suspend(); err != nil {
+		t.Fatalf("Failed to suspend VM %s. (%v)", vm.ID(), err)
+	}
+	if _, err := vm.WaitForStatus(ovirtclient.VMStatusSuspended); err != nil {
+		t.Fatalf("VM %s did not reach the %s status. (%v)", vm.ID(), ovirtclient.VMStatusSuspended, err)
+	}
+
+	if err := vm.Start(); err != nil {
+		t.Fatalf("Failed to resume VM %s. (%v)", vm.ID(), err)
+	}
+	assertVMWillStart(t, vm)
+}
+
+func TestStoppedVMCannotBeSuspended(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+
+	vm := assertCanCreateVM(t, helper, helper.GenerateTestResourceName(t), nil)
+	if err := vm.Suspend(); err == nil {
+		t.Fatalf("Suspending a stopped VM did not result in an error.")
+	}
+}
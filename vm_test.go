@@ -353,6 +353,104 @@ func TestVMCreationWithInit(t *testing.T) { //nolint:funlen
 
 }
 
+func TestVMCreationWithFullInit(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+
+	init := ovirtclient.NewInitialization("", "test-vm").
+		WithAuthorizedSSHKeys([]string{"ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIA test@example.com"}).
+		WithUserName("cloud-user").
+		WithRootPassword("secret").
+		WithDNSServers([]string{"192.168.0.1", "192.168.0.2"}).
+		WithDNSSearch([]string{"example.com"}).
+		WithTimezone("Etc/UTC").
+		WithRegenerateSSHKeys(true).
+		WithNicConfigurations([]ovirtclient.NicConfiguration{
+			ovirtclient.NewNicConfiguration("eth0", ovirtclient.IP{
+				Version: ovirtclient.IPVersionV4,
+				Address: "192.168.178.15",
+				Gateway: "192.168.178.1",
+				Netmask: "255.255.255.0",
+			}),
+			ovirtclient.NewDHCPNicConfiguration("eth1").
+				WithIPV6BootProtocol(ovirtclient.BootProtocolAutoconf),
+		})
+
+	vm := assertCanCreateVM(
+		t,
+		helper,
+		helper.GenerateTestResourceName(t),
+		ovirtclient.CreateVMParams().MustWithInitialization(init),
+	)
+	vm, err := helper.GetClient().GetVM(vm.ID())
+	if err != nil {
+		t.Fatalf("Failed to re-fetch VM after creation (%v)", err)
+	}
+	vmInit := vm.Initialization()
+
+	if keys := vmInit.AuthorizedSSHKeys(); len(keys) != 1 || keys[0] != init.AuthorizedSSHKeys()[0] {
+		t.Fatalf("Incorrect authorized SSH keys: %v", keys)
+	}
+	if vmInit.UserName() != "cloud-user" {
+		t.Fatalf("Incorrect user name: %s", vmInit.UserName())
+	}
+	if servers := vmInit.DNSServers(); len(servers) != 2 || servers[1] != "192.168.0.2" {
+		t.Fatalf("Incorrect DNS servers: %v", servers)
+	}
+	if domains := vmInit.DNSSearch(); len(domains) != 1 || domains[0] != "example.com" {
+		t.Fatalf("Incorrect DNS search domains: %v", domains)
+	}
+	if vmInit.Timezone() != "Etc/UTC" {
+		t.Fatalf("Incorrect timezone: %s", vmInit.Timezone())
+	}
+	if !vmInit.RegenerateSSHKeys() {
+		t.Fatalf("Regenerate SSH keys flag not set.")
+	}
+	nics := vmInit.NicConfigurations()
+	if len(nics) != 2 {
+		t.Fatalf("Incorrect number of NIC configurations: %d", len(nics))
+	}
+	assertNIC(t, nics[0], init.NicConfigurations()[0])
+	if nics[1].BootProtocol() != ovirtclient.BootProtocolDHCP {
+		t.Fatalf("Incorrect boot protocol on second NIC: %s", nics[1].BootProtocol())
+	}
+	if nics[1].IPV6BootProtocol() != ovirtclient.BootProtocolAutoconf {
+		t.Fatalf("Incorrect IPv6 boot protocol on second NIC: %s", nics[1].IPV6BootProtocol())
+	}
+}
+
+func TestVMUpdateInitialization(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+
+	vm := assertCanCreateVM(t, helper, helper.GenerateTestResourceName(t), nil)
+	init := ovirtclient.NewInitialization("", "").
+		WithDomain("example.local").
+		WithOrgName("Example").
+		WithWindowsLicenseKey("AAAAA-BBBBB-CCCCC-DDDDD-EEEEE")
+	vm, err := vm.Update(ovirtclient.UpdateVMParams().MustWithInitialization(init))
+	if err != nil {
+		t.Fatalf("Failed to update VM initialization (%v)", err)
+	}
+	if vm.Initialization().Domain() != "example.local" {
+		t.Fatalf("Incorrect domain: %s", vm.Initialization().Domain())
+	}
+	if vm.Initialization().OrgName() != "Example" {
+		t.Fatalf("Incorrect organization name: %s", vm.Initialization().OrgName())
+	}
+}
+
+func TestInvalidNicBootProtocol(t *testing.T) {
+	t.Parallel()
+
+	init := ovirtclient.NewInitialization("", "").WithNicConfiguration(
+		ovirtclient.NewDHCPNicConfiguration("eth0").WithBootProtocol("invalid"),
+	)
+	if _, err := ovirtclient.CreateVMParams().WithInitialization(init); err == nil {
+		t.Fatalf("Setting an invalid boot protocol did not result in an error.")
+	}
+}
+
 func TestVMCreationWithDescription(t *testing.T) {
 	t.Parallel()
 	testDescription := "test description"
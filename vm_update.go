@@ -32,6 +32,9 @@ func (o *oVirtClient) UpdateVM(
 	if hasOSUpdates(params) {
 		vm.SetOs(buildOSForUpdate(params))
 	}
+	if init := params.Initialization(); init != nil {
+		vm.SetInitialization(newSDKInitializationBuilder(init).MustBuild())
+	}
 
 	err = retry(
 		fmt.Sprintf("updating vm %s", id),
@@ -104,6 +107,9 @@ func (m *mockClient) UpdateVM(id VMID, params UpdateVMParameters, _ ...RetryStra
 	vm := m.vms[id]
 	vm = m.updateVMBasicFields(vm, params)
 	vm = m.updateVMKernelParams(vm, params)
+	if init := params.Initialization(); init != nil {
+		vm.initialization = init
+	}
 
 	m.vms[id] = vm
 	return vm, nil
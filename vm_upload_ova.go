@@ -0,0 +1,190 @@
+package ovirtclient
+
+import (
+	"io"
+)
+
+func (o *oVirtClient) UploadVMFromOVA(
+	clusterID ClusterID,
+	storageDomainID StorageDomainID,
+	name string,
+	reader io.ReadSeeker,
+	params OptionalOVAImportParameters,
+	retries ...RetryStrategy,
+) (VM, error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(o))
+	return uploadVMFromOVA(o, clusterID, storageDomainID, name, reader, params, retries...)
+}
+
+func (m *mockClient) UploadVMFromOVA(
+	clusterID ClusterID,
+	storageDomainID StorageDomainID,
+	name string,
+	reader io.ReadSeeker,
+	params OptionalOVAImportParameters,
+	retries ...RetryStrategy,
+) (VM, error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(m))
+	return uploadVMFromOVA(m, clusterID, storageDomainID, name, reader, params, retries...)
+}
+
+func uploadVMFromOVA(
+	client Client,
+	clusterID ClusterID,
+	storageDomainID StorageDomainID,
+	name string,
+	reader io.ReadSeeker,
+	params OptionalOVAImportParameters,
+	retries ...RetryStrategy,
+) (result VM, err error) {
+	if params == nil {
+		params = OVAImportParams()
+	}
+	if err := validateOVAImportParameters(clusterID, storageDomainID, name); err != nil {
+		return nil, err
+	}
+	envelope, entries, err := readOVA(reader)
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string]ovaEntry, len(envelope.Files))
+	for _, f := range envelope.Files {
+		entry, ok := entries[f.Href]
+		if !ok {
+			return nil, newError(EBadArgument, "file %s referenced in the OVF descriptor is missing from the archive", f.Href)
+		}
+		files[f.ID] = entry
+	}
+	for _, d := range envelope.Disks {
+		if _, ok := files[d.FileRef]; !ok {
+			return nil, newError(EBadArgument, "disk %s references unknown file %s", d.DiskID, d.FileRef)
+		}
+		if _, err := imageFormatFromOVFFormat(d.Format); err != nil {
+			return nil, err
+		}
+		if err := DiskInterface(d.DiskInterface).Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	vmParams, err := newVMParamsFromOVF(envelope.VirtualSystem)
+	if err != nil {
+		return nil, err
+	}
+	tpl, err := client.GetBlankTemplate(retries...)
+	if err != nil {
+		return nil, wrap(err, EUnidentified, "failed to find blank template for OVA import")
+	}
+	vm, err := client.CreateVM(clusterID, tpl.ID(), name, vmParams, retries...)
+	if err != nil {
+		return nil, wrap(err, EUnidentified, "failed to create VM %s from OVA", name)
+	}
+	defer func() {
+		if err != nil {
+			_ = client.RemoveVM(vm.ID(), retries...)
+		}
+	}()
+
+	for _, d := range envelope.Disks {
+		if err := uploadOVADisk(client, vm.ID(), storageDomainID, d, reader, files[d.FileRef], params, retries...); err != nil {
+			return nil, err
+		}
+	}
+	return client.GetVM(vm.ID(), retries...)
+}
+
+func newVMParamsFromOVF(system ovfVirtualSystem) (BuildableVMParameters, error) {
+	vmParams := NewCreateVMParams()
+	if _, err := vmParams.WithComment(system.Comment); err != nil {
+		return nil, err
+	}
+	if _, err := vmParams.WithDescription(system.Description); err != nil {
+		return nil, err
+	}
+	if system.OperatingSystem != "" {
+		vmParams.WithOS(NewVMOSParameters().MustWithType(system.OperatingSystem))
+	}
+	for _, item := range system.Items {
+		switch item.ResourceType {
+		case ovfResourceTypeCPU:
+			if item.Sockets == 0 || item.CoresPerSocket == 0 || item.ThreadsPerCore == 0 {
+				continue
+			}
+			if _, err := vmParams.WithCPU(
+				NewVMCPUParams().MustWithTopo(
+					NewVMCPUTopoParams().
+						MustWithSockets(item.Sockets).
+						MustWithCores(item.CoresPerSocket).
+						MustWithThreads(item.ThreadsPerCore),
+				),
+			); err != nil {
+				return nil, wrap(err, EBadArgument, "invalid CPU topology in OVF descriptor")
+			}
+		case ovfResourceTypeMemory:
+			if _, err := vmParams.WithMemory(int64(item.VirtualQuantity) * 1024 * 1024); err != nil { //nolint:gosec
+				return nil, wrap(err, EBadArgument, "invalid memory size in OVF descriptor")
+			}
+		}
+	}
+	return vmParams, nil
+}
+
+func uploadOVADisk(
+	client Client,
+	vmID VMID,
+	storageDomainID StorageDomainID,
+	d ovfDisk,
+	reader io.ReadSeeker,
+	entry ovaEntry,
+	params OptionalOVAImportParameters,
+	retries ...RetryStrategy,
+) error {
+	format, err := imageFormatFromOVFFormat(d.Format)
+	if err != nil {
+		return err
+	}
+	diskParams := CreateDiskParams()
+	if d.Alias != "" {
+		if _, err := diskParams.WithAlias(d.Alias); err != nil {
+			return err
+		}
+	}
+	if sparse := params.Sparse(); sparse != nil {
+		if _, err := diskParams.WithSparse(*sparse); err != nil {
+			return err
+		}
+	}
+	var diskID DiskID
+	if entry.size == 0 {
+		// The archive contains no image data, create a blank disk instead.
+		disk, err := client.CreateDisk(storageDomainID, format, d.Capacity, diskParams, retries...)
+		if err != nil {
+			return wrap(err, EUnidentified, "failed to create blank disk %s from OVA", d.DiskID)
+		}
+		diskID = disk.ID()
+	} else {
+		upload, err := client.UploadToNewDisk(
+			storageDomainID,
+			format,
+			uint64(entry.size),
+			diskParams,
+			newOVAEntryReader(reader, entry),
+			retries...,
+		)
+		if err != nil {
+			return wrap(err, EUnidentified, "failed to upload disk %s from OVA", d.DiskID)
+		}
+		diskID = upload.Disk().ID()
+	}
+	if _, err := client.CreateDiskAttachment(
+		vmID,
+		diskID,
+		DiskInterface(d.DiskInterface),
+		CreateDiskAttachmentParams().MustWithBootable(d.Boot),
+		retries...,
+	); err != nil {
+		_ = client.RemoveDisk(diskID, retries...)
+		return wrap(err, EUnidentified, "failed to attach disk %s to VM %s", diskID, vmID)
+	}
+	return nil
+}
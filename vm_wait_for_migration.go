@@ -0,0 +1,71 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) WaitForVMMigration(id VMID, retries ...RetryStrategy) (vm VM, err error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(o))
+	migration := &vmMigrationWatch{}
+	err = retry(
+		fmt.Sprintf("waiting for VM %s migration to finish", id),
+		o.logger,
+		retries,
+		func() error {
+			vm, err = o.GetVM(id, retries...)
+			if err != nil {
+				return err
+			}
+			return migration.check(vm)
+		})
+	return
+}
+
+func (m *mockClient) WaitForVMMigration(id VMID, retries ...RetryStrategy) (vm VM, err error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(m))
+	migration := &vmMigrationWatch{}
+	err = retry(
+		fmt.Sprintf("waiting for VM %s migration to finish", id),
+		m.logger,
+		retries,
+		func() error {
+			vm, err = m.GetVM(id, retries...)
+			if err != nil {
+				return err
+			}
+			return migration.check(vm)
+		})
+	return
+}
+
+// vmMigrationWatch tracks a VM while waiting for its migration to finish. Right after a migration is started the
+// engine may still report the VM as up on the source host, so the migration is only considered finished once the VM
+// is up on a different host, or is up again after it has been seen migrating.
+type vmMigrationWatch struct {
+	started      bool
+	sourceHostID HostID
+	migrating    bool
+}
+
+func (w *vmMigrationWatch) check(vm VM) error {
+	var hostID HostID
+	if vm.HostID() != nil {
+		hostID = *vm.HostID()
+	}
+	if !w.started {
+		w.started = true
+		w.sourceHostID = hostID
+	}
+	switch vm.Status() {
+	case VMStatusMigrating:
+		w.migrating = true
+		return newError(EPending, "VM %s is still migrating", vm.ID())
+	case VMStatusUp:
+		if !w.migrating && hostID == w.sourceHostID {
+			return newError(EPending, "VM %s is still up on host %s, the migration has not started yet", vm.ID(), hostID)
+		}
+		return nil
+	default:
+		return newError(EPending, "VM status is %s, not %s", vm.Status(), VMStatusUp)
+	}
+}
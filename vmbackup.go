@@ -0,0 +1,465 @@
+package ovirtclient
+
+import (
+	"io"
+	"strings"
+	"time"
+
+	ovirtsdk4 "github.com/ovirt/go-ovirt"
+)
+
+// VMBackupID is the identifier for VM backups.
+type VMBackupID string
+
+// VMCheckpointID is the identifier for VM checkpoints. Checkpoints are created by the oVirt Engine when a backup is
+// started and can be used as the starting point of a subsequent incremental backup.
+type VMCheckpointID string
+
+// VMBackupClient contains the methods required for taking full and incremental backups of VMs.
+type VMBackupClient interface {
+	// StartVMBackup starts a backup of the specified disks of a VM and waits for the backup to become ready for
+	// downloading. If fromCheckpointID is empty a full backup is taken, otherwise the backup only marks the blocks
+	// changed since the specified checkpoint as dirty. The backup MUST be finished using FinalizeVMBackup, otherwise
+	// the disks stay locked.
+	StartVMBackup(
+		vmID VMID,
+		diskIDs []DiskID,
+		fromCheckpointID VMCheckpointID,
+		retries ...RetryStrategy,
+	) (VMBackup, error)
+	// GetVMBackup returns a single backup of a VM.
+	GetVMBackup(vmID VMID, id VMBackupID, retries ...RetryStrategy) (VMBackup, error)
+	// DownloadVMBackupDisk opens an image transfer for a disk that is part of a ready backup. The returned download
+	// exposes the dirty extents of the disk, which can then be read individually. The caller MUST close the returned
+	// download before finalizing the backup.
+	DownloadVMBackupDisk(
+		vmID VMID,
+		id VMBackupID,
+		diskID DiskID,
+		retries ...RetryStrategy,
+	) (VMBackupDiskDownload, error)
+	// FinalizeVMBackup finishes a backup and waits for the oVirt Engine to release the disks. After a successful
+	// finalization the checkpoint of the backup can be used for the next incremental backup.
+	FinalizeVMBackup(vmID VMID, id VMBackupID, retries ...RetryStrategy) error
+	// ListVMCheckpoints lists all checkpoints of a VM, starting with the oldest one.
+	ListVMCheckpoints(vmID VMID, retries ...RetryStrategy) ([]VMCheckpoint, error)
+	// RemoveVMCheckpoint removes a checkpoint of a VM. The oVirt Engine only allows removing the oldest checkpoint
+	// (the root of the checkpoint chain).
+	RemoveVMCheckpoint(vmID VMID, id VMCheckpointID, retries ...RetryStrategy) error
+}
+
+// VMBackupData is the core of VMBackup, providing only data access functions.
+type VMBackupData interface {
+	// ID returns the identifier of the backup.
+	ID() VMBackupID
+	// VMID returns the ID of the virtual machine this backup belongs to.
+	VMID() VMID
+	// Phase returns the current phase of the backup.
+	Phase() VMBackupPhase
+	// DiskIDs returns the list of disks included in the backup.
+	DiskIDs() []DiskID
+	// FromCheckpointID returns the checkpoint this backup was taken from. This is empty for full backups.
+	FromCheckpointID() VMCheckpointID
+	// ToCheckpointID returns the checkpoint created by this backup. This checkpoint can be passed to StartVMBackup
+	// to take an incremental backup containing the changes since this backup.
+	ToCheckpointID() VMCheckpointID
+	// CreationDate returns the time the backup was started.
+	CreationDate() time.Time
+}
+
+// VMBackup is a full or incremental backup of the disks of a VM.
+type VMBackup interface {
+	VMBackupData
+
+	// VM fetches the virtual machine this backup belongs to.
+	VM(retries ...RetryStrategy) (VM, error)
+	// DownloadDisk opens an image transfer for one of the disks in this backup.
+	DownloadDisk(diskID DiskID, retries ...RetryStrategy) (VMBackupDiskDownload, error)
+	// Finalize finishes the current backup.
+	Finalize(retries ...RetryStrategy) error
+}
+
+// VMBackupPhase is the phase a backup is in.
+type VMBackupPhase string
+
+const (
+	// VMBackupPhaseInitializing indicates that the backup is being initialized.
+	VMBackupPhaseInitializing VMBackupPhase = "initializing"
+	// VMBackupPhaseStarting indicates that the backup is being started on the host.
+	VMBackupPhaseStarting VMBackupPhase = "starting"
+	// VMBackupPhaseReady indicates that the backup is ready and the disks can be downloaded.
+	VMBackupPhaseReady VMBackupPhase = "ready"
+	// VMBackupPhaseFinalizing indicates that the backup is being finalized.
+	VMBackupPhaseFinalizing VMBackupPhase = "finalizing"
+	// VMBackupPhaseSucceeded indicates that the backup has been finalized successfully.
+	VMBackupPhaseSucceeded VMBackupPhase = "succeeded"
+	// VMBackupPhaseFailed indicates that the backup has failed.
+	VMBackupPhaseFailed VMBackupPhase = "failed"
+)
+
+// VMBackupPhaseList is a list of VMBackupPhase.
+type VMBackupPhaseList []VMBackupPhase
+
+// VMBackupPhaseValues returns all possible VMBackupPhase values.
+func VMBackupPhaseValues() VMBackupPhaseList {
+	return []VMBackupPhase{
+		VMBackupPhaseInitializing,
+		VMBackupPhaseStarting,
+		VMBackupPhaseReady,
+		VMBackupPhaseFinalizing,
+		VMBackupPhaseSucceeded,
+		VMBackupPhaseFailed,
+	}
+}
+
+// Strings creates a string list of the values.
+func (l VMBackupPhaseList) Strings() []string {
+	result := make([]string, len(l))
+	for i, phase := range l {
+		result[i] = string(phase)
+	}
+	return result
+}
+
+// Validate returns an error if the backup phase is not valid.
+func (p VMBackupPhase) Validate() error {
+	for _, phase := range VMBackupPhaseValues() {
+		if phase == p {
+			return nil
+		}
+	}
+	return newError(
+		EBadArgument,
+		"invalid backup phase: %s must be one of: %s",
+		p,
+		strings.Join(VMBackupPhaseValues().Strings(), ", "),
+	)
+}
+
+// VMCheckpointData is the core of VMCheckpoint, providing only data access functions.
+type VMCheckpointData interface {
+	// ID returns the identifier of the checkpoint.
+	ID() VMCheckpointID
+	// VMID returns the ID of the virtual machine this checkpoint belongs to.
+	VMID() VMID
+	// ParentID returns the ID of the previous checkpoint in the chain. This is empty for the first checkpoint.
+	ParentID() VMCheckpointID
+	// State returns the state of the checkpoint.
+	State() VMCheckpointState
+	// DiskIDs returns the list of disks tracked by this checkpoint.
+	DiskIDs() []DiskID
+	// CreationDate returns the time the checkpoint was created.
+	CreationDate() time.Time
+}
+
+// VMCheckpoint is a point in time from which the changed blocks of the VM disks are tracked.
+type VMCheckpoint interface {
+	VMCheckpointData
+
+	// VM fetches the virtual machine this checkpoint belongs to.
+	VM(retries ...RetryStrategy) (VM, error)
+	// Remove removes the current checkpoint.
+	Remove(retries ...RetryStrategy) error
+}
+
+// VMCheckpointState is the state of a checkpoint.
+type VMCheckpointState string
+
+const (
+	// VMCheckpointStateCreated indicates that the checkpoint is valid and can be used for incremental backups.
+	VMCheckpointStateCreated VMCheckpointState = "created"
+	// VMCheckpointStateInvalid indicates that the checkpoint can no longer be used for incremental backups, for
+	// example because a disk was restored from a snapshot.
+	VMCheckpointStateInvalid VMCheckpointState = "invalid"
+)
+
+// VMCheckpointStateList is a list of VMCheckpointState.
+type VMCheckpointStateList []VMCheckpointState
+
+// VMCheckpointStateValues returns all possible VMCheckpointState values.
+func VMCheckpointStateValues() VMCheckpointStateList {
+	return []VMCheckpointState{
+		VMCheckpointStateCreated,
+		VMCheckpointStateInvalid,
+	}
+}
+
+// Strings creates a string list of the values.
+func (l VMCheckpointStateList) Strings() []string {
+	result := make([]string, len(l))
+	for i, state := range l {
+		result[i] = string(state)
+	}
+	return result
+}
+
+// Validate returns an error if the checkpoint state is not valid.
+func (s VMCheckpointState) Validate() error {
+	for _, state := range VMCheckpointStateValues() {
+		if state == s {
+			return nil
+		}
+	}
+	return newError(
+		EBadArgument,
+		"invalid checkpoint state: %s must be one of: %s",
+		s,
+		strings.Join(VMCheckpointStateValues().Strings(), ", "),
+	)
+}
+
+// DiskExtent describes a contiguous range of a disk image.
+type DiskExtent struct {
+	// Start is the offset of the extent from the start of the disk image in bytes.
+	Start uint64
+	// Length is the length of the extent in bytes.
+	Length uint64
+	// Zero indicates that the extent reads as zeroes and does not need to be transferred.
+	Zero bool
+	// Dirty indicates that the extent has changed since the checkpoint the backup was taken from. For full backups
+	// every extent that is not zero is dirty.
+	Dirty bool
+}
+
+// appendDiskExtent appends an extent to the list, merging it with the last extent if they have the same flags.
+func appendDiskExtent(extents []DiskExtent, extent DiskExtent) []DiskExtent {
+	if len(extents) > 0 {
+		last := &extents[len(extents)-1]
+		if last.Zero == extent.Zero && last.Dirty == extent.Dirty && last.Start+last.Length == extent.Start {
+			last.Length += extent.Length
+			return extents
+		}
+	}
+	return append(extents, extent)
+}
+
+// isZero returns true if the data only contains zero bytes.
+func isZero(data []byte) bool {
+	for _, b := range data {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// VMBackupDiskDownload is an image download of a single disk in a backup. Instead of streaming the whole image it
+// exposes the extents of the disk so only the dirty parts need to be read. The caller MUST close the download,
+// otherwise the image transfer stays open and the backup cannot be finalized.
+type VMBackupDiskDownload interface {
+	io.ReaderAt
+
+	// DiskID returns the ID of the disk being downloaded.
+	DiskID() DiskID
+	// Incremental returns true if the extents are relative to a previous checkpoint.
+	Incremental() bool
+	// Extents returns all extents of the disk image, ordered by their offset.
+	Extents() []DiskExtent
+	// DirtyExtents returns the extents that need to be transferred for this backup, ordered by their offset.
+	DirtyExtents() []DiskExtent
+	// WriteDirtyExtentsTo reads all dirty extents and writes them to the same offset in the target. When the target
+	// contains the image of the previous backup the result will be the image of the current backup. It returns the
+	// number of bytes written.
+	WriteDirtyExtentsTo(target io.WriterAt) (uint64, error)
+	// BytesRead returns the number of bytes read so far. This can be used to provide a progress bar.
+	BytesRead() uint64
+	// Size returns the size of the disk image in bytes.
+	Size() uint64
+	// Close closes the image transfer.
+	Close() error
+}
+
+// dirtyExtents returns the extents marked as dirty from the passed list.
+func dirtyExtents(extents []DiskExtent) []DiskExtent {
+	var result []DiskExtent
+	for _, extent := range extents {
+		if extent.Dirty {
+			result = append(result, extent)
+		}
+	}
+	return result
+}
+
+// writeDirtyExtentsTo copies all dirty extents from source to target using a fixed-size buffer.
+func writeDirtyExtentsTo(source io.ReaderAt, extents []DiskExtent, target io.WriterAt) (uint64, error) {
+	var written uint64
+	buf := make([]byte, 1024*1024)
+	for _, extent := range dirtyExtents(extents) {
+		for offset := extent.Start; offset < extent.Start+extent.Length; {
+			chunk := buf
+			if remaining := extent.Start + extent.Length - offset; remaining < uint64(len(chunk)) {
+				chunk = chunk[:remaining]
+			}
+			n, err := source.ReadAt(chunk, int64(offset))
+			if err != nil && (err != io.EOF || n != len(chunk)) {
+				return written, wrap(err, EUnidentified, "failed to read extent at offset %d", offset)
+			}
+			if _, err := target.WriteAt(chunk, int64(offset)); err != nil {
+				return written, wrap(err, ELocalIO, "failed to write extent at offset %d", offset)
+			}
+			offset += uint64(n)
+			written += uint64(n)
+		}
+	}
+	return written, nil
+}
+
+func convertSDKVMBackup(sdkObject *ovirtsdk4.Backup, vmID VMID, client Client) (VMBackup, error) {
+	id, ok := sdkObject.Id()
+	if !ok {
+		return nil, newFieldNotFound("backup", "id")
+	}
+	phase, ok := sdkObject.Phase()
+	if !ok {
+		return nil, newFieldNotFound("backup", "phase")
+	}
+	result := &vmBackup{
+		client: client,
+		id:     VMBackupID(id),
+		vmID:   vmID,
+		phase:  VMBackupPhase(phase),
+	}
+	if disks, ok := sdkObject.Disks(); ok {
+		for _, disk := range disks.Slice() {
+			if diskID, ok := disk.Id(); ok {
+				result.diskIDs = append(result.diskIDs, DiskID(diskID))
+			}
+		}
+	}
+	if fromCheckpointID, ok := sdkObject.FromCheckpointId(); ok {
+		result.fromCheckpointID = VMCheckpointID(fromCheckpointID)
+	}
+	if toCheckpointID, ok := sdkObject.ToCheckpointId(); ok {
+		result.toCheckpointID = VMCheckpointID(toCheckpointID)
+	}
+	if creationDate, ok := sdkObject.CreationDate(); ok {
+		result.creationDate = creationDate
+	}
+	return result, nil
+}
+
+type vmBackup struct {
+	client Client
+
+	id               VMBackupID
+	vmID             VMID
+	phase            VMBackupPhase
+	diskIDs          []DiskID
+	fromCheckpointID VMCheckpointID
+	toCheckpointID   VMCheckpointID
+	creationDate     time.Time
+}
+
+func (b *vmBackup) ID() VMBackupID {
+	return b.id
+}
+
+func (b *vmBackup) VMID() VMID {
+	return b.vmID
+}
+
+func (b *vmBackup) Phase() VMBackupPhase {
+	return b.phase
+}
+
+func (b *vmBackup) DiskIDs() []DiskID {
+	return b.diskIDs
+}
+
+func (b *vmBackup) FromCheckpointID() VMCheckpointID {
+	return b.fromCheckpointID
+}
+
+func (b *vmBackup) ToCheckpointID() VMCheckpointID {
+	return b.toCheckpointID
+}
+
+func (b *vmBackup) CreationDate() time.Time {
+	return b.creationDate
+}
+
+func (b *vmBackup) VM(retries ...RetryStrategy) (VM, error) {
+	return b.client.GetVM(b.vmID, retries...)
+}
+
+func (b *vmBackup) DownloadDisk(diskID DiskID, retries ...RetryStrategy) (VMBackupDiskDownload, error) {
+	return b.client.DownloadVMBackupDisk(b.vmID, b.id, diskID, retries...)
+}
+
+func (b *vmBackup) Finalize(retries ...RetryStrategy) error {
+	return b.client.FinalizeVMBackup(b.vmID, b.id, retries...)
+}
+
+func convertSDKVMCheckpoint(sdkObject *ovirtsdk4.Checkpoint, vmID VMID, client Client) (VMCheckpoint, error) {
+	id, ok := sdkObject.Id()
+	if !ok {
+		return nil, newFieldNotFound("checkpoint", "id")
+	}
+	state, ok := sdkObject.State()
+	if !ok {
+		return nil, newFieldNotFound("checkpoint", "state")
+	}
+	result := &vmCheckpoint{
+		client: client,
+		id:     VMCheckpointID(id),
+		vmID:   vmID,
+		state:  VMCheckpointState(state),
+	}
+	if parentID, ok := sdkObject.ParentId(); ok {
+		result.parentID = VMCheckpointID(parentID)
+	}
+	if disks, ok := sdkObject.Disks(); ok {
+		for _, disk := range disks.Slice() {
+			if diskID, ok := disk.Id(); ok {
+				result.diskIDs = append(result.diskIDs, DiskID(diskID))
+			}
+		}
+	}
+	if creationDate, ok := sdkObject.CreationDate(); ok {
+		result.creationDate = creationDate
+	}
+	return result, nil
+}
+
+type vmCheckpoint struct {
+	client Client
+
+	id           VMCheckpointID
+	vmID         VMID
+	parentID     VMCheckpointID
+	state        VMCheckpointState
+	diskIDs      []DiskID
+	creationDate time.Time
+}
+
+func (c *vmCheckpoint) ID() VMCheckpointID {
+	return c.id
+}
+
+func (c *vmCheckpoint) VMID() VMID {
+	return c.vmID
+}
+
+func (c *vmCheckpoint) ParentID() VMCheckpointID {
+	return c.parentID
+}
+
+func (c *vmCheckpoint) State() VMCheckpointState {
+	return c.state
+}
+
+func (c *vmCheckpoint) DiskIDs() []DiskID {
+	return c.diskIDs
+}
+
+func (c *vmCheckpoint) CreationDate() time.Time {
+	return c.creationDate
+}
+
+func (c *vmCheckpoint) VM(retries ...RetryStrategy) (VM, error) {
+	return c.client.GetVM(c.vmID, retries...)
+}
+
+func (c *vmCheckpoint) Remove(retries ...RetryStrategy) error {
+	return c.client.RemoveVMCheckpoint(c.vmID, c.id, retries...)
+}
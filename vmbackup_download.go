@@ -0,0 +1,276 @@
+package ovirtclient
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+func (o *oVirtClient) DownloadVMBackupDisk(
+	vmID VMID,
+	id VMBackupID,
+	diskID DiskID,
+	retries ...RetryStrategy,
+) (VMBackupDiskDownload, error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(o))
+
+	backup, err := o.GetVMBackup(vmID, id, retries...)
+	if err != nil {
+		return nil, err
+	}
+	if backup.Phase() != VMBackupPhaseReady {
+		return nil, newError(
+			EBadArgument,
+			"backup %s is in phase %s instead of %s",
+			id,
+			backup.Phase(),
+			VMBackupPhaseReady,
+		)
+	}
+
+	o.logger.Infof("Starting download of disk %s from backup %s...", diskID, id)
+	dl := &vmBackupDiskDownload{
+		lock:        &sync.Mutex{},
+		diskID:      diskID,
+		incremental: backup.FromCheckpointID() != "",
+		httpClient:  o.httpClient,
+		logger:      o.logger,
+		retries:     retries,
+		transfer:    newBackupImageTransfer(o, o.logger, diskID, id, retries),
+	}
+	if dl.transferURL, err = dl.transfer.initialize(); err != nil {
+		return nil, dl.transfer.finalize(err)
+	}
+	if err := dl.fetchExtents(); err != nil {
+		return nil, dl.transfer.finalize(err)
+	}
+	return dl, nil
+}
+
+type vmBackupDiskDownload struct {
+	lock *sync.Mutex
+
+	diskID      DiskID
+	incremental bool
+	extents     []DiskExtent
+	size        uint64
+	bytesRead   uint64
+
+	httpClient  http.Client
+	logger      Logger
+	retries     []RetryStrategy
+	transfer    imageTransfer
+	transferURL string
+}
+
+// fetchExtents queries the ImageIO extents API. Incremental backups use the dirty context, which reports the blocks
+// changed since the checkpoint. Full backups use the zero context, in which case all extents that are not zero are
+// considered dirty.
+func (v *vmBackupDiskDownload) fetchExtents() error {
+	extentsContext := "zero"
+	if v.incremental {
+		extentsContext = "dirty"
+	}
+	imageIOExtents, err := fetchImageIOExtents(
+		v.httpClient,
+		v.logger,
+		v.retries,
+		v.transfer,
+		v.transferURL,
+		extentsContext,
+		v.diskID,
+	)
+	if err != nil {
+		return err
+	}
+	v.extents = make([]DiskExtent, len(imageIOExtents))
+	v.size = 0
+	for i, extent := range imageIOExtents {
+		v.extents[i] = DiskExtent{
+			Start:  extent.Start,
+			Length: extent.Length,
+			Zero:   extent.Zero,
+			Dirty:  extent.Dirty || (!v.incremental && !extent.Zero),
+		}
+		if end := extent.Start + extent.Length; end > v.size {
+			v.size = end
+		}
+	}
+	return nil
+}
+
+func (v *vmBackupDiskDownload) DiskID() DiskID {
+	return v.diskID
+}
+
+func (v *vmBackupDiskDownload) Incremental() bool {
+	return v.incremental
+}
+
+func (v *vmBackupDiskDownload) Extents() []DiskExtent {
+	return v.extents
+}
+
+func (v *vmBackupDiskDownload) DirtyExtents() []DiskExtent {
+	return dirtyExtents(v.extents)
+}
+
+// ReadAt reads a range of the disk image using an HTTP range request.
+func (v *vmBackupDiskDownload) ReadAt(p []byte, off int64) (n int, err error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if off < 0 || uint64(off) >= v.size {
+		return 0, io.EOF
+	}
+	length := uint64(len(p))
+	if remaining := v.size - uint64(off); remaining < length {
+		length = remaining
+	}
+	err = retry(
+		fmt.Sprintf("reading %d bytes at offset %d of disk %s", length, off, v.diskID),
+		v.logger,
+		v.retries,
+		func() error {
+			var e error
+			n, e = readImageIORange(
+				context.Background(),
+				v.httpClient,
+				v.transfer,
+				v.transferURL,
+				v.diskID,
+				p[:length],
+				off,
+			)
+			return e
+		},
+	)
+	if err != nil {
+		return n, err
+	}
+	v.lock.Lock()
+	v.bytesRead += uint64(n)
+	v.lock.Unlock()
+	if uint64(n) < uint64(len(p)) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+func (v *vmBackupDiskDownload) WriteDirtyExtentsTo(target io.WriterAt) (uint64, error) {
+	return writeDirtyExtentsTo(v, v.extents, target)
+}
+
+func (v *vmBackupDiskDownload) BytesRead() uint64 {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	return v.bytesRead
+}
+
+func (v *vmBackupDiskDownload) Size() uint64 {
+	return v.size
+}
+
+// Close finalizes the image transfer. The backup itself stays open until FinalizeVMBackup is called.
+func (v *vmBackupDiskDownload) Close() error {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	if v.transfer == nil {
+		return nil
+	}
+	err := v.transfer.finalize(nil)
+	if err == nil {
+		v.transfer = nil
+	}
+	return err
+}
+
+func (m *mockClient) DownloadVMBackupDisk(
+	vmID VMID,
+	id VMBackupID,
+	diskID DiskID,
+	_ ...RetryStrategy,
+) (VMBackupDiskDownload, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	backup, err := m.getVMBackup(vmID, id)
+	if err != nil {
+		return nil, err
+	}
+	if backup.phase != VMBackupPhaseReady {
+		return nil, newError(
+			EBadArgument,
+			"backup %s is in phase %s instead of %s",
+			id,
+			backup.phase,
+			VMBackupPhaseReady,
+		)
+	}
+	data, ok := backup.data[diskID]
+	if !ok {
+		return nil, newError(ENotFound, "disk %s is not part of backup %s", diskID, id)
+	}
+	return &mockVMBackupDiskDownload{
+		lock:        &sync.Mutex{},
+		diskID:      diskID,
+		incremental: backup.fromCheckpointID != "",
+		extents:     backup.extents[diskID],
+		reader:      bytes.NewReader(data),
+	}, nil
+}
+
+type mockVMBackupDiskDownload struct {
+	lock *sync.Mutex
+
+	diskID      DiskID
+	incremental bool
+	extents     []DiskExtent
+	reader      *bytes.Reader
+	bytesRead   uint64
+}
+
+func (m *mockVMBackupDiskDownload) DiskID() DiskID {
+	return m.diskID
+}
+
+func (m *mockVMBackupDiskDownload) Incremental() bool {
+	return m.incremental
+}
+
+func (m *mockVMBackupDiskDownload) Extents() []DiskExtent {
+	return m.extents
+}
+
+func (m *mockVMBackupDiskDownload) DirtyExtents() []DiskExtent {
+	return dirtyExtents(m.extents)
+}
+
+func (m *mockVMBackupDiskDownload) ReadAt(p []byte, off int64) (int, error) {
+	n, err := m.reader.ReadAt(p, off)
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.bytesRead += uint64(n)
+	return n, err
+}
+
+func (m *mockVMBackupDiskDownload) WriteDirtyExtentsTo(target io.WriterAt) (uint64, error) {
+	return writeDirtyExtentsTo(m, m.extents, target)
+}
+
+func (m *mockVMBackupDiskDownload) BytesRead() uint64 {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.bytesRead
+}
+
+func (m *mockVMBackupDiskDownload) Size() uint64 {
+	return uint64(m.reader.Size())
+}
+
+func (m *mockVMBackupDiskDownload) Close() error {
+	return nil
+}
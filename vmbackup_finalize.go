@@ -0,0 +1,56 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) FinalizeVMBackup(vmID VMID, id VMBackupID, retries ...RetryStrategy) (err error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	correlationID := fmt.Sprintf("backup_finalize_%s", generateRandomID(5, o.nonSecureRandom))
+	err = retry(
+		fmt.Sprintf("finalizing backup %s of VM %s", id, vmID),
+		o.logger,
+		retries,
+		func() error {
+			_, err := o.conn.
+				SystemService().
+				VmsService().
+				VmService(string(vmID)).
+				BackupsService().
+				BackupService(string(id)).
+				Finalize().
+				Query("correlation_id", correlationID).
+				Send()
+			return err
+		})
+	if err != nil {
+		return err
+	}
+	if _, err := o.waitForVMBackupPhase(
+		vmID,
+		id,
+		VMBackupPhaseSucceeded,
+		defaultRetries(retries, defaultLongTimeouts(o)),
+	); err != nil && !HasErrorCode(err, ENotFound) {
+		// Newer engines remove the backup once it is finalized, so ENotFound also means success.
+		return wrap(err, EUnidentified, "failed to wait for finalization of backup %s", id)
+	}
+	return nil
+}
+
+func (m *mockClient) FinalizeVMBackup(vmID VMID, id VMBackupID, _ ...RetryStrategy) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	item, err := m.getVMBackup(vmID, id)
+	if err != nil {
+		return err
+	}
+	if item.phase != VMBackupPhaseReady {
+		return newError(EBadArgument, "backup %s is in phase %s instead of %s", id, item.phase, VMBackupPhaseReady)
+	}
+	item.phase = VMBackupPhaseSucceeded
+	item.data = nil
+	item.extents = nil
+	return nil
+}
@@ -0,0 +1,70 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) GetVMBackup(vmID VMID, id VMBackupID, retries ...RetryStrategy) (result VMBackup, err error) {
+	retries = defaultRetries(retries, defaultReadTimeouts(o))
+	err = retry(
+		fmt.Sprintf("getting backup %s of VM %s", id, vmID),
+		o.logger,
+		retries,
+		func() error {
+			response, err := o.conn.
+				SystemService().
+				VmsService().
+				VmService(string(vmID)).
+				BackupsService().
+				BackupService(string(id)).
+				Get().
+				Send()
+			if err != nil {
+				return err
+			}
+			sdkObject, ok := response.Backup()
+			if !ok {
+				return newError(
+					ENotFound,
+					"no backup returned when getting backup ID %s of VM %s",
+					id,
+					vmID,
+				)
+			}
+			result, err = convertSDKVMBackup(sdkObject, vmID, o)
+			if err != nil {
+				return wrap(
+					err,
+					EBug,
+					"failed to convert backup %s",
+					id,
+				)
+			}
+			return nil
+		})
+	return
+}
+
+func (m *mockClient) GetVMBackup(vmID VMID, id VMBackupID, _ ...RetryStrategy) (VMBackup, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	item, err := m.getVMBackup(vmID, id)
+	if err != nil {
+		return nil, err
+	}
+	result := item.vmBackup
+	return &result, nil
+}
+
+// getVMBackup returns the backup of a VM. The caller must hold the mock client lock.
+func (m *mockClient) getVMBackup(vmID VMID, id VMBackupID) (*vmBackupWithData, error) {
+	if _, ok := m.vms[vmID]; !ok {
+		return nil, newError(ENotFound, "vm with ID %s not found", vmID)
+	}
+	item, ok := m.vmBackups[id]
+	if !ok || item.vmID != vmID {
+		return nil, newError(ENotFound, "backup with ID %s not found for VM %s", id, vmID)
+	}
+	return item, nil
+}
@@ -0,0 +1,162 @@
+package ovirtclient
+
+import (
+	"bytes"
+	"sort"
+)
+
+// mockDirtyBlockSize is the granularity in which the mock tracks changed disk blocks. This matches the default
+// granularity of the dirty bitmaps used by QEMU.
+const mockDirtyBlockSize = 64 * 1024
+
+// mockDiskRange is a range of a disk image in bytes, with end being exclusive.
+type mockDiskRange struct {
+	start uint64
+	end   uint64
+}
+
+// vmBackupWithData adds the disk contents and extents captured at the start of the backup for mocking purposes.
+type vmBackupWithData struct {
+	vmBackup
+
+	data    map[DiskID][]byte
+	extents map[DiskID][]DiskExtent
+}
+
+// finished returns true if the backup no longer holds the VM.
+func (b *vmBackupWithData) finished() bool {
+	return b.phase == VMBackupPhaseSucceeded || b.phase == VMBackupPhaseFailed
+}
+
+// vmCheckpointWithRanges adds the ranges written since the checkpoint was created for each disk for mocking
+// purposes.
+type vmCheckpointWithRanges struct {
+	vmCheckpoint
+
+	dirtyRanges map[DiskID][]mockDiskRange
+}
+
+// recordDiskWrite compares the previous and the new contents of a disk and marks the changed blocks as dirty in all
+// checkpoints tracking the disk.
+func (m *mockClient) recordDiskWrite(diskID DiskID, oldData []byte, newData []byte) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	var changed []mockDiskRange
+	length := len(newData)
+	if len(oldData) > length {
+		length = len(oldData)
+	}
+	for start := 0; start < length; start += mockDirtyBlockSize {
+		end := start + mockDirtyBlockSize
+		if end > length {
+			end = length
+		}
+		if bytes.Equal(mockDiskBlock(oldData, start, end), mockDiskBlock(newData, start, end)) {
+			continue
+		}
+		changed = append(changed, mockDiskRange{uint64(start), uint64(end)})
+	}
+	if len(changed) == 0 {
+		return
+	}
+
+	for _, checkpoints := range m.vmCheckpoints {
+		for _, checkpoint := range checkpoints {
+			if ranges, ok := checkpoint.dirtyRanges[diskID]; ok {
+				checkpoint.dirtyRanges[diskID] = mergeMockDiskRanges(append(ranges, changed...))
+			}
+		}
+	}
+}
+
+// invalidateVMCheckpoints marks all checkpoints tracking the disk as invalid. This happens when the disk contents are
+// replaced without the changes being tracked, for example when restoring a snapshot. The caller must hold the mock
+// client lock.
+func (m *mockClient) invalidateVMCheckpoints(diskID DiskID) {
+	for _, checkpoints := range m.vmCheckpoints {
+		for _, checkpoint := range checkpoints {
+			if _, ok := checkpoint.dirtyRanges[diskID]; ok {
+				checkpoint.state = VMCheckpointStateInvalid
+			}
+		}
+	}
+}
+
+// mockDiskBlock returns the block between start and end of the data, padded with zeroes if the data is shorter.
+func mockDiskBlock(data []byte, start int, end int) []byte {
+	block := make([]byte, end-start)
+	if start < len(data) {
+		copy(block, data[start:])
+	}
+	return block
+}
+
+// mergeMockDiskRanges sorts the ranges and merges overlapping or adjacent ones.
+func mergeMockDiskRanges(ranges []mockDiskRange) []mockDiskRange {
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].start < ranges[j].start
+	})
+	var result []mockDiskRange
+	for _, r := range ranges {
+		if len(result) > 0 && result[len(result)-1].end >= r.start {
+			if r.end > result[len(result)-1].end {
+				result[len(result)-1].end = r.end
+			}
+			continue
+		}
+		result = append(result, r)
+	}
+	return result
+}
+
+// mockFullExtents calculates the extents of a full backup. Blocks containing only zeroes are reported as zero
+// extents, everything else is dirty.
+func mockFullExtents(data []byte) []DiskExtent {
+	var extents []DiskExtent
+	for start := 0; start < len(data); start += mockDirtyBlockSize {
+		end := start + mockDirtyBlockSize
+		if end > len(data) {
+			end = len(data)
+		}
+		zero := isZero(data[start:end])
+		extents = appendDiskExtent(extents, DiskExtent{
+			Start:  uint64(start),
+			Length: uint64(end - start),
+			Zero:   zero,
+			Dirty:  !zero,
+		})
+	}
+	return extents
+}
+
+// mockIncrementalExtents calculates the extents of an incremental backup from the ranges written since the
+// checkpoint.
+func mockIncrementalExtents(data []byte, dirtyRanges []mockDiskRange) []DiskExtent {
+	var extents []DiskExtent
+	size := uint64(len(data))
+	offset := uint64(0)
+	for _, r := range dirtyRanges {
+		if r.start >= size {
+			break
+		}
+		end := r.end
+		if end > size {
+			end = size
+		}
+		if r.start > offset {
+			extents = appendDiskExtent(extents, DiskExtent{Start: offset, Length: r.start - offset})
+		}
+		extents = appendDiskExtent(extents, DiskExtent{
+			Start:  r.start,
+			Length: end - r.start,
+			Zero:   isZero(data[r.start:end]),
+			Dirty:  true,
+		})
+		offset = end
+	}
+	if offset < size {
+		extents = appendDiskExtent(extents, DiskExtent{Start: offset, Length: size - offset})
+	}
+	return extents
+}
@@ -0,0 +1,222 @@
+package ovirtclient
+
+import (
+	"fmt"
+	"time"
+
+	ovirtsdk4 "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) StartVMBackup(
+	vmID VMID,
+	diskIDs []DiskID,
+	fromCheckpointID VMCheckpointID,
+	retries ...RetryStrategy,
+) (result VMBackup, err error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	if err := validateVMBackupDiskIDs(diskIDs); err != nil {
+		return nil, err
+	}
+
+	disks := make([]*ovirtsdk4.Disk, len(diskIDs))
+	for i, diskID := range diskIDs {
+		disks[i] = ovirtsdk4.NewDiskBuilder().Id(string(diskID)).MustBuild()
+	}
+	builder := ovirtsdk4.NewBackupBuilder().DisksOfAny(disks...)
+	if fromCheckpointID != "" {
+		builder.FromCheckpointId(string(fromCheckpointID))
+	}
+	sdkBackup, err := builder.Build()
+	if err != nil {
+		return nil, wrap(err, EBug, "failed to build backup for VM %s", vmID)
+	}
+
+	correlationID := fmt.Sprintf("backup_start_%s", generateRandomID(5, o.nonSecureRandom))
+	err = retry(
+		fmt.Sprintf("starting backup of VM %s", vmID),
+		o.logger,
+		retries,
+		func() error {
+			response, err := o.conn.
+				SystemService().
+				VmsService().
+				VmService(string(vmID)).
+				BackupsService().
+				Add().
+				Backup(sdkBackup).
+				Query("correlation_id", correlationID).
+				Send()
+			if err != nil {
+				return err
+			}
+			sdkObject, ok := response.Backup()
+			if !ok {
+				return newError(
+					ENotFound,
+					"no backup returned after starting backup of VM %s",
+					vmID,
+				)
+			}
+			result, err = convertSDKVMBackup(sdkObject, vmID, o)
+			if err != nil {
+				return wrap(
+					err,
+					EBug,
+					"failed to convert backup of VM %s",
+					vmID,
+				)
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return o.waitForVMBackupPhase(vmID, result.ID(), VMBackupPhaseReady, defaultRetries(retries, defaultLongTimeouts(o)))
+}
+
+// waitForVMBackupPhase waits for a backup to reach the specified phase. It returns an error if the backup fails.
+func (o *oVirtClient) waitForVMBackupPhase(
+	vmID VMID,
+	id VMBackupID,
+	phase VMBackupPhase,
+	retries []RetryStrategy,
+) (result VMBackup, err error) {
+	err = retry(
+		fmt.Sprintf("waiting for backup %s of VM %s to reach phase %s", id, vmID, phase),
+		o.logger,
+		retries,
+		func() error {
+			result, err = o.GetVMBackup(vmID, id, retries...)
+			if err != nil {
+				return err
+			}
+			switch result.Phase() {
+			case phase:
+				return nil
+			case VMBackupPhaseFailed:
+				return newError(EUnidentified, "backup %s of VM %s failed", id, vmID)
+			default:
+				return newError(EPending, "backup %s is in phase %s instead of %s", id, result.Phase(), phase)
+			}
+		})
+	return
+}
+
+func validateVMBackupDiskIDs(diskIDs []DiskID) error {
+	if len(diskIDs) == 0 {
+		return newError(EBadArgument, "at least one disk must be included in a backup")
+	}
+	for i, diskID := range diskIDs {
+		if diskID == "" {
+			return newError(EBadArgument, "disk ID #%d is empty", i)
+		}
+	}
+	return nil
+}
+
+func (m *mockClient) StartVMBackup(
+	vmID VMID,
+	diskIDs []DiskID,
+	fromCheckpointID VMCheckpointID,
+	_ ...RetryStrategy,
+) (VMBackup, error) {
+	if err := validateVMBackupDiskIDs(diskIDs); err != nil {
+		return nil, err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.vms[vmID]; !ok {
+		return nil, newError(ENotFound, "vm with ID %s not found", vmID)
+	}
+	for _, existingBackup := range m.vmBackups {
+		if existingBackup.vmID == vmID && !existingBackup.finished() {
+			return nil, newError(
+				EBadArgument,
+				"VM %s already has a backup in progress (%s)",
+				vmID,
+				existingBackup.id,
+			)
+		}
+	}
+	attachedDiskIDs := map[DiskID]struct{}{}
+	for _, attachment := range m.vmDiskAttachmentsByVM[vmID] {
+		attachedDiskIDs[attachment.diskID] = struct{}{}
+	}
+	disks := make(map[DiskID]*diskWithData, len(diskIDs))
+	for _, diskID := range diskIDs {
+		if _, ok := attachedDiskIDs[diskID]; !ok {
+			return nil, newError(EBadArgument, "disk %s is not attached to VM %s", diskID, vmID)
+		}
+		disk := m.disks[diskID]
+		if disk.status != DiskStatusOK {
+			return nil, newError(EDiskLocked, "disk %s is %s", diskID, disk.status)
+		}
+		disks[diskID] = disk
+	}
+
+	var parentID VMCheckpointID
+	var fromCheckpoint *vmCheckpointWithRanges
+	for _, checkpoint := range m.vmCheckpoints[vmID] {
+		parentID = checkpoint.id
+		if checkpoint.id == fromCheckpointID {
+			fromCheckpoint = checkpoint
+		}
+	}
+	if fromCheckpointID != "" {
+		if fromCheckpoint == nil {
+			return nil, newError(ENotFound, "checkpoint with ID %s not found for VM %s", fromCheckpointID, vmID)
+		}
+		if fromCheckpoint.state != VMCheckpointStateCreated {
+			return nil, newError(EBadArgument, "checkpoint %s is %s", fromCheckpointID, fromCheckpoint.state)
+		}
+		for _, diskID := range diskIDs {
+			if _, ok := fromCheckpoint.dirtyRanges[diskID]; !ok {
+				return nil, newError(EBadArgument, "disk %s is not tracked by checkpoint %s", diskID, fromCheckpointID)
+			}
+		}
+	}
+
+	backup := &vmBackupWithData{
+		vmBackup: vmBackup{
+			client:           m,
+			id:               VMBackupID(m.GenerateUUID()),
+			vmID:             vmID,
+			phase:            VMBackupPhaseReady,
+			diskIDs:          diskIDs,
+			fromCheckpointID: fromCheckpointID,
+			toCheckpointID:   VMCheckpointID(m.GenerateUUID()),
+			creationDate:     time.Now(),
+		},
+		extents: make(map[DiskID][]DiskExtent, len(diskIDs)),
+		data:    make(map[DiskID][]byte, len(diskIDs)),
+	}
+	checkpoint := &vmCheckpointWithRanges{
+		vmCheckpoint: vmCheckpoint{
+			client:       m,
+			id:           backup.toCheckpointID,
+			vmID:         vmID,
+			parentID:     parentID,
+			state:        VMCheckpointStateCreated,
+			diskIDs:      diskIDs,
+			creationDate: backup.creationDate,
+		},
+		dirtyRanges: make(map[DiskID][]mockDiskRange, len(diskIDs)),
+	}
+	for diskID, disk := range disks {
+		data := append([]byte(nil), disk.data...)
+		backup.data[diskID] = data
+		if fromCheckpoint != nil {
+			backup.extents[diskID] = mockIncrementalExtents(data, fromCheckpoint.dirtyRanges[diskID])
+		} else {
+			backup.extents[diskID] = mockFullExtents(data)
+		}
+		checkpoint.dirtyRanges[diskID] = nil
+	}
+	m.vmBackups[backup.id] = backup
+	m.vmCheckpoints[vmID] = append(m.vmCheckpoints[vmID], checkpoint)
+
+	result := backup.vmBackup
+	return &result, nil
+}
@@ -0,0 +1,191 @@
+package ovirtclient_test
+
+import (
+	"bytes"
+	"testing"
+
+	ovirtclient "github.com/dyudin0821/go-ovirt-client/v3"
+)
+
+const backupTestBlockSize = 64 * 1024
+
+func TestVMIncrementalBackup(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+
+	vm := assertCanCreateVM(t, helper, helper.GenerateTestResourceName(t), nil)
+	disk := assertCanCreateDisk(t, helper)
+	// The second block of the image is left empty so the full backup has a zero extent.
+	data := make([]byte, 4*backupTestBlockSize)
+	for i := range data {
+		if i < backupTestBlockSize || i >= 2*backupTestBlockSize {
+			data[i] = byte(i%251 + 1)
+		}
+	}
+	assertCanUploadDiskData(t, client, disk, data)
+	assertCanAttachDisk(t, vm, disk)
+
+	fullBackup := assertCanStartVMBackup(t, helper, vm, disk, "")
+	fullImage := make(writerAtBuffer, len(data))
+	extents := assertCanDownloadVMBackupDisk(t, fullBackup, disk, fullImage)
+	if len(extents) != 2 {
+		t.Fatalf("Incorrect number of dirty extents in full backup: %d instead of 2.", len(extents))
+	}
+	if extents[0].Start != 0 || extents[0].Length != backupTestBlockSize {
+		t.Fatalf("Incorrect first dirty extent in full backup: %v.", extents[0])
+	}
+	if extents[1].Start != 2*backupTestBlockSize || extents[1].Length != 2*backupTestBlockSize {
+		t.Fatalf("Incorrect second dirty extent in full backup: %v.", extents[1])
+	}
+	if !bytes.Equal(fullImage, data) {
+		t.Fatalf("The full backup image does not match the disk contents.")
+	}
+	assertCanFinalizeVMBackup(t, fullBackup)
+
+	checkpoints := assertCanListVMCheckpoints(t, client, vm)
+	if len(checkpoints) != 1 {
+		t.Fatalf("Incorrect number of checkpoints after full backup: %d instead of 1.", len(checkpoints))
+	}
+	if checkpoints[0].ID() != fullBackup.ToCheckpointID() {
+		t.Fatalf(
+			"Incorrect checkpoint ID after full backup: %s instead of %s.",
+			checkpoints[0].ID(),
+			fullBackup.ToCheckpointID(),
+		)
+	}
+
+	changedData := append([]byte(nil), data...)
+	changedData[2*backupTestBlockSize+100] ^= 0xff
+	assertCanUploadDiskData(t, client, disk, changedData)
+
+	incrementalBackup := assertCanStartVMBackup(t, helper, vm, disk, fullBackup.ToCheckpointID())
+	if incrementalBackup.FromCheckpointID() != fullBackup.ToCheckpointID() {
+		t.Fatalf(
+			"Incorrect from checkpoint ID on incremental backup: %s instead of %s.",
+			incrementalBackup.FromCheckpointID(),
+			fullBackup.ToCheckpointID(),
+		)
+	}
+	incrementalImage := append(writerAtBuffer(nil), fullImage...)
+	extents = assertCanDownloadVMBackupDisk(t, incrementalBackup, disk, incrementalImage)
+	if len(extents) != 1 {
+		t.Fatalf("Incorrect number of dirty extents in incremental backup: %d instead of 1.", len(extents))
+	}
+	if extents[0].Start != 2*backupTestBlockSize || extents[0].Length != backupTestBlockSize {
+		t.Fatalf("Incorrect dirty extent in incremental backup: %v.", extents[0])
+	}
+	if !bytes.Equal(incrementalImage, changedData) {
+		t.Fatalf("The full backup with the incremental backup applied does not match the disk contents.")
+	}
+	assertCanFinalizeVMBackup(t, incrementalBackup)
+
+	checkpoints = assertCanListVMCheckpoints(t, client, vm)
+	if len(checkpoints) != 2 {
+		t.Fatalf("Incorrect number of checkpoints after incremental backup: %d instead of 2.", len(checkpoints))
+	}
+	if checkpoints[1].ParentID() != checkpoints[0].ID() {
+		t.Fatalf("Incorrect parent checkpoint: %s instead of %s.", checkpoints[1].ParentID(), checkpoints[0].ID())
+	}
+	if err := checkpoints[1].Remove(); err == nil {
+		t.Fatalf("Removing a checkpoint that is not the root of the chain did not result in an error.")
+	}
+	if err := checkpoints[0].Remove(); err != nil {
+		t.Fatalf("Failed to remove checkpoint %s. (%v)", checkpoints[0].ID(), err)
+	}
+	if checkpoints = assertCanListVMCheckpoints(t, client, vm); len(checkpoints) != 1 {
+		t.Fatalf("Incorrect number of checkpoints after removal: %d instead of 1.", len(checkpoints))
+	}
+}
+
+func TestVMBackupFromNonExistentCheckpoint(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+
+	vm := assertCanCreateVM(t, helper, helper.GenerateTestResourceName(t), nil)
+	disk := assertCanCreateDisk(t, helper)
+	assertCanAttachDisk(t, vm, disk)
+
+	_, err := helper.GetClient().StartVMBackup(
+		vm.ID(),
+		[]ovirtclient.DiskID{disk.ID()},
+		ovirtclient.VMCheckpointID(helper.GenerateRandomID(5)),
+	)
+	if !ovirtclient.HasErrorCode(err, ovirtclient.ENotFound) {
+		t.Fatalf("Starting a backup from a non-existent checkpoint did not result in an ENotFound error. (%v)", err)
+	}
+}
+
+// writerAtBuffer is a fixed-size in-memory io.WriterAt.
+type writerAtBuffer []byte
+
+func (w writerAtBuffer) WriteAt(p []byte, off int64) (int, error) {
+	return copy(w[off:], p), nil
+}
+
+func assertCanUploadDiskData(t *testing.T, client ovirtclient.Client, disk ovirtclient.Disk, data []byte) {
+	if err := client.UploadToDisk(disk.ID(), uint64(len(data)), &nopReadCloser{bytes.NewReader(data)}); err != nil {
+		t.Fatalf("Failed to upload data to disk %s. (%v)", disk.ID(), err)
+	}
+}
+
+func assertCanStartVMBackup(
+	t *testing.T,
+	helper ovirtclient.TestHelper,
+	vm ovirtclient.VM,
+	disk ovirtclient.Disk,
+	fromCheckpointID ovirtclient.VMCheckpointID,
+) ovirtclient.VMBackup {
+	backup, err := helper.GetClient().StartVMBackup(vm.ID(), []ovirtclient.DiskID{disk.ID()}, fromCheckpointID)
+	if err != nil {
+		t.Fatalf("Failed to start backup of VM %s. (%v)", vm.ID(), err)
+	}
+	if backup.Phase() != ovirtclient.VMBackupPhaseReady {
+		t.Fatalf("Incorrect backup phase: %s instead of %s.", backup.Phase(), ovirtclient.VMBackupPhaseReady)
+	}
+	return backup
+}
+
+func assertCanDownloadVMBackupDisk(
+	t *testing.T,
+	backup ovirtclient.VMBackup,
+	disk ovirtclient.Disk,
+	target writerAtBuffer,
+) []ovirtclient.DiskExtent {
+	download, err := backup.DownloadDisk(disk.ID())
+	if err != nil {
+		t.Fatalf("Failed to download disk %s from backup %s. (%v)", disk.ID(), backup.ID(), err)
+	}
+	defer func() {
+		if err := download.Close(); err != nil {
+			t.Fatalf("Failed to close backup download of disk %s. (%v)", disk.ID(), err)
+		}
+	}()
+	extents := download.DirtyExtents()
+	var expectedBytes uint64
+	for _, extent := range extents {
+		expectedBytes += extent.Length
+	}
+	written, err := download.WriteDirtyExtentsTo(target)
+	if err != nil {
+		t.Fatalf("Failed to write dirty extents of disk %s. (%v)", disk.ID(), err)
+	}
+	if written != expectedBytes {
+		t.Fatalf("Incorrect number of bytes written: %d instead of %d.", written, expectedBytes)
+	}
+	return extents
+}
+
+func assertCanFinalizeVMBackup(t *testing.T, backup ovirtclient.VMBackup) {
+	if err := backup.Finalize(); err != nil {
+		t.Fatalf("Failed to finalize backup %s. (%v)", backup.ID(), err)
+	}
+}
+
+func assertCanListVMCheckpoints(t *testing.T, client ovirtclient.Client, vm ovirtclient.VM) []ovirtclient.VMCheckpoint {
+	checkpoints, err := client.ListVMCheckpoints(vm.ID())
+	if err != nil {
+		t.Fatalf("Failed to list checkpoints of VM %s. (%v)", vm.ID(), err)
+	}
+	return checkpoints
+}
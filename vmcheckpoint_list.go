@@ -0,0 +1,48 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) ListVMCheckpoints(vmID VMID, retries ...RetryStrategy) (result []VMCheckpoint, err error) {
+	retries = defaultRetries(retries, defaultReadTimeouts(o))
+	result = []VMCheckpoint{}
+	err = retry(
+		fmt.Sprintf("listing checkpoints of VM %s", vmID),
+		o.logger,
+		retries,
+		func() error {
+			response, e := o.conn.SystemService().VmsService().VmService(string(vmID)).CheckpointsService().List().Send()
+			if e != nil {
+				return e
+			}
+			sdkObjects, ok := response.Checkpoints()
+			if !ok {
+				return nil
+			}
+			result = make([]VMCheckpoint, len(sdkObjects.Slice()))
+			for i, sdkObject := range sdkObjects.Slice() {
+				result[i], e = convertSDKVMCheckpoint(sdkObject, vmID, o)
+				if e != nil {
+					return wrap(e, EBug, "failed to convert checkpoint during listing item #%d", i)
+				}
+			}
+			return nil
+		})
+	return
+}
+
+func (m *mockClient) ListVMCheckpoints(vmID VMID, _ ...RetryStrategy) ([]VMCheckpoint, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.vms[vmID]; !ok {
+		return nil, newError(ENotFound, "vm with ID %s not found", vmID)
+	}
+	result := make([]VMCheckpoint, len(m.vmCheckpoints[vmID]))
+	for i, item := range m.vmCheckpoints[vmID] {
+		c := item.vmCheckpoint
+		result[i] = &c
+	}
+	return result, nil
+}
@@ -0,0 +1,62 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) RemoveVMCheckpoint(vmID VMID, id VMCheckpointID, retries ...RetryStrategy) (err error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	correlationID := fmt.Sprintf("checkpoint_remove_%s", generateRandomID(5, o.nonSecureRandom))
+	err = retry(
+		fmt.Sprintf("removing checkpoint %s of VM %s", id, vmID),
+		o.logger,
+		retries,
+		func() error {
+			_, err := o.conn.
+				SystemService().
+				VmsService().
+				VmService(string(vmID)).
+				CheckpointsService().
+				CheckpointService(string(id)).
+				Remove().
+				Query("correlation_id", correlationID).
+				Send()
+			return err
+		})
+	if err != nil {
+		return err
+	}
+	if err := o.waitForJobFinished(correlationID, defaultRetries(retries, defaultLongTimeouts(o))); err != nil {
+		return wrap(err, EUnidentified, "failed to wait for removal of checkpoint %s", id)
+	}
+	return nil
+}
+
+func (m *mockClient) RemoveVMCheckpoint(vmID VMID, id VMCheckpointID, _ ...RetryStrategy) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.vms[vmID]; !ok {
+		return newError(ENotFound, "vm with ID %s not found", vmID)
+	}
+	checkpoints := m.vmCheckpoints[vmID]
+	for i, item := range checkpoints {
+		if item.id != id {
+			continue
+		}
+		if i != 0 {
+			return newError(
+				EBadArgument,
+				"only the root checkpoint %s of VM %s can be removed",
+				checkpoints[0].id,
+				vmID,
+			)
+		}
+		m.vmCheckpoints[vmID] = checkpoints[1:]
+		if len(m.vmCheckpoints[vmID]) > 0 {
+			m.vmCheckpoints[vmID][0].parentID = ""
+		}
+		return nil
+	}
+	return newError(ENotFound, "checkpoint with ID %s not found for VM %s", id, vmID)
+}
@@ -0,0 +1,539 @@
+package ovirtclient
+
+import (
+	"strings"
+
+	ovirtsdk "github.com/ovirt/go-ovirt"
+)
+
+//go:generate go run scripts/rest/rest.go -i "VmPool" -n "VM pool" -o "VMPool" -s "Pool" -T VMPoolID
+
+// VMPoolID is the identifier for VM pools.
+type VMPoolID string
+
+// VMPoolClient contains the methods for working with VM pools. A VM pool is a group of identical VMs created from
+// the same template that can be allocated to users on demand.
+type VMPoolClient interface {
+	// CreateVMPool creates a VM pool with the specified number of VMs from a template. The VMs of the pool are
+	// created in the background and are available once this call returns.
+	CreateVMPool(
+		clusterID ClusterID,
+		templateID TemplateID,
+		name string,
+		size uint,
+		params OptionalVMPoolParameters,
+		retries ...RetryStrategy,
+	) (VMPool, error)
+	// ListVMPools lists all VM pools.
+	ListVMPools(retries ...RetryStrategy) ([]VMPool, error)
+	// GetVMPool returns a single VM pool.
+	GetVMPool(id VMPoolID, retries ...RetryStrategy) (VMPool, error)
+	// UpdateVMPool updates the size and allocation limits of a VM pool. Increasing the size of a pool creates new
+	// VMs, decreasing it is not supported by the engine.
+	UpdateVMPool(id VMPoolID, params UpdateVMPoolParameters, retries ...RetryStrategy) (VMPool, error)
+	// RemoveVMPool removes a VM pool, including all of its VMs.
+	RemoveVMPool(id VMPoolID, retries ...RetryStrategy) error
+	// AllocateVMFromPool allocates a VM from the pool to the current user and starts it. The engine does not report
+	// which VM was allocated, use ListVMs and filter by VMPoolID to find the VMs of a pool.
+	AllocateVMFromPool(id VMPoolID, retries ...RetryStrategy) error
+}
+
+// VMPoolData is the data segment of a VM pool.
+type VMPoolData interface {
+	// ID returns the unique identifier of the VM pool.
+	ID() VMPoolID
+	// Name returns the name of the VM pool.
+	Name() string
+	// Comment returns the comment of the VM pool.
+	Comment() string
+	// Description returns the description of the VM pool.
+	Description() string
+	// ClusterID returns the ID of the cluster the VMs of the pool are created in.
+	ClusterID() ClusterID
+	// TemplateID returns the ID of the template the VMs of the pool are created from.
+	TemplateID() TemplateID
+	// Size returns the number of VMs in the pool.
+	Size() uint
+	// PrestartedVMs returns the number of VMs that are kept running to be allocated without delay.
+	PrestartedVMs() uint
+	// MaxUserVMs returns the maximum number of VMs a single user can allocate from the pool.
+	MaxUserVMs() uint
+	// Type returns the pool type, which determines what happens to a VM when the user releases it.
+	Type() VMPoolType
+	// Stateful indicates that the VMs of the pool keep their state between allocations.
+	Stateful() bool
+}
+
+// VMPool is a group of identical VMs that can be allocated to users on demand.
+type VMPool interface {
+	VMPoolData
+
+	// Cluster fetches the cluster the VMs of the pool are created in.
+	Cluster(retries ...RetryStrategy) (Cluster, error)
+	// Template fetches the template the VMs of the pool are created from.
+	Template(retries ...RetryStrategy) (Template, error)
+	// Update updates the size and allocation limits of the VM pool.
+	Update(params UpdateVMPoolParameters, retries ...RetryStrategy) (VMPool, error)
+	// Remove removes the VM pool, including all of its VMs.
+	Remove(retries ...RetryStrategy) error
+	// AllocateVM allocates a VM from the pool to the current user and starts it.
+	AllocateVM(retries ...RetryStrategy) error
+}
+
+// VMPoolType determines what happens to a VM of the pool when the user releases it.
+type VMPoolType string
+
+const (
+	// VMPoolTypeAutomatic returns the VM to the pool when the user shuts it down. The VM is reverted to its
+	// original state unless the pool is stateful.
+	VMPoolTypeAutomatic VMPoolType = "automatic"
+	// VMPoolTypeManual keeps the VM assigned to the user until an administrator returns it to the pool.
+	VMPoolTypeManual VMPoolType = "manual"
+)
+
+// Validate returns an error if the VM pool type doesn't have a valid value.
+func (t VMPoolType) Validate() error {
+	for _, poolType := range VMPoolTypeValues() {
+		if poolType == t {
+			return nil
+		}
+	}
+	return newError(
+		EBadArgument,
+		"Invalid value for VM pool type: %s must be one of: %s",
+		t,
+		strings.Join(VMPoolTypeValues().Strings(), ", "),
+	)
+}
+
+// VMPoolTypeList is a list of VMPoolType.
+type VMPoolTypeList []VMPoolType
+
+// Strings creates a string list of the values.
+func (l VMPoolTypeList) Strings() []string {
+	result := make([]string, len(l))
+	for i, poolType := range l {
+		result[i] = string(poolType)
+	}
+	return result
+}
+
+// VMPoolTypeValues returns all possible VMPoolType values.
+func VMPoolTypeValues() VMPoolTypeList {
+	return []VMPoolType{
+		VMPoolTypeAutomatic,
+		VMPoolTypeManual,
+	}
+}
+
+// OptionalVMPoolParameters are the optional parameters for creating a VM pool.
+type OptionalVMPoolParameters interface {
+	// Comment returns the comment for the VM pool.
+	Comment() string
+	// Description returns the description for the VM pool.
+	Description() string
+	// PrestartedVMs returns the number of VMs that should be kept running. Must not be larger than the pool size.
+	PrestartedVMs() *uint
+	// MaxUserVMs returns the maximum number of VMs a single user can allocate from the pool.
+	MaxUserVMs() *uint
+	// Type returns the pool type. Defaults to VMPoolTypeAutomatic.
+	Type() *VMPoolType
+	// Stateful returns whether the VMs of the pool should keep their state between allocations.
+	Stateful() *bool
+}
+
+// BuildableVMPoolParameters is a buildable version of OptionalVMPoolParameters.
+type BuildableVMPoolParameters interface {
+	OptionalVMPoolParameters
+
+	// WithComment sets the comment for the VM pool.
+	WithComment(comment string) (BuildableVMPoolParameters, error)
+	// MustWithComment is identical to WithComment, but panics instead of returning an error.
+	MustWithComment(comment string) BuildableVMPoolParameters
+
+	// WithDescription sets the description for the VM pool.
+	WithDescription(description string) (BuildableVMPoolParameters, error)
+	// MustWithDescription is identical to WithDescription, but panics instead of returning an error.
+	MustWithDescription(description string) BuildableVMPoolParameters
+
+	// WithPrestartedVMs sets the number of VMs that should be kept running.
+	WithPrestartedVMs(prestartedVMs uint) (BuildableVMPoolParameters, error)
+	// MustWithPrestartedVMs is identical to WithPrestartedVMs, but panics instead of returning an error.
+	MustWithPrestartedVMs(prestartedVMs uint) BuildableVMPoolParameters
+
+	// WithMaxUserVMs sets the maximum number of VMs a single user can allocate from the pool.
+	WithMaxUserVMs(maxUserVMs uint) (BuildableVMPoolParameters, error)
+	// MustWithMaxUserVMs is identical to WithMaxUserVMs, but panics instead of returning an error.
+	MustWithMaxUserVMs(maxUserVMs uint) BuildableVMPoolParameters
+
+	// WithType sets the pool type.
+	WithType(poolType VMPoolType) (BuildableVMPoolParameters, error)
+	// MustWithType is identical to WithType, but panics instead of returning an error.
+	MustWithType(poolType VMPoolType) BuildableVMPoolParameters
+
+	// WithStateful sets whether the VMs of the pool should keep their state between allocations.
+	WithStateful(stateful bool) (BuildableVMPoolParameters, error)
+	// MustWithStateful is identical to WithStateful, but panics instead of returning an error.
+	MustWithStateful(stateful bool) BuildableVMPoolParameters
+}
+
+// CreateVMPoolParams returns a buildable set of parameters for creating a VM pool.
+func CreateVMPoolParams() BuildableVMPoolParameters {
+	return &vmPoolParams{}
+}
+
+type vmPoolParams struct {
+	comment       string
+	description   string
+	prestartedVMs *uint
+	maxUserVMs    *uint
+	poolType      *VMPoolType
+	stateful      *bool
+}
+
+func (v *vmPoolParams) Comment() string {
+	return v.comment
+}
+
+func (v *vmPoolParams) Description() string {
+	return v.description
+}
+
+func (v *vmPoolParams) PrestartedVMs() *uint {
+	return v.prestartedVMs
+}
+
+func (v *vmPoolParams) MaxUserVMs() *uint {
+	return v.maxUserVMs
+}
+
+func (v *vmPoolParams) Type() *VMPoolType {
+	return v.poolType
+}
+
+func (v *vmPoolParams) Stateful() *bool {
+	return v.stateful
+}
+
+func (v *vmPoolParams) WithComment(comment string) (BuildableVMPoolParameters, error) {
+	v.comment = comment
+	return v, nil
+}
+
+func (v *vmPoolParams) MustWithComment(comment string) BuildableVMPoolParameters {
+	builder, err := v.WithComment(comment)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (v *vmPoolParams) WithDescription(description string) (BuildableVMPoolParameters, error) {
+	v.description = description
+	return v, nil
+}
+
+func (v *vmPoolParams) MustWithDescription(description string) BuildableVMPoolParameters {
+	builder, err := v.WithDescription(description)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (v *vmPoolParams) WithPrestartedVMs(prestartedVMs uint) (BuildableVMPoolParameters, error) {
+	v.prestartedVMs = &prestartedVMs
+	return v, nil
+}
+
+func (v *vmPoolParams) MustWithPrestartedVMs(prestartedVMs uint) BuildableVMPoolParameters {
+	builder, err := v.WithPrestartedVMs(prestartedVMs)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (v *vmPoolParams) WithMaxUserVMs(maxUserVMs uint) (BuildableVMPoolParameters, error) {
+	if maxUserVMs == 0 {
+		return nil, newError(EBadArgument, "the maximum number of VMs per user must be positive")
+	}
+	v.maxUserVMs = &maxUserVMs
+	return v, nil
+}
+
+func (v *vmPoolParams) MustWithMaxUserVMs(maxUserVMs uint) BuildableVMPoolParameters {
+	builder, err := v.WithMaxUserVMs(maxUserVMs)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (v *vmPoolParams) WithType(poolType VMPoolType) (BuildableVMPoolParameters, error) {
+	if err := poolType.Validate(); err != nil {
+		return nil, err
+	}
+	v.poolType = &poolType
+	return v, nil
+}
+
+func (v *vmPoolParams) MustWithType(poolType VMPoolType) BuildableVMPoolParameters {
+	builder, err := v.WithType(poolType)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (v *vmPoolParams) WithStateful(stateful bool) (BuildableVMPoolParameters, error) {
+	v.stateful = &stateful
+	return v, nil
+}
+
+func (v *vmPoolParams) MustWithStateful(stateful bool) BuildableVMPoolParameters {
+	builder, err := v.WithStateful(stateful)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+// UpdateVMPoolParameters are the parameters for updating a VM pool. Nil values are left unchanged.
+type UpdateVMPoolParameters interface {
+	// Size returns the new number of VMs in the pool.
+	Size() *uint
+	// PrestartedVMs returns the new number of VMs that should be kept running.
+	PrestartedVMs() *uint
+	// MaxUserVMs returns the new maximum number of VMs a single user can allocate from the pool.
+	MaxUserVMs() *uint
+}
+
+// BuildableUpdateVMPoolParameters is a buildable version of UpdateVMPoolParameters.
+type BuildableUpdateVMPoolParameters interface {
+	UpdateVMPoolParameters
+
+	// WithSize sets the new number of VMs in the pool.
+	WithSize(size uint) (BuildableUpdateVMPoolParameters, error)
+	// MustWithSize is identical to WithSize, but panics instead of returning an error.
+	MustWithSize(size uint) BuildableUpdateVMPoolParameters
+
+	// WithPrestartedVMs sets the new number of VMs that should be kept running.
+	WithPrestartedVMs(prestartedVMs uint) (BuildableUpdateVMPoolParameters, error)
+	// MustWithPrestartedVMs is identical to WithPrestartedVMs, but panics instead of returning an error.
+	MustWithPrestartedVMs(prestartedVMs uint) BuildableUpdateVMPoolParameters
+
+	// WithMaxUserVMs sets the new maximum number of VMs a single user can allocate from the pool.
+	WithMaxUserVMs(maxUserVMs uint) (BuildableUpdateVMPoolParameters, error)
+	// MustWithMaxUserVMs is identical to WithMaxUserVMs, but panics instead of returning an error.
+	MustWithMaxUserVMs(maxUserVMs uint) BuildableUpdateVMPoolParameters
+}
+
+// UpdateVMPoolParams returns a buildable set of parameters for updating a VM pool.
+func UpdateVMPoolParams() BuildableUpdateVMPoolParameters {
+	return &updateVMPoolParams{}
+}
+
+type updateVMPoolParams struct {
+	size          *uint
+	prestartedVMs *uint
+	maxUserVMs    *uint
+}
+
+func (u *updateVMPoolParams) Size() *uint {
+	return u.size
+}
+
+func (u *updateVMPoolParams) PrestartedVMs() *uint {
+	return u.prestartedVMs
+}
+
+func (u *updateVMPoolParams) MaxUserVMs() *uint {
+	return u.maxUserVMs
+}
+
+func (u *updateVMPoolParams) WithSize(size uint) (BuildableUpdateVMPoolParameters, error) {
+	if size == 0 {
+		return nil, newError(EBadArgument, "the VM pool size must be positive")
+	}
+	u.size = &size
+	return u, nil
+}
+
+func (u *updateVMPoolParams) MustWithSize(size uint) BuildableUpdateVMPoolParameters {
+	builder, err := u.WithSize(size)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (u *updateVMPoolParams) WithPrestartedVMs(prestartedVMs uint) (BuildableUpdateVMPoolParameters, error) {
+	u.prestartedVMs = &prestartedVMs
+	return u, nil
+}
+
+func (u *updateVMPoolParams) MustWithPrestartedVMs(prestartedVMs uint) BuildableUpdateVMPoolParameters {
+	builder, err := u.WithPrestartedVMs(prestartedVMs)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (u *updateVMPoolParams) WithMaxUserVMs(maxUserVMs uint) (BuildableUpdateVMPoolParameters, error) {
+	if maxUserVMs == 0 {
+		return nil, newError(EBadArgument, "the maximum number of VMs per user must be positive")
+	}
+	u.maxUserVMs = &maxUserVMs
+	return u, nil
+}
+
+func (u *updateVMPoolParams) MustWithMaxUserVMs(maxUserVMs uint) BuildableUpdateVMPoolParameters {
+	builder, err := u.WithMaxUserVMs(maxUserVMs)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func convertSDKVMPool(object *ovirtsdk.VmPool, client Client) (VMPool, error) {
+	id, ok := object.Id()
+	if !ok {
+		return nil, newFieldNotFound("VM pool", "ID")
+	}
+	name, ok := object.Name()
+	if !ok {
+		return nil, newFieldNotFound("VM pool", "name")
+	}
+	cluster, ok := object.Cluster()
+	if !ok {
+		return nil, newFieldNotFound("VM pool", "cluster")
+	}
+	clusterID, ok := cluster.Id()
+	if !ok {
+		return nil, newFieldNotFound("cluster on VM pool", "ID")
+	}
+	tpl, ok := object.Template()
+	if !ok {
+		return nil, newFieldNotFound("VM pool", "template")
+	}
+	templateID, ok := tpl.Id()
+	if !ok {
+		return nil, newFieldNotFound("template on VM pool", "ID")
+	}
+	size, ok := object.Size()
+	if !ok {
+		return nil, newFieldNotFound("VM pool", "size")
+	}
+
+	result := &vmPool{
+		client:     client,
+		id:         VMPoolID(id),
+		name:       name,
+		clusterID:  ClusterID(clusterID),
+		templateID: TemplateID(templateID),
+		size:       uint(size), //nolint:gosec
+		maxUserVMs: 1,
+		poolType:   VMPoolTypeAutomatic,
+	}
+	if comment, ok := object.Comment(); ok {
+		result.comment = comment
+	}
+	if description, ok := object.Description(); ok {
+		result.description = description
+	}
+	if prestartedVMs, ok := object.PrestartedVms(); ok {
+		result.prestartedVMs = uint(prestartedVMs) //nolint:gosec
+	}
+	if maxUserVMs, ok := object.MaxUserVms(); ok {
+		result.maxUserVMs = uint(maxUserVMs) //nolint:gosec
+	}
+	if poolType, ok := object.Type(); ok {
+		result.poolType = VMPoolType(poolType)
+	}
+	if stateful, ok := object.Stateful(); ok {
+		result.stateful = stateful
+	}
+	return result, nil
+}
+
+type vmPool struct {
+	client Client
+
+	id            VMPoolID
+	name          string
+	comment       string
+	description   string
+	clusterID     ClusterID
+	templateID    TemplateID
+	size          uint
+	prestartedVMs uint
+	maxUserVMs    uint
+	poolType      VMPoolType
+	stateful      bool
+}
+
+func (v *vmPool) ID() VMPoolID {
+	return v.id
+}
+
+func (v *vmPool) Name() string {
+	return v.name
+}
+
+func (v *vmPool) Comment() string {
+	return v.comment
+}
+
+func (v *vmPool) Description() string {
+	return v.description
+}
+
+func (v *vmPool) ClusterID() ClusterID {
+	return v.clusterID
+}
+
+func (v *vmPool) TemplateID() TemplateID {
+	return v.templateID
+}
+
+func (v *vmPool) Size() uint {
+	return v.size
+}
+
+func (v *vmPool) PrestartedVMs() uint {
+	return v.prestartedVMs
+}
+
+func (v *vmPool) MaxUserVMs() uint {
+	return v.maxUserVMs
+}
+
+func (v *vmPool) Type() VMPoolType {
+	return v.poolType
+}
+
+func (v *vmPool) Stateful() bool {
+	return v.stateful
+}
+
+func (v *vmPool) Cluster(retries ...RetryStrategy) (Cluster, error) {
+	return v.client.GetCluster(v.clusterID, retries...)
+}
+
+func (v *vmPool) Template(retries ...RetryStrategy) (Template, error) {
+	return v.client.GetTemplate(v.templateID, retries...)
+}
+
+func (v *vmPool) Update(params UpdateVMPoolParameters, retries ...RetryStrategy) (VMPool, error) {
+	return v.client.UpdateVMPool(v.id, params, retries...)
+}
+
+func (v *vmPool) Remove(retries ...RetryStrategy) error {
+	return v.client.RemoveVMPool(v.id, retries...)
+}
+
+func (v *vmPool) AllocateVM(retries ...RetryStrategy) error {
+	return v.client.AllocateVMFromPool(v.id, retries...)
+}
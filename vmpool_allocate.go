@@ -0,0 +1,64 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) AllocateVMFromPool(id VMPoolID, retries ...RetryStrategy) (err error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	err = retry(
+		fmt.Sprintf("allocating VM from pool %s", id),
+		o.logger,
+		retries,
+		func() error {
+			_, err := o.conn.SystemService().VmPoolsService().PoolService(string(id)).AllocateVm().Send()
+			return err
+		})
+	return
+}
+
+func (m *mockClient) AllocateVMFromPool(id VMPoolID, _ ...RetryStrategy) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	pool, ok := m.vmPools[id]
+	if !ok {
+		return newError(ENotFound, "VM pool with ID %s not found", id)
+	}
+
+	var runningVM, stoppedVM *vm
+	var allocated uint
+	for _, poolVM := range m.vmPoolVMs(id) {
+		if !m.isVMPoolVMFree(pool, poolVM) {
+			allocated++
+			continue
+		}
+		switch {
+		case poolVM.status == VMStatusDown && stoppedVM == nil:
+			stoppedVM = poolVM
+		case poolVM.status != VMStatusDown && runningVM == nil:
+			runningVM = poolVM
+		}
+	}
+	if allocated >= pool.maxUserVMs {
+		return newError(
+			EConflict,
+			"cannot allocate VM from pool %s, the maximum of %d VMs per user has been reached",
+			id,
+			pool.maxUserVMs,
+		)
+	}
+
+	switch {
+	case runningVM != nil:
+		m.vmPoolAllocations[id][runningVM.id] = struct{}{}
+	case stoppedVM != nil:
+		if err := m.bootVM(stoppedVM, false); err != nil {
+			return err
+		}
+		m.vmPoolAllocations[id][stoppedVM.id] = struct{}{}
+	default:
+		return newError(EConflict, "cannot allocate VM from pool %s, no free VMs are left in the pool", id)
+	}
+	return m.startPrestartedVMs(pool)
+}
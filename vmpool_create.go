@@ -0,0 +1,209 @@
+package ovirtclient
+
+import (
+	"fmt"
+
+	ovirtsdk "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) CreateVMPool(
+	clusterID ClusterID,
+	templateID TemplateID,
+	name string,
+	size uint,
+	params OptionalVMPoolParameters,
+	retries ...RetryStrategy,
+) (result VMPool, err error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(o))
+	if params == nil {
+		params = CreateVMPoolParams()
+	}
+	if err := validateVMPoolCreationParameters(clusterID, templateID, name, size, params); err != nil {
+		return nil, err
+	}
+	sdkPool, err := createSDKVMPool(clusterID, templateID, name, size, params)
+	if err != nil {
+		return nil, err
+	}
+
+	correlationID := fmt.Sprintf("vmpool_create_%s", generateRandomID(5, o.nonSecureRandom))
+	err = retry(
+		fmt.Sprintf("creating VM pool %s", name),
+		o.logger,
+		retries,
+		func() error {
+			response, err := o.conn.
+				SystemService().
+				VmPoolsService().
+				Add().
+				Pool(sdkPool).
+				Query("correlation_id", correlationID).
+				Send()
+			if err != nil {
+				return err
+			}
+			sdkObject, ok := response.Pool()
+			if !ok {
+				return newError(
+					ENotFound,
+					"no VM pool returned after creating VM pool %s",
+					name,
+				)
+			}
+			result, err = convertSDKVMPool(sdkObject, o)
+			if err != nil {
+				return wrap(
+					err,
+					EBug,
+					"failed to convert VM pool %s",
+					name,
+				)
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	if err := o.waitForJobFinished(correlationID, retries); err != nil {
+		return nil, wrap(err, EUnidentified, "failed to wait for the VMs of pool %s to be created", name)
+	}
+	return result, nil
+}
+
+func createSDKVMPool(
+	clusterID ClusterID,
+	templateID TemplateID,
+	name string,
+	size uint,
+	params OptionalVMPoolParameters,
+) (*ovirtsdk.VmPool, error) {
+	builder := ovirtsdk.NewVmPoolBuilder().
+		Name(name).
+		ClusterBuilder(ovirtsdk.NewClusterBuilder().Id(string(clusterID))).
+		TemplateBuilder(ovirtsdk.NewTemplateBuilder().Id(string(templateID))).
+		Size(int64(size))
+	if comment := params.Comment(); comment != "" {
+		builder.Comment(comment)
+	}
+	if description := params.Description(); description != "" {
+		builder.Description(description)
+	}
+	if prestartedVMs := params.PrestartedVMs(); prestartedVMs != nil {
+		builder.PrestartedVms(int64(*prestartedVMs))
+	}
+	if maxUserVMs := params.MaxUserVMs(); maxUserVMs != nil {
+		builder.MaxUserVms(int64(*maxUserVMs))
+	}
+	if poolType := params.Type(); poolType != nil {
+		builder.Type(ovirtsdk.VmPoolType(*poolType))
+	}
+	if stateful := params.Stateful(); stateful != nil {
+		builder.Stateful(*stateful)
+	}
+	pool, err := builder.Build()
+	if err != nil {
+		return nil, wrap(err, EBug, "failed to build VM pool")
+	}
+	return pool, nil
+}
+
+func validateVMPoolCreationParameters(
+	clusterID ClusterID,
+	templateID TemplateID,
+	name string,
+	size uint,
+	params OptionalVMPoolParameters,
+) error {
+	if name == "" {
+		return newError(EBadArgument, "name cannot be empty for VM pool creation")
+	}
+	if clusterID == "" {
+		return newError(EBadArgument, "cluster ID cannot be empty for VM pool creation")
+	}
+	if templateID == "" {
+		return newError(EBadArgument, "template ID cannot be empty for VM pool creation")
+	}
+	if size == 0 {
+		return newError(EBadArgument, "the VM pool size must be positive")
+	}
+	if prestartedVMs := params.PrestartedVMs(); prestartedVMs != nil && *prestartedVMs > size {
+		return newError(
+			EBadArgument,
+			"the number of prestarted VMs is larger than the VM pool size (%d > %d)",
+			*prestartedVMs,
+			size,
+		)
+	}
+	if poolType := params.Type(); poolType != nil {
+		if err := poolType.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *mockClient) CreateVMPool(
+	clusterID ClusterID,
+	templateID TemplateID,
+	name string,
+	size uint,
+	params OptionalVMPoolParameters,
+	_ ...RetryStrategy,
+) (VMPool, error) {
+	if params == nil {
+		params = CreateVMPoolParams()
+	}
+	if err := validateVMPoolCreationParameters(clusterID, templateID, name, size, params); err != nil {
+		return nil, err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.clusters[clusterID]; !ok {
+		return nil, newError(ENotFound, "cluster with ID %s not found", clusterID)
+	}
+	tpl, ok := m.templates[templateID]
+	if !ok {
+		return nil, newError(ENotFound, "template with ID %s not found", templateID)
+	}
+	if tpl.status != TemplateStatusOK {
+		return nil, newError(EConflict, "template in status \"%s\"", tpl.status)
+	}
+	for _, existingPool := range m.vmPools {
+		if existingPool.name == name {
+			return nil, newError(EConflict, "A VM pool with the name \"%s\" already exists.", name)
+		}
+	}
+
+	pool := &vmPool{
+		client:      m,
+		id:          VMPoolID(m.GenerateUUID()),
+		name:        name,
+		comment:     params.Comment(),
+		description: params.Description(),
+		clusterID:   clusterID,
+		templateID:  templateID,
+		maxUserVMs:  1,
+		poolType:    VMPoolTypeAutomatic,
+	}
+	if prestartedVMs := params.PrestartedVMs(); prestartedVMs != nil {
+		pool.prestartedVMs = *prestartedVMs
+	}
+	if maxUserVMs := params.MaxUserVMs(); maxUserVMs != nil {
+		pool.maxUserVMs = *maxUserVMs
+	}
+	if poolType := params.Type(); poolType != nil {
+		pool.poolType = *poolType
+	}
+	if stateful := params.Stateful(); stateful != nil {
+		pool.stateful = *stateful
+	}
+	m.vmPools[pool.id] = pool
+	m.vmPoolAllocations[pool.id] = map[VMID]struct{}{}
+
+	if err := m.resizeVMPool(pool, size); err != nil {
+		return nil, err
+	}
+	return pool, nil
+}
@@ -0,0 +1,86 @@
+package ovirtclient
+
+import (
+	"fmt"
+	"net"
+	"sort"
+)
+
+// resizeVMPool creates VMs from the pool template until the pool has the specified size and starts the prestarted
+// VMs. The caller must hold the mock client lock.
+func (m *mockClient) resizeVMPool(pool *vmPool, size uint) error {
+	tpl, ok := m.templates[pool.templateID]
+	if !ok {
+		return newError(ENotFound, "template with ID %s not found", pool.templateID)
+	}
+	vmParams := &vmParams{}
+	for i := uint(len(m.vmPoolVMs(pool.id))) + 1; i <= size; i++ {
+		name := fmt.Sprintf("%s-%d", pool.name, i)
+		for _, existingVM := range m.vms {
+			if existingVM.name == name {
+				return newError(EConflict, "A VM with the name \"%s\" already exists.", name)
+			}
+		}
+		poolVM := m.createVM(name, vmParams, pool.clusterID, pool.templateID, m.createVMCPU(vmParams, tpl))
+		poolVM.vmPoolID = &pool.id
+		m.attachVMDisksFromTemplate(tpl, poolVM, vmParams)
+		m.vmIPs[poolVM.id] = map[string][]net.IP{}
+		m.addGraphicsConsoles(poolVM)
+	}
+	pool.size = size
+	return m.startPrestartedVMs(pool)
+}
+
+// startPrestartedVMs boots free VMs of the pool until the number of running free VMs reaches the number of
+// prestarted VMs. The caller must hold the mock client lock.
+func (m *mockClient) startPrestartedVMs(pool *vmPool) error {
+	var running uint
+	var stopped []*vm
+	for _, poolVM := range m.vmPoolVMs(pool.id) {
+		if !m.isVMPoolVMFree(pool, poolVM) {
+			continue
+		}
+		if poolVM.status == VMStatusDown {
+			stopped = append(stopped, poolVM)
+		} else {
+			running++
+		}
+	}
+	for _, poolVM := range stopped {
+		if running >= pool.prestartedVMs {
+			break
+		}
+		if err := m.bootVM(poolVM, false); err != nil {
+			return err
+		}
+		running++
+	}
+	return nil
+}
+
+// vmPoolVMs returns the VMs belonging to a pool ordered by name. The caller must hold the mock client lock.
+func (m *mockClient) vmPoolVMs(id VMPoolID) []*vm {
+	var result []*vm
+	for _, item := range m.vms {
+		if item.vmPoolID != nil && *item.vmPoolID == id {
+			result = append(result, item)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].name < result[j].name
+	})
+	return result
+}
+
+// isVMPoolVMFree returns true if the VM can be allocated from the pool. VMs of automatic pools return to the pool
+// when they are shut down. The caller must hold the mock client lock.
+func (m *mockClient) isVMPoolVMFree(pool *vmPool, item *vm) bool {
+	if _, allocated := m.vmPoolAllocations[pool.id][item.id]; !allocated {
+		return true
+	}
+	if pool.poolType == VMPoolTypeAutomatic && item.status == VMStatusDown {
+		delete(m.vmPoolAllocations[pool.id], item.id)
+		return true
+	}
+	return false
+}
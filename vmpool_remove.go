@@ -0,0 +1,62 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) RemoveVMPool(id VMPoolID, retries ...RetryStrategy) (err error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(o))
+	correlationID := fmt.Sprintf("vmpool_remove_%s", generateRandomID(5, o.nonSecureRandom))
+	err = retry(
+		fmt.Sprintf("removing VM pool %s", id),
+		o.logger,
+		retries,
+		func() error {
+			_, err := o.conn.
+				SystemService().
+				VmPoolsService().
+				PoolService(string(id)).
+				Remove().
+				Query("correlation_id", correlationID).
+				Send()
+			return err
+		})
+	if err != nil {
+		return err
+	}
+	if err := o.waitForJobFinished(correlationID, retries); err != nil {
+		return wrap(err, EUnidentified, "failed to wait for VM pool %s to be removed", id)
+	}
+	return nil
+}
+
+func (m *mockClient) RemoveVMPool(id VMPoolID, _ ...RetryStrategy) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.vmPools[id]; !ok {
+		return newError(ENotFound, "VM pool with ID %s not found", id)
+	}
+	poolVMs := m.vmPoolVMs(id)
+	for _, poolVM := range poolVMs {
+		if poolVM.status != VMStatusDown {
+			return newError(
+				EConflict,
+				"cannot remove VM pool %s, VM %s is \"%s\" not \"%s\"",
+				id,
+				poolVM.id,
+				poolVM.status,
+				VMStatusDown,
+			)
+		}
+		if err := m.checkVMDisksUnlocked(poolVM.id); err != nil {
+			return err
+		}
+	}
+	for _, poolVM := range poolVMs {
+		m.removeVM(poolVM.id)
+	}
+	delete(m.vmPoolAllocations, id)
+	delete(m.vmPools, id)
+	return nil
+}
@@ -0,0 +1,176 @@
+package ovirtclient_test
+
+import (
+	"testing"
+
+	ovirtclient "github.com/dyudin0821/go-ovirt-client/v3"
+)
+
+func TestVMPoolCreation(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+
+	pool := assertCanCreateVMPool(
+		t,
+		helper,
+		2,
+		ovirtclient.CreateVMPoolParams().MustWithDescription("test pool"),
+	)
+	if pool.Size() != 2 {
+		t.Fatalf("Incorrect VM pool size: %d instead of %d.", pool.Size(), 2)
+	}
+	if pool.Description() != "test pool" {
+		t.Fatalf("Incorrect VM pool description: %s instead of %s.", pool.Description(), "test pool")
+	}
+	if pool.TemplateID() != helper.GetVMPoolTemplateID() {
+		t.Fatalf("Incorrect VM pool template: %s instead of %s.", pool.TemplateID(), helper.GetVMPoolTemplateID())
+	}
+
+	fetchedPool, err := helper.GetClient().GetVMPool(pool.ID())
+	if err != nil {
+		t.Fatalf("Failed to fetch VM pool %s. (%v)", pool.ID(), err)
+	}
+	if fetchedPool.Name() != pool.Name() {
+		t.Fatalf("Incorrect VM pool name: %s instead of %s.", fetchedPool.Name(), pool.Name())
+	}
+
+	pools, err := helper.GetClient().ListVMPools()
+	if err != nil {
+		t.Fatalf("Failed to list VM pools. (%v)", err)
+	}
+	found := false
+	for _, p := range pools {
+		if p.ID() == pool.ID() {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("VM pool %s not found in VM pool list.", pool.ID())
+	}
+
+	if poolVMs := assertCanListVMPoolVMs(t, helper, pool); len(poolVMs) != 2 {
+		t.Fatalf("Incorrect number of VMs in pool: %d instead of %d.", len(poolVMs), 2)
+	}
+}
+
+func TestVMPoolUpdateSize(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+
+	pool := assertCanCreateVMPool(t, helper, 1, nil)
+	pool, err := pool.Update(ovirtclient.UpdateVMPoolParams().MustWithSize(3).MustWithMaxUserVMs(2))
+	if err != nil {
+		t.Fatalf("Failed to update VM pool %s. (%v)", pool.ID(), err)
+	}
+	if pool.Size() != 3 {
+		t.Fatalf("Incorrect VM pool size after update: %d instead of %d.", pool.Size(), 3)
+	}
+	if pool.MaxUserVMs() != 2 {
+		t.Fatalf("Incorrect maximum VMs per user after update: %d instead of %d.", pool.MaxUserVMs(), 2)
+	}
+	if poolVMs := assertCanListVMPoolVMs(t, helper, pool); len(poolVMs) != 3 {
+		t.Fatalf("Incorrect number of VMs in pool after update: %d instead of %d.", len(poolVMs), 3)
+	}
+}
+
+func TestVMPoolAllocateVM(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+
+	pool := assertCanCreateVMPool(t, helper, 1, nil)
+	if err := pool.AllocateVM(); err != nil {
+		t.Fatalf("Failed to allocate VM from pool %s. (%v)", pool.ID(), err)
+	}
+	poolVMs := assertCanListVMPoolVMs(t, helper, pool)
+	if len(poolVMs) != 1 {
+		t.Fatalf("Incorrect number of VMs in pool: %d instead of %d.", len(poolVMs), 1)
+	}
+	allocatedVM := poolVMs[0]
+	t.Cleanup(func() {
+		if err := allocatedVM.Stop(true); err != nil {
+			t.Fatalf("Failed to stop allocated VM %s. (%v)", allocatedVM.ID(), err)
+		}
+		if _, err := allocatedVM.WaitForStatus(ovirtclient.VMStatusDown); err != nil {
+			t.Fatalf("Failed to wait for allocated VM %s to stop. (%v)", allocatedVM.ID(), err)
+		}
+	})
+	assertVMWillStart(t, allocatedVM)
+
+	if err := pool.AllocateVM(); err == nil {
+		t.Fatalf("Allocating a VM from an exhausted pool did not result in an error.")
+	}
+}
+
+func TestVMPoolVMCannotBeRemovedDirectly(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+
+	pool := assertCanCreateVMPool(t, helper, 1, nil)
+	poolVMs := assertCanListVMPoolVMs(t, helper, pool)
+	if len(poolVMs) != 1 {
+		t.Fatalf("Incorrect number of VMs in pool: %d instead of %d.", len(poolVMs), 1)
+	}
+	if err := poolVMs[0].Remove(); err == nil {
+		t.Fatalf("Removing a VM that is part of a pool did not result in an error.")
+	}
+}
+
+func TestVMPoolRemove(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+
+	pool := assertCanCreateVMPool(t, helper, 2, nil)
+	poolVMs := assertCanListVMPoolVMs(t, helper, pool)
+	assertCanRemoveVMPool(t, pool)
+	for _, poolVM := range poolVMs {
+		if _, err := helper.GetClient().GetVM(poolVM.ID()); !ovirtclient.HasErrorCode(err, ovirtclient.ENotFound) {
+			t.Fatalf("VM %s of the removed pool still exists. (%v)", poolVM.ID(), err)
+		}
+	}
+}
+
+func assertCanCreateVMPool(
+	t *testing.T,
+	helper ovirtclient.TestHelper,
+	size uint,
+	params ovirtclient.OptionalVMPoolParameters,
+) ovirtclient.VMPool {
+	pool, err := helper.GetClient().CreateVMPool(
+		helper.GetClusterID(),
+		helper.GetVMPoolTemplateID(),
+		helper.GenerateTestResourceName(t),
+		size,
+		params,
+	)
+	if pool != nil {
+		t.Cleanup(func() {
+			if err := pool.Remove(); err != nil && !ovirtclient.HasErrorCode(err, ovirtclient.ENotFound) {
+				t.Fatalf("Failed to remove VM pool %s. (%v)", pool.ID(), err)
+			}
+		})
+	}
+	if err != nil {
+		t.Fatalf("Failed to create VM pool. (%v)", err)
+	}
+	return pool
+}
+
+func assertCanRemoveVMPool(t *testing.T, pool ovirtclient.VMPool) {
+	if err := pool.Remove(); err != nil {
+		t.Fatalf("Failed to remove VM pool %s. (%v)", pool.ID(), err)
+	}
+}
+
+func assertCanListVMPoolVMs(t *testing.T, helper ovirtclient.TestHelper, pool ovirtclient.VMPool) []ovirtclient.VM {
+	vms, err := helper.GetClient().ListVMs()
+	if err != nil {
+		t.Fatalf("Failed to list VMs. (%v)", err)
+	}
+	var result []ovirtclient.VM
+	for _, vm := range vms {
+		if poolID := vm.VMPoolID(); poolID != nil && *poolID == pool.ID() {
+			result = append(result, vm)
+		}
+	}
+	return result
+}
@@ -0,0 +1,123 @@
+package ovirtclient
+
+import (
+	"fmt"
+
+	ovirtsdk "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) UpdateVMPool(
+	id VMPoolID,
+	params UpdateVMPoolParameters,
+	retries ...RetryStrategy,
+) (result VMPool, err error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(o))
+	if params == nil {
+		return nil, newError(EBadArgument, "params must not be nil")
+	}
+	builder := ovirtsdk.NewVmPoolBuilder().Id(string(id))
+	if size := params.Size(); size != nil {
+		builder.Size(int64(*size))
+	}
+	if prestartedVMs := params.PrestartedVMs(); prestartedVMs != nil {
+		builder.PrestartedVms(int64(*prestartedVMs))
+	}
+	if maxUserVMs := params.MaxUserVMs(); maxUserVMs != nil {
+		builder.MaxUserVms(int64(*maxUserVMs))
+	}
+	sdkPool, err := builder.Build()
+	if err != nil {
+		return nil, wrap(err, EBug, "failed to build VM pool")
+	}
+
+	correlationID := fmt.Sprintf("vmpool_update_%s", generateRandomID(5, o.nonSecureRandom))
+	err = retry(
+		fmt.Sprintf("updating VM pool %s", id),
+		o.logger,
+		retries,
+		func() error {
+			response, err := o.conn.
+				SystemService().
+				VmPoolsService().
+				PoolService(string(id)).
+				Update().
+				Pool(sdkPool).
+				Query("correlation_id", correlationID).
+				Send()
+			if err != nil {
+				return err
+			}
+			sdkObject, ok := response.Pool()
+			if !ok {
+				return newError(
+					ENotFound,
+					"no VM pool returned after updating VM pool %s",
+					id,
+				)
+			}
+			result, err = convertSDKVMPool(sdkObject, o)
+			if err != nil {
+				return wrap(
+					err,
+					EBug,
+					"failed to convert VM pool %s",
+					id,
+				)
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	if err := o.waitForJobFinished(correlationID, retries); err != nil {
+		return nil, wrap(err, EUnidentified, "failed to wait for VM pool %s to be updated", id)
+	}
+	return result, nil
+}
+
+func (m *mockClient) UpdateVMPool(id VMPoolID, params UpdateVMPoolParameters, _ ...RetryStrategy) (VMPool, error) {
+	if params == nil {
+		return nil, newError(EBadArgument, "params must not be nil")
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	pool, ok := m.vmPools[id]
+	if !ok {
+		return nil, newError(ENotFound, "VM pool with ID %s not found", id)
+	}
+	size := pool.size
+	if newSize := params.Size(); newSize != nil {
+		if *newSize < pool.size {
+			return nil, newError(
+				EBadArgument,
+				"cannot decrease the size of VM pool %s from %d to %d, remove VMs from the pool instead",
+				id,
+				pool.size,
+				*newSize,
+			)
+		}
+		size = *newSize
+	}
+	prestartedVMs := pool.prestartedVMs
+	if newPrestartedVMs := params.PrestartedVMs(); newPrestartedVMs != nil {
+		prestartedVMs = *newPrestartedVMs
+	}
+	if prestartedVMs > size {
+		return nil, newError(
+			EBadArgument,
+			"the number of prestarted VMs is larger than the VM pool size (%d > %d)",
+			prestartedVMs,
+			size,
+		)
+	}
+
+	pool.prestartedVMs = prestartedVMs
+	if maxUserVMs := params.MaxUserVMs(); maxUserVMs != nil {
+		pool.maxUserVMs = *maxUserVMs
+	}
+	if err := m.resizeVMPool(pool, size); err != nil {
+		return nil, err
+	}
+	return pool, nil
+}